@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+
+	"westex/engines/economy/pkg/core"
+	"westex/engines/economy/pkg/scenarios"
+)
+
+// benchCommand handles `sim-cli bench`: run the standard scenarios with
+// logging disabled and report throughput and allocations
+func benchCommand(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	sizes := fs.String("sizes", "1000,10000,100000", "Comma-separated population sizes")
+	ticks := fs.Int("ticks", 10, "Ticks to run per scenario")
+	cpuProfile := fs.String("cpuprofile", "", "Write a CPU profile to this file")
+	memProfile := fs.String("memprofile", "", "Write a heap profile to this file after the last scenario")
+	phases := fs.Bool("phases", false, "Print the time spent in each phase")
+	fs.Parse(args)
+
+	populations := make([]int, 0)
+	for _, field := range strings.Split(*sizes, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || size <= 0 {
+			log.Fatalf("Invalid population size: %q", field)
+		}
+		populations = append(populations, size)
+	}
+
+	if *cpuProfile != "" {
+		file, err := os.Create(*cpuProfile)
+		if err != nil {
+			log.Fatalf("Failed to create CPU profile: %v", err)
+		}
+		defer file.Close()
+		if err := pprof.StartCPUProfile(file); err != nil {
+			log.Fatalf("Failed to start CPU profile: %v", err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	fmt.Printf("%10s %8s %12s %12s %14s %14s\n", "PEOPLE", "TICKS", "TICKS/SEC", "MS/TICK", "ALLOCS/TICK", "BYTES/TICK")
+	for _, people := range populations {
+		result := benchScenario(people, *ticks)
+		fmt.Printf("%10d %8d %12.2f %12.2f %14d %14d\n",
+			people, *ticks, result.ticksPerSecond(), result.msPerTick(), result.allocsPerTick(), result.bytesPerTick())
+		if *phases {
+			for _, timing := range result.phases.Timings() {
+				fmt.Printf("%10s %-20s %10.2f ms/tick\n", "", timing.Name,
+					float64(timing.Total.Microseconds())/1000/float64(*ticks))
+			}
+		}
+	}
+
+	if *memProfile != "" {
+		file, err := os.Create(*memProfile)
+		if err != nil {
+			log.Fatalf("Failed to create heap profile: %v", err)
+		}
+		defer file.Close()
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(file); err != nil {
+			log.Fatalf("Failed to write heap profile: %v", err)
+		}
+	}
+}
+
+// benchResult is the measurement of one scenario
+type benchResult struct {
+	ticks   int
+	elapsed time.Duration
+	allocs  uint64
+	bytes   uint64
+	phases  *core.PhaseTimings
+}
+
+func (r benchResult) ticksPerSecond() float64 { return float64(r.ticks) / r.elapsed.Seconds() }
+func (r benchResult) msPerTick() float64 {
+	return float64(r.elapsed.Microseconds()) / 1000 / float64(r.ticks)
+}
+func (r benchResult) allocsPerTick() uint64 { return r.allocs / uint64(r.ticks) }
+func (r benchResult) bytesPerTick() uint64  { return r.bytes / uint64(r.ticks) }
+
+// benchScenario times the ticks of one standard scenario; building the
+// region is not measured
+func benchScenario(people, ticks int) benchResult {
+	engine := core.NewEngine(scenarios.Standard(people))
+	engine.Logger.SetEnabled(false)
+	engine.SetSeed(1)
+	phases := core.NewPhaseTimings()
+	engine.Tracer = phases
+	ctx := context.Background()
+
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	for i := 0; i < ticks; i++ {
+		if err := engine.Step(ctx); err != nil {
+			log.Fatalf("Benchmark stopped: %v", err)
+		}
+	}
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return benchResult{
+		ticks:   ticks,
+		elapsed: elapsed,
+		allocs:  after.Mallocs - before.Mallocs,
+		bytes:   after.TotalAlloc - before.TotalAlloc,
+		phases:  phases,
+	}
+}
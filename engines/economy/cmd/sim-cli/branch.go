@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"westex/engines/economy"
+	"westex/engines/economy/pkg/config"
+	"westex/engines/economy/pkg/core"
+	"westex/engines/economy/pkg/government"
+)
+
+// repeatedFlag collects the values of a flag given several times
+type repeatedFlag []string
+
+func (p *repeatedFlag) String() string { return strings.Join(*p, ",") }
+
+func (p *repeatedFlag) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// branchCommand handles `sim-cli branch`: run a config up to a tick, fork it
+// once per policy plus an unchanged baseline, run every branch to the end
+// and compare the outcomes
+func branchCommand(args []string) {
+	fs := flag.NewFlagSet("branch", flag.ExitOnError)
+	configFile := fs.String("config", "", "Path to YAML configuration file")
+	at := fs.Int("at", 0, "Tick to branch at")
+	ticks := fs.Int("ticks", 0, "Total ticks to run (overrides the config)")
+	seed := fs.Uint64("seed", 0, "Random seed (overrides the config)")
+	var policies repeatedFlag
+	fs.Var(&policies, "policy", "Policy YAML applied to one branch (repeatable)")
+	fs.Parse(args)
+
+	if *configFile == "" || len(policies) == 0 {
+		log.Fatalf("Usage: sim-cli branch -config base.yaml -at 10 -policy a.yaml [-policy b.yaml ...]")
+	}
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	region, err := config.BuildRegionFromConfig(cfg)
+	if err != nil {
+		log.Fatalf("Failed to build region: %v", err)
+	}
+	engine, err := economy.BuildEngine(cfg, region)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *seed != 0 {
+		engine.SetSeed(*seed)
+	}
+	total := cfg.Simulation.Ticks
+	if *ticks > 0 {
+		total = *ticks
+	}
+	if *at < 0 || *at > total {
+		log.Fatalf("Branch tick %d is outside the run (0-%d)", *at, total)
+	}
+
+	branchPolicies := make([]*config.BranchPolicy, 0, len(policies))
+	for _, path := range policies {
+		policy, err := config.LoadPolicy(path)
+		if err != nil {
+			log.Fatalf("Failed to load policy: %v", err)
+		}
+		branchPolicies = append(branchPolicies, policy)
+	}
+
+	engine.Logger.SetEnabled(false)
+	ctx := context.Background()
+
+	fmt.Printf("🌳 Running %s to tick %d...\n", cfg.Region.Name, *at)
+	if err := stepTo(ctx, engine, *at); err != nil {
+		log.Fatalf("Simulation stopped: %v", err)
+	}
+
+	names := []string{"baseline"}
+	branches := []*core.Engine{engine.Fork()}
+	for _, policy := range branchPolicies {
+		branch := engine.Fork()
+		applyPolicy(branch, policy)
+		names = append(names, policy.Name)
+		branches = append(branches, branch)
+	}
+
+	results := make([]*core.Results, len(branches))
+	for i, branch := range branches {
+		fmt.Printf("🌿 Running branch %q to tick %d...\n", names[i], total)
+		if err := stepTo(ctx, branch, total); err != nil {
+			log.Fatalf("Branch %s stopped: %v", names[i], err)
+		}
+		results[i] = branch.Results()
+	}
+
+	printComparison(names, results, *at)
+}
+
+// stepTo advances an engine until it reaches the given tick
+func stepTo(ctx context.Context, engine *core.Engine, tick int) error {
+	for engine.CurrentTick < tick {
+		if err := engine.Step(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPolicy overrides a branch's settings with a policy's sections
+func applyPolicy(engine *core.Engine, policy *config.BranchPolicy) {
+	if policy.WagePerHour > 0 {
+		engine.WagePerHour = policy.WagePerHour
+	}
+	if policy.MonetaryPolicy != nil {
+		if engine.CentralBank == nil {
+			engine.CentralBank = config.BuildCentralBank(&config.RegionConfig{MonetaryPolicy: policy.MonetaryPolicy})
+			// Keep existing deposits: the new central bank steers the branch's bank
+			engine.CentralBank.Bank = engine.Bank
+		}
+		config.ApplyMonetaryPolicy(policy.MonetaryPolicy, engine.CentralBank)
+	}
+	if policy.Government != nil {
+		if engine.Government == nil {
+			engine.Government = government.NewGovernment(policy.Government.Treasury, policy.Government.SalesTaxRate)
+		} else {
+			engine.Government.SalesTaxRate = policy.Government.SalesTaxRate
+		}
+	}
+	if policy.Informal != nil {
+		engine.Informal = &core.InformalEconomy{
+			Premium:           policy.Informal.Premium,
+			BaseParticipation: policy.Informal.BaseParticipation,
+			TaxSensitivity:    policy.Informal.TaxSensitivity,
+		}
+	}
+	if policy.Barter != nil {
+		engine.Barter = &core.BarterSettings{
+			HoursPerUnit:   policy.Barter.HoursPerUnit,
+			MoneyThreshold: policy.Barter.MoneyThreshold,
+		}
+	}
+}
+
+// printComparison prints each branch's outcome side by side
+func printComparison(names []string, results []*core.Results, at int) {
+	fmt.Printf("\n📊 BRANCH COMPARISON (forked at tick %d, ran to tick %d)\n\n", at, results[0].Ticks)
+
+	fmt.Printf("%-24s", "")
+	for _, name := range names {
+		fmt.Printf(" %16s", name)
+	}
+	fmt.Printf("\n")
+
+	row := func(label string, value func(*core.Results) float32) {
+		fmt.Printf("%-24s", label)
+		for _, r := range results {
+			fmt.Printf(" %16.2f", value(r))
+		}
+		fmt.Printf("\n")
+	}
+
+	row("Total wealth", func(r *core.Results) float32 { return r.TotalWealth })
+	row("People wealth", func(r *core.Results) float32 { return r.PeopleWealth })
+	row("Treasury", func(r *core.Results) float32 { return r.Treasury })
+
+	industries := make([]string, 0, len(results[0].IndustryMoney))
+	for name := range results[0].IndustryMoney {
+		industries = append(industries, name)
+	}
+	sort.Strings(industries)
+	for _, industry := range industries {
+		row(industry, func(r *core.Results) float32 { return r.IndustryMoney[industry] })
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"westex/engines/economy/pkg/config"
+)
+
+// generateCommand handles `sim-cli generate`: create random region configs
+// from a few knobs, one per seed
+func generateCommand(args []string) {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	name := fs.String("name", "", "Region name (default: Region-<seed>)")
+	population := fs.Int("population", 1000, "Number of people")
+	development := fs.Float64("development", 0.5, "Development level from 0 (subsistence) to 1 (industrialized)")
+	richness := fs.Float64("richness", 0.5, "Natural resource richness from 0 (barren) to 1 (abundant)")
+	seed := fs.Uint64("seed", 1, "Seed of the first region")
+	count := fs.Int("count", 1, "Regions to generate, with consecutive seeds")
+	theme := fs.String("names", "", "Name theme for people and industries, e.g. indian (default: numbered names)")
+	out := fs.String("out", "", "YAML file to write (default: stdout), or a directory with -count > 1")
+	fs.Parse(args)
+
+	if *count > 1 {
+		if *out == "" {
+			log.Fatalf("-count needs -out to name a directory")
+		}
+		if err := os.MkdirAll(*out, 0755); err != nil {
+			log.Fatalf("Failed to create %s: %v", *out, err)
+		}
+	}
+
+	for i := 0; i < *count; i++ {
+		opts := config.GenerateOptions{
+			Name:        *name,
+			Population:  *population,
+			Development: float32(*development),
+			Richness:    float32(*richness),
+			Seed:        *seed + uint64(i),
+			Names:       *theme,
+		}
+		if *count > 1 {
+			opts.Name = ""
+		}
+		cfg, err := config.Generate(opts)
+		if err != nil {
+			log.Fatalf("Failed to generate region: %v", err)
+		}
+
+		path := *out
+		if *count > 1 {
+			path = filepath.Join(*out, fmt.Sprintf("%s.yaml", cfg.Region.Name))
+		}
+		if path == "" {
+			data, err := yaml.Marshal(cfg)
+			if err != nil {
+				log.Fatalf("Failed to marshal region: %v", err)
+			}
+			os.Stdout.Write(data)
+			continue
+		}
+		if err := config.SaveConfig(cfg, path); err != nil {
+			log.Fatalf("%v", err)
+		}
+		fmt.Printf("🗺️  %s: %d people, %d industries → %s\n",
+			cfg.Region.Name, cfg.Population.TotalSize, len(cfg.Industries), path)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"westex/engines/economy/pkg/config"
+)
+
+// graphCommand handles `sim-cli graph`: write a config's resources,
+// industries, problems and segments as a Graphviz DOT graph, to check how a
+// scenario is wired before running it
+func graphCommand(args []string) {
+	fs := flag.NewFlagSet("graph", flag.ExitOnError)
+	out := fs.String("o", "", "DOT file to write (default: stdout)")
+
+	// The config may come before the flags, as in `graph config.yaml -o FILE`
+	var configFile string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		configFile, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if configFile == "" && fs.NArg() == 1 {
+		configFile = fs.Arg(0)
+	} else if configFile == "" || fs.NArg() > 0 {
+		log.Fatalf("Usage: sim-cli graph config.yaml [-o economy.dot]")
+	}
+
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *out, err)
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := config.WriteDOT(w, cfg); err != nil {
+		log.Fatalf("Failed to write graph: %v", err)
+	}
+	if *out != "" {
+		fmt.Printf("🕸️  Graph written to %s (render with: dot -Tsvg %s -o economy.svg)\n", *out, *out)
+	}
+}
@@ -1,34 +1,174 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
 
+	"westex/engines/economy"
 	"westex/engines/economy/pkg/config"
 	"westex/engines/economy/pkg/core"
 	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/logging"
+	"westex/engines/economy/pkg/otlp"
+	"westex/engines/economy/pkg/report"
+	"westex/engines/economy/pkg/runs"
 	"westex/engines/economy/pkg/utils"
 )
 
+// runOptions are command-line settings layered over the config file
+type runOptions struct {
+	OutDir    string            // Export results and a manifest here ("" = no export)
+	ChartDir  string            // Draw charts of the run here ("" = none)
+	ChartType string            // report.FormatSVG or report.FormatPNG
+	Seed      uint64            // Overrides simulation.seed when non-zero
+	Ticks     int               // Overrides simulation.ticks when non-zero
+	Overrides map[string]string // Flags that replaced config values, for the manifest
+	LogLevel  logging.Level     // From -q, -v and -vv
+	Sampling  logging.Sampling  // Purchases printed at -v
+	OTLP      string            // Collector to send tick and phase spans to ("" = none)
+
+	// Write the log to files under LogDir/<run ID> instead of the terminal
+	LogDir      string
+	LogRotate   string // logging.RotatePerTick or logging.RotateBySize
+	LogMaxBytes int64
+}
+
+// openLog points the engine's logger at rotating files for the run when
+// -log-dir is set, keeping a progress bar on the terminal. The returned
+// function closes the files.
+func (o runOptions) openLog(engine *core.Engine, runID string) func() {
+	if o.LogDir == "" {
+		return func() {}
+	}
+	dir := filepath.Join(o.LogDir, runID)
+	out, err := logging.NewRotatingFile(dir, o.LogRotate, o.LogMaxBytes)
+	if err != nil {
+		log.Fatalf("Failed to open log files: %v", err)
+	}
+	engine.Logger.SetOutput(out)
+	if engine.OnProgress == nil {
+		engine.OnProgress = core.ConsoleProgress(os.Stdout)
+	}
+	o.printf("📝 Logging to %s\n", dir)
+	return func() { out.Close() }
+}
+
+// printf prints setup information unless the run is quiet
+func (o runOptions) printf(format string, args ...any) {
+	if o.LogLevel > logging.LevelQuiet {
+		fmt.Printf(format, args...)
+	}
+}
+
+// applyLogLevel sets the engine's logging; quiet runs also get no progress
+// bar, so only the final summary is printed
+func (o runOptions) applyLogLevel(engine *core.Engine) {
+	engine.Logger.SetLevel(o.LogLevel)
+	engine.Logger.SetSampling(o.Sampling)
+	if o.LogLevel == logging.LevelQuiet {
+		engine.OnProgress = func(core.Progress) {}
+	}
+}
+
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "runs":
+			runsCommand(os.Args[2:])
+			return
+		case "branch":
+			branchCommand(os.Args[2:])
+			return
+		case "bench":
+			benchCommand(os.Args[2:])
+			return
+		case "world":
+			worldCommand(os.Args[2:])
+			return
+		case "generate":
+			generateCommand(os.Args[2:])
+			return
+		case "verify":
+			verifyCommand(os.Args[2:])
+			return
+		case "report":
+			reportCommand(os.Args[2:])
+			return
+		case "graph":
+			graphCommand(os.Args[2:])
+			return
+		case "repl":
+			replCommand(os.Args[2:])
+			return
+		}
+	}
+
 	// Parse command-line flags
 	configFile := flag.String("config", "", "Path to YAML configuration file")
+	outDir := flag.String("out", "", "Directory to export results and a run manifest into")
+	chartDir := flag.String("charts", "", "Directory to draw charts of wealth, prices, employment and needs met into")
+	chartType := flag.String("chart-format", report.FormatSVG, "Chart image format with -charts: svg or png")
+	seed := flag.Uint64("seed", 0, "Random seed (overrides the config)")
+	ticks := flag.Int("ticks", 0, "Number of ticks to run (overrides the config)")
+	quiet := flag.Bool("q", false, "Quiet: print only the final summary")
+	verbose := flag.Bool("v", false, "Verbose: print every phase, with sampled purchases")
+	trace := flag.Bool("vv", false, "Very verbose: print every transaction")
+	sampleFirst := flag.Int("sample-first", logging.DefaultSampling.First, "With -v, print the first N purchases of each tick")
+	sampleEvery := flag.Int("sample-every", logging.DefaultSampling.Every, "With -v, then print every Kth purchase (0 = none)")
+	logDir := flag.String("log-dir", "", "Write the log to files in a per-run directory under this one")
+	logRotate := flag.String("log-rotate", logging.RotatePerTick, "Log file rotation: tick or size")
+	logMaxBytes := flag.Int64("log-max-bytes", 10<<20, "File size limit with -log-rotate size")
+	otlpEndpoint := flag.String("otlp", "", "OpenTelemetry collector to send tick and phase spans to, as in http://localhost:4318")
 	flag.Parse()
 
+	opts := runOptions{
+		OutDir:    *outDir,
+		ChartDir:  *chartDir,
+		ChartType: *chartType,
+		Seed:      *seed,
+		Ticks:     *ticks,
+		Overrides: make(map[string]string),
+		LogLevel:  logging.FlagLevel(*quiet, *verbose, *trace),
+		Sampling:  logging.Sampling{First: *sampleFirst, Every: *sampleEvery},
+		OTLP:      *otlpEndpoint,
+
+		LogDir:      *logDir,
+		LogRotate:   *logRotate,
+		LogMaxBytes: *logMaxBytes,
+	}
+	if *chartType != report.FormatSVG && *chartType != report.FormatPNG {
+		log.Fatalf("Invalid -chart-format %q, expected svg or png", *chartType)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" || f.Name == "ticks" {
+			opts.Overrides[f.Name] = f.Value.String()
+		}
+	})
+
 	if *configFile != "" {
-		// Run from YAML config
-		runFromConfig(*configFile)
+		// Run from YAML config, failing if the scenario's assertions do
+		if err := runFromConfig(*configFile, opts); err != nil {
+			log.Fatalf("%v", err)
+		}
 	} else {
 		// Run with programmatic setup (default)
-		runProgrammatic()
+		runProgrammatic(opts)
 	}
 }
 
-// runFromConfig loads and runs simulation from a YAML configuration file
-func runFromConfig(filepath string) {
-	fmt.Println("=== Running simulation from config file ===")
-	fmt.Printf("Loading: %s\n\n", filepath)
+// runFromConfig loads and runs simulation from a YAML configuration file,
+// returning an error if any of its assertions failed
+func runFromConfig(filepath string, opts runOptions) error {
+	opts.printf("=== Running simulation from config file ===\n")
+	opts.printf("Loading: %s\n\n", filepath)
 
 	// Load configuration
 	cfg, err := config.LoadConfig(filepath)
@@ -36,11 +176,11 @@ func runFromConfig(filepath string) {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	fmt.Printf("Loaded config for: %s\n", cfg.Region.Name)
-	fmt.Printf("  - %d problems defined\n", len(cfg.Problems))
-	fmt.Printf("  - %d resources available\n", len(cfg.Resources))
-	fmt.Printf("  - %d industries\n", len(cfg.Industries))
-	fmt.Printf("  - Population: %d\n\n", cfg.Population.TotalSize)
+	opts.printf("Loaded config for: %s\n", cfg.Region.Name)
+	opts.printf("  - %d problems defined\n", len(cfg.Problems))
+	opts.printf("  - %d resources available\n", len(cfg.Resources))
+	opts.printf("  - %d industries\n", len(cfg.Industries))
+	opts.printf("  - Population: %d\n\n", cfg.Population.TotalSize)
 
 	// Build region from config
 	region, err := config.BuildRegionFromConfig(cfg)
@@ -48,26 +188,153 @@ func runFromConfig(filepath string) {
 		log.Fatalf("Failed to build region: %v", err)
 	}
 
-	fmt.Printf("Region '%s' created successfully!\n", region.Name)
-	fmt.Printf("  - Industries: %d\n", len(region.Industries))
-	fmt.Printf("  - People: %d\n", len(region.People))
-	fmt.Printf("  - Population Segments: %d\n\n", len(region.PopulationSegments))
+	opts.printf("Region '%s' created successfully!\n", region.Name)
+	opts.printf("  - Industries: %d\n", len(region.Industries))
+	opts.printf("  - People: %d\n", len(region.People))
+	opts.printf("  - Population Segments: %d\n\n", len(region.PopulationSegments))
 
 	// Create engine with config parameters
-	engine := core.NewEngineWithParams(
-		region,
-		cfg.Simulation.WagePerHour,
-		cfg.Simulation.WeeksPerTick,
-		cfg.Simulation.HoursPerWeek,
-	)
+	engine, err := economy.BuildEngine(cfg, region)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if opts.Seed != 0 {
+		engine.SetSeed(opts.Seed)
+	}
+	opts.applyLogLevel(engine)
+	var exporter *otlp.Exporter
+	if opts.OTLP != "" {
+		if exporter, err = otlp.NewExporter(opts.OTLP, "economy"); err != nil {
+			log.Fatalf("%v", err)
+		}
+		exporter.OnError = func(err error) { log.Printf("Failed to export traces: %v", err) }
+		engine.Tracer = exporter
+	}
+	ticks := cfg.Simulation.Ticks
+	if opts.Ticks > 0 {
+		ticks = opts.Ticks
+	}
+
+	// Record the run so it can be reproduced
+	var manifest *runs.Manifest
+	if opts.OutDir != "" {
+		data, err := os.ReadFile(filepath)
+		if err != nil {
+			log.Fatalf("Failed to read config: %v", err)
+		}
+		manifest = runs.NewManifest(filepath, data, engine.Seed, opts.Overrides)
+		manifest.Ticks = ticks
+	}
+	if opts.OutDir != "" || opts.ChartDir != "" {
+		engine.TrackHistory()
+	}
+	runID := time.Now().Format("20060102-150405")
+	if manifest != nil {
+		runID = manifest.ID
+	}
+	defer opts.openLog(engine, runID)()
 
 	// Run simulation
-	engine.Run(cfg.Simulation.Ticks)
+	runEngine(engine, ticks)
+	if exporter != nil {
+		exporter.Flush(context.Background()) // Failures were logged by OnError as they happened
+	}
+
+	// An interrupted run leaves a checkpoint next to its partial export
+	if engine.Interrupted {
+		checkpointPath := fmt.Sprintf("checkpoint-tick-%d.json", engine.CurrentTick)
+		var err error
+		if manifest != nil {
+			checkpointPath = manifest.Path(opts.OutDir, runs.CheckpointFile)
+			err = manifest.WriteFile(opts.OutDir, runs.CheckpointFile, engine.Checkpoint())
+		} else {
+			err = runs.WriteJSON(checkpointPath, engine.Checkpoint())
+		}
+		if err != nil {
+			log.Fatalf("Failed to write checkpoint: %v", err)
+		}
+		fmt.Printf("💾 Checkpoint written to %s\n", checkpointPath)
+	}
+
+	if manifest != nil {
+		manifest.EndTime = time.Now()
+		manifest.Ticks = engine.CurrentTick
+		manifest.Interrupted = engine.Interrupted
+		for _, change := range engine.Changes {
+			manifest.Changes = append(manifest.Changes, runs.ParameterChange(change))
+		}
+		if err := manifest.Save(opts.OutDir, engine.Results()); err != nil {
+			log.Fatalf("Failed to export run: %v", err)
+		}
+		if err := manifest.WriteFile(opts.OutDir, runs.HistoryFile, engine.History); err != nil {
+			log.Fatalf("Failed to export history: %v", err)
+		}
+		if engine.Trajectories != nil {
+			if err := manifest.WriteFile(opts.OutDir, runs.TrajectoriesFile, engine.Trajectories); err != nil {
+				log.Fatalf("Failed to export trajectories: %v", err)
+			}
+		}
+		if engine.Cohorts != nil {
+			if err := core.SaveCohortsCSV(manifest.Path(opts.OutDir, runs.CohortsFile), engine.Cohorts); err != nil {
+				log.Fatalf("Failed to export cohorts: %v", err)
+			}
+		}
+		if engine.Matrices != nil {
+			err := manifest.WriteFile(opts.OutDir, runs.MatricesFile, engine.Matrices)
+			if err == nil {
+				err = core.SaveMatricesCSV(manifest.Dir(opts.OutDir), engine.Matrices)
+			}
+			if err != nil {
+				log.Fatalf("Failed to export matrices: %v", err)
+			}
+		}
+		fmt.Printf("📁 Run %s exported to %s\n", manifest.ID, manifest.Dir(opts.OutDir))
+	}
+	if opts.ChartDir != "" {
+		if err := report.SaveCharts(opts.ChartDir, engine.History, opts.ChartType); err != nil {
+			log.Fatalf("Failed to draw charts: %v", err)
+		}
+		fmt.Printf("📈 Charts drawn in %s\n", opts.ChartDir)
+	}
+	if len(engine.Failures) > 0 {
+		return fmt.Errorf("%d of %d assertions failed", len(engine.Failures), len(engine.Assertions))
+	}
+	return nil
+}
+
+// runsCommand handles `sim-cli runs <subcommand>`
+func runsCommand(args []string) {
+	if len(args) == 0 || args[0] != "list" {
+		log.Fatalf("Usage: sim-cli runs list [-dir DIR]")
+	}
+
+	fs := flag.NewFlagSet("runs list", flag.ExitOnError)
+	dir := fs.String("dir", "runs", "Directory holding exported runs")
+	fs.Parse(args[1:])
+
+	manifests, err := runs.List(*dir)
+	if err != nil {
+		log.Fatalf("Failed to list runs: %v", err)
+	}
+	if len(manifests) == 0 {
+		fmt.Printf("No runs in %s\n", *dir)
+		return
+	}
+
+	fmt.Printf("%-26s %-20s %6s %20s  %-10s %-8s %s\n", "ID", "STARTED", "TICKS", "SEED", "CONFIG", "GIT", "PATH")
+	for _, m := range manifests {
+		revision := m.GitRevision
+		if len(revision) > 8 {
+			revision = revision[:8]
+		}
+		fmt.Printf("%-26s %-20s %6d %20d  %-10s %-8s %s\n",
+			m.ID, m.StartTime.Format("2006-01-02 15:04:05"), m.Ticks, m.Seed, m.ConfigHash[:10], revision, m.ConfigPath)
+	}
 }
 
 // runProgrammatic runs simulation with programmatic setup
-func runProgrammatic() {
-	fmt.Println("=== Running simulation with programmatic setup ===")
+func runProgrammatic(opts runOptions) {
+	opts.printf("=== Running simulation with programmatic setup ===\n")
 
 	region := entities.NewRegion("Mumbai")
 
@@ -102,6 +369,7 @@ func runProgrammatic() {
 	// Create population segments
 	workersPopulation := &entities.PopulationSegment{
 		Name:     "Workers",
+		Role:     entities.RoleWorkers,
 		Problems: []*entities.Problem{},
 		Size:     200,
 	}
@@ -113,25 +381,35 @@ func runProgrammatic() {
 	region.AddPopulationSegment(workersPopulation)
 	region.AddPopulationSegment(generalPopulationSegment)
 
-	// Create 1000 people
-	workersCount := 0
-	for i := 1; i <= generalPopulationSegment.Size; i++ {
+	// Create 1000 people; segment sizes follow the members who join
+	people, workers := generalPopulationSegment.Size, workersPopulation.Size
+	for i := 1; i <= people; i++ {
 		person := entities.NewPerson(fmt.Sprintf("Person-%d", i), 50.0, 8.0)
 		person.AddSegment(generalPopulationSegment)
 		// Probabilistically assign to workers segment
-		if utils.ProbableChance(float32(workersPopulation.Size) / float32(generalPopulationSegment.Size)) {
+		if utils.ProbableChance(float32(workers) / float32(people)) {
 			person.AddSegment(workersPopulation)
-			workersCount++
 		}
 		region.AddPerson(person)
 	}
-	workersPopulation.UpdateSize(workersCount)
 
 	// Update problem demands
 	healthCareProblem.UpdateDemand(0.1)
 	foodProblem.UpdateDemand(0.99)
 
 	// Create and run engine
-	engine := core.CreateNewEngine(region)
-	engine.Run(3)
+	engine := core.NewEngine(region)
+	opts.applyLogLevel(engine)
+	defer opts.openLog(engine, time.Now().Format("20060102-150405"))()
+	runEngine(engine, 3)
+}
+
+// runEngine runs the engine for a number of ticks. Ctrl-C stops it once the
+// tick in progress finishes, leaving Interrupted set.
+func runEngine(engine *core.Engine, ticks int) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := engine.Run(ctx, ticks); err != nil && !errors.Is(err, context.Canceled) {
+		log.Printf("Simulation stopped: %v", err)
+	}
 }
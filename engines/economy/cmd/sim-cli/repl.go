@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"westex/engines/economy"
+	"westex/engines/economy/pkg/config"
+	"westex/engines/economy/pkg/logging"
+	"westex/engines/economy/pkg/repl"
+	"westex/engines/economy/pkg/runs"
+)
+
+// replCommand handles `sim-cli repl`: build a config's engine and drive it
+// from typed commands, stepping, inspecting and changing it between ticks
+func replCommand(args []string) {
+	fs := flag.NewFlagSet("repl", flag.ExitOnError)
+	seed := fs.Uint64("seed", 0, "Random seed (overrides the config)")
+	verbose := fs.Bool("v", false, "Print the engine's log of every tick")
+	outDir := fs.String("out", "", "Directory to export the session's run into on leaving")
+
+	// The config may come before the flags, as in `repl config.yaml -seed 7`
+	var configFile string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		configFile, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if configFile == "" && fs.NArg() == 1 {
+		configFile = fs.Arg(0)
+	} else if configFile == "" || fs.NArg() > 0 {
+		log.Fatalf("Usage: sim-cli repl config.yaml [-seed N] [-out DIR] [-v]")
+	}
+
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	region, err := config.BuildRegionFromConfig(cfg)
+	if err != nil {
+		log.Fatalf("Failed to build region: %v", err)
+	}
+	engine, err := economy.BuildEngine(cfg, region)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *seed != 0 {
+		engine.SetSeed(*seed)
+	}
+	// The manifest records the parameters changed along the way, so an
+	// exported session can be told apart from a plain run of the config
+	var manifest *runs.Manifest
+	if *outDir != "" {
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			log.Fatalf("Failed to read config: %v", err)
+		}
+		overrides := make(map[string]string)
+		if *seed != 0 {
+			overrides["seed"] = fmt.Sprint(*seed)
+		}
+		manifest = runs.NewManifest(configFile, data, engine.Seed, overrides)
+		engine.TrackHistory()
+	}
+	if *verbose {
+		engine.Logger.SetLevel(logging.LevelVerbose)
+	} else {
+		engine.Logger.SetEnabled(false)
+	}
+
+	fmt.Printf("🧪 %s loaded: %d industries, %d people. Type help for commands.\n",
+		region.Name, len(region.Industries), len(region.People))
+	if err := repl.NewSession(engine, os.Stdout).Run(context.Background(), os.Stdin); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if manifest == nil {
+		return
+	}
+	manifest.EndTime = time.Now()
+	manifest.Ticks = engine.CurrentTick
+	for _, change := range engine.Changes {
+		manifest.Changes = append(manifest.Changes, runs.ParameterChange(change))
+	}
+	if err := manifest.Save(*outDir, engine.Results()); err != nil {
+		log.Fatalf("Failed to export run: %v", err)
+	}
+	if err := manifest.WriteFile(*outDir, runs.HistoryFile, engine.History); err != nil {
+		log.Fatalf("Failed to export history: %v", err)
+	}
+	fmt.Printf("📁 Run %s exported to %s\n", manifest.ID, manifest.Dir(*outDir))
+}
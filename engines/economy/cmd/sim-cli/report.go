@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"westex/engines/economy/pkg/report"
+)
+
+// reportCommand handles `sim-cli report`: render a run exported with -out as
+// a standalone HTML page of charts that needs nothing else to view
+func reportCommand(args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	out := fs.String("o", "", "HTML file to write (default: report.html in the run directory)")
+
+	// The run directory may come before the flags, as in `report DIR -o FILE`
+	var dir string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		dir, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if dir == "" && fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	} else if dir == "" || fs.NArg() > 0 {
+		log.Fatalf("Usage: sim-cli report RUN_DIR [-o report.html]")
+	}
+
+	run, err := report.Load(dir)
+	if err != nil {
+		log.Fatalf("Failed to load run: %v", err)
+	}
+	path := *out
+	if path == "" {
+		path = filepath.Join(dir, "report.html")
+	}
+	if err := report.WriteFile(path, run); err != nil {
+		log.Fatalf("%v", err)
+	}
+	fmt.Printf("📊 Report written to %s\n", path)
+}
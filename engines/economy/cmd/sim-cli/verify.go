@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"westex/engines/economy"
+	"westex/engines/economy/pkg/config"
+	"westex/engines/economy/pkg/runs"
+)
+
+// defaultVerifySeed seeds golden runs whose config and manifest set no seed,
+// so a golden file and its checks always draw the same numbers
+const defaultVerifySeed = 1
+
+// verifyCommand handles `sim-cli verify`: rerun a config with a fixed seed
+// and compare the results with a golden results.json, exiting non-zero if
+// anything moved beyond the tolerances
+func verifyCommand(args []string) {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	golden := fs.String("golden", "", "Golden results.json to compare with")
+	seed := fs.Uint64("seed", 0, "Random seed (default: the config's, the golden run's manifest's, or 1)")
+	ticks := fs.Int("ticks", 0, "Ticks to run (default: as many as the golden run)")
+	tolerance := fs.Float64("tolerance", 1e-4, "Relative change allowed in numbers")
+	var fields repeatedFlag
+	fs.Var(&fields, "field", "Tolerance for a path and the values under it, as path=tolerance (repeatable)")
+	update := fs.Bool("update", false, "Write the new results to the golden file instead of comparing")
+	fs.Parse(args)
+
+	if *golden == "" || fs.NArg() != 1 {
+		log.Fatalf("Usage: sim-cli verify -golden results.json [-tolerance 0.0001] [-field path=0.01 ...] [-update] config.yaml")
+	}
+	configFile := fs.Arg(0)
+
+	tolerances := runs.Tolerances{Default: *tolerance, Fields: make(map[string]float64, len(fields))}
+	for _, field := range fields {
+		path, value, ok := strings.Cut(field, "=")
+		parsed, err := strconv.ParseFloat(value, 64)
+		if !ok || err != nil {
+			log.Fatalf("Invalid -field %q, expected path=tolerance", field)
+		}
+		tolerances.Fields[path] = parsed
+	}
+
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	region, err := config.BuildRegionFromConfig(cfg)
+	if err != nil {
+		log.Fatalf("Failed to build region: %v", err)
+	}
+	engine, err := economy.BuildEngine(cfg, region)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	engine.Logger.SetEnabled(false)
+
+	var want []byte
+	if !*update {
+		if want, err = os.ReadFile(*golden); err != nil {
+			log.Fatalf("Failed to read golden results: %v", err)
+		}
+	}
+
+	runSeed := *seed
+	if runSeed == 0 {
+		runSeed = cfg.Simulation.Seed
+	}
+	if runSeed == 0 {
+		runSeed = goldenManifestSeed(*golden)
+	}
+	if runSeed == 0 {
+		runSeed = defaultVerifySeed
+	}
+	engine.SetSeed(runSeed)
+
+	total := *ticks
+	if total == 0 && want != nil {
+		var header struct {
+			Ticks int `json:"ticks"`
+		}
+		if err := json.Unmarshal(want, &header); err != nil {
+			log.Fatalf("Failed to parse golden results: %v", err)
+		}
+		total = header.Ticks
+	}
+	if total == 0 {
+		total = cfg.Simulation.Ticks
+	}
+
+	fmt.Printf("🔁 Running %s for %d ticks with seed %d...\n", cfg.Region.Name, total, runSeed)
+	if err := stepTo(context.Background(), engine, total); err != nil {
+		log.Fatalf("Simulation stopped: %v", err)
+	}
+	got, err := json.MarshalIndent(engine.Results(), "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode results: %v", err)
+	}
+
+	if *update {
+		if err := os.WriteFile(*golden, got, 0644); err != nil {
+			log.Fatalf("Failed to write golden results: %v", err)
+		}
+		fmt.Printf("💾 Golden results written to %s\n", *golden)
+		return
+	}
+
+	differences, err := runs.Diff(want, got, tolerances)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if len(differences) == 0 {
+		fmt.Printf("✅ Results match %s\n", *golden)
+		return
+	}
+	fmt.Printf("❌ %d values differ from %s:\n", len(differences), *golden)
+	for _, difference := range differences {
+		fmt.Printf("  %s\n", difference)
+	}
+	os.Exit(1)
+}
+
+// goldenManifestSeed returns the seed in the manifest next to a golden
+// results file exported with -out, or 0 without one
+func goldenManifestSeed(golden string) uint64 {
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(golden), runs.ManifestFile))
+	if err != nil {
+		return 0
+	}
+	var manifest runs.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return 0
+	}
+	return manifest.Seed
+}
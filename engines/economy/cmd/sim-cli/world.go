@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"westex/engines/economy"
+	"westex/engines/economy/pkg/config"
+	"westex/engines/economy/pkg/core"
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/finance"
+)
+
+// worldCommand handles `sim-cli world`: run several region configs as one
+// world that trades between regions after every tick
+func worldCommand(args []string) {
+	fs := flag.NewFlagSet("world", flag.ExitOnError)
+	ticks := fs.Int("ticks", 0, "Number of ticks to run (default: the first config's simulation.ticks)")
+	parallel := fs.Bool("parallel", false, "Run the regions' phases concurrently")
+	sensitivity := fs.Float64("fx-sensitivity", 0.05, "How far a tick's trade balance moves floating exchange rates")
+	summaryJSON := fs.String("summary-json", "", "Write the world league table to this JSON file")
+	summaryCSV := fs.String("summary-csv", "", "Write the world league table to this CSV file")
+	var configs, commutes repeatedFlag
+	fs.Var(&configs, "config", "Region YAML configuration (repeatable)")
+	fs.Var(&commutes, "commute", "Commute route home:work:cost letting idle workers take jobs next door (repeatable)")
+	fs.Parse(args)
+
+	if len(configs) < 2 {
+		log.Fatalf("Usage: sim-cli world -config a.yaml -config b.yaml [-parallel] [-ticks N]")
+	}
+
+	world := core.NewWorld(finance.NewExchangeMarket(entities.DefaultCurrency, float32(*sensitivity)))
+	world.Parallel = *parallel
+	total := *ticks
+	for _, commute := range commutes {
+		route, err := parseCommute(commute)
+		if err != nil {
+			log.Fatalf("Invalid -commute %q: %v", commute, err)
+		}
+		world.Commutes = append(world.Commutes, route)
+	}
+
+	cfgs := make([]*config.RegionConfig, len(configs))
+	for i, path := range configs {
+		cfg, err := config.LoadConfig(path)
+		if err != nil {
+			log.Fatalf("Failed to load config %s: %v", path, err)
+		}
+		cfgs[i] = cfg
+	}
+	registry, err := config.CheckTradeUnits(cfgs)
+	if err != nil {
+		log.Fatalf("Regions cannot trade: %v", err)
+	}
+	world.Units = registry
+
+	for i, path := range configs {
+		cfg := cfgs[i]
+		region, err := config.BuildRegionFromConfig(cfg)
+		if err != nil {
+			log.Fatalf("Failed to build region %s: %v", path, err)
+		}
+		engine, err := economy.BuildEngine(cfg, region)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		// Region logs would interleave; the world logs the trade phase
+		engine.Logger.SetEnabled(false)
+		config.ListCurrency(cfg, region, world.Exchange)
+		world.AddRegion(engine)
+
+		if total == 0 {
+			total = cfg.Simulation.Ticks
+		}
+		fmt.Printf("🗺️  Loaded %s (%s, %d people, %d industries)\n",
+			region.Name, region.Currency, len(region.People), len(region.Industries))
+	}
+
+	fmt.Printf("\n🌍 Running %d regions for %d ticks (parallel: %v)...\n", len(world.Regions), total, world.Parallel)
+	if err := world.Run(context.Background(), total); err != nil {
+		log.Fatalf("World stopped: %v", err)
+	}
+
+	summary := world.Summary()
+	fmt.Printf("\n🏆 WORLD SUMMARY (money in %s)\n", world.Exchange.Base)
+	core.PrintSummary(os.Stdout, summary)
+
+	if *summaryJSON != "" {
+		if err := core.SaveSummaryJSON(*summaryJSON, summary); err != nil {
+			log.Fatalf("%v", err)
+		}
+		fmt.Printf("📁 Summary written to %s\n", *summaryJSON)
+	}
+	if *summaryCSV != "" {
+		if err := core.SaveSummaryCSV(*summaryCSV, summary); err != nil {
+			log.Fatalf("%v", err)
+		}
+		fmt.Printf("📁 Summary written to %s\n", *summaryCSV)
+	}
+}
+
+// parseCommute reads a commute route given as home:work:cost
+func parseCommute(value string) (core.CommuteRoute, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return core.CommuteRoute{}, fmt.Errorf("expected home:work:cost")
+	}
+	cost, err := strconv.ParseFloat(parts[2], 32)
+	if err != nil || cost < 0 {
+		return core.CommuteRoute{}, fmt.Errorf("cost must be a non-negative number")
+	}
+	return core.CommuteRoute{Home: parts[0], Work: parts[1], Cost: float32(cost)}, nil
+}
@@ -0,0 +1,260 @@
+// Package economy is the library entry point to the simulation. New builds a
+// simulation from a config file, a parsed config or a region built in code,
+// Run and Step advance it, and Report returns its results:
+//
+//	sim, err := economy.New("configs/mumbai.yaml", economy.WithSeed(42))
+//	if err != nil {
+//		return err
+//	}
+//	if err := sim.Run(ctx); err != nil {
+//		return err
+//	}
+//	fmt.Println(sim.Report().TotalWealth)
+//
+// The packages under pkg stay available for what the facade doesn't cover,
+// through Engine.
+package economy
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"westex/engines/economy/pkg/config"
+	"westex/engines/economy/pkg/core"
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/logging"
+)
+
+// Simulation is an economy being simulated
+type Simulation struct {
+	engine *core.Engine
+	ticks  int // Ticks Run advances
+}
+
+// Option adjusts a simulation as New builds it
+type Option func(*Simulation) error
+
+// WithSeed makes the simulation's random draws reproducible, overriding the
+// config's seed
+func WithSeed(seed uint64) Option {
+	return func(s *Simulation) error {
+		s.engine.SetSeed(seed)
+		return nil
+	}
+}
+
+// WithTicks sets how many ticks Run advances, overriding the config's
+func WithTicks(ticks int) Option {
+	return func(s *Simulation) error {
+		if ticks <= 0 {
+			return fmt.Errorf("ticks must be positive")
+		}
+		s.ticks = ticks
+		return nil
+	}
+}
+
+// WithLog writes the simulation's log to w at the given level. Without it
+// the simulation logs nothing.
+func WithLog(w io.Writer, level logging.Level) Option {
+	return func(s *Simulation) error {
+		s.engine.Logger.SetEnabled(true)
+		s.engine.Logger.SetOutput(w)
+		s.engine.Logger.SetLevel(level)
+		return nil
+	}
+}
+
+// New builds a simulation from a config file path, a *config.RegionConfig or
+// an *entities.Region. A region built in code runs with the default
+// simulation settings for the number of ticks set by WithTicks.
+func New(source any, options ...Option) (*Simulation, error) {
+	s := &Simulation{}
+	switch source := source.(type) {
+	case string:
+		cfg, err := config.LoadConfig(source)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.fromConfig(cfg); err != nil {
+			return nil, err
+		}
+	case *config.RegionConfig:
+		if err := s.fromConfig(source); err != nil {
+			return nil, err
+		}
+	case *entities.Region:
+		s.engine = core.NewEngine(source)
+	default:
+		return nil, fmt.Errorf("cannot build a simulation from %T", source)
+	}
+	s.engine.Logger.SetEnabled(false)
+
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// fromConfig builds the simulation's region and engine from a config
+func (s *Simulation) fromConfig(cfg *config.RegionConfig) error {
+	region, err := config.BuildRegionFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to build region: %w", err)
+	}
+	if s.engine, err = BuildEngine(cfg, region); err != nil {
+		return err
+	}
+	s.ticks = cfg.Simulation.Ticks
+	return nil
+}
+
+// Run advances the simulation by its ticks, stopping early if it converges
+// or the context is cancelled, and checks the assertions due at the end
+func (s *Simulation) Run(ctx context.Context) error {
+	if s.ticks <= 0 {
+		return fmt.Errorf("no ticks to run, use WithTicks")
+	}
+	start := s.engine.CurrentTick
+	for i := 0; i < s.ticks; i++ {
+		if err := s.engine.Step(ctx); err != nil {
+			return err
+		}
+		if s.engine.ConvergedAt > start {
+			break
+		}
+	}
+	s.engine.Finish()
+	return nil
+}
+
+// Step advances the simulation by one tick
+func (s *Simulation) Step(ctx context.Context) error {
+	return s.engine.Step(ctx)
+}
+
+// Tick returns the last tick completed
+func (s *Simulation) Tick() int {
+	return s.engine.CurrentTick
+}
+
+// Report returns the state of the economy and what happened in the latest
+// tick
+func (s *Simulation) Report() *core.Results {
+	return s.engine.Results()
+}
+
+// Engine returns the engine behind the simulation, for what the facade
+// doesn't cover
+func (s *Simulation) Engine() *core.Engine {
+	return s.engine
+}
+
+// BuildEngine creates an engine for a region built from a config, with every
+// subsystem the config enables
+func BuildEngine(cfg *config.RegionConfig, region *entities.Region) (*core.Engine, error) {
+	engine := core.NewEngine(region,
+		core.WithWage(cfg.Simulation.WagePerHour),
+		core.WithTickLength(cfg.Simulation.WeeksPerTick, cfg.Simulation.HoursPerWeek),
+	)
+	if cfg.Simulation.DemandAdjustmentRate > 0 {
+		engine.DemandAdjustmentRate = cfg.Simulation.DemandAdjustmentRate
+	}
+	if cfg.Simulation.MarketMode != "" {
+		engine.MarketMode = cfg.Simulation.MarketMode
+	}
+	engine.ProfitMargin = cfg.Simulation.ProfitMargin
+	engine.Rationing = cfg.Simulation.Rationing
+	engine.QueueOrder = cfg.Simulation.QueueOrder
+	engine.MaxOvertime = cfg.Simulation.MaxOvertime
+	engine.WageTiming = cfg.Simulation.WageTiming
+	engine.SkillPay = cfg.Simulation.SkillPay
+	engine.MultipleJobs = cfg.Simulation.MultipleJobs
+	engine.InputAllocation = cfg.Simulation.InputAllocation
+	engine.Weekly = cfg.Simulation.Weekly
+	engine.WarmUp = cfg.Simulation.WarmUpTicks
+	engine.MeasureTicks = cfg.Simulation.MeasureTicks
+	engine.Guards = cfg.Simulation.Guards
+	if err := engine.SetPhases(cfg.Simulation.Phases); err != nil {
+		return nil, fmt.Errorf("invalid phases: %w", err)
+	}
+	engine.HistoryLength = cfg.Simulation.HistoryLength
+	engine.TrackPeople(cfg.Simulation.TrackPeople)
+	if cfg.Simulation.Cohorts {
+		engine.TrackCohorts()
+	}
+	if cfg.Simulation.Matrices {
+		engine.TrackMatrices()
+	}
+	engine.LoyalShoppers = cfg.Simulation.LoyalShoppers
+	if centralBank := config.BuildCentralBank(cfg); centralBank != nil {
+		engine.CentralBank = centralBank
+		engine.Bank = centralBank.Bank
+	}
+	engine.Government = config.BuildGovernment(cfg)
+	engine.Welfare = config.BuildWelfare(cfg)
+	engine.Clock = config.BuildClock(cfg)
+	engine.Transitions = config.BuildTransitions(cfg)
+	engine.Forecasting = config.BuildForecasting(cfg)
+	if cfg.Informal != nil {
+		engine.Informal = &core.InformalEconomy{
+			Premium:           cfg.Informal.Premium,
+			BaseParticipation: cfg.Informal.BaseParticipation,
+			TaxSensitivity:    cfg.Informal.TaxSensitivity,
+		}
+	}
+	if cfg.Barter != nil {
+		engine.Barter = &core.BarterSettings{
+			HoursPerUnit:   cfg.Barter.HoursPerUnit,
+			MoneyThreshold: cfg.Barter.MoneyThreshold,
+		}
+	}
+	if cfg.Marketing != nil {
+		engine.Marketing = &core.MarketingSettings{
+			BaseAwareness: cfg.Marketing.BaseAwareness,
+			HalfSpend:     cfg.Marketing.HalfSpend,
+		}
+	}
+	if c := cfg.Convergence; c != nil {
+		engine.Convergence = &core.ConvergenceSettings{Window: 5, Tolerance: 0.01}
+		if c.Window > 0 {
+			engine.Convergence.Window = c.Window
+		}
+		if c.Tolerance > 0 {
+			engine.Convergence.Tolerance = c.Tolerance
+		}
+	}
+	if len(cfg.Assertions) > 0 {
+		assertions := make([]core.Assertion, len(cfg.Assertions))
+		for i, a := range cfg.Assertions {
+			assertions[i] = core.Assertion{
+				Name: a.Name, Metric: a.Metric, Of: a.Of, Op: a.Op, Value: a.Value, At: a.At, From: a.From,
+			}
+		}
+		if err := engine.SetAssertions(assertions); err != nil {
+			return nil, fmt.Errorf("invalid assertions: %w", err)
+		}
+	}
+	if cfg.Simulation.Seed != 0 {
+		engine.SetSeed(cfg.Simulation.Seed)
+	}
+
+	var err error
+	engine.Units, err = config.BuildUnits(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid units: %w", err)
+	}
+	engine.Shocks, err = config.BuildShocks(cfg, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build shocks: %w", err)
+	}
+	engine.Insurers, err = config.BuildInsurers(cfg, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build insurers: %w", err)
+	}
+
+	return engine, nil
+}
@@ -0,0 +1,39 @@
+package economy
+
+import (
+	"context"
+	"testing"
+
+	"westex/engines/economy/pkg/config"
+)
+
+func TestNew_RunsConfig(t *testing.T) {
+	run := func() float32 {
+		cfg, err := config.Generate(config.GenerateOptions{Population: 50, Development: 0.5, Richness: 0.5, Seed: 3})
+		if err != nil {
+			t.Fatalf("Failed to generate a region: %v", err)
+		}
+		sim, err := New(cfg, WithSeed(42), WithTicks(3))
+		if err != nil {
+			t.Fatalf("Expected the simulation to build, got %v", err)
+		}
+		if err := sim.Run(context.Background()); err != nil {
+			t.Fatalf("Expected run to succeed, got %v", err)
+		}
+		if sim.Tick() != 3 || sim.Report().Ticks != 3 {
+			t.Errorf("Expected 3 ticks, got %d", sim.Tick())
+		}
+		return sim.Report().TotalWealth
+	}
+
+	if first, second := run(), run(); first != second {
+		t.Errorf("Expected the same seed to give the same wealth, got %.2f and %.2f", first, second)
+	}
+
+	if _, err := New(42); err == nil {
+		t.Error("Expected error for an unsupported source")
+	}
+	if _, err := New("missing.yaml"); err == nil {
+		t.Error("Expected error for a missing config file")
+	}
+}
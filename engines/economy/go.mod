@@ -1,5 +1,5 @@
 module westex/engines/economy
 
-go 1.21
+go 1.22
 
 require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,89 @@
+package clock
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is how start dates are written in configs
+const DateLayout = "2006-01-02"
+
+// Lengths of calendar units in weeks
+const (
+	weeksPerMonth = 52.0 / 12
+	weeksPerYear  = 52.0
+)
+
+// Clock maps ticks to calendar time: tick 1 starts on Start and every tick
+// lasts WeeksPerTick weeks
+type Clock struct {
+	Start        time.Time
+	WeeksPerTick int
+}
+
+// New creates a clock starting on a date
+func New(start time.Time, weeksPerTick int) *Clock {
+	return &Clock{Start: start, WeeksPerTick: weeksPerTick}
+}
+
+// Date returns the day a tick starts on
+func (c *Clock) Date(tick int) time.Time {
+	return c.Start.AddDate(0, 0, (tick-1)*c.WeeksPerTick*7)
+}
+
+// Label names the month a tick starts in, such as "March 2025"
+func (c *Clock) Label(tick int) string {
+	return c.Date(tick).Format("January 2006")
+}
+
+// Month returns the month a tick starts in, for seasonal mechanics
+func (c *Clock) Month(tick int) time.Month {
+	return c.Date(tick).Month()
+}
+
+// TickAt returns the tick a date falls in (0 or less before the start)
+func (c *Clock) TickAt(date time.Time) int {
+	days := int(math.Floor(date.Sub(c.Start).Hours() / 24))
+	if days < 0 {
+		return 0
+	}
+	return days/(c.WeeksPerTick*7) + 1
+}
+
+// ParseDuration converts a duration such as "2 years", "6 months",
+// "10 weeks" or "12 ticks" into ticks of the given length, rounding to the
+// nearest tick. A bare number is a number of ticks.
+func ParseDuration(duration string, weeksPerTick int) (int, error) {
+	if weeksPerTick <= 0 {
+		return 0, fmt.Errorf("weeks per tick must be positive")
+	}
+	fields := strings.Fields(duration)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, fmt.Errorf("invalid duration %q, expected a number and a unit such as \"2 years\"", duration)
+	}
+	amount, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid duration %q, expected a number and a unit such as \"2 years\"", duration)
+	}
+	if len(fields) == 1 {
+		return int(math.Round(amount)), nil
+	}
+
+	var weeks float64
+	switch strings.TrimSuffix(strings.ToLower(fields[1]), "s") {
+	case "tick":
+		return int(math.Round(amount)), nil
+	case "week":
+		weeks = amount
+	case "month":
+		weeks = amount * weeksPerMonth
+	case "year":
+		weeks = amount * weeksPerYear
+	default:
+		return 0, fmt.Errorf("unknown unit %q in duration %q (ticks, weeks, months or years)", fields[1], duration)
+	}
+	return int(math.Round(weeks / float64(weeksPerTick))), nil
+}
@@ -0,0 +1,60 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock_Dates(t *testing.T) {
+	c := New(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), 4)
+
+	if date := c.Date(1).Format(DateLayout); date != "2025-01-01" {
+		t.Errorf("Expected tick 1 to start on 2025-01-01, got %s", date)
+	}
+	if date := c.Date(3).Format(DateLayout); date != "2025-02-26" {
+		t.Errorf("Expected tick 3 to start on 2025-02-26, got %s", date)
+	}
+	if label := c.Label(4); label != "March 2025" {
+		t.Errorf("Expected tick 4 to be March 2025, got %s", label)
+	}
+	if month := c.Month(14); month != time.December {
+		t.Errorf("Expected tick 14 to start in December, got %s", month)
+	}
+	if tick := c.TickAt(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)); tick != 3 {
+		t.Errorf("Expected March 1st to fall in tick 3, got %d", tick)
+	}
+	if tick := c.TickAt(time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)); tick != 0 {
+		t.Errorf("Expected a date before the start to be tick 0, got %d", tick)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]int{
+		"2 years":  26,
+		"6 months": 7,
+		"10 weeks": 3,
+		"1 week":   0,
+		"12 ticks": 12,
+		"1 Year":   13,
+		"8":        8,
+	}
+	for duration, want := range cases {
+		ticks, err := ParseDuration(duration, 4)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got %v", duration, err)
+			continue
+		}
+		if ticks != want {
+			t.Errorf("Expected %q to be %d ticks, got %d", duration, want, ticks)
+		}
+	}
+
+	for _, duration := range []string{"", "two years", "3 fortnights", "-1 years", "1 2 3"} {
+		if _, err := ParseDuration(duration, 4); err == nil {
+			t.Errorf("Expected error for %q", duration)
+		}
+	}
+	if _, err := ParseDuration("1 year", 0); err == nil {
+		t.Error("Expected error for ticks of no weeks")
+	}
+}
@@ -2,21 +2,70 @@ package config
 
 import (
 	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"westex/engines/economy/pkg/clock"
 	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/finance"
+	"westex/engines/economy/pkg/government"
+	"westex/engines/economy/pkg/insurance"
+	"westex/engines/economy/pkg/market"
+	"westex/engines/economy/pkg/names"
+	"westex/engines/economy/pkg/production"
+	"westex/engines/economy/pkg/shocks"
+	"westex/engines/economy/pkg/transitions"
+	"westex/engines/economy/pkg/welfare"
 )
 
 // BuildRegionFromConfig creates a Region from configuration
 func BuildRegionFromConfig(config *RegionConfig) (*entities.Region, error) {
 	region := entities.NewRegion(config.Region.Name)
+	if config.Region.Currency != "" {
+		region.Currency = config.Region.Currency
+	}
+
+	// Create zones
+	for _, zConfig := range config.Zones {
+		region.AddZone(entities.NewZone(zConfig.Name, zConfig.X, zConfig.Y))
+	}
+	if config.Transport != nil {
+		region.Transport = &entities.TransportNetwork{
+			CostPerDistance:  config.Transport.CostPerDistance,
+			HoursPerDistance: config.Transport.HoursPerDistance,
+			CapacityScale:    config.Transport.CapacityScale,
+		}
+	}
 
 	// Create problems map for lookup
 	problemsMap := make(map[string]*entities.Problem)
 	for _, pConfig := range config.Problems {
-		problem := entities.NewProblem(pConfig.Name, pConfig.Description, pConfig.Demand)
+		severity := pConfig.Demand
+		if pConfig.Severity > 0 {
+			severity = pConfig.Severity
+		}
+		problem := entities.NewProblem(pConfig.Name, pConfig.Description, severity)
+		problem.Escalation = pConfig.Escalation
+		problem.Decay = pConfig.Decay
 		problem.IsBasicNeed = pConfig.IsBasicNeed
+		problem.UnitsPerPerson = pConfig.UnitsPerPerson
+		problem.UpdateDemand(pConfig.Demand)
 		region.AddProblem(problem)
 		problemsMap[pConfig.Name] = problem
 	}
+	for _, pConfig := range config.Problems {
+		for _, part := range pConfig.Parts {
+			sub, exists := problemsMap[part.Problem]
+			if !exists {
+				return nil, fmt.Errorf("problem %s references unknown part: %s", pConfig.Name, part.Problem)
+			}
+			weight := part.Weight
+			if weight == 0 {
+				weight = 1
+			}
+			problemsMap[pConfig.Name].AddPart(sub, weight)
+		}
+	}
 
 	// Create resources map for lookup
 	resourcesMap := make(map[string]*entities.Resource)
@@ -24,12 +73,31 @@ func BuildRegionFromConfig(config *RegionConfig) (*entities.Region, error) {
 		resource := entities.NewResource(rConfig.Name, rConfig.Unit)
 		resource.Quantity = rConfig.InitialQuantity
 		resource.IsFree = rConfig.IsFree
+		resource.Kind = rConfig.Kind
 		resource.RegenerationRate = rConfig.RegenerationRate
+		resource.Capacity = rConfig.Capacity
+		resource.CollapseBelow = rConfig.CollapseBelow
+		for _, tag := range rConfig.Tags {
+			resource.AddTag(tag)
+		}
 		region.AddResource(resource)
 		resourcesMap[rConfig.Name] = resource
 	}
 
-	// Create industries
+	// Create industries. Every industry holds its own stock of each product
+	// it makes, apart from the region's resources and from other makers',
+	// and stocks lists them by product name.
+	industriesMap := make(map[string]*entities.Industry)
+	stocks := make(map[string][]*entities.Resource)
+	shared := make(map[*entities.Resource]bool) // Makers' stocks used directly as inputs
+
+	// Inputs delivered under a forward contract, by buyer and product
+	contracted := make(map[[2]string]bool)
+	for _, cConfig := range config.Contracts {
+		if cConfig.BuyerIndustry != "" {
+			contracted[[2]string{cConfig.BuyerIndustry, cConfig.Product}] = true
+		}
+	}
 	for _, iConfig := range config.Industries {
 		// Get problems this industry solves
 		solvedProblems := make([]*entities.Problem, 0)
@@ -46,24 +114,37 @@ func BuildRegionFromConfig(config *RegionConfig) (*entities.Region, error) {
 		for _, resourceName := range iConfig.InputResources {
 			if resource, exists := resourcesMap[resourceName]; exists {
 				inputResources = append(inputResources, resource)
+			} else if made, exists := stocks[resourceName]; exists {
+				// A product bought under a forward contract arrives in a stock
+				// of the industry's own; without one, the industry draws on
+				// its first maker's stock
+				if contracted[[2]string{iConfig.Name, resourceName}] {
+					inputResources = append(inputResources, made[0].NewStock())
+				} else {
+					inputResources = append(inputResources, made[0])
+					shared[made[0]] = true
+				}
 			} else {
 				return nil, fmt.Errorf("industry %s references unknown input resource: %s", iConfig.Name, resourceName)
 			}
 		}
 
-		// Create output resources (products)
+		// Create output resources (products); retailers get theirs from suppliers
+		outputNames := iConfig.OutputResources
+		if len(iConfig.SuppliedBy) > 0 {
+			outputNames = nil
+		}
 		outputResources := make([]*entities.Resource, 0)
-		for _, resourceName := range iConfig.OutputResources {
-			// Check if resource already exists
+		for _, resourceName := range outputNames {
+			// Products named after a regional resource keep its unit but
+			// not its stock
+			unit := "units"
 			if resource, exists := resourcesMap[resourceName]; exists {
-				outputResources = append(outputResources, resource)
-			} else {
-				// Create new product resource
-				resource := entities.NewResource(resourceName, "units")
-				resource.Quantity = 0 // Products start at 0
-				outputResources = append(outputResources, resource)
-				resourcesMap[resourceName] = resource
+				unit = resource.Unit
 			}
+			stock := entities.NewResource(resourceName, unit)
+			outputResources = append(outputResources, stock)
+			stocks[resourceName] = append(stocks[resourceName], stock)
 		}
 
 		// Create industry
@@ -72,7 +153,139 @@ func BuildRegionFromConfig(config *RegionConfig) (*entities.Region, error) {
 			UpdateLabor(iConfig.LaborNeeded).
 			SetInitialCapital(iConfig.InitialCapital)
 
+		industry.IsTransport = iConfig.Transport
+		industry.MarketingSpend = iConfig.MarketingSpend
+		industry.ProductionTarget = iConfig.ProductionTarget
+		industry.Shifts = iConfig.Shifts
+		industry.NightPremium = iConfig.NightPremium
+		industry.FixedCosts = iConfig.FixedCosts
+		industry.HiresFrom = append([]string(nil), iConfig.HiresFrom...)
+		industry.InputPriority = iConfig.InputPriority
+		if d := iConfig.Downtime; d != nil {
+			industry.Reliability = &entities.Reliability{Chance: d.Chance, Every: d.Every, Duration: d.Duration}
+		}
+		industry.Public = iConfig.Public
+		industry.Subsidy = iConfig.Subsidy
+		for _, tag := range iConfig.Tags {
+			industry.AddTag(tag)
+		}
+		if industry.Public && industry.Subsidy == 0 {
+			industry.Subsidy = 1.0
+		}
+		if iConfig.Zone != "" {
+			industry.Zone = region.GetZone(iConfig.Zone)
+			if industry.Zone == nil {
+				return nil, fmt.Errorf("industry %s references unknown zone: %s", iConfig.Name, iConfig.Zone)
+			}
+		}
+
+		if iConfig.School != nil {
+			industry.IsSchool = true
+			industry.Seats = iConfig.School.Seats
+			industry.CourseTicks = iConfig.School.CourseTicks
+			industry.SkillGain = iConfig.School.SkillGain
+			industry.Tuition = iConfig.School.Tuition
+		}
+
 		region.AddIndustry(industry)
+		industriesMap[iConfig.Name] = industry
+	}
+
+	// Apply product attributes (substitute efficiency and complements) to
+	// every maker's stock of the product
+	for _, pConfig := range config.Products {
+		made, exists := stocks[pConfig.Name]
+		if !exists {
+			return nil, fmt.Errorf("product config references unknown product: %s", pConfig.Name)
+		}
+		if pConfig.Satisfies < 0 {
+			return nil, fmt.Errorf("product %s satisfies a negative share of a need: %.2f", pConfig.Name, pConfig.Satisfies)
+		}
+		// Complements are bought by name from whoever has them in stock
+		complements := make([]*entities.Resource, 0, len(pConfig.Complements))
+		for _, complementName := range pConfig.Complements {
+			if made, exists := stocks[complementName]; exists {
+				complements = append(complements, made[0])
+			} else if resource, exists := resourcesMap[complementName]; exists {
+				complements = append(complements, resource)
+			} else {
+				return nil, fmt.Errorf("product %s references unknown complement: %s", pConfig.Name, complementName)
+			}
+		}
+		solves := make([]*entities.Problem, 0, len(pConfig.Solves))
+		for _, problemName := range pConfig.Solves {
+			problem, exists := problemsMap[problemName]
+			if !exists {
+				return nil, fmt.Errorf("product %s references unknown problem: %s", pConfig.Name, problemName)
+			}
+			for _, industry := range region.Industries {
+				if industry.OutputProducts.Find(pConfig.Name) != nil && !industry.Solves(problem) {
+					return nil, fmt.Errorf("product %s solves %s, which its maker %s does not", pConfig.Name, problemName, industry.Name)
+				}
+			}
+			solves = append(solves, problem)
+		}
+
+		if pConfig.Unit != "" {
+			setUnit(region, pConfig.Name, pConfig.Unit, resourcesMap)
+		}
+		for _, product := range made {
+			if pConfig.Efficiency > 0 {
+				product.Efficiency = pConfig.Efficiency
+			}
+			product.Satisfies = pConfig.Satisfies
+			for _, complement := range complements {
+				product.AddComplement(complement)
+			}
+			for _, tag := range pConfig.Tags {
+				product.AddTag(tag)
+			}
+			if len(solves) > 0 {
+				product.Solves = solves
+			}
+		}
+	}
+
+	// Link retailers to their suppliers once every industry exists
+	industryConfigs := make(map[string]IndustryConfig)
+	for _, iConfig := range config.Industries {
+		industryConfigs[iConfig.Name] = iConfig
+	}
+	for _, iConfig := range config.Industries {
+		if len(iConfig.SuppliedBy) == 0 {
+			continue
+		}
+		retailer := industriesMap[iConfig.Name]
+		retailer.TargetInventory = iConfig.TargetInventory
+		retailer.Markup = iConfig.Markup
+
+		for _, supplierName := range iConfig.SuppliedBy {
+			supplier, exists := industriesMap[supplierName]
+			if !exists {
+				return nil, fmt.Errorf("retailer %s references unknown supplier: %s", iConfig.Name, supplierName)
+			}
+			if len(industryConfigs[supplierName].SuppliedBy) > 0 {
+				return nil, fmt.Errorf("retailer %s cannot be supplied by retailer %s", iConfig.Name, supplierName)
+			}
+			retailer.AddSupplier(supplier)
+
+			// Retailers hold their own stock of each supplied product
+			for _, product := range supplier.OutputProducts {
+				if retailer.OutputProducts.Find(product.Name) == nil {
+					retailer.OutputProducts = append(retailer.OutputProducts, product.NewStock())
+				}
+			}
+		}
+	}
+
+	// Stock industries start with, once retailers hold their products
+	for _, iConfig := range config.Industries {
+		industry := industriesMap[iConfig.Name]
+		for _, stock := range iConfig.InitialInventory {
+			if err := stockUp(industry, stock, resourcesMap, shared); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Create population segments map
@@ -88,31 +301,495 @@ func BuildRegionFromConfig(config *RegionConfig) (*entities.Region, error) {
 
 		size := int(float32(config.Population.TotalSize) * sConfig.Percentage)
 		segment := &entities.PopulationSegment{
-			Name:     sConfig.Name,
-			Problems: segmentProblems,
-			Size:     size,
+			Name:              sConfig.Name,
+			Problems:          segmentProblems,
+			Size:              size,
+			SavingsPropensity: sConfig.SavingsPropensity,
+			ReservationWage:   sConfig.ReservationWage,
+			TargetIncome:      sConfig.TargetIncome,
+			Retired:           sConfig.Retired,
+			Role:              sConfig.Role,
 		}
 		segmentsMap[sConfig.Name] = segment
 		region.AddPopulationSegment(segment)
 	}
 
+	// Create contracts
+	for _, cConfig := range config.Contracts {
+		contract, err := buildContract(cConfig, industriesMap, segmentsMap)
+		if err != nil {
+			return nil, err
+		}
+		region.AddContract(contract)
+	}
+
 	// Create people
 	personID := 1
-	for _, sConfig := range config.Population.Segments {
+	for i, sConfig := range config.Population.Segments {
 		segment := segmentsMap[sConfig.Name]
 		count := int(float32(config.Population.TotalSize) * sConfig.Percentage)
 
-		for i := 0; i < count; i++ {
+		var zone *entities.Zone
+		if sConfig.Zone != "" {
+			zone = region.GetZone(sConfig.Zone)
+			if zone == nil {
+				return nil, fmt.Errorf("segment %s references unknown zone: %s", sConfig.Name, sConfig.Zone)
+			}
+		}
+
+		money, skill, hours := constant(sConfig.InitialMoney), constant(1), constant(sConfig.LaborHours)
+		if sConfig.Wealth != nil {
+			money = draws(sConfig.Wealth, config.Simulation.Seed, i, wealthStream)
+		}
+		if sConfig.Productivity != nil {
+			skill = draws(sConfig.Productivity, config.Simulation.Seed, i, productivityStream)
+		}
+		if sConfig.Hours != nil {
+			hours = draws(sConfig.Hours, config.Simulation.Seed, i, hoursStream)
+		}
+
+		for j := 0; j < count; j++ {
 			person := entities.NewPerson(
 				fmt.Sprintf("Person-%d", personID),
-				sConfig.InitialMoney,
-				sConfig.LaborHours,
+				money(),
+				min(hours(), hoursPerDay),
 			)
+			person.Skill = skill()
 			person.AddSegment(segment)
+			person.Zone = zone
+			for _, tag := range sConfig.Tags {
+				person.AddTag(tag)
+			}
 			region.AddPerson(person)
 			personID++
 		}
 	}
 
+	// Hand out shares once people exist
+	for _, iConfig := range config.Industries {
+		industry := industriesMap[iConfig.Name]
+		industry.DividendPayout = iConfig.DividendPayout
+		industry.Cooperative = iConfig.Cooperative
+		if industry.Cooperative && industry.DividendPayout == 0 {
+			industry.DividendPayout = 1.0
+		}
+		for _, oConfig := range iConfig.Owners {
+			if err := assignOwners(region, industry, oConfig, segmentsMap); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if config.Population.Households != nil {
+		formHouseholds(region, config.Population.Households, config.Simulation.Seed)
+	}
+
+	// Readable names replace the numbered ones last, once owners (who are
+	// configured by their numbered name) have their shares
+	if config.Population.Names != "" {
+		generator, err := names.NewGenerator(config.Population.Names, config.Simulation.Seed)
+		if err != nil {
+			return nil, err
+		}
+		for _, person := range region.People {
+			person.Name = generator.Person()
+		}
+	}
+
 	return region, nil
 }
+
+// formHouseholds groups the region's people, in a seeded random order, into
+// households with sizes drawn from the configured distribution. Members
+// after the first may be children, who keep their needs but never work.
+func formHouseholds(region *entities.Region, config *HouseholdsConfig, seed uint64) {
+	rng := rand.New(rand.NewPCG(seed, seed^0x2545f4914f6cdd1d))
+	people := append([]*entities.Person(nil), region.People...)
+	rng.Shuffle(len(people), func(i, j int) { people[i], people[j] = people[j], people[i] })
+
+	for start := 0; start < len(people); {
+		size := config.Sizes[len(config.Sizes)-1].Size
+		draw := rng.Float32()
+		for _, option := range config.Sizes {
+			if draw < option.Share {
+				size = option.Size
+				break
+			}
+			draw -= option.Share
+		}
+
+		household := entities.NewHousehold(fmt.Sprintf("Household-%d", len(region.Households)+1))
+		for _, person := range people[start:min(start+size, len(people))] {
+			if len(household.Members) > 0 && rng.Float32() < config.ChildShare {
+				person.Child = true
+				person.LaborHours = 0
+			}
+			household.AddMember(person)
+		}
+		region.AddHousehold(household)
+		start += size
+	}
+}
+
+// assignOwners issues an owner config's shares to a person or segment members
+func assignOwners(
+	region *entities.Region,
+	industry *entities.Industry,
+	oConfig OwnerConfig,
+	segmentsMap map[string]*entities.PopulationSegment,
+) error {
+	if oConfig.Person != "" {
+		for _, person := range region.People {
+			if person.Name == oConfig.Person {
+				industry.IssueShares(person, oConfig.Shares)
+				return nil
+			}
+		}
+		return fmt.Errorf("industry %s references unknown owner: %s", industry.Name, oConfig.Person)
+	}
+
+	segment, exists := segmentsMap[oConfig.Segment]
+	if !exists {
+		return fmt.Errorf("industry %s references unknown owner segment: %s", industry.Name, oConfig.Segment)
+	}
+	members := segment.Members()
+	for _, member := range members {
+		industry.IssueShares(member, oConfig.Shares/float32(len(members)))
+	}
+	return nil
+}
+
+// stockUp puts a starting stock in an industry's inventory or input stock.
+// Land and other stock resources are occupied from the region's; the region
+// owns other resources, and the maker owns a product used without a
+// contract, so an industry can't hold a stock of its own of those.
+func stockUp(industry *entities.Industry, stock StockConfig, resourcesMap map[string]*entities.Resource, shared map[*entities.Resource]bool) error {
+	if stock.Units < 0 {
+		return fmt.Errorf("industry %s: initial inventory of %s must not be negative", industry.Name, stock.Of)
+	}
+	if product := industry.OutputProducts.Find(stock.Of); product != nil {
+		product.Add(stock.Units)
+		return nil
+	}
+	for _, input := range industry.InputResources {
+		if input.Name != stock.Of {
+			continue
+		}
+		if input.IsStock() {
+			if held := industry.Occupy(input, stock.Units); held < stock.Units {
+				return fmt.Errorf("industry %s: only %.2f %s of %s free for its initial inventory", industry.Name, held, input.Unit, input.Name)
+			}
+			return nil
+		}
+		if input == resourcesMap[input.Name] {
+			return fmt.Errorf("industry %s: %s is the region's resource and can't be held as initial inventory", industry.Name, input.Name)
+		}
+		if shared[input] {
+			return fmt.Errorf("industry %s: %s comes from its maker's stock without a contract, so stock the maker instead", industry.Name, input.Name)
+		}
+		input.Add(stock.Units)
+		return nil
+	}
+	return fmt.Errorf("industry %s: initial inventory of %s, which it neither makes nor uses", industry.Name, stock.Of)
+}
+
+// setUnit counts every industry's stock of a product, made or bought as an
+// input, in the unit, leaving the region's same-named resource as it is
+func setUnit(region *entities.Region, name, unit string, resourcesMap map[string]*entities.Resource) {
+	for _, industry := range region.Industries {
+		for _, stocks := range [][]*entities.Resource{industry.InputResources, industry.OutputProducts} {
+			for _, stock := range stocks {
+				if stock.Name == name && stock != resourcesMap[name] {
+					stock.Unit = unit
+				}
+			}
+		}
+	}
+}
+
+// BuildCentralBank creates the monetary authority, or nil if none is configured
+func BuildCentralBank(config *RegionConfig) *finance.CentralBank {
+	policy := config.MonetaryPolicy
+	if policy == nil {
+		return nil
+	}
+
+	bank := finance.NewBank(policy.DepositSpread, policy.LendingSpread)
+	bank.Lending = buildLending(policy.Lending)
+	centralBank := finance.NewCentralBank(policy.InterestRate, bank)
+	for _, iConfig := range policy.Interventions {
+		centralBank.Schedule(finance.Intervention{
+			Tick:   iConfig.Tick,
+			Type:   iConfig.Type,
+			Amount: iConfig.Amount,
+			Rate:   iConfig.Rate,
+		})
+	}
+	return centralBank
+}
+
+// buildLending creates the bank's lending policy, or nil if it doesn't lend
+func buildLending(lConfig *LendingConfig) *finance.Lending {
+	if lConfig == nil {
+		return nil
+	}
+	lending := &finance.Lending{
+		Term:             12,
+		MaxDebtToRevenue: lConfig.MaxDebtToRevenue,
+		MinScore:         lConfig.MinScore,
+		RiskPremium:      lConfig.RiskPremium,
+	}
+	if lConfig.Term > 0 {
+		lending.Term = lConfig.Term
+	}
+	return lending
+}
+
+// ApplyMonetaryPolicy changes an existing central bank to a branch's
+// monetary policy: its bank gets the new spreads, the new policy rate takes
+// effect and the policy's interventions are scheduled
+func ApplyMonetaryPolicy(policy *MonetaryPolicyConfig, centralBank *finance.CentralBank) {
+	centralBank.Bank.DepositSpread = policy.DepositSpread
+	centralBank.Bank.LendingSpread = policy.LendingSpread
+	if policy.Lending != nil {
+		centralBank.Bank.Lending = buildLending(policy.Lending)
+	}
+	centralBank.SetPolicyRate(policy.InterestRate)
+	for _, iConfig := range policy.Interventions {
+		centralBank.Schedule(finance.Intervention{
+			Tick:   iConfig.Tick,
+			Type:   iConfig.Type,
+			Amount: iConfig.Amount,
+			Rate:   iConfig.Rate,
+		})
+	}
+}
+
+// ListCurrency adds a region's currency to an exchange market using the
+// regime and starting rate from its config
+func ListCurrency(config *RegionConfig, region *entities.Region, exchange *finance.ExchangeMarket) {
+	if _, listed := exchange.Currencies[region.Currency]; listed {
+		return
+	}
+	rate := config.Region.Rate
+	if rate <= 0 {
+		rate = 1.0
+	}
+	regime := config.Region.Exchange
+	if regime == "" {
+		regime = finance.FixedRate
+	}
+	exchange.AddCurrency(region.Currency, rate, regime)
+}
+
+// BuildGovernment creates the government, or nil if none is configured
+func BuildGovernment(config *RegionConfig) *government.Government {
+	if config.Government == nil {
+		return nil
+	}
+	gov := government.NewGovernment(config.Government.Treasury, config.Government.SalesTaxRate)
+	if reserve := config.Government.Reserve; reserve != nil {
+		gov.Reserve = government.NewReserve(reserve.Capacity, reserve.BuyShare, reserve.ReleaseAt, reserve.Price)
+	}
+	if len(config.Government.PriceControls) > 0 {
+		gov.PriceControls = make(market.PriceControls, len(config.Government.PriceControls))
+		for _, control := range config.Government.PriceControls {
+			gov.PriceControls[control.Product] = market.PriceControl{Ceiling: control.Ceiling, Floor: control.Floor}
+		}
+	}
+	if u := config.Government.Unemployment; u != nil {
+		gov.Unemployment = government.NewUnemployment(u.PayrollTaxRate, u.ReplacementRate, u.Duration)
+	}
+	if pension := config.Government.Pension; pension != nil {
+		gov.Pension = &government.Pension{Amount: pension.Amount, Source: pension.Source}
+		if gov.Pension.Source == "" {
+			gov.Pension.Source = government.PensionFromTreasury
+		}
+	}
+	return gov
+}
+
+// BuildClock creates the calendar clock for a config with a start date, or
+// returns nil without one
+func BuildClock(config *RegionConfig) *clock.Clock {
+	start, err := time.Parse(clock.DateLayout, config.Simulation.StartDate)
+	if err != nil {
+		return nil
+	}
+	return clock.New(start, config.Simulation.WeeksPerTick)
+}
+
+// BuildWelfare creates the utility function people are scored with, or nil
+// if welfare scoring is off
+func BuildWelfare(config *RegionConfig) *welfare.Utility {
+	if config.Welfare == nil {
+		return nil
+	}
+	utility := welfare.DefaultUtility()
+	w := config.Welfare
+	if w.NeedsWeight > 0 || w.LeisureWeight > 0 || w.SavingsWeight > 0 {
+		utility.NeedsWeight = w.NeedsWeight
+		utility.LeisureWeight = w.LeisureWeight
+		utility.SavingsWeight = w.SavingsWeight
+	}
+	if w.SavingsScale > 0 {
+		utility.SavingsScale = w.SavingsScale
+	}
+	return utility
+}
+
+// BuildTransitions creates the segment transition tracker, or nil if no
+// transitions are configured
+func BuildTransitions(config *RegionConfig) *transitions.Tracker {
+	if len(config.Transitions) == 0 {
+		return nil
+	}
+	rules := make([]transitions.Rule, 0, len(config.Transitions))
+	for _, tConfig := range config.Transitions {
+		rules = append(rules, transitions.Rule{
+			From:    tConfig.From,
+			To:      tConfig.To,
+			Trigger: transitions.Trigger(tConfig.When),
+			Ticks:   tConfig.Ticks,
+			Chance:  tConfig.Chance,
+		})
+	}
+	return transitions.NewTracker(rules)
+}
+
+// BuildForecasting creates the forecaster industries plan production with, or
+// nil if they always produce at capacity
+func BuildForecasting(config *RegionConfig) *production.Forecaster {
+	if config.Forecasting == nil {
+		return nil
+	}
+	f := config.Forecasting
+	forecaster := &production.Forecaster{
+		Method: production.ForecastMovingAverage,
+		Window: f.Window,
+		Alpha:  0.5,
+		Buffer: 0.1,
+	}
+	if f.Method != "" {
+		forecaster.Method = f.Method
+	}
+	if f.Alpha > 0 {
+		forecaster.Alpha = f.Alpha
+	}
+	if f.Buffer > 0 {
+		forecaster.Buffer = f.Buffer
+	}
+	return forecaster
+}
+
+// BuildShocks resolves the configured shocks against a built region
+func BuildShocks(config *RegionConfig, region *entities.Region) ([]*shocks.Shock, error) {
+	result := make([]*shocks.Shock, 0, len(config.Shocks))
+	for _, sConfig := range config.Shocks {
+		shock := &shocks.Shock{
+			Type:        sConfig.Type,
+			Tick:        sConfig.Tick,
+			Probability: sConfig.Probability,
+			Severity:    sConfig.Severity,
+		}
+		if sConfig.Industry != "" {
+			if shock.Industry = region.GetIndustry(sConfig.Industry); shock.Industry == nil {
+				return nil, fmt.Errorf("shock references unknown industry: %s", sConfig.Industry)
+			}
+		}
+		if sConfig.Segment != "" {
+			if shock.Segment = region.GetPopulationSegment(sConfig.Segment); shock.Segment == nil {
+				return nil, fmt.Errorf("shock references unknown segment: %s", sConfig.Segment)
+			}
+		}
+		result = append(result, shock)
+	}
+	return result, nil
+}
+
+// BuildInsurers creates the configured insurers and their policies; segment
+// policies are written for every member of the segment
+func BuildInsurers(config *RegionConfig, region *entities.Region) ([]*insurance.Insurer, error) {
+	insurers := make([]*insurance.Insurer, 0, len(config.Insurance))
+	for _, iConfig := range config.Insurance {
+		insurer := insurance.NewInsurer(iConfig.Name, iConfig.Reserves)
+		for _, pConfig := range iConfig.Policies {
+			if pConfig.Industry != "" {
+				industry := region.GetIndustry(pConfig.Industry)
+				if industry == nil {
+					return nil, fmt.Errorf("insurer %s references unknown industry: %s", iConfig.Name, pConfig.Industry)
+				}
+				insurer.AddPolicy(&insurance.Policy{
+					Industry: industry,
+					Covers:   pConfig.Covers,
+					Coverage: pConfig.Coverage,
+					Premium:  pConfig.Premium,
+				})
+				continue
+			}
+
+			segment := region.GetPopulationSegment(pConfig.Segment)
+			if segment == nil {
+				return nil, fmt.Errorf("insurer %s references unknown segment: %s", iConfig.Name, pConfig.Segment)
+			}
+			for _, person := range segment.Members() {
+				insurer.AddPolicy(&insurance.Policy{
+					Person:   person,
+					Covers:   pConfig.Covers,
+					Coverage: pConfig.Coverage,
+					Premium:  pConfig.Premium,
+				})
+			}
+		}
+		insurers = append(insurers, insurer)
+	}
+	return insurers, nil
+}
+
+// buildContract resolves the parties of a contract config
+func buildContract(
+	cConfig ContractConfig,
+	industriesMap map[string]*entities.Industry,
+	segmentsMap map[string]*entities.PopulationSegment,
+) (*entities.Contract, error) {
+	seller, exists := industriesMap[cConfig.Seller]
+	if !exists {
+		return nil, fmt.Errorf("contract references unknown seller: %s", cConfig.Seller)
+	}
+	product := seller.OutputProducts.Find(cConfig.Product)
+	if product == nil {
+		return nil, fmt.Errorf("contract seller %s does not produce %s", cConfig.Seller, cConfig.Product)
+	}
+
+	var contract *entities.Contract
+	switch {
+	case cConfig.BuyerIndustry != "" && cConfig.BuyerSegment != "":
+		return nil, fmt.Errorf("contract for %s must have either a buyer industry or a buyer segment", cConfig.Product)
+	case cConfig.BuyerIndustry != "":
+		buyer, exists := industriesMap[cConfig.BuyerIndustry]
+		if !exists {
+			return nil, fmt.Errorf("contract references unknown buyer industry: %s", cConfig.BuyerIndustry)
+		}
+		contract = entities.NewForwardOrder(seller, product, buyer, cConfig.UnitsPerTick, cConfig.Price)
+	case cConfig.BuyerSegment != "":
+		segment, exists := segmentsMap[cConfig.BuyerSegment]
+		if !exists {
+			return nil, fmt.Errorf("contract references unknown buyer segment: %s", cConfig.BuyerSegment)
+		}
+		contract = entities.NewSubscription(seller, product, segment, cConfig.UnitsPerTick, cConfig.Price)
+	default:
+		return nil, fmt.Errorf("contract for %s has no buyer", cConfig.Product)
+	}
+
+	endTick := 0
+	if cConfig.DurationTicks > 0 {
+		endTick = max(cConfig.StartTick, 1) + cConfig.DurationTicks - 1
+	}
+	contract.SetTerm(cConfig.StartTick, endTick)
+	contract.PenaltyRate = cConfig.PenaltyRate
+	if cConfig.MaxBreaches > 0 {
+		contract.MaxBreaches = cConfig.MaxBreaches
+	}
+
+	return contract, nil
+}
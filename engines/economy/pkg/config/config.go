@@ -3,76 +3,481 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"westex/engines/economy/pkg/clock"
+	"westex/engines/economy/pkg/names"
 )
 
 // RegionConfig represents the complete configuration for a region
 type RegionConfig struct {
-	Region     RegionInfo           `yaml:"region"`
-	Problems   []ProblemConfig      `yaml:"problems"`
-	Resources  []ResourceConfig     `yaml:"resources"`
-	Industries []IndustryConfig     `yaml:"industries"`
-	Population PopulationConfig     `yaml:"population"`
-	Simulation SimulationConfig     `yaml:"simulation"`
+	Region     RegionInfo       `yaml:"region"`
+	Problems   []ProblemConfig  `yaml:"problems"`
+	Resources  []ResourceConfig `yaml:"resources"`
+	Units      []UnitConfig     `yaml:"units"` // Units beyond the built-in ones
+	Industries []IndustryConfig `yaml:"industries"`
+	Products   []ProductConfig  `yaml:"products"`
+	Contracts  []ContractConfig `yaml:"contracts"`
+	Population PopulationConfig `yaml:"population"`
+	Simulation SimulationConfig `yaml:"simulation"`
+
+	MonetaryPolicy *MonetaryPolicyConfig `yaml:"monetary_policy"` // Optional central bank
+	Shocks         []ShockConfig         `yaml:"shocks"`
+	Insurance      []InsurerConfig       `yaml:"insurance"`
+	Government     *GovernmentConfig     `yaml:"government"`       // Optional taxation
+	Informal       *InformalConfig       `yaml:"informal_economy"` // Optional black market
+	Barter         *BarterConfig         `yaml:"barter"`           // Optional barter fallback
+	Marketing      *MarketingConfig      `yaml:"marketing"`        // Optional advertising competition
+	Welfare        *WelfareConfig        `yaml:"welfare"`          // Optional wellbeing scoring
+	Zones          []ZoneConfig          `yaml:"zones"`
+	Transport      *TransportConfig      `yaml:"transport"`   // Optional costs of moving between zones
+	Transitions    []TransitionConfig    `yaml:"transitions"` // Rules moving people between segments
+	Forecasting    *ForecastingConfig    `yaml:"forecasting"` // Optional demand-driven production planning
+	Convergence    *ConvergenceConfig    `yaml:"convergence"` // Optional early stop at a steady state
+	Assertions     []AssertionConfig     `yaml:"assertions"`  // Expected outcomes, checked as the run goes
 }
 
 // RegionInfo contains basic region information
 type RegionInfo struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
+	Name        string  `yaml:"name"`
+	Description string  `yaml:"description"`
+	Currency    string  `yaml:"currency"`      // Currency code, default "USD"
+	Exchange    string  `yaml:"exchange_rate"` // "fixed" (default) or "floating"
+	Rate        float32 `yaml:"rate"`          // Starting value in the base currency (default 1.0)
 }
 
 // ProblemConfig defines a problem/need in the economy
 type ProblemConfig struct {
 	Name        string  `yaml:"name"`
 	Description string  `yaml:"description"`
-	Demand      float32 `yaml:"demand"`      // 0.0 to 1.0 - what % of population needs this
-	IsBasicNeed bool    `yaml:"basic_need"`  // true for survival needs, false for pleasures
+	Demand      float32 `yaml:"demand"`     // 0.0 to 1.0 - what % of population needs this
+	IsBasicNeed bool    `yaml:"basic_need"` // true for survival needs, false for pleasures
+
+	UnitsPerPerson int `yaml:"units_per_person"` // units one shopper buys per tick (default 1)
+
+	Severity   float32 `yaml:"severity"`   // How critical the need usually is, 0 to 1 (default: demand)
+	Escalation float32 `yaml:"escalation"` // Severity added each tick a person goes without
+	Decay      float32 `yaml:"decay"`      // Share of the extra severity lost each tick the need is met
+
+	Parts []ProblemPartConfig `yaml:"parts"` // Sub-problems making this a composite need
+}
+
+// ProblemPartConfig is a sub-problem of a composite need
+type ProblemPartConfig struct {
+	Problem string  `yaml:"problem"` // Must be another configured problem
+	Weight  float32 `yaml:"weight"`  // Share in the need's satisfaction (default 1)
 }
 
 // ResourceConfig defines a resource
 type ResourceConfig struct {
-	Name            string  `yaml:"name"`
-	Unit            string  `yaml:"unit"`
-	InitialQuantity float32 `yaml:"initial_quantity"`
-	IsFree          bool    `yaml:"is_free"`          // true for land, water, etc.
-	RegenerationRate float32 `yaml:"regeneration_rate"` // units per tick
+	Name             string   `yaml:"name"`
+	Unit             string   `yaml:"unit"`
+	InitialQuantity  float32  `yaml:"initial_quantity"`
+	IsFree           bool     `yaml:"is_free"`           // true for land, water, etc.
+	Kind             string   `yaml:"kind"`              // "flow" (default) is used up, "stock" occupied while producing
+	RegenerationRate float32  `yaml:"regeneration_rate"` // units per tick
+	Capacity         float32  `yaml:"capacity"`          // Carrying capacity for logistic regrowth (0 = constant)
+	CollapseBelow    float32  `yaml:"collapse_below"`    // Share of capacity below which regrowth is lost
+	Tags             []string `yaml:"tags"`              // Labels for queries and analyses
 }
 
 // IndustryConfig defines an industry
 type IndustryConfig struct {
-	Name            string   `yaml:"name"`
-	SolvesProblems  []string `yaml:"solves_problems"`  // Problem names
-	InputResources  []string `yaml:"input_resources"`  // Resource names
-	OutputResources []string `yaml:"output_resources"` // Resource names
-	LaborNeeded     float32  `yaml:"labor_needed"`     // Number of workers
-	InitialCapital  float32  `yaml:"initial_capital"`  // Starting money
+	Name             string          `yaml:"name"`
+	SolvesProblems   []string        `yaml:"solves_problems"`   // Problem names
+	InputResources   []string        `yaml:"input_resources"`   // Resource names
+	OutputResources  []string        `yaml:"output_resources"`  // Resource names
+	LaborNeeded      float32         `yaml:"labor_needed"`      // Number of workers
+	InitialCapital   float32         `yaml:"initial_capital"`   // Starting money
+	InitialInventory []StockConfig   `yaml:"initial_inventory"` // Starting stock of products and inputs
+	SuppliedBy       []string        `yaml:"supplied_by"`       // Producers this retailer restocks from (makes it a retailer)
+	TargetInventory  float32         `yaml:"target_inventory"`  // Retailer stock level per product to order up to
+	Markup           float32         `yaml:"markup"`            // Retailer markup over wholesale price, e.g. 0.2
+	Owners           []OwnerConfig   `yaml:"owners"`            // Founders/investors holding shares
+	DividendPayout   float32         `yaml:"dividend_payout"`   // Share of profit paid as dividends, e.g. 0.5
+	School           *SchoolConfig   `yaml:"school"`            // Makes the industry a school
+	Zone             string          `yaml:"zone"`              // Zone the industry operates in
+	Transport        bool            `yaml:"transport"`         // Output is transport capacity
+	MarketingSpend   float32         `yaml:"marketing_spend"`   // Advertising budget per tick
+	ProductionTarget float32         `yaml:"production_target"` // Units to have for sale each tick (default: forecast or capacity)
+	Shifts           int             `yaml:"shifts"`            // Production rounds per tick, each with its own crew (default 1)
+	NightPremium     float32         `yaml:"night_premium"`     // Extra wage share for shifts after the first, e.g. 0.5
+	Downtime         *DowntimeConfig `yaml:"downtime"`          // Scheduled maintenance and random breakdowns
+	FixedCosts       float32         `yaml:"fixed_costs"`       // Overheads (rent, administration) paid every tick
+	HiresFrom        []string        `yaml:"hires_from"`        // Segments it hires workers from (default: Workers)
+	InputPriority    int             `yaml:"input_priority"`    // Rank for scarce shared inputs, highest first
+	Cooperative      bool            `yaml:"cooperative"`       // Worker-owned, profits go to worker-members
+	Public           bool            `yaml:"public"`            // Government-run, sales funded by the treasury
+	Subsidy          float32         `yaml:"subsidy"`           // Share of the price the treasury pays (default 1 = free)
+	Tags             []string        `yaml:"tags"`              // Labels for queries and analyses
+}
+
+// StockConfig is a starting stock of one of an industry's products or inputs
+type StockConfig struct {
+	Of    string  `yaml:"of"`    // Output product or input
+	Units float32 `yaml:"units"` // Units in stock at the start
+}
+
+// DowntimeConfig halts an industry's production for maintenance every few
+// ticks or when it breaks down
+type DowntimeConfig struct {
+	Chance   float32 `yaml:"chance"`   // Chance of a breakdown each tick
+	Every    int     `yaml:"every"`    // Scheduled maintenance every N ticks (0 = none)
+	Duration int     `yaml:"duration"` // Ticks each outage lasts (default 1)
+}
+
+// ZoneConfig places a zone on the region's map
+type ZoneConfig struct {
+	Name string  `yaml:"name"`
+	X    float32 `yaml:"x"`
+	Y    float32 `yaml:"y"`
+}
+
+// TransportConfig prices moving goods and workers between zones
+type TransportConfig struct {
+	CostPerDistance  float32 `yaml:"cost_per_distance"`  // Shipping cost per unit per distance
+	HoursPerDistance float32 `yaml:"hours_per_distance"` // Commute hours lost per distance
+	CapacityScale    float32 `yaml:"capacity_scale"`     // Transport output that halves frictions
+}
+
+// SchoolConfig turns an industry into a school that trades students' labor
+// hours now for higher skill later
+type SchoolConfig struct {
+	Seats       int     `yaml:"seats"`        // Students taught at once
+	CourseTicks int     `yaml:"course_ticks"` // Ticks until graduation
+	SkillGain   float32 `yaml:"skill_gain"`   // Skill added on graduation, e.g. 0.25
+	Tuition     float32 `yaml:"tuition"`      // Fee per student per tick
+}
+
+// OwnerConfig assigns shares of an industry to a named person or spreads
+// them evenly across the members of a segment
+type OwnerConfig struct {
+	Person  string  `yaml:"person"`
+	Segment string  `yaml:"segment"`
+	Shares  float32 `yaml:"shares"`
+}
+
+// UnitConfig defines a unit resources and products can be counted in, as
+// a multiple of another (sacks of 50 kg) or as a count of its own (visits)
+type UnitConfig struct {
+	Name   string  `yaml:"name"`
+	Of     string  `yaml:"of"`     // Unit it is a multiple of (empty = a count of its own)
+	Factor float32 `yaml:"factor"` // How many of Of make one
+}
+
+// ProductConfig adds market behaviour to an industry output product
+type ProductConfig struct {
+	Name        string   `yaml:"name"`        // Must match an industry output resource
+	Unit        string   `yaml:"unit"`        // Unit its stock is counted in (default: the same-named resource's, else units)
+	Efficiency  float32  `yaml:"efficiency"`  // Satisfaction per unit relative to substitutes (default 1.0)
+	Complements []string `yaml:"complements"` // Products that must be bought alongside this one
+	Solves      []string `yaml:"solves"`      // Problems it is sold for (default: all its industry's)
+	Satisfies   float32  `yaml:"satisfies"`   // Share of one person's weekly need a unit meets (default: one unit of the need)
+	Tags        []string `yaml:"tags"`        // Labels for queries and analyses
+}
+
+// ContractConfig defines a forward order (buyer_industry) or a service
+// subscription (buyer_segment) that runs for several ticks
+type ContractConfig struct {
+	Seller        string  `yaml:"seller"`         // Industry delivering the product
+	Product       string  `yaml:"product"`        // One of the seller's output products
+	BuyerIndustry string  `yaml:"buyer_industry"` // Industry buying the product as an input
+	BuyerSegment  string  `yaml:"buyer_segment"`  // Segment whose members subscribe
+	UnitsPerTick  float32 `yaml:"units_per_tick"` // Per industry, or per segment member
+	Price         float32 `yaml:"price"`          // Locked unit price
+	StartTick     int     `yaml:"start_tick"`
+	DurationTicks int     `yaml:"duration_ticks"` // 0 = open-ended
+	PenaltyRate   float32 `yaml:"penalty_rate"`   // Share of undelivered value compensated
+	MaxBreaches   int     `yaml:"max_breaches"`   // Seller breaches before termination (default 3)
 }
 
 // PopulationConfig defines population structure
 type PopulationConfig struct {
-	TotalSize int                       `yaml:"total_size"`
-	Segments  []PopulationSegmentConfig `yaml:"segments"`
+	TotalSize  int                       `yaml:"total_size"`
+	Segments   []PopulationSegmentConfig `yaml:"segments"`
+	Names      string                    `yaml:"names"`      // Name theme for people, e.g. "indian" (default: Person-1..N)
+	Households *HouseholdsConfig         `yaml:"households"` // Optional grouping of people into households
+}
+
+// HouseholdsConfig groups the population into households of varying size
+type HouseholdsConfig struct {
+	Sizes      []HouseholdSizeConfig `yaml:"sizes"`
+	ChildShare float32               `yaml:"child_share"` // Chance each member after the first is a child
+}
+
+// HouseholdSizeConfig is the share of households with a given number of members
+type HouseholdSizeConfig struct {
+	Size  int     `yaml:"size"`
+	Share float32 `yaml:"share"`
 }
 
 // PopulationSegmentConfig defines a population segment
 type PopulationSegmentConfig struct {
-	Name        string   `yaml:"name"`
-	Percentage  float32  `yaml:"percentage"`   // % of total population
-	HasProblems []string `yaml:"has_problems"` // Problem names
-	InitialMoney float32 `yaml:"initial_money"` // Starting money per person
-	LaborHours   float32 `yaml:"labor_hours"`   // Available hours per tick
+	Name              string   `yaml:"name"`
+	Percentage        float32  `yaml:"percentage"`         // % of total population
+	HasProblems       []string `yaml:"has_problems"`       // Problem names
+	InitialMoney      float32  `yaml:"initial_money"`      // Starting money per person
+	LaborHours        float32  `yaml:"labor_hours"`        // Hours a working day members can work
+	SavingsPropensity float32  `yaml:"propensity_to_save"` // Share of leftover cash deposited each tick
+	ReservationWage   float32  `yaml:"reservation_wage"`   // Lowest hourly wage members work for
+	TargetIncome      float32  `yaml:"target_income"`      // Wage income per tick members work for
+	Zone              string   `yaml:"zone"`               // Zone the segment's members live in
+	Retired           bool     `yaml:"retired"`            // Members don't work and draw a pension
+	Role              string   `yaml:"role"`               // "workers" to look for jobs (default: only a segment named Workers)
+	Tags              []string `yaml:"tags"`               // Labels every member starts with
+
+	// Differences between members, drawn instead of the same for everyone
+	Wealth       *DistributionConfig `yaml:"wealth"`       // Starting money, instead of initial_money
+	Productivity *DistributionConfig `yaml:"productivity"` // Output per hour as a multiple of the baseline
+	Hours        *DistributionConfig `yaml:"hours"`        // Hours a working day, instead of labor_hours
+}
+
+// DistributionConfig describes a distribution members' starting money,
+// productivity or hours are drawn from, so they differ from person to person
+type DistributionConfig struct {
+	Distribution string  `yaml:"distribution"` // "uniform", "lognormal" or "pareto"
+	Min          float32 `yaml:"min"`          // Uniform lower bound, or the pareto's smallest amount
+	Max          float32 `yaml:"max"`          // Uniform upper bound
+	Median       float32 `yaml:"median"`       // Lognormal median
+	Sigma        float32 `yaml:"sigma"`        // Lognormal spread of the log
+	Alpha        float32 `yaml:"alpha"`        // Pareto shape; the smaller, the more unequal
+	Seed         uint64  `yaml:"seed"`         // Seed for the draws (default: the simulation seed)
 }
 
 // SimulationConfig defines simulation parameters
 type SimulationConfig struct {
-	Ticks                    int     `yaml:"ticks"`
-	WeeksPerTick             int     `yaml:"weeks_per_tick"`
-	HoursPerWeek             float32 `yaml:"hours_per_week"`
-	WagePerHour              float32 `yaml:"wage_per_hour"`
-	ProfitMargin             float32 `yaml:"profit_margin"`              // e.g., 0.10 for 10%
-	ConsumptionFactorPerWeek float32 `yaml:"consumption_factor_per_week"`
+	Ticks                    int      `yaml:"ticks"`
+	Duration                 string   `yaml:"duration"`   // Run length such as "2 years", replacing ticks
+	StartDate                string   `yaml:"start_date"` // Calendar date of tick 1, e.g. 2025-01-01 (default: bare tick numbers)
+	WeeksPerTick             int      `yaml:"weeks_per_tick"`
+	HoursPerWeek             float32  `yaml:"hours_per_week"`
+	WagePerHour              float32  `yaml:"wage_per_hour"`
+	ProfitMargin             float32  `yaml:"profit_margin"` // e.g., 0.10 for 10%
+	ConsumptionFactorPerWeek float32  `yaml:"consumption_factor_per_week"`
+	DemandAdjustmentRate     float32  `yaml:"demand_adjustment_rate"` // 0.0 to 1.0, how fast demand reacts (0 = engine default)
+	MarketMode               string   `yaml:"market_mode"`            // "posted" (default) or "orderbook"
+	Rationing                string   `yaml:"rationing"`              // "equal", "severity" or "lottery" for short basic needs
+	QueueOrder               string   `yaml:"queue_order"`            // "shuffle" or "rotate" who goes first each tick
+	MaxOvertime              float32  `yaml:"max_overtime"`           // Extra hours workers may choose, as a share of standard hours
+	WageTiming               string   `yaml:"wage_timing"`            // "before_production" (default), "after_sales" or "weekly"
+	SkillPay                 bool     `yaml:"skill_pay"`              // Pay each worker's wage in proportion to their productivity
+	MultipleJobs             bool     `yaml:"multiple_jobs"`          // Workers with hours to spare take a second job
+	InputAllocation          string   `yaml:"input_allocation"`       // "pro_rata", "priority" or "auction" for scarce shared inputs
+	Weekly                   bool     `yaml:"weekly"`                 // Produce and shop week by week inside each tick
+	Phases                   []string `yaml:"phases"`                 // Phases run each tick, in order (default: all)
+	WarmUpTicks              int      `yaml:"warm_up_ticks"`          // Ticks left out of the summary and exports
+	MeasureTicks             int      `yaml:"measure_ticks"`          // Ticks measured after the warm-up (default: the rest)
+	Guards                   string   `yaml:"guards"`                 // "warn" or "abort" on NaN or negative money and stock
+	HistoryLength            int      `yaml:"history_length"`         // Purchases and ticks each person remembers, 0 = off
+	LoyalShoppers            bool     `yaml:"loyal_shoppers"`         // Return to the last seller first (needs history_length)
+	TrackPeople              int      `yaml:"track_people"`           // Random sample of people followed in full detail, 0 = off
+	Cohorts                  bool     `yaml:"cohorts"`                // Report outcomes by starting segment and wealth quintile
+	Matrices                 bool     `yaml:"matrices"`               // Export tick by industry and tick by problem matrices
+	Seed                     uint64   `yaml:"seed"`                   // Random seed, 0 = random
+}
+
+// MonetaryPolicyConfig defines the central bank and its scheduled interventions
+type MonetaryPolicyConfig struct {
+	InterestRate  float32              `yaml:"interest_rate"`  // Policy rate per tick
+	DepositSpread float32              `yaml:"deposit_spread"` // Bank deposit rate below policy rate
+	LendingSpread float32              `yaml:"lending_spread"` // Bank lending rate above policy rate
+	Interventions []InterventionConfig `yaml:"interventions"`
+	Lending       *LendingConfig       `yaml:"lending"` // Optional bank loans to industries
+}
+
+// LendingConfig lets the bank lend industries what they are short of their
+// wage bill, repaid in installments at the lending rate
+type LendingConfig struct {
+	Term             int     `yaml:"term"`                // Ticks a loan is repaid over (default 12)
+	MaxDebtToRevenue float32 `yaml:"max_debt_to_revenue"` // Covenant: debt at most this many ticks of revenue (0 = no limit)
+	MinScore         float32 `yaml:"min_score"`           // Lowest credit score lent to, 0-1 (0 = anyone)
+	RiskPremium      float32 `yaml:"risk_premium"`        // Extra rate per tick for a credit score of 0
+}
+
+// InterventionConfig schedules a monetary action at a tick
+type InterventionConfig struct {
+	Tick   int     `yaml:"tick"`
+	Type   string  `yaml:"type"`   // "helicopter", "bailout" or "rate"
+	Amount float32 `yaml:"amount"` // Per person (helicopter) or money floor (bailout)
+	Rate   float32 `yaml:"rate"`   // New policy rate (rate)
+}
+
+// ShockConfig defines an adverse event that fires at a tick or with a probability
+type ShockConfig struct {
+	Type        string  `yaml:"type"`        // "crop_failure" or "health"
+	Industry    string  `yaml:"industry"`    // Target of crop failures
+	Segment     string  `yaml:"segment"`     // Target of health events (empty = everyone)
+	Tick        int     `yaml:"tick"`        // Fixed tick, or 0 to use probability
+	Probability float32 `yaml:"probability"` // Per tick (per person for health events)
+	Severity    float32 `yaml:"severity"`    // Share of stock lost, or cost per person
+}
+
+// InsurerConfig defines an insurer and the policies it writes
+type InsurerConfig struct {
+	Name     string         `yaml:"name"`
+	Reserves float32        `yaml:"reserves"`
+	Policies []PolicyConfig `yaml:"policies"`
+}
+
+// PolicyConfig insures an industry or every member of a segment
+type PolicyConfig struct {
+	Industry string  `yaml:"industry"`
+	Segment  string  `yaml:"segment"`
+	Covers   string  `yaml:"covers"`   // Shock type
+	Coverage float32 `yaml:"coverage"` // Maximum payout per claim
+	Premium  float32 `yaml:"premium"`  // Per tick
+}
+
+// GovernmentConfig defines the government's treasury and tax rates
+type GovernmentConfig struct {
+	Treasury     float32 `yaml:"treasury"`       // Starting treasury
+	SalesTaxRate float32 `yaml:"sales_tax_rate"` // e.g. 0.18 for 18%
+
+	Reserve *ReserveConfig `yaml:"reserve"` // Optional strategic stockpile
+	Pension *PensionConfig `yaml:"pension"` // Optional income for retired segments
+
+	Unemployment *UnemploymentConfig `yaml:"unemployment"` // Optional benefits for idle workers
+
+	PriceControls []PriceControlConfig `yaml:"price_controls"` // Optional per-product price limits
+}
+
+// PriceControlConfig caps or props up the price of one product
+type PriceControlConfig struct {
+	Product string  `yaml:"product"` // Resource name
+	Ceiling float32 `yaml:"ceiling"` // Highest price allowed, 0 = none
+	Floor   float32 `yaml:"floor"`   // Lowest price allowed, 0 = none
+}
+
+// UnemploymentConfig pays idle workers a benefit funded by payroll tax
+type UnemploymentConfig struct {
+	PayrollTaxRate  float32 `yaml:"payroll_tax_rate"` // Share of wage bills, e.g. 0.02
+	ReplacementRate float32 `yaml:"replacement_rate"` // Share of the last wage paid, e.g. 0.5
+	Duration        int     `yaml:"duration"`         // Ticks paid per spell, 0 = no limit
+}
+
+// PensionConfig pays retirees an income each tick
+type PensionConfig struct {
+	Amount float32 `yaml:"amount"` // Income per retiree per tick
+	Source string  `yaml:"source"` // "treasury" (default) or "savings" drawdown
+}
+
+// ReserveConfig lets the government stockpile surplus basic-need products
+// and release them during shortages
+type ReserveConfig struct {
+	Capacity  float32 `yaml:"capacity"`   // Most units held per product
+	BuyShare  float32 `yaml:"buy_share"`  // Share of unsold surplus bought each tick, e.g. 0.2
+	ReleaseAt float32 `yaml:"release_at"` // Share of needy people unserved that triggers a release, e.g. 0.1
+	Price     float32 `yaml:"price"`      // Price per unit (default: the market price)
+}
+
+// InformalConfig enables an untaxed informal sector for unmet demand
+type InformalConfig struct {
+	Premium           float32 `yaml:"premium"`            // Markup over the formal price, e.g. 0.3
+	BaseParticipation float32 `yaml:"base_participation"` // Chance an unmet buyer goes informal without taxes
+	TaxSensitivity    float32 `yaml:"tax_sensitivity"`    // Extra participation per unit of sales tax rate
+}
+
+// BarterConfig enables labor-for-goods barter when money is scarce
+type BarterConfig struct {
+	HoursPerUnit   float32 `yaml:"hours_per_unit"`  // Labor hours per unit of product
+	MoneyThreshold float32 `yaml:"money_threshold"` // Only people with less money barter
+}
+
+// MarketingConfig makes shoppers consider rival sellers by awareness, which
+// industries buy with their marketing_spend
+type MarketingConfig struct {
+	BaseAwareness float32 `yaml:"base_awareness"` // Chance a shopper considers a seller that doesn't advertise
+	HalfSpend     float32 `yaml:"half_spend"`     // Spend per tick that closes half the gap to full awareness
+}
+
+// ConvergenceConfig stops the run once prices, employment and the wealth
+// distribution hold steady
+type ConvergenceConfig struct {
+	Window    int     `yaml:"window"`    // Ticks the metrics must hold steady over (default 5)
+	Tolerance float32 `yaml:"tolerance"` // Largest change allowed, as a share of each metric's average (default 0.01)
+}
+
+// AssertionConfig is an expected outcome of the scenario, such as
+// unemployment under 0.2 at tick 50. A run with a failed assertion exits
+// non-zero.
+type AssertionConfig struct {
+	Name   string  `yaml:"name"`   // Shown when it fails (default: the condition)
+	Metric string  `yaml:"metric"` // unemployment, inventory, gini...
+	Of     string  `yaml:"of"`     // Product or resource, for inventory and resource
+	Op     string  `yaml:"op"`     // <, <=, > or >=
+	Value  float32 `yaml:"value"`
+	At     int     `yaml:"at"`   // Check once, at the end of this tick
+	From   int     `yaml:"from"` // Check at the end of every tick from this one on
+	// With neither at nor from, checked once at the end of the run
+}
+
+// WelfareConfig weighs needs, leisure and savings in people's utility. Leaving
+// every weight out uses the default weights.
+type WelfareConfig struct {
+	NeedsWeight   float32 `yaml:"needs_weight"`
+	LeisureWeight float32 `yaml:"leisure_weight"`
+	SavingsWeight float32 `yaml:"savings_weight"`
+	SavingsScale  float32 `yaml:"savings_scale"` // Wealth at which savings count half (default 1000)
+}
+
+// TransitionConfig moves people from one population segment to another
+// while the simulation runs
+type TransitionConfig struct {
+	From   string  `yaml:"from"`   // Segment people leave
+	To     string  `yaml:"to"`     // Segment people join
+	When   string  `yaml:"when"`   // "unemployed" (idle in a row) or "after" (time in the segment)
+	Ticks  int     `yaml:"ticks"`  // Ticks the condition has to hold (default 1)
+	Chance float32 `yaml:"chance"` // Chance an eligible person moves each tick (default 1)
+}
+
+// ForecastingConfig has industries forecast their sales and produce, and
+// hire, only what they expect to sell
+type ForecastingConfig struct {
+	Method string  `yaml:"method"` // "moving_average" (default) or "exponential"
+	Window int     `yaml:"window"` // Ticks the moving average spans (default all 10 remembered)
+	Alpha  float32 `yaml:"alpha"`  // Smoothing weight of the latest tick, 0-1 (default 0.5)
+	Buffer float32 `yaml:"buffer"` // Safety stock on top of the forecast, as a share of it (default 0.1)
+}
+
+// BranchPolicy is a policy applied to one branch of a forked simulation.
+// Sections left out keep the settings the branch inherited.
+type BranchPolicy struct {
+	Name           string                `yaml:"name"`
+	WagePerHour    float32               `yaml:"wage_per_hour"`
+	MonetaryPolicy *MonetaryPolicyConfig `yaml:"monetary_policy"`
+	Government     *GovernmentConfig     `yaml:"government"`
+	Informal       *InformalConfig       `yaml:"informal_economy"`
+	Barter         *BarterConfig         `yaml:"barter"`
+}
+
+// LoadPolicy loads a branch policy from a YAML file, naming it after the
+// file if it has no name
+func LoadPolicy(filepath string) (*BranchPolicy, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var policy BranchPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+	if policy.Name == "" {
+		policy.Name = filepath
+	}
+	if policy.MonetaryPolicy != nil {
+		for _, intervention := range policy.MonetaryPolicy.Interventions {
+			switch intervention.Type {
+			case "helicopter", "bailout", "rate":
+			default:
+				return nil, fmt.Errorf("invalid policy: unknown intervention type: %s", intervention.Type)
+			}
+		}
+	}
+
+	return &policy, nil
 }
 
 // LoadConfig loads configuration from a YAML file
@@ -93,6 +498,11 @@ func LoadConfig(filepath string) (*RegionConfig, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	// A duration such as "2 years" sets the number of ticks
+	if config.Simulation.Duration != "" {
+		config.Simulation.Ticks, _ = clock.ParseDuration(config.Simulation.Duration, config.Simulation.WeeksPerTick)
+	}
+
 	return &config, nil
 }
 
@@ -105,19 +515,338 @@ func validateConfig(config *RegionConfig) error {
 	if len(config.Problems) == 0 {
 		return fmt.Errorf("at least one problem is required")
 	}
+	problems := make(map[string]ProblemConfig, len(config.Problems))
+	for _, problem := range config.Problems {
+		if problem.UnitsPerPerson < 0 {
+			return fmt.Errorf("problem %s: units_per_person must not be negative", problem.Name)
+		}
+		if problem.Severity < 0 || problem.Severity > 1 || problem.Escalation < 0 || problem.Escalation > 1 ||
+			problem.Decay < 0 || problem.Decay > 1 {
+			return fmt.Errorf("problem %s: severity, escalation and decay must be between 0 and 1", problem.Name)
+		}
+		problems[problem.Name] = problem
+	}
+	for _, problem := range config.Problems {
+		for _, part := range problem.Parts {
+			if _, exists := problems[part.Problem]; !exists {
+				return fmt.Errorf("problem %s has unknown part: %s", problem.Name, part.Problem)
+			}
+			if part.Weight < 0 {
+				return fmt.Errorf("problem %s: part weights must not be negative", problem.Name)
+			}
+		}
+		if containsPart(problems, problem, problem.Name) {
+			return fmt.Errorf("problem %s is part of itself", problem.Name)
+		}
+	}
+	for _, industry := range config.Industries {
+		for _, solved := range industry.SolvesProblems {
+			if len(problems[solved].Parts) > 0 {
+				return fmt.Errorf("industry %s solves composite need %s; it should solve one of its parts", industry.Name, solved)
+			}
+		}
+	}
+
+	if err := checkUnits(config); err != nil {
+		return err
+	}
+	for _, resource := range config.Resources {
+		switch resource.Kind {
+		case "", "flow", "stock":
+		default:
+			return fmt.Errorf("resource %s: unknown kind %s", resource.Name, resource.Kind)
+		}
+		if resource.Capacity < 0 {
+			return fmt.Errorf("resource %s: capacity must not be negative", resource.Name)
+		}
+		if resource.CollapseBelow < 0 || resource.CollapseBelow >= 1 {
+			return fmt.Errorf("resource %s: collapse_below must be between 0 and 1", resource.Name)
+		}
+		if resource.CollapseBelow > 0 && resource.Capacity == 0 {
+			return fmt.Errorf("resource %s: collapse_below needs a capacity", resource.Name)
+		}
+	}
 
 	if len(config.Industries) == 0 {
 		return fmt.Errorf("at least one industry is required")
 	}
+	for _, industry := range config.Industries {
+		if industry.Subsidy < 0 || industry.Subsidy > 1 {
+			return fmt.Errorf("industry %s: subsidy must be between 0 and 1", industry.Name)
+		}
+		if industry.Public && config.Government == nil {
+			return fmt.Errorf("industry %s: public industries need a government section", industry.Name)
+		}
+		if industry.ProductionTarget < 0 {
+			return fmt.Errorf("industry %s: production_target must not be negative", industry.Name)
+		}
+		if industry.FixedCosts < 0 {
+			return fmt.Errorf("industry %s: fixed_costs must not be negative", industry.Name)
+		}
+		if industry.Shifts < 0 || industry.NightPremium < 0 {
+			return fmt.Errorf("industry %s: shifts and night_premium must not be negative", industry.Name)
+		}
+		for _, segment := range industry.HiresFrom {
+			if !hasSegment(config, segment) {
+				return fmt.Errorf("industry %s: hires_from references unknown segment %s", industry.Name, segment)
+			}
+		}
+		if d := industry.Downtime; d != nil {
+			if d.Chance < 0 || d.Chance > 1 || d.Every < 0 || d.Duration < 0 {
+				return fmt.Errorf("industry %s: downtime needs chance between 0 and 1 and every and duration not negative", industry.Name)
+			}
+		}
+	}
 
 	if config.Population.TotalSize <= 0 {
 		return fmt.Errorf("population size must be positive")
 	}
+	if config.Population.Names != "" {
+		if _, err := names.NewGenerator(config.Population.Names, 0); err != nil {
+			return err
+		}
+	}
+
+	if households := config.Population.Households; households != nil {
+		if len(households.Sizes) == 0 {
+			return fmt.Errorf("households need at least one size")
+		}
+		total := float32(0)
+		for _, size := range households.Sizes {
+			if size.Size <= 0 || size.Share < 0 {
+				return fmt.Errorf("household sizes must be positive and shares not negative")
+			}
+			total += size.Share
+		}
+		if total < 0.99 || total > 1.01 {
+			return fmt.Errorf("household size shares must sum to 1.0, got %.2f", total)
+		}
+		if households.ChildShare < 0 || households.ChildShare > 1 {
+			return fmt.Errorf("households child_share must be between 0 and 1")
+		}
+	}
+
+	switch config.Region.Exchange {
+	case "", "fixed", "floating":
+	default:
+		return fmt.Errorf("unknown exchange rate regime: %s", config.Region.Exchange)
+	}
+
+	switch config.Simulation.MarketMode {
+	case "", "posted", "orderbook":
+	default:
+		return fmt.Errorf("unknown market mode: %s", config.Simulation.MarketMode)
+	}
+
+	switch config.Simulation.Rationing {
+	case "", "equal", "severity", "lottery":
+	default:
+		return fmt.Errorf("unknown rationing mode: %s", config.Simulation.Rationing)
+	}
+
+	switch config.Simulation.QueueOrder {
+	case "", "shuffle", "rotate":
+	default:
+		return fmt.Errorf("unknown queue order: %s", config.Simulation.QueueOrder)
+	}
+
+	if config.Simulation.Duration != "" {
+		ticks, err := clock.ParseDuration(config.Simulation.Duration, config.Simulation.WeeksPerTick)
+		if err != nil {
+			return fmt.Errorf("simulation duration: %w", err)
+		}
+		if ticks == 0 {
+			return fmt.Errorf("simulation duration %s is shorter than one tick", config.Simulation.Duration)
+		}
+	}
+	if config.Simulation.StartDate != "" {
+		if _, err := time.Parse(clock.DateLayout, config.Simulation.StartDate); err != nil {
+			return fmt.Errorf("simulation start_date must be a date like 2025-01-01, got %s", config.Simulation.StartDate)
+		}
+	}
+	if config.Simulation.WarmUpTicks < 0 || config.Simulation.MeasureTicks < 0 {
+		return fmt.Errorf("warm_up_ticks and measure_ticks must not be negative")
+	}
+
+	switch config.Simulation.Guards {
+	case "", "warn", "abort":
+	default:
+		return fmt.Errorf("unknown guards mode: %s", config.Simulation.Guards)
+	}
+
+	switch config.Simulation.WageTiming {
+	case "", "before_production", "after_sales", "weekly":
+	default:
+		return fmt.Errorf("unknown wage timing: %s", config.Simulation.WageTiming)
+	}
+
+	switch config.Simulation.InputAllocation {
+	case "", "pro_rata", "priority", "auction":
+	default:
+		return fmt.Errorf("unknown input allocation: %s", config.Simulation.InputAllocation)
+	}
+
+	if config.Simulation.TrackPeople < 0 {
+		return fmt.Errorf("track_people must not be negative")
+	}
+	if config.Simulation.LoyalShoppers && config.Simulation.HistoryLength <= 0 {
+		return fmt.Errorf("loyal_shoppers needs a positive history_length")
+	}
+
+	if config.MonetaryPolicy != nil {
+		for _, intervention := range config.MonetaryPolicy.Interventions {
+			switch intervention.Type {
+			case "helicopter", "bailout", "rate":
+			default:
+				return fmt.Errorf("unknown monetary intervention type: %s", intervention.Type)
+			}
+		}
+		if lending := config.MonetaryPolicy.Lending; lending != nil {
+			if lending.Term < 0 || lending.MaxDebtToRevenue < 0 || lending.RiskPremium < 0 {
+				return fmt.Errorf("lending term, max_debt_to_revenue and risk_premium must not be negative")
+			}
+			if lending.MinScore < 0 || lending.MinScore > 1 {
+				return fmt.Errorf("lending min_score must be between 0 and 1")
+			}
+		}
+	}
+
+	if config.Government != nil && config.Government.Reserve != nil {
+		reserve := config.Government.Reserve
+		if reserve.BuyShare < 0 || reserve.BuyShare > 1 {
+			return fmt.Errorf("reserve buy_share must be between 0 and 1")
+		}
+		if reserve.ReleaseAt < 0 || reserve.ReleaseAt > 1 {
+			return fmt.Errorf("reserve release_at must be between 0 and 1")
+		}
+	}
+
+	if config.Government != nil && config.Government.Unemployment != nil {
+		u := config.Government.Unemployment
+		if u.PayrollTaxRate < 0 || u.PayrollTaxRate > 1 {
+			return fmt.Errorf("unemployment payroll_tax_rate must be between 0 and 1")
+		}
+		if u.ReplacementRate < 0 {
+			return fmt.Errorf("unemployment replacement_rate must not be negative")
+		}
+		if u.Duration < 0 {
+			return fmt.Errorf("unemployment duration must not be negative")
+		}
+	}
+
+	if config.Government != nil {
+		for _, control := range config.Government.PriceControls {
+			if !isProduct(config, control.Product) {
+				return fmt.Errorf("price control for unknown product: %s", control.Product)
+			}
+			if control.Ceiling < 0 || control.Floor < 0 {
+				return fmt.Errorf("price control for %s: limits must not be negative", control.Product)
+			}
+			if control.Ceiling > 0 && control.Floor > control.Ceiling {
+				return fmt.Errorf("price control for %s: floor above ceiling", control.Product)
+			}
+		}
+	}
+
+	if config.Government != nil && config.Government.Pension != nil {
+		pension := config.Government.Pension
+		if pension.Amount < 0 {
+			return fmt.Errorf("pension amount must not be negative")
+		}
+		switch pension.Source {
+		case "", "treasury", "savings":
+		default:
+			return fmt.Errorf("unknown pension source: %s", pension.Source)
+		}
+	}
+
+	if config.Welfare != nil {
+		w := config.Welfare
+		if w.NeedsWeight < 0 || w.LeisureWeight < 0 || w.SavingsWeight < 0 || w.SavingsScale < 0 {
+			return fmt.Errorf("welfare weights and savings_scale must not be negative")
+		}
+	}
+
+	if c := config.Convergence; c != nil {
+		if c.Window < 0 || c.Window == 1 || c.Tolerance < 0 {
+			return fmt.Errorf("convergence needs a window of at least 2 ticks and a tolerance not negative")
+		}
+	}
+
+	for _, a := range config.Assertions {
+		if a.Metric == "" {
+			return fmt.Errorf("assertion %q needs a metric", a.Name)
+		}
+		switch a.Op {
+		case "<", "<=", ">", ">=":
+		default:
+			return fmt.Errorf("assertion on %s: unknown op %q", a.Metric, a.Op)
+		}
+		if a.At < 0 || a.From < 0 || (a.At > 0 && a.From > 0) {
+			return fmt.Errorf("assertion on %s: set at or from, not both, and not negative", a.Metric)
+		}
+	}
+
+	if config.Marketing != nil {
+		if config.Marketing.BaseAwareness < 0 || config.Marketing.BaseAwareness > 1 {
+			return fmt.Errorf("marketing base_awareness must be between 0 and 1")
+		}
+		if config.Marketing.HalfSpend <= 0 {
+			return fmt.Errorf("marketing half_spend must be positive")
+		}
+	}
+
+	for _, transition := range config.Transitions {
+		if !hasSegment(config, transition.From) || !hasSegment(config, transition.To) {
+			return fmt.Errorf("transition %s -> %s references an unknown segment", transition.From, transition.To)
+		}
+		if transition.From == transition.To {
+			return fmt.Errorf("transition from %s must move to another segment", transition.From)
+		}
+		switch transition.When {
+		case "unemployed", "after":
+		default:
+			return fmt.Errorf("unknown transition trigger: %s", transition.When)
+		}
+		if transition.Ticks < 0 || transition.Chance < 0 || transition.Chance > 1 {
+			return fmt.Errorf("transition %s -> %s needs ticks >= 0 and chance between 0 and 1", transition.From, transition.To)
+		}
+	}
+
+	if config.Forecasting != nil {
+		f := config.Forecasting
+		switch f.Method {
+		case "", "moving_average", "exponential":
+		default:
+			return fmt.Errorf("unknown forecasting method: %s", f.Method)
+		}
+		if f.Window < 0 || f.Alpha < 0 || f.Alpha > 1 || f.Buffer < 0 {
+			return fmt.Errorf("forecasting needs window >= 0, alpha between 0 and 1 and buffer >= 0")
+		}
+	}
+
+	for _, shock := range config.Shocks {
+		if shock.Type != "crop_failure" && shock.Type != "health" {
+			return fmt.Errorf("unknown shock type: %s", shock.Type)
+		}
+	}
 
 	// Validate percentages sum to ~100%
 	totalPercentage := float32(0)
 	for _, segment := range config.Population.Segments {
 		totalPercentage += segment.Percentage
+		if segment.Role != "" && segment.Role != "workers" {
+			return fmt.Errorf("segment %s: unknown role: %s", segment.Name, segment.Role)
+		}
+		drawn := []string{"wealth", "productivity", "hours"}
+		for i, dist := range []*DistributionConfig{segment.Wealth, segment.Productivity, segment.Hours} {
+			if dist == nil {
+				continue
+			}
+			if err := checkDistribution(dist); err != nil {
+				return fmt.Errorf("segment %s: %s: %w", segment.Name, drawn[i], err)
+			}
+		}
 	}
 	if totalPercentage < 0.99 || totalPercentage > 1.01 {
 		return fmt.Errorf("population segment percentages must sum to 1.0, got %.2f", totalPercentage)
@@ -126,6 +855,50 @@ func validateConfig(config *RegionConfig) error {
 	return nil
 }
 
+// containsPart reports whether a problem's parts, at any depth, include the
+// named problem
+func containsPart(problems map[string]ProblemConfig, problem ProblemConfig, name string) bool {
+	return containsPartSeen(problems, problem, name, make(map[string]bool))
+}
+
+func containsPartSeen(problems map[string]ProblemConfig, problem ProblemConfig, name string, seen map[string]bool) bool {
+	for _, part := range problem.Parts {
+		if part.Problem == name {
+			return true
+		}
+		if seen[part.Problem] {
+			continue
+		}
+		seen[part.Problem] = true
+		if containsPartSeen(problems, problems[part.Problem], name, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSegment reports whether a population segment is configured by name
+func hasSegment(config *RegionConfig, name string) bool {
+	for _, segment := range config.Population.Segments {
+		if segment.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isProduct reports whether some industry outputs a resource by name
+func isProduct(config *RegionConfig, name string) bool {
+	for _, industry := range config.Industries {
+		for _, output := range industry.OutputResources {
+			if output == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // SaveConfig saves configuration to a YAML file
 func SaveConfig(config *RegionConfig, filepath string) error {
 	data, err := yaml.Marshal(config)
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"math"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -156,3 +158,547 @@ func TestBuildRegionFromConfig(t *testing.T) {
 		t.Errorf("Expected 100 people, got %d", len(region.People))
 	}
 }
+
+func TestBuildRegionFromConfig_Products(t *testing.T) {
+	config := &RegionConfig{
+		Region:   RegionInfo{Name: "Test"},
+		Problems: []ProblemConfig{{Name: "Food", Demand: 0.9}},
+		Industries: []IndustryConfig{
+			{Name: "Bakery", SolvesProblems: []string{"Food"}, OutputResources: []string{"Bread"}},
+			{Name: "FuelCo", OutputResources: []string{"Fuel"}},
+		},
+		Products: []ProductConfig{
+			{Name: "Bread", Efficiency: 0.7, Complements: []string{"Fuel"}},
+		},
+		Population: PopulationConfig{TotalSize: 10},
+	}
+
+	region, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+
+	bread := region.Industries[0].OutputProducts[0]
+	if bread.Efficiency != 0.7 {
+		t.Errorf("Expected bread efficiency 0.7, got %.2f", bread.Efficiency)
+	}
+	if len(bread.Complements) != 1 || bread.Complements[0] != region.Industries[1].OutputProducts[0] {
+		t.Error("Expected bread to require the FuelCo fuel product")
+	}
+
+	config.Products[0].Complements = []string{"Gas"}
+	if _, err := BuildRegionFromConfig(config); err == nil {
+		t.Error("Expected error for unknown complement")
+	}
+}
+
+func TestBuildRegionFromConfig_ProductSolves(t *testing.T) {
+	config := &RegionConfig{
+		Region:   RegionInfo{Name: "Test"},
+		Problems: []ProblemConfig{{Name: "Prevention", Demand: 0.5}, {Name: "Treatment", Demand: 0.5}, {Name: "Food", Demand: 0.9}},
+		Industries: []IndustryConfig{
+			{Name: "Clinic", SolvesProblems: []string{"Prevention", "Treatment"}, OutputResources: []string{"Wellness", "Medical"}},
+		},
+		Products: []ProductConfig{
+			{Name: "Wellness", Solves: []string{"Prevention"}},
+			{Name: "Medical", Solves: []string{"Treatment"}},
+		},
+		Population: PopulationConfig{TotalSize: 10},
+	}
+
+	region, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+	clinic := region.Industries[0]
+	if product := clinic.ProductFor(region.GetProblem("Prevention")); product == nil || product.Name != "Wellness" {
+		t.Errorf("Expected Wellness for prevention, got %v", product)
+	}
+	if product := clinic.ProductFor(region.GetProblem("Treatment")); product == nil || product.Name != "Medical" {
+		t.Errorf("Expected Medical for treatment, got %v", product)
+	}
+
+	config.Products[1].Solves = []string{"Food"}
+	if _, err := BuildRegionFromConfig(config); err == nil {
+		t.Error("Expected error for a product solving a problem its maker doesn't")
+	}
+	config.Products[1].Solves = []string{"Surgery"}
+	if _, err := BuildRegionFromConfig(config); err == nil {
+		t.Error("Expected error for an unknown problem")
+	}
+}
+
+func TestBuildRegionFromConfig_Tags(t *testing.T) {
+	config := &RegionConfig{
+		Region:    RegionInfo{Name: "Test"},
+		Problems:  []ProblemConfig{{Name: "Food", Demand: 0.9}},
+		Resources: []ResourceConfig{{Name: "Water", Unit: "liters", Tags: []string{"natural"}}},
+		Industries: []IndustryConfig{
+			{Name: "Farm", SolvesProblems: []string{"Food"}, OutputResources: []string{"Grain"}, Tags: []string{"export"}},
+		},
+		Products: []ProductConfig{{Name: "Grain", Tags: []string{"staple"}}},
+		Population: PopulationConfig{
+			TotalSize: 10,
+			Segments: []PopulationSegmentConfig{
+				{Name: "Street Vendors", Percentage: 0.4, Tags: []string{"informal"}},
+				{Name: "Clerks", Percentage: 0.6},
+			},
+		},
+	}
+
+	region, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+
+	query := region.Query()
+	if got := query.People().WithTag("informal").Count(); got != 4 {
+		t.Errorf("Expected 4 informal people, got %d", got)
+	}
+	if got := query.Industries().WithTag("export").Count(); got != 1 {
+		t.Errorf("Expected 1 exporting industry, got %d", got)
+	}
+	if !region.GetResource("Water").HasTag("natural") || !region.Industries[0].OutputProducts[0].HasTag("staple") {
+		t.Error("Expected resource and product tags from the config")
+	}
+}
+
+func TestBuildRegionFromConfig_Households(t *testing.T) {
+	config := &RegionConfig{
+		Region:     RegionInfo{Name: "Test"},
+		Problems:   []ProblemConfig{{Name: "Food", Demand: 0.9}},
+		Industries: []IndustryConfig{{Name: "Farm", SolvesProblems: []string{"Food"}, OutputResources: []string{"Grain"}}},
+		Population: PopulationConfig{
+			TotalSize: 100,
+			Segments:  []PopulationSegmentConfig{{Name: "Workers", Percentage: 1, LaborHours: 8}},
+			Households: &HouseholdsConfig{
+				Sizes:      []HouseholdSizeConfig{{Size: 1, Share: 0.5}, {Size: 4, Share: 0.5}},
+				ChildShare: 0.5,
+			},
+		},
+		Simulation: SimulationConfig{Ticks: 1, WeeksPerTick: 1, HoursPerWeek: 40, WagePerHour: 10, Seed: 3},
+	}
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("Expected valid households, got %v", err)
+	}
+
+	region, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+	members, children := 0, 0
+	for _, household := range region.Households {
+		if len(household.Members) > 4 || household.Members[0].Child {
+			t.Fatalf("Expected households of up to 4 led by an adult, got %d members", len(household.Members))
+		}
+		members += len(household.Members)
+		children += household.Children()
+	}
+	if members != 100 {
+		t.Errorf("Expected everyone in a household, got %d", members)
+	}
+	if children == 0 || len(region.Households) >= 100 {
+		t.Errorf("Expected some children and shared households, got %d children in %d households", children, len(region.Households))
+	}
+
+	config.Population.Households.Sizes[0].Share = 0.9
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected error for size shares not summing to 1")
+	}
+}
+
+func TestBuildRegionFromConfig_CompositeNeeds(t *testing.T) {
+	config := &RegionConfig{
+		Region: RegionInfo{Name: "Test"},
+		Problems: []ProblemConfig{
+			{Name: "Health", Demand: 0.9, Parts: []ProblemPartConfig{{Problem: "Nutrition", Weight: 0.6}, {Problem: "Medical Care"}}},
+			{Name: "Nutrition", Demand: 0.9},
+			{Name: "Medical Care", Demand: 0.2},
+		},
+		Industries: []IndustryConfig{{Name: "Farm", SolvesProblems: []string{"Nutrition"}, OutputResources: []string{"Grain"}}},
+		Population: PopulationConfig{
+			TotalSize: 10,
+			Segments:  []PopulationSegmentConfig{{Name: "General", Percentage: 1, HasProblems: []string{"Health"}}},
+		},
+		Simulation: SimulationConfig{Ticks: 1, WeeksPerTick: 1, HoursPerWeek: 40, WagePerHour: 10},
+	}
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("Expected a valid composite need, got %v", err)
+	}
+	region, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+	health := region.GetProblem("Health")
+	if len(health.Parts) != 2 || health.Parts[1].Weight != 1 {
+		t.Errorf("Expected 2 parts with the second weighing 1 by default, got %v", health.Parts)
+	}
+	if needs := region.People[0].GetAllProblems(); len(needs) != 2 {
+		t.Errorf("Expected people to need both parts, got %d needs", len(needs))
+	}
+
+	config.Industries[0].SolvesProblems = []string{"Health"}
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected error for an industry solving a composite need")
+	}
+	config.Industries[0].SolvesProblems = []string{"Nutrition"}
+	config.Problems[1].Parts = []ProblemPartConfig{{Problem: "Health"}}
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected error for a need that is part of itself")
+	}
+}
+
+func TestValidateConfig_Transitions(t *testing.T) {
+	config := &RegionConfig{
+		Region:     RegionInfo{Name: "Test"},
+		Problems:   []ProblemConfig{{Name: "Food", Demand: 0.9}},
+		Industries: []IndustryConfig{{Name: "Farm", SolvesProblems: []string{"Food"}, OutputResources: []string{"Grain"}}},
+		Population: PopulationConfig{
+			TotalSize: 10,
+			Segments:  []PopulationSegmentConfig{{Name: "Workers", Percentage: 0.5}, {Name: "Informal", Percentage: 0.5}},
+		},
+		Simulation:  SimulationConfig{Ticks: 1, WeeksPerTick: 1, HoursPerWeek: 40, WagePerHour: 10},
+		Transitions: []TransitionConfig{{From: "Workers", To: "Informal", When: "unemployed", Ticks: 2}},
+	}
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("Expected a valid transition, got %v", err)
+	}
+	if tracker := BuildTransitions(config); tracker == nil || tracker.Rules[0].Ticks != 2 {
+		t.Error("Expected a tracker with the configured rule")
+	}
+
+	config.Transitions[0].When = "someday"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected error for an unknown trigger")
+	}
+	config.Transitions[0] = TransitionConfig{From: "Workers", To: "Retirees", When: "after"}
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected error for an unknown segment")
+	}
+
+	config.Transitions = nil
+	config.Population.Segments[0].Role = "managers"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected error for an unknown segment role")
+	}
+}
+
+func TestBuildRegionFromConfig_Retailers(t *testing.T) {
+	config := &RegionConfig{
+		Region:   RegionInfo{Name: "Test"},
+		Problems: []ProblemConfig{{Name: "Food", Demand: 0.9}},
+		Industries: []IndustryConfig{
+			{Name: "Shop", SolvesProblems: []string{"Food"}, SuppliedBy: []string{"Farm"}, TargetInventory: 50, Markup: 0.2},
+			{Name: "Farm", OutputResources: []string{"Food"}},
+		},
+		Population: PopulationConfig{TotalSize: 10},
+	}
+
+	region, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+
+	shop, farm := region.Industries[0], region.Industries[1]
+	if !shop.IsRetailer || !farm.SellsWholesale {
+		t.Error("Expected Shop to be a retailer and Farm to sell wholesale")
+	}
+	if len(shop.OutputProducts) != 1 || shop.OutputProducts[0] == farm.OutputProducts[0] {
+		t.Error("Expected Shop to hold its own Food stock")
+	}
+	if shop.TargetInventory != 50 || shop.Markup != 0.2 {
+		t.Errorf("Unexpected retailer settings: target %.0f markup %.2f", shop.TargetInventory, shop.Markup)
+	}
+}
+
+func TestBuildRegionFromConfig_SeparateInventories(t *testing.T) {
+	config := &RegionConfig{
+		Region:    RegionInfo{Name: "Test"},
+		Resources: []ResourceConfig{{Name: "Timber", Unit: "tons", InitialQuantity: 1000}},
+		Problems:  []ProblemConfig{{Name: "Food", Demand: 0.9}},
+		Industries: []IndustryConfig{
+			{Name: "Farm A", SolvesProblems: []string{"Food"}, OutputResources: []string{"Food"}},
+			{Name: "Farm B", SolvesProblems: []string{"Food"}, OutputResources: []string{"Food"}},
+			{Name: "Forestry", InputResources: []string{"Timber"}, OutputResources: []string{"Timber"}},
+			{Name: "Kitchen", InputResources: []string{"Food"}, OutputResources: []string{"Meals"}},
+		},
+		Products:   []ProductConfig{{Name: "Food", Satisfies: 0.5}},
+		Population: PopulationConfig{TotalSize: 10},
+	}
+
+	region, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+
+	farmA, farmB, forestry, kitchen := region.Industries[0], region.Industries[1], region.Industries[2], region.Industries[3]
+	if farmA.OutputProducts[0] == farmB.OutputProducts[0] {
+		t.Error("Expected each farm to hold its own Food stock")
+	}
+	if farmB.OutputProducts[0].Satisfies != 0.5 {
+		t.Errorf("Expected product attributes on every maker's stock, got satisfies %.2f", farmB.OutputProducts[0].Satisfies)
+	}
+
+	timber := forestry.OutputProducts[0]
+	if timber == forestry.InputResources[0] || timber.Quantity != 0 || timber.Unit != "tons" {
+		t.Errorf("Expected an empty Timber stock in tons apart from the region's, got %.0f %s", timber.Quantity, timber.Unit)
+	}
+	if kitchen.InputResources[0] != farmA.OutputProducts[0] {
+		t.Error("Expected Kitchen to draw on Farm A's Food without a contract")
+	}
+
+	// Under a contract, deliveries go into a stock of the buyer's
+	config.Contracts = []ContractConfig{{Seller: "Farm A", Product: "Food", BuyerIndustry: "Kitchen", UnitsPerTick: 5, Price: 2}}
+	region, err = BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+	farmA, kitchen = region.Industries[0], region.Industries[3]
+	if kitchen.InputResources[0] == farmA.OutputProducts[0] || kitchen.InputResources[0].Quantity != 0 {
+		t.Error("Expected Kitchen to hold its own empty Food input stock")
+	}
+}
+
+func TestValidateConfig_Units(t *testing.T) {
+	config := &RegionConfig{
+		Region:    RegionInfo{Name: "Test"},
+		Problems:  []ProblemConfig{{Name: "Food", Demand: 0.9}},
+		Resources: []ResourceConfig{{Name: "Grain", Unit: "sacks"}, {Name: "Flour", Unit: "kg"}},
+		Units:     []UnitConfig{{Name: "sacks", Of: "kg", Factor: 50}},
+		Industries: []IndustryConfig{
+			{Name: "Mill", SolvesProblems: []string{"Food"}, InputResources: []string{"Grain"}, OutputResources: []string{"Flour"}},
+		},
+		Products:   []ProductConfig{{Name: "Flour", Unit: "tons"}},
+		Population: PopulationConfig{TotalSize: 10, Segments: []PopulationSegmentConfig{{Name: "All", Percentage: 1}}},
+	}
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("Expected sacks and tons to be valid, got %v", err)
+	}
+
+	region, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+	if unit := region.Industries[0].OutputProducts[0].Unit; unit != "tons" {
+		t.Errorf("Expected Flour counted in tons, got %s", unit)
+	}
+
+	config.Products[0].Unit = "liters"
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected flour in liters not to match the kg resource")
+	}
+	config.Products[0].Unit = ""
+	config.Units = nil
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected undefined sacks to be rejected")
+	}
+}
+
+func TestCheckTradeUnits(t *testing.T) {
+	region := func(name, unit string) *RegionConfig {
+		return &RegionConfig{
+			Region:     RegionInfo{Name: name},
+			Industries: []IndustryConfig{{Name: "Mill", OutputResources: []string{"Flour"}}},
+			Products:   []ProductConfig{{Name: "Flour", Unit: unit}},
+		}
+	}
+
+	registry, err := CheckTradeUnits([]*RegionConfig{region("A", "kg"), region("B", "tons")})
+	if err != nil {
+		t.Fatalf("Expected kg and tons to trade, got %v", err)
+	}
+	if kg, _ := registry.Convert(1, "tons", "kg"); kg != 1000 {
+		t.Errorf("Expected a ton to ship as 1000 kg, got %.2f", kg)
+	}
+	if _, err := CheckTradeUnits([]*RegionConfig{region("A", "kg"), region("B", "liters")}); err == nil {
+		t.Error("Expected kg and liters not to trade")
+	}
+}
+
+func TestBuildRegionFromConfig_InitialInventory(t *testing.T) {
+	config := &RegionConfig{
+		Region: RegionInfo{Name: "Test"},
+		Resources: []ResourceConfig{
+			{Name: "Land", Unit: "acres", InitialQuantity: 100, Kind: "stock"},
+			{Name: "Water", Unit: "liters", InitialQuantity: 100},
+		},
+		Problems: []ProblemConfig{{Name: "Food", Demand: 0.9}},
+		Industries: []IndustryConfig{
+			{Name: "Mill", InputResources: []string{"Land"}, OutputResources: []string{"Flour"},
+				InitialInventory: []StockConfig{{Of: "Flour", Units: 40}, {Of: "Land", Units: 30}}},
+			{Name: "Bakery", SolvesProblems: []string{"Food"}, InputResources: []string{"Flour", "Water"}, OutputResources: []string{"Bread"},
+				InitialInventory: []StockConfig{{Of: "Flour", Units: 10}, {Of: "Bread", Units: 5}}},
+		},
+		Contracts:  []ContractConfig{{Seller: "Mill", Product: "Flour", BuyerIndustry: "Bakery", UnitsPerTick: 5, Price: 2}},
+		Population: PopulationConfig{TotalSize: 10},
+	}
+
+	region, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+	mill, bakery := region.Industries[0], region.Industries[1]
+	land := mill.InputResources[0]
+	if mill.OutputProducts[0].Quantity != 40 || mill.Holding(land) != 30 || land.Quantity != 70 {
+		t.Errorf("Expected 40 Flour and 30 of 100 acres held, got %.0f, %.0f and %.0f free",
+			mill.OutputProducts[0].Quantity, mill.Holding(land), land.Quantity)
+	}
+	if bakery.InputResources[0].Quantity != 10 || bakery.OutputProducts[0].Quantity != 5 {
+		t.Errorf("Expected 10 Flour in and 5 Bread out, got %.0f and %.0f",
+			bakery.InputResources[0].Quantity, bakery.OutputProducts[0].Quantity)
+	}
+
+	// The region's water is not the bakery's to hold
+	config.Industries[1].InitialInventory = []StockConfig{{Of: "Water", Units: 10}}
+	if _, err := BuildRegionFromConfig(config); err == nil {
+		t.Error("Expected initial inventory of a regional resource to fail")
+	}
+
+	// Without the contract, the bakery's Flour is the mill's
+	config.Industries[1].InitialInventory = []StockConfig{{Of: "Flour", Units: 10}}
+	config.Contracts = nil
+	if _, err := BuildRegionFromConfig(config); err == nil {
+		t.Error("Expected initial inventory of the mill's Flour to fail")
+	}
+}
+
+func TestBuildRegionFromConfig_Distributions(t *testing.T) {
+	config := &RegionConfig{
+		Region:     RegionInfo{Name: "Test"},
+		Problems:   []ProblemConfig{{Name: "Food", Demand: 0.9}},
+		Industries: []IndustryConfig{{Name: "Farm", SolvesProblems: []string{"Food"}, OutputResources: []string{"Grain"}}},
+		Population: PopulationConfig{
+			TotalSize: 200,
+			Segments: []PopulationSegmentConfig{
+				{Name: "Owners", Percentage: 0.5, Wealth: &DistributionConfig{Distribution: DistributionPareto, Min: 100, Alpha: 1.5}},
+				{Name: "Workers", Percentage: 0.5, Wealth: &DistributionConfig{Distribution: DistributionUniform, Min: 10, Max: 20},
+					Productivity: &DistributionConfig{Distribution: DistributionLognormal, Median: 1, Sigma: 0.3},
+					Hours:        &DistributionConfig{Distribution: DistributionPareto, Min: 6, Alpha: 1}},
+			},
+		},
+		Simulation: SimulationConfig{Seed: 7},
+	}
+
+	region, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+	lowest, highest := float32(math.MaxFloat32), float32(0)
+	for _, person := range region.People[:100] {
+		lowest, highest = min(lowest, person.Money), max(highest, person.Money)
+	}
+	if lowest < 100 || highest < 2*lowest {
+		t.Errorf("Expected pareto wealth from 100 with a long tail, got %.0f to %.0f", lowest, highest)
+	}
+	skills := make(map[float32]bool)
+	for _, person := range region.People[100:] {
+		if person.Money < 10 || person.Money > 20 {
+			t.Fatalf("Expected uniform wealth between 10 and 20, got %.2f", person.Money)
+		}
+		if person.LaborHours < 6 || person.LaborHours > 24 {
+			t.Fatalf("Expected hours from 6 up to a day, got %.2f", person.LaborHours)
+		}
+		skills[person.Skill] = true
+	}
+	if len(skills) < 50 || region.People[0].Skill != 1 {
+		t.Errorf("Expected workers' productivity to differ and owners' to stay 1, got %d values and %.2f",
+			len(skills), region.People[0].Skill)
+	}
+
+	// The same seed draws the same
+	again, err := BuildRegionFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to build region: %v", err)
+	}
+	for i, person := range region.People {
+		if again.People[i].Money != person.Money || again.People[i].Skill != person.Skill {
+			t.Fatalf("Expected seeded draws to repeat, person %d got %.2f then %.2f", i, person.Money, again.People[i].Money)
+		}
+	}
+
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("Expected valid wealth distributions, got %v", err)
+	}
+	config.Population.Segments[0].Wealth = &DistributionConfig{Distribution: DistributionLognormal, Sigma: 1}
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected lognormal wealth without a median to fail validation")
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	policyYAML := `
+name: "Tight money"
+wage_per_hour: 12
+monetary_policy:
+  interest_rate: 0.05
+government:
+  sales_tax_rate: 0.2
+`
+	tmpfile, err := os.CreateTemp("", "test-policy-*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+
+	if _, err := tmpfile.Write([]byte(policyYAML)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := LoadPolicy(tmpfile.Name())
+	if err != nil {
+		t.Fatalf("Failed to load policy: %v", err)
+	}
+	if policy.Name != "Tight money" || policy.WagePerHour != 12 {
+		t.Errorf("Expected name and wage to load, got %q / %.2f", policy.Name, policy.WagePerHour)
+	}
+	if policy.MonetaryPolicy == nil || policy.MonetaryPolicy.InterestRate != 0.05 {
+		t.Error("Expected monetary policy section to load")
+	}
+	if policy.Government == nil || policy.Government.SalesTaxRate != 0.2 {
+		t.Error("Expected government section to load")
+	}
+	if policy.Informal != nil || policy.Barter != nil {
+		t.Error("Expected omitted sections to stay nil")
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	config := &RegionConfig{
+		Region:    RegionInfo{Name: `The "Test"`},
+		Problems:  []ProblemConfig{{Name: "Food"}, {Name: "Fun"}},
+		Resources: []ResourceConfig{{Name: "Land"}},
+		Industries: []IndustryConfig{
+			{Name: "Farm", InputResources: []string{"Land"}, OutputResources: []string{"Grain"}},
+			{Name: "Bakery", InputResources: []string{"Grain"}, OutputResources: []string{"Bread"}},
+			{Name: "Shop", SolvesProblems: []string{"Food"}, SuppliedBy: []string{"Bakery"}},
+		},
+		Population: PopulationConfig{Segments: []PopulationSegmentConfig{{Name: "Everyone", HasProblems: []string{"Food"}}}},
+	}
+
+	var b strings.Builder
+	if err := WriteDOT(&b, config); err != nil {
+		t.Fatalf("Failed to write graph: %v", err)
+	}
+	graph := b.String()
+	for _, want := range []string{
+		`digraph "The \"Test\"" {`,
+		`"resource:Land" -> "industry:Farm";`,
+		`"industry:Farm" -> "resource:Grain";`,
+		`"resource:Grain" -> "industry:Bakery";`,
+		`"industry:Bakery" -> "industry:Shop" [style=dashed`,
+		`"industry:Shop" -> "problem:Food";`,
+		`"segment:Everyone" -> "problem:Food"`,
+		`"problem:Fun" [label="Fun", shape=ellipse, color=red, fontcolor=red, style=dashed];`,
+	} {
+		if !strings.Contains(graph, want) {
+			t.Errorf("Expected the graph to contain %s, got:\n%s", want, graph)
+		}
+	}
+	// Bread is a final product no industry uses, so it gets no node
+	if strings.Contains(graph, "Bread") {
+		t.Error("Expected no node for a product no industry takes as an input")
+	}
+}
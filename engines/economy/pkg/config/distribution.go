@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// Distributions a segment's members' starting money, productivity or hours
+// can be drawn from
+const (
+	DistributionUniform   = "uniform"   // Evenly between min and max
+	DistributionLognormal = "lognormal" // Around a median, with a long tail above it
+	DistributionPareto    = "pareto"    // From min up, with a heavy tail
+)
+
+// Streams of the seed each kind of draw takes, so adding one kind of draw to
+// a segment leaves the others as they were
+const (
+	wealthStream       = 0x9e3779b97f4a7c15
+	productivityStream = 0xbf58476d1ce4e5b9
+	hoursStream        = 0x94d049bb133111eb
+)
+
+// hoursPerDay caps the hours a working day drawn for a member
+const hoursPerDay = 24
+
+// checkDistribution makes sure a distribution is known and its parameters
+// describe one
+func checkDistribution(dist *DistributionConfig) error {
+	switch dist.Distribution {
+	case DistributionUniform:
+		if dist.Min < 0 || dist.Max < dist.Min {
+			return fmt.Errorf("uniform distribution needs 0 <= min <= max")
+		}
+	case DistributionLognormal:
+		if dist.Median <= 0 || dist.Sigma < 0 {
+			return fmt.Errorf("lognormal distribution needs a positive median and sigma not negative")
+		}
+	case DistributionPareto:
+		if dist.Min <= 0 || dist.Alpha <= 0 {
+			return fmt.Errorf("pareto distribution needs a positive min and alpha")
+		}
+	default:
+		return fmt.Errorf("unknown distribution: %s", dist.Distribution)
+	}
+	return nil
+}
+
+// draws returns a source of values from a distribution for a segment's
+// members. Each segment and kind of draw takes its own stream of the seed,
+// so adding members to one segment leaves the others' draws as they were.
+func draws(dist *DistributionConfig, seed uint64, segment int, stream uint64) func() float32 {
+	if dist.Seed != 0 {
+		seed = dist.Seed
+	}
+	rng := rand.New(rand.NewPCG(seed, uint64(segment)^stream))
+
+	return func() float32 {
+		switch dist.Distribution {
+		case DistributionLognormal:
+			return dist.Median * float32(math.Exp(rng.NormFloat64()*float64(dist.Sigma)))
+		case DistributionPareto:
+			// 1 - Float64 is in (0, 1], so the draw is never infinite
+			return dist.Min / float32(math.Pow(1-rng.Float64(), 1/float64(dist.Alpha)))
+		}
+		return dist.Min + rng.Float32()*(dist.Max-dist.Min)
+	}
+}
+
+// constant returns a source of the same value for every member
+func constant(value float32) func() float32 {
+	return func() float32 { return value }
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"westex/engines/economy/pkg/names"
+)
+
+// GenerateOptions are the high-level knobs of the region generator
+type GenerateOptions struct {
+	Name        string
+	Population  int
+	Development float32 // 0 (subsistence) to 1 (industrialized): more needs, richer people, better wages
+	Richness    float32 // 0 (barren) to 1 (abundant): stock and regrowth of natural resources
+	Seed        uint64  // Same options and seed give the same region
+	Names       string  // Name theme for industries and people, e.g. "indian" (default: plain numbered names)
+}
+
+// needTemplate is a need the generator can give a region, with the industry
+// serving it and the natural resources that industry consumes
+type needTemplate struct {
+	problem        string
+	description    string
+	basic          bool
+	demand         [2]float32 // Range the share of people needing it is drawn from
+	minDevelopment float32    // Least development at which the need appears
+	industry       string
+	product        string
+	inputs         []string
+}
+
+// needTemplates are ordered from the most to the least fundamental need
+var needTemplates = []needTemplate{
+	{"Food", "Need for sustenance and nutrition", true, [2]float32{0.9, 1.0}, 0, "Farm", "Grain", []string{"Land", "Water"}},
+	{"Shelter", "Need for housing", true, [2]float32{0.3, 0.6}, 0, "Builder", "Housing", []string{"Timber"}},
+	{"Clothing", "Need for clothes", false, [2]float32{0.3, 0.6}, 0.1, "Textile Mill", "Clothes", []string{"Cotton"}},
+	{"Healthcare", "Need for medical care", true, [2]float32{0.05, 0.2}, 0.25, "Clinic", "Medicine", []string{"Chemicals"}},
+	{"Entertainment", "Need for leisure and fun", false, [2]float32{0.1, 0.3}, 0.4, "Studio", "Shows", nil},
+	{"Electronics", "Need for gadgets and appliances", false, [2]float32{0.1, 0.4}, 0.6, "Electronics Factory", "Gadgets", []string{"Metals"}},
+}
+
+// naturalResources gives each input its unit and whether it is free to take
+var naturalResources = map[string]struct {
+	unit string
+	free bool
+}{
+	"Land":      {"acres", true},
+	"Water":     {"liters", true},
+	"Timber":    {"logs", false},
+	"Cotton":    {"bales", false},
+	"Chemicals": {"kg", false},
+	"Metals":    {"kg", false},
+}
+
+// Generator defaults, matching the example configs
+const (
+	generatedWeeksPerTick = 4
+	generatedHoursPerWeek = 40
+)
+
+// Generate creates a plausible random region from a few knobs. Every need
+// the region's development allows gets enough industries to serve most of
+// its demand, natural resources are sized to what those industries consume,
+// and the workforce is sized to staff them. The result is a regular config
+// that can be saved with SaveConfig or built with BuildRegionFromConfig.
+func Generate(opts GenerateOptions) (*RegionConfig, error) {
+	if opts.Population <= 0 {
+		return nil, fmt.Errorf("population must be positive")
+	}
+	if opts.Development < 0 || opts.Development > 1 || opts.Richness < 0 || opts.Richness > 1 {
+		return nil, fmt.Errorf("development and richness must be between 0 and 1")
+	}
+	if opts.Name == "" {
+		opts.Name = fmt.Sprintf("Region-%d", opts.Seed)
+	}
+	var namer *names.Generator
+	if opts.Names != "" {
+		var err error
+		if namer, err = names.NewGenerator(opts.Names, opts.Seed); err != nil {
+			return nil, err
+		}
+	}
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))
+	between := func(lo, hi float32) float32 { return lo + rng.Float32()*(hi-lo) }
+	dev := opts.Development
+
+	config := &RegionConfig{
+		Region: RegionInfo{
+			Name:        opts.Name,
+			Description: fmt.Sprintf("Generated region (development %.2f, richness %.2f, seed %d)", dev, opts.Richness, opts.Seed),
+		},
+		Simulation: SimulationConfig{
+			Ticks:                    10,
+			WeeksPerTick:             generatedWeeksPerTick,
+			HoursPerWeek:             generatedHoursPerWeek,
+			WagePerHour:              5 + 5*dev,
+			ProfitMargin:             0.10,
+			ConsumptionFactorPerWeek: 1.0,
+			Seed:                     opts.Seed,
+		},
+	}
+
+	hours := float32(generatedWeeksPerTick * generatedHoursPerWeek) // Units a fully staffed industry makes per tick
+	laborNeeded := float32(math.Round(float64(2 + 2*dev)))
+	consumption := make(map[string]float32) // Input units used per tick
+	var basicNeeds, allNeeds []string
+	totalLabor := float32(0)
+
+	for _, t := range needTemplates {
+		if t.minDevelopment > dev {
+			continue
+		}
+		demand := float32(math.Round(float64(between(t.demand[0], t.demand[1]))*100) / 100)
+		config.Problems = append(config.Problems, ProblemConfig{
+			Name:        t.problem,
+			Description: t.description,
+			Demand:      demand,
+			IsBasicNeed: t.basic,
+		})
+		allNeeds = append(allNeeds, t.problem)
+		if t.basic {
+			basicNeeds = append(basicNeeds, t.problem)
+		}
+
+		// Enough industries for most of the demand, more when developed
+		units := float32(opts.Population) * demand * between(0.6, 0.9+0.3*dev)
+		count := max(int(math.Ceil(float64(units/hours))), 1)
+		for i := 1; i <= count; i++ {
+			name := t.industry
+			switch {
+			case namer != nil:
+				name = namer.Industry(t.industry)
+			case count > 1:
+				name = fmt.Sprintf("%s %d", t.industry, i)
+			}
+			config.Industries = append(config.Industries, IndustryConfig{
+				Name:            name,
+				SolvesProblems:  []string{t.problem},
+				InputResources:  t.inputs,
+				OutputResources: []string{t.product},
+				LaborNeeded:     laborNeeded,
+				InitialCapital:  float32(math.Round(float64(laborNeeded * config.Simulation.WagePerHour * hours * between(2, 3+5*dev)))),
+			})
+			totalLabor += laborNeeded
+		}
+		for _, input := range t.inputs {
+			consumption[input] += hours * float32(count)
+		}
+	}
+
+	// Natural resources last 5 to 50 ticks and regrow half to all of what is used
+	for _, name := range []string{"Land", "Water", "Timber", "Cotton", "Chemicals", "Metals"} {
+		used, needed := consumption[name]
+		if !needed {
+			continue
+		}
+		config.Resources = append(config.Resources, ResourceConfig{
+			Name:             name,
+			Unit:             naturalResources[name].unit,
+			InitialQuantity:  float32(math.Round(float64(used * (5 + 45*opts.Richness) * between(0.8, 1.2)))),
+			IsFree:           naturalResources[name].free,
+			RegenerationRate: float32(math.Round(float64(used * (0.5 + 0.5*opts.Richness)))),
+		})
+	}
+
+	// Enough workers to staff every industry, with some to spare
+	workers := min(totalLabor*between(1.05, 1.3)/float32(opts.Population), 0.6)
+	money := float32(math.Round(float64(between(30, 60) + 450*dev)))
+	config.Population = PopulationConfig{
+		TotalSize: opts.Population,
+		Names:     opts.Names,
+		Segments: []PopulationSegmentConfig{
+			{
+				Name:         "Workers",
+				Role:         "workers",
+				Percentage:   workers,
+				HasProblems:  basicNeeds,
+				InitialMoney: money * 2,
+				LaborHours:   8,
+			},
+			{
+				Name:         "General Population",
+				Percentage:   1 - workers,
+				HasProblems:  allNeeds,
+				InitialMoney: money,
+			},
+		},
+	}
+
+	if err := validateConfig(config); err != nil {
+		return nil, fmt.Errorf("generated an invalid config: %w", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerate_IsReproducibleAndBuildable(t *testing.T) {
+	opts := GenerateOptions{Population: 500, Development: 0.5, Richness: 0.5, Seed: 42}
+	first, err := Generate(opts)
+	if err != nil {
+		t.Fatalf("Expected a valid region, got %v", err)
+	}
+	second, _ := Generate(opts)
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected the same seed to generate the same region")
+	}
+
+	region, err := BuildRegionFromConfig(first)
+	if err != nil {
+		t.Fatalf("Expected the generated config to build, got %v", err)
+	}
+	if len(region.People) == 0 || len(region.Industries) == 0 {
+		t.Errorf("Expected people and industries, got %d and %d", len(region.People), len(region.Industries))
+	}
+	for _, problem := range region.Problems {
+		served := false
+		for _, industry := range region.Industries {
+			for _, owned := range industry.OwnedProblems {
+				served = served || owned.ID == problem.ID
+			}
+		}
+		if !served {
+			t.Errorf("Expected an industry serving %s", problem.Name)
+		}
+	}
+}
+
+func TestGenerate_DevelopmentAddsNeedsAndRichnessAddsStock(t *testing.T) {
+	poor, _ := Generate(GenerateOptions{Population: 500, Development: 0, Richness: 0, Seed: 1})
+	rich, _ := Generate(GenerateOptions{Population: 500, Development: 1, Richness: 1, Seed: 1})
+
+	if len(rich.Problems) <= len(poor.Problems) {
+		t.Errorf("Expected a developed region to have more needs, got %d vs %d", len(rich.Problems), len(poor.Problems))
+	}
+	stock := func(config *RegionConfig, name string) float32 {
+		for _, resource := range config.Resources {
+			if resource.Name == name {
+				return resource.InitialQuantity
+			}
+		}
+		return 0
+	}
+	if stock(rich, "Land") <= stock(poor, "Land") {
+		t.Errorf("Expected a resource-rich region to hold more land, got %.0f vs %.0f", stock(rich, "Land"), stock(poor, "Land"))
+	}
+}
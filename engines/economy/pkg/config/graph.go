@@ -0,0 +1,119 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// WriteDOT writes the region's wiring as a Graphviz digraph: resources feed
+// the industries that take them as inputs, industries solve problems, and
+// segments have problems. Products one industry makes and another takes as
+// an input link the two, and retailers are linked to the producers that
+// supply them. Problems no industry solves are drawn in red and problems no
+// segment has are dashed, as they are usually wiring mistakes.
+func WriteDOT(w io.Writer, config *RegionConfig) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "digraph %s {\n", dotQuote(config.Region.Name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"Helvetica\"];\n")
+
+	solved := make(map[string]bool)
+	inputs := make(map[string]bool)
+	for _, industry := range config.Industries {
+		for _, problem := range industry.SolvesProblems {
+			solved[problem] = true
+		}
+		for _, resource := range industry.InputResources {
+			inputs[resource] = true
+		}
+	}
+	had := make(map[string]bool)
+	for _, segment := range config.Population.Segments {
+		for _, problem := range segment.HasProblems {
+			had[problem] = true
+		}
+	}
+
+	b.WriteString("\n  // Resources\n")
+	resources := make([]string, 0, len(config.Resources))
+	for _, resource := range config.Resources {
+		resources = append(resources, resource.Name)
+		fmt.Fprintf(b, "  %s [label=%s, shape=box, style=filled, fillcolor=\"#e8f0fe\"];\n",
+			dotID("resource", resource.Name), dotQuote(resource.Name))
+	}
+	// Intermediate products are drawn like resources, between the industry
+	// that makes them and those that use them
+	for _, industry := range config.Industries {
+		for _, product := range industry.OutputResources {
+			if inputs[product] && !slices.Contains(resources, product) {
+				resources = append(resources, product)
+				fmt.Fprintf(b, "  %s [label=%s, shape=box, style=filled, fillcolor=\"#e6f4ea\"];\n",
+					dotID("resource", product), dotQuote(product))
+			}
+		}
+	}
+
+	b.WriteString("\n  // Industries\n")
+	for _, industry := range config.Industries {
+		fmt.Fprintf(b, "  %s [label=%s, shape=component, style=filled, fillcolor=\"#fef7e0\"];\n",
+			dotID("industry", industry.Name), dotQuote(industry.Name))
+	}
+
+	b.WriteString("\n  // Problems\n")
+	for _, problem := range config.Problems {
+		attrs := []string{"label=" + dotQuote(problem.Name), "shape=ellipse"}
+		if !solved[problem.Name] {
+			attrs = append(attrs, "color=red", "fontcolor=red")
+		}
+		if !had[problem.Name] {
+			attrs = append(attrs, "style=dashed")
+		}
+		fmt.Fprintf(b, "  %s [%s];\n", dotID("problem", problem.Name), strings.Join(attrs, ", "))
+	}
+
+	b.WriteString("\n  // Segments\n")
+	for _, segment := range config.Population.Segments {
+		fmt.Fprintf(b, "  %s [label=%s, shape=house, style=filled, fillcolor=\"#fce8e6\"];\n",
+			dotID("segment", segment.Name), dotQuote(segment.Name))
+	}
+
+	b.WriteString("\n")
+	for _, industry := range config.Industries {
+		node := dotID("industry", industry.Name)
+		for _, resource := range industry.InputResources {
+			fmt.Fprintf(b, "  %s -> %s;\n", dotID("resource", resource), node)
+		}
+		for _, product := range industry.OutputResources {
+			if inputs[product] {
+				fmt.Fprintf(b, "  %s -> %s;\n", node, dotID("resource", product))
+			}
+		}
+		for _, producer := range industry.SuppliedBy {
+			fmt.Fprintf(b, "  %s -> %s [style=dashed, label=\"supplies\"];\n", dotID("industry", producer), node)
+		}
+		for _, problem := range industry.SolvesProblems {
+			fmt.Fprintf(b, "  %s -> %s;\n", node, dotID("problem", problem))
+		}
+	}
+	for _, segment := range config.Population.Segments {
+		for _, problem := range segment.HasProblems {
+			fmt.Fprintf(b, "  %s -> %s [color=gray];\n", dotID("segment", segment.Name), dotID("problem", problem))
+		}
+	}
+	b.WriteString("}\n")
+	return b.Flush()
+}
+
+// dotID names a node by its kind and name, so a resource and an industry may
+// share a name
+func dotID(kind, name string) string {
+	return dotQuote(kind + ":" + name)
+}
+
+// dotQuote quotes a string for DOT
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+
+	"westex/engines/economy/pkg/units"
+)
+
+// defaultUnit is what resources and products without a unit are counted in
+const defaultUnit = "units"
+
+// BuildUnits returns the registry of the built-in units and those the config
+// defines, each defined after the units it is a multiple of
+func BuildUnits(config *RegionConfig) (*units.Registry, error) {
+	registry := units.NewRegistry()
+	for _, uConfig := range config.Units {
+		if err := registry.Define(uConfig.Name, uConfig.Of, uConfig.Factor); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// ProductUnits returns the unit each product is counted in, by name: its
+// product config's, else the same-named resource's, else units
+func ProductUnits(config *RegionConfig) map[string]string {
+	productUnits := make(map[string]string)
+	for _, industry := range config.Industries {
+		for _, name := range industry.OutputResources {
+			productUnits[name] = defaultUnit
+		}
+	}
+	for _, resource := range config.Resources {
+		if _, made := productUnits[resource.Name]; made && resource.Unit != "" {
+			productUnits[resource.Name] = resource.Unit
+		}
+	}
+	for _, product := range config.Products {
+		if product.Unit != "" {
+			productUnits[product.Name] = product.Unit
+		}
+	}
+	return productUnits
+}
+
+// checkUnits makes sure every resource and product is counted in a known
+// unit, and that a product named after a resource is counted in a unit that
+// converts to the resource's
+func checkUnits(config *RegionConfig) error {
+	registry, err := BuildUnits(config)
+	if err != nil {
+		return err
+	}
+
+	resourceUnits := make(map[string]string, len(config.Resources))
+	for _, resource := range config.Resources {
+		unit := resource.Unit
+		if unit == "" {
+			unit = defaultUnit
+		}
+		if _, ok := registry.Lookup(unit); !ok {
+			return fmt.Errorf("resource %s is counted in unknown unit %s; define it under units", resource.Name, unit)
+		}
+		resourceUnits[resource.Name] = unit
+	}
+	for _, product := range config.Products {
+		if product.Unit == "" {
+			continue
+		}
+		if _, ok := registry.Lookup(product.Unit); !ok {
+			return fmt.Errorf("product %s is counted in unknown unit %s; define it under units", product.Name, product.Unit)
+		}
+		if unit, ok := resourceUnits[product.Name]; ok && !registry.Compatible(product.Unit, unit) {
+			return fmt.Errorf("product %s is counted in %s, which does not convert to resource %s's %s",
+				product.Name, product.Unit, product.Name, unit)
+		}
+	}
+	return nil
+}
+
+// CheckTradeUnits makes sure regions that trade with each other count every
+// product they have in common in units that convert, and returns the
+// registry of all of their units for converting shipments
+func CheckTradeUnits(configs []*RegionConfig) (*units.Registry, error) {
+	registry := units.NewRegistry()
+	for _, config := range configs {
+		for _, uConfig := range config.Units {
+			if err := registry.Define(uConfig.Name, uConfig.Of, uConfig.Factor); err != nil {
+				return nil, fmt.Errorf("region %s: %w", config.Region.Name, err)
+			}
+		}
+	}
+
+	seen := make(map[string]string)
+	owner := make(map[string]string)
+	for _, config := range configs {
+		for product, unit := range ProductUnits(config) {
+			first, ok := seen[product]
+			if !ok {
+				seen[product] = unit
+				owner[product] = config.Region.Name
+				continue
+			}
+			if !registry.Compatible(unit, first) {
+				return nil, fmt.Errorf("product %s is counted in %s in %s but in %s in %s, which do not convert",
+					product, first, owner[product], unit, config.Region.Name)
+			}
+		}
+	}
+	return registry, nil
+}
@@ -0,0 +1,211 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Metrics an assertion can check, measured at the end of a tick
+var assertionMetrics = []string{
+	"unemployment",     // Share of the workers offering hours no industry took on
+	"vacancies",        // Jobs industries couldn't fill
+	"units_produced",   // Units produced in the tick
+	"wages_paid",       // Wages paid in the tick
+	"purchases",        // Purchases in the product market
+	"spent",            // Money spent in the product market
+	"people_satisfied", // Share of people whose needs the market met
+	"total_wealth",     // Industries' money and people's wealth
+	"gini",             // Inequality of people's wealth
+	"treasury",         // Government treasury (0 without a government)
+	"inventory",        // Stock of the product named by Of, over every industry
+	"resource",         // Quantity of the resource named by Of
+}
+
+// Assertion is an expected outcome of a scenario, such as unemployment
+// staying under 20% after tick 50. With neither At nor From set it is checked
+// once, at the end of the run.
+type Assertion struct {
+	Name   string  // Shown when it fails (default: the condition)
+	Metric string  // One of the assertion metrics
+	Of     string  // Product or resource, for inventory and resource
+	Op     string  // <, <=, > or >=
+	Value  float32 // Value the metric is compared with
+	At     int     // Checked once, at the end of this tick
+	From   int     // Checked at the end of every tick from this one on
+}
+
+// String describes the assertion's condition
+func (a Assertion) String() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	metric := a.Metric
+	if a.Of != "" {
+		metric += " of " + a.Of
+	}
+	condition := fmt.Sprintf("%s %s %g", metric, a.Op, a.Value)
+	switch {
+	case a.At > 0:
+		return condition + fmt.Sprintf(" at tick %d", a.At)
+	case a.From > 0:
+		return condition + fmt.Sprintf(" from tick %d", a.From)
+	}
+	return condition + " at the end"
+}
+
+// AssertionFailure is an assertion that didn't hold, at the first tick it
+// didn't
+type AssertionFailure struct {
+	Assertion string  `json:"assertion"`
+	Tick      int     `json:"tick"`
+	Value     float32 `json:"value"`            // Metric's value at that tick
+	Reason    string  `json:"reason,omitempty"` // Set when it couldn't be checked
+}
+
+// SetAssertions checks the assertions against the region and has the engine
+// evaluate them as it runs
+func (e *Engine) SetAssertions(assertions []Assertion) error {
+	for _, a := range assertions {
+		if !slices.Contains(assertionMetrics, a.Metric) {
+			return fmt.Errorf("unknown metric %s (metrics: %s)", a.Metric, strings.Join(assertionMetrics, ", "))
+		}
+		switch a.Op {
+		case "<", "<=", ">", ">=":
+		default:
+			return fmt.Errorf("%s: unknown comparison %s", a, a.Op)
+		}
+		if a.At > 0 && a.From > 0 {
+			return fmt.Errorf("%s: set at or from, not both", a)
+		}
+		switch a.Metric {
+		case "inventory":
+			if !e.hasProduct(a.Of) {
+				return fmt.Errorf("%s: no industry makes %q", a, a.Of)
+			}
+		case "resource":
+			if e.Region.GetResource(a.Of) == nil {
+				return fmt.Errorf("%s: unknown resource %q", a, a.Of)
+			}
+		}
+	}
+	e.Assertions = assertions
+	e.failed = make([]bool, len(assertions))
+	return nil
+}
+
+// checkAssertions checks the assertions due at the end of the tick
+func (e *Engine) checkAssertions(tick *TickResult) {
+	for i, a := range e.Assertions {
+		if tick.Tick == a.At || (a.From > 0 && tick.Tick >= a.From) {
+			e.checkAssertion(i, tick)
+		}
+	}
+}
+
+// finishAssertions checks the assertions due at the end of the run, and
+// fails those for a tick the run never reached
+func (e *Engine) finishAssertions() {
+	for i, a := range e.Assertions {
+		switch {
+		case a.At > e.CurrentTick || a.From > e.CurrentTick:
+			if !e.failed[i] {
+				e.failed[i] = true
+				e.Failures = append(e.Failures, AssertionFailure{
+					Assertion: a.String(),
+					Tick:      e.CurrentTick,
+					Reason:    fmt.Sprintf("run ended at tick %d", e.CurrentTick),
+				})
+			}
+		case a.At == 0 && a.From == 0 && e.LastTick != nil:
+			e.checkAssertion(i, e.LastTick)
+		}
+	}
+}
+
+// checkAssertion records the assertion as failed if it doesn't hold for the
+// tick, once
+func (e *Engine) checkAssertion(i int, tick *TickResult) {
+	if e.failed[i] {
+		return
+	}
+	a := e.Assertions[i]
+	value := e.assertionMetric(a, tick)
+	var holds bool
+	switch a.Op {
+	case "<":
+		holds = value < a.Value
+	case "<=":
+		holds = value <= a.Value
+	case ">":
+		holds = value > a.Value
+	case ">=":
+		holds = value >= a.Value
+	}
+	if holds {
+		return
+	}
+	e.failed[i] = true
+	e.Failures = append(e.Failures, AssertionFailure{Assertion: a.String(), Tick: tick.Tick, Value: value})
+	e.Logger.LogWarning(fmt.Sprintf("Tick %d: assertion failed: %s (was %.4g)", tick.Tick, a, value))
+}
+
+// assertionMetric measures an assertion's metric for a tick
+func (e *Engine) assertionMetric(a Assertion, tick *TickResult) float32 {
+	switch a.Metric {
+	case "unemployment":
+		if tick.Production.Available == 0 {
+			return 0
+		}
+		return float32(tick.Production.Unemployed) / float32(tick.Production.Available)
+	case "vacancies":
+		return float32(tick.Production.Vacancies)
+	case "units_produced":
+		return tick.Production.UnitsProduced
+	case "wages_paid":
+		return tick.Production.WagesPaid
+	case "purchases":
+		return float32(tick.Market.Purchases)
+	case "spent":
+		return tick.Market.Spent
+	case "people_satisfied":
+		if tick.Market.People == 0 {
+			return 0
+		}
+		return float32(tick.Market.PeopleSatisfied) / float32(tick.Market.People)
+	case "total_wealth":
+		return tick.TotalWealth
+	case "gini":
+		return wealthGini(e)
+	case "treasury":
+		if e.Government == nil {
+			return 0
+		}
+		return e.Government.Treasury
+	case "inventory":
+		stock := float32(0)
+		for _, industry := range e.Region.Industries {
+			for _, product := range industry.OutputProducts {
+				if product.Name == a.Of {
+					stock += product.Quantity
+				}
+			}
+		}
+		return stock
+	case "resource":
+		return e.Region.GetResource(a.Of).Quantity
+	}
+	return 0
+}
+
+// hasProduct reports whether any industry makes the named product
+func (e *Engine) hasProduct(name string) bool {
+	for _, industry := range e.Region.Industries {
+		for _, product := range industry.OutputProducts {
+			if product.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package core
+
+// Checkpoint is a copy of the mutable state of the economy at the end of a
+// tick. It is written when a run stops early and served to concurrent
+// readers as the engine's Snapshot.
+type Checkpoint struct {
+	Tick       int                `json:"tick"`
+	Seed       uint64             `json:"seed"`
+	Region     string             `json:"region"`
+	Industries []IndustryState    `json:"industries"`
+	People     []PersonState      `json:"people"`
+	Resources  map[string]float32 `json:"resources"`
+	Demand     map[string]float32 `json:"demand"`               // Problem demand by name
+	LastTick   *TickResult        `json:"last_tick,omitempty"`  // What happened in the tick
+	Violations []Violation        `json:"violations,omitempty"` // Invalid values that stopped the run
+}
+
+// IndustryState is an industry's money and product stock in a checkpoint
+type IndustryState struct {
+	Name     string             `json:"name"`
+	Money    float32            `json:"money"`
+	Stock    map[string]float32 `json:"stock"`
+	Bankrupt bool               `json:"bankrupt,omitempty"`
+}
+
+// PersonState is a person's balances in a checkpoint
+type PersonState struct {
+	Name    string  `json:"name"`
+	Money   float32 `json:"money"`
+	Savings float32 `json:"savings,omitempty"`
+	Skill   float32 `json:"skill"`
+}
+
+// Checkpoint captures the current state of the economy
+func (e *Engine) Checkpoint() *Checkpoint {
+	checkpoint := &Checkpoint{
+		Tick:       e.CurrentTick,
+		Seed:       e.Seed,
+		Region:     e.Region.Name,
+		Industries: make([]IndustryState, 0, len(e.Region.Industries)),
+		People:     make([]PersonState, 0, len(e.Region.People)),
+		Resources:  make(map[string]float32, len(e.Region.Resources)),
+		Demand:     make(map[string]float32, len(e.Region.Problems)),
+		LastTick:   e.LastTick,
+		Violations: e.violations,
+	}
+
+	for _, industry := range e.Region.Industries {
+		state := IndustryState{
+			Name:     industry.Name,
+			Money:    industry.Money,
+			Stock:    make(map[string]float32, len(industry.OutputProducts)),
+			Bankrupt: industry.Bankrupt,
+		}
+		for _, product := range industry.OutputProducts {
+			state.Stock[product.Name] = product.Quantity
+		}
+		checkpoint.Industries = append(checkpoint.Industries, state)
+	}
+	for _, person := range e.Region.People {
+		checkpoint.People = append(checkpoint.People, PersonState{
+			Name:    person.Name,
+			Money:   person.Money,
+			Savings: person.Savings,
+			Skill:   person.Skill,
+		})
+	}
+	for _, resource := range e.Region.Resources {
+		checkpoint.Resources[resource.Name] = resource.Quantity
+	}
+	for _, problem := range e.Region.Problems {
+		checkpoint.Demand[problem.Name] = problem.Demand
+	}
+
+	return checkpoint
+}
@@ -0,0 +1,186 @@
+package core
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"westex/engines/economy/pkg/events"
+	"westex/engines/economy/pkg/market"
+)
+
+// Cohort groupings: people are grouped once, by what they started with
+const (
+	BySegment        = "segment"         // Their first population segment
+	ByWealthQuintile = "wealth_quintile" // Fifths of the population by starting wealth, Q1 the poorest
+)
+
+// CohortReport follows groups of people sharing a starting characteristic
+// through the run, so outcomes can be compared by where people started
+type CohortReport struct {
+	Cohorts []*Cohort `json:"cohorts"`
+
+	member     map[int][]int // Cohorts each person belongs to, one per grouping, by person ID
+	opening    []float32     // Each cohort's wealth at the start of the tick
+	spent      []float32     // Each cohort's formal market spending this tick
+	subscribed *events.Bus   // Bus the report listens to for purchases
+}
+
+// Cohort is one group of people and how it fared tick by tick
+type Cohort struct {
+	Grouping string       `json:"grouping"`
+	Name     string       `json:"name"`
+	Size     int          `json:"size"` // Members when the cohorts were formed
+	Ticks    []CohortTick `json:"ticks"`
+}
+
+// CohortTick is a cohort's averages over the members still in the region
+type CohortTick struct {
+	Tick         int     `json:"tick"`
+	People       int     `json:"people"`
+	Wealth       float32 `json:"wealth"`
+	Income       float32 `json:"income"`       // The change in wealth plus spending
+	Satisfaction float32 `json:"satisfaction"` // Share of needs met, in ticks with a product market
+	Employed     float32 `json:"employed"`     // Share who worked this tick
+}
+
+// TrackCohorts groups people by segment and starting wealth quintile at the
+// next tick and follows each cohort from then on
+func (e *Engine) TrackCohorts() {
+	e.Cohorts = &CohortReport{}
+}
+
+// Clone returns an independent copy of the report so far, which listens to
+// the bus of whichever engine ticks it next
+func (r *CohortReport) Clone() *CohortReport {
+	clone := &CohortReport{member: r.member}
+	for _, cohort := range r.Cohorts {
+		copied := *cohort
+		copied.Ticks = append([]CohortTick(nil), cohort.Ticks...)
+		clone.Cohorts = append(clone.Cohorts, &copied)
+	}
+	return clone
+}
+
+// openCohorts forms the cohorts on the first tick and notes each one's
+// wealth at the start of the tick
+func (e *Engine) openCohorts() {
+	r := e.Cohorts
+	if r.member == nil {
+		e.formCohorts()
+	}
+	if r.subscribed != e.Events {
+		r.subscribed = e.Events
+		events.On(e.Events, func(purchase events.PurchaseMade) {
+			for _, i := range r.member[purchase.PersonID] {
+				r.spent[i] += purchase.TotalCost
+			}
+		})
+	}
+
+	r.opening = make([]float32, len(r.Cohorts))
+	r.spent = make([]float32, len(r.Cohorts))
+	for _, person := range e.Region.People {
+		for _, i := range r.member[person.ID] {
+			r.opening[i] += person.Wealth()
+		}
+	}
+}
+
+// formCohorts puts everyone in their segment's cohort and their starting
+// wealth quintile's
+func (e *Engine) formCohorts() {
+	r := e.Cohorts
+	r.member = make(map[int][]int, len(e.Region.People))
+	index := make(map[string]int)
+	join := func(id int, grouping, name string) {
+		key := grouping + "/" + name
+		i, ok := index[key]
+		if !ok {
+			i = len(r.Cohorts)
+			index[key] = i
+			r.Cohorts = append(r.Cohorts, &Cohort{Grouping: grouping, Name: name})
+		}
+		r.Cohorts[i].Size++
+		r.member[id] = append(r.member[id], i)
+	}
+
+	for _, segment := range e.Region.PopulationSegments {
+		index[BySegment+"/"+segment.Name] = len(r.Cohorts)
+		r.Cohorts = append(r.Cohorts, &Cohort{Grouping: BySegment, Name: segment.Name})
+	}
+	for _, person := range e.Region.People {
+		if len(person.Segments) > 0 {
+			join(person.ID, BySegment, person.Segments[0].Name)
+		}
+	}
+
+	people := append(e.Region.People[:0:0], e.Region.People...)
+	sort.SliceStable(people, func(i, j int) bool { return people[i].Wealth() < people[j].Wealth() })
+	for rank, person := range people {
+		join(person.ID, ByWealthQuintile, fmt.Sprintf("Q%d", rank*5/len(people)+1))
+	}
+}
+
+// closeCohorts records each cohort's averages for the tick
+func (e *Engine) closeCohorts(result *market.MarketResult) {
+	r := e.Cohorts
+	var met map[int]float32
+	if result != nil {
+		met = market.NeedsMet(e.Region, result)
+	}
+
+	rows := make([]CohortTick, len(r.Cohorts))
+	wealth := make([]float32, len(r.Cohorts))
+	for _, person := range e.Region.People {
+		for _, i := range r.member[person.ID] {
+			row := &rows[i]
+			row.People++
+			wealth[i] += person.Wealth()
+			row.Satisfaction += met[person.ID]
+			if len(e.lastJobs[person.ID]) > 0 {
+				row.Employed++
+			}
+		}
+	}
+	for i, cohort := range r.Cohorts {
+		row := rows[i]
+		row.Tick = e.CurrentTick
+		if row.People > 0 {
+			n := float32(row.People)
+			row.Wealth = wealth[i] / n
+			row.Income = (wealth[i] - r.opening[i] + r.spent[i]) / n
+			row.Satisfaction /= n
+			row.Employed /= n
+		}
+		cohort.Ticks = append(cohort.Ticks, row)
+	}
+}
+
+// SaveCohortsCSV writes every cohort's ticks, one row per cohort and tick,
+// with a header row
+func SaveCohortsCSV(path string, report *CohortReport) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create cohort report: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	w.Write([]string{"tick", "grouping", "cohort", "size", "people", "wealth", "income", "satisfaction", "employed"})
+	for _, cohort := range report.Cohorts {
+		for _, tick := range cohort.Ticks {
+			w.Write([]string{
+				strconv.Itoa(tick.Tick), cohort.Grouping, cohort.Name, strconv.Itoa(cohort.Size), strconv.Itoa(tick.People),
+				formatFloat(tick.Wealth), formatFloat(tick.Income), formatFloat(tick.Satisfaction), formatFloat(tick.Employed),
+			})
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write cohort report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"fmt"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// CommuteRoute lets workers living in one region take jobs in a neighboring
+// one. Commuters pay Cost per tick, in their home currency, out of the
+// wages they send home.
+type CommuteRoute struct {
+	Home string  // Region the commuters live in
+	Work string  // Region they work in
+	Cost float32 // Commuting cost per commuter per tick
+}
+
+// LaborFlow is one tick's commuting along a route
+type LaborFlow struct {
+	From        string  // Home region
+	To          string  // Work region
+	Workers     int     // Commuters sent
+	Wages       float32 // Earned, in the work region's currency
+	Remitted    float32 // Sent home after commuting costs, in the home currency
+	CommuteCost float32 // Paid by the commuters, in the home currency
+}
+
+// commuter is a resident working abroad for the tick through a stand-in the
+// work region pays, so regions running in parallel never share a person
+type commuter struct {
+	route    int
+	resident *entities.Person
+	stand    *entities.Person
+}
+
+// assignCommuters sends the workers each home region left idle last tick to
+// the jobs its neighbors left unfilled, along the routes in order
+func (w *World) assignCommuters() {
+	for _, engine := range w.Regions {
+		clear(engine.away)
+		engine.guests = engine.guests[:0]
+	}
+	w.commuters = w.commuters[:0]
+	sent := make(map[int]bool)
+	for i, route := range w.Commutes {
+		home, work := w.region(route.Home), w.region(route.Work)
+		if home == nil || work == nil || home == work {
+			continue
+		}
+		if home.Region.Currency != work.Region.Currency && w.Exchange == nil {
+			continue
+		}
+		for _, person := range home.idle {
+			if work.vacancies <= len(work.guests) {
+				break
+			}
+			if sent[person.ID] {
+				continue
+			}
+			stand := &entities.Person{
+				ID:         person.ID,
+				Name:       person.Name,
+				Segments:   person.Segments,
+				LaborHours: person.LaborHours,
+				HoursLeft:  person.HoursLeft,
+				Skill:      person.Skill,
+			}
+			if home.away == nil {
+				home.away = make(map[int]bool)
+			}
+			home.away[person.ID] = true
+			work.guests = append(work.guests, stand)
+			w.commuters = append(w.commuters, commuter{route: i, resident: person, stand: stand})
+			sent[person.ID] = true
+		}
+	}
+}
+
+// remitWages sends each commuter's wages home, converted into the home
+// currency, less the commuting cost. The home region exports the labor, so
+// the wages count as its exports in its own currency.
+func (w *World) remitWages() ([]LaborFlow, error) {
+	flows := make([]LaborFlow, len(w.Commutes))
+	for i, route := range w.Commutes {
+		flows[i] = LaborFlow{From: route.Home, To: route.Work}
+	}
+
+	for _, c := range w.commuters {
+		route, flow := w.Commutes[c.route], &flows[c.route]
+		home, work := w.region(route.Home), w.region(route.Work)
+		wages := c.stand.Money
+		earned := wages
+		if wages > 0 && home.Region.Currency != work.Region.Currency {
+			converted, err := w.Exchange.Convert(wages, work.Region.Currency, home.Region.Currency)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remit wages from %s to %s: %w", route.Work, route.Home, err)
+			}
+			if err := w.Exchange.RecordTrade(converted, home.Region.Currency, work.Region.Currency); err != nil {
+				return nil, fmt.Errorf("failed to remit wages from %s to %s: %w", route.Work, route.Home, err)
+			}
+			earned = converted
+		}
+		cost := min(route.Cost, max(c.resident.Money+earned, 0))
+		c.resident.Money += earned - cost
+
+		flow.Workers++
+		flow.Wages += wages
+		flow.Remitted += earned - cost
+		flow.CommuteCost += cost
+	}
+
+	kept := flows[:0]
+	for _, flow := range flows {
+		if flow.Workers > 0 {
+			kept = append(kept, flow)
+		}
+	}
+	return kept, nil
+}
+
+// region finds a region's engine by name
+func (w *World) region(name string) *Engine {
+	for _, engine := range w.Regions {
+		if engine.Region.Name == name {
+			return engine
+		}
+	}
+	return nil
+}
+
+// logLaborFlows reports the tick's commuting in the trade phase
+func (w *World) logLaborFlows() {
+	for _, flow := range w.LaborFlows {
+		w.Logger.LogEvent(fmt.Sprintf("👷 %s → %s: %d commuters earned %.2f, sent home %.2f after %.2f commuting",
+			flow.From, flow.To, flow.Workers, flow.Wages, flow.Remitted, flow.CommuteCost))
+	}
+}
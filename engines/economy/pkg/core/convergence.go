@@ -0,0 +1,91 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ConvergenceSettings stop a run once the economy settles: when prices,
+// employment and the wealth distribution all change less than Tolerance over
+// the last Window ticks
+type ConvergenceSettings struct {
+	Window    int     // Ticks the metrics must hold steady over
+	Tolerance float32 // Largest change allowed, as a share of each metric's average
+}
+
+// steadyMetrics are the metrics watched for convergence after one tick
+type steadyMetrics struct {
+	price      float32 // Average price paid
+	employment float32 // Share of the workers offering hours who found a job
+	gini       float32 // Inequality of people's wealth
+}
+
+// trackConvergence records the tick's metrics once the warm-up is over and
+// marks the tick the economy converged at, the first one closing a steady
+// window
+func (e *Engine) trackConvergence(tick *TickResult, price float32) {
+	if e.ConvergedAt > 0 || tick.Tick <= e.WarmUp {
+		return
+	}
+
+	metrics := steadyMetrics{price: price, gini: wealthGini(e)}
+	if production := tick.Production; production.Available > 0 {
+		metrics.employment = 1 - float32(production.Unemployed)/float32(production.Available)
+	}
+	e.steady = append(e.steady, metrics)
+	if len(e.steady) > e.Convergence.Window {
+		e.steady = e.steady[1:]
+	}
+	if len(e.steady) < e.Convergence.Window {
+		return
+	}
+
+	tolerance := e.Convergence.Tolerance
+	if steady(e.steady, func(m steadyMetrics) float32 { return m.price }, tolerance) &&
+		steady(e.steady, func(m steadyMetrics) float32 { return m.employment }, tolerance) &&
+		steady(e.steady, func(m steadyMetrics) float32 { return m.gini }, tolerance) {
+		e.ConvergedAt = tick.Tick
+		e.Logger.LogEvent(fmt.Sprintf("\n🎯 Converged: prices, employment and wealth held within %.1f%% for %d ticks",
+			tolerance*100, e.Convergence.Window))
+	}
+}
+
+// steady reports whether a metric's range over the window is within the
+// tolerance of its average
+func steady(window []steadyMetrics, metric func(steadyMetrics) float32, tolerance float32) bool {
+	lowest, highest, total := metric(window[0]), metric(window[0]), float32(0)
+	for _, m := range window {
+		value := metric(m)
+		lowest = min(lowest, value)
+		highest = max(highest, value)
+		total += value
+	}
+	average := total / float32(len(window))
+	if average <= 0 {
+		return highest == lowest
+	}
+	return highest-lowest <= tolerance*average
+}
+
+// wealthGini is the Gini coefficient of people's wealth: 0 when everyone
+// holds the same, approaching 1 when one person holds it all
+func wealthGini(e *Engine) float32 {
+	wealth := make([]float32, 0, len(e.Region.People))
+	total := float32(0)
+	for _, person := range e.Region.People {
+		w := max(person.Wealth(), 0)
+		wealth = append(wealth, w)
+		total += w
+	}
+	if total <= 0 {
+		return 0
+	}
+	sort.Slice(wealth, func(i, j int) bool { return wealth[i] < wealth[j] })
+
+	n := float32(len(wealth))
+	weighted := float32(0)
+	for i, w := range wealth {
+		weighted += float32(i+1) * w
+	}
+	return 2*weighted/(n*total) - (n+1)/n
+}
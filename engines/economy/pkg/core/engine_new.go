@@ -1,13 +1,27 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
 	"time"
 
+	"westex/engines/economy/pkg/clock"
+	"westex/engines/economy/pkg/education"
 	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/events"
+	"westex/engines/economy/pkg/finance"
+	"westex/engines/economy/pkg/government"
+	"westex/engines/economy/pkg/insurance"
 	"westex/engines/economy/pkg/logging"
 	"westex/engines/economy/pkg/market"
 	"westex/engines/economy/pkg/production"
+	"westex/engines/economy/pkg/shocks"
+	"westex/engines/economy/pkg/transitions"
+	"westex/engines/economy/pkg/units"
+	"westex/engines/economy/pkg/welfare"
 )
 
 // Engine is the core simulation engine
@@ -19,207 +33,1444 @@ type Engine struct {
 	WeeksPerTick int
 	HoursPerWeek float32
 	InitialState *InitialState
+
+	// DemandAdjustmentRate is the share of the gap to target demand closed
+	// each tick; 0 freezes problem demand at its configured value
+	DemandAdjustmentRate float32
+
+	// MarketMode selects the product market mechanism (market.ModePosted or
+	// market.ModeOrderBook)
+	MarketMode string
+	// Market and Production replace the market mechanism and the production
+	// model (nil = MarketMode's market, one unit per hour of labor). Forks
+	// share them.
+	Market     MarketMechanism
+	Production ProductionModel
+	// ProfitMargin is the markup industries ask over production cost in
+	// order-book mode
+	ProfitMargin float32
+	// Rationing allocates basic needs in short supply in the posted market
+	// (market.RationNone keeps first come, first served)
+	Rationing string
+	// QueueOrder is the order people take their turn for jobs and in the
+	// posted market each tick (market.QueueFixed keeps population order)
+	QueueOrder string
+	// MaxOvertime caps the extra hours a worker chasing a target income
+	// takes on, as a share of the standard hours
+	MaxOvertime float32
+	// MultipleJobs lets workers with hours to spare after one job take a
+	// second at another industry in the same tick; industries then hire
+	// hours rather than whole people
+	MultipleJobs bool
+	// WageTiming is when industries pay their wage bill
+	// (production.PayBeforeProduction, the default, production.PayAfterSales
+	// or production.PayWeekly)
+	WageTiming string
+	// SkillPay pays each worker the wage times their skill, so more
+	// productive workers earn more for the same hours
+	SkillPay bool
+	// InputAllocation is how inputs several industries share are split
+	// before production when they cannot cover everyone
+	// (production.AllocateProRata, AllocatePriority or AllocateAuction; ""
+	// leaves them first come, first served in region order)
+	InputAllocation string
+	// Weekly runs production, wholesale and the product market once per week
+	// of the tick, each person shopping in one of the weeks, so weekly pay
+	// and stock reach the shelves as they would week by week
+	Weekly bool
+
+	// HistoryLength is how many purchases and ticks each person remembers
+	// (0 disables purchase histories)
+	HistoryLength int
+	// LoyalShoppers send people back to the seller they last bought a need
+	// from; it needs purchase histories
+	LoyalShoppers bool
+
+	// Forecasting has industries plan output and hiring from their forecast
+	// sales (nil keeps every industry producing at full capacity)
+	Forecasting *production.Forecaster
+
+	// Welfare scores people's wellbeing every tick (nil disables it)
+	Welfare *welfare.Utility
+	// WelfareHistory holds one welfare report per tick while Welfare is set
+	WelfareHistory []welfare.Report
+
+	// Transitions move people between population segments at the end of
+	// each tick (nil keeps segments fixed)
+	Transitions *transitions.Tracker
+
+	// CentralBank is the optional monetary authority (nil disables it)
+	CentralBank *finance.CentralBank
+	// Bank holds household savings; its rates follow the central bank
+	Bank *finance.Bank
+
+	// Shocks are adverse events that may hit the economy each tick
+	Shocks []*shocks.Shock
+	// Insurers sell policies against shocks
+	Insurers []*insurance.Insurer
+
+	// Government collects taxes into its treasury (nil disables taxation)
+	Government *government.Government
+	// Informal enables the black market for unmet demand (nil disables it)
+	Informal *InformalEconomy
+	// Barter lets cash-less people swap labor for goods (nil disables it)
+	Barter *BarterSettings
+	// Marketing lets industries advertise to be considered over their rivals
+	// (nil disables it: shoppers know every seller)
+	Marketing *MarketingSettings
+
+	// Events carries simulation events to the logger and any other subscribers
+	Events *events.Bus
+
+	// Interrupted is set when the last Run stopped early, on Ctrl-C or an error
+	Interrupted bool
+
+	// Tracer receives a span per tick and per phase (nil disables tracing)
+	Tracer Tracer
+
+	// OnProgress is called by Run after every tick. When it is nil and the
+	// logger is disabled, Run draws a console progress bar instead.
+	OnProgress func(Progress)
+
+	// ServeSnapshots publishes a copy of the state after every tick, so other
+	// goroutines (e.g. an API server) can read it through Snapshot while the
+	// engine mutates the live Region. The Region itself is not safe for
+	// concurrent access.
+	ServeSnapshots bool
+	snapshots      snapshots
+
+	// Clock dates ticks on the calendar for logs, exports and seasonal
+	// mechanics (nil = bare tick numbers)
+	Clock *clock.Clock
+	// Units converts resource quantities to common units in the final
+	// summary (nil = as counted)
+	Units *units.Registry
+
+	// Seed makes random draws reproducible; Rand is seeded from it
+	Seed uint64
+	Rand *rand.Rand
+	// source is Rand's generator, kept so forks can continue the same stream
+	source *rand.PCG
+
+	// Buffers reused from tick to tick to keep garbage down on large runs
+	productMarket *postedMarket
+	workers       []*entities.Person
+
+	// week is the week of the tick being run, from 0, and weeks how many
+	// the tick runs in (1 unless Weekly); down marks the industries found
+	// down in the first week, which stay down for the tick
+	week  int
+	weeks int
+	down  map[int]bool
+	// occupied is the most any shift of each industry produced this tick,
+	// by industry ID, which is all the land it keeps
+	occupied map[int]float32
+
+	// busy marks the people who worked or studied this tick and output is
+	// the units produced, both read when scoring welfare
+	busy   map[int]bool
+	output float32
+
+	// idle are the workers who offered labor but found no job this tick and
+	// payrollTax is what their employed peers' industries paid for them
+	idle       []*entities.Person
+	payrollTax float32
+	laborForce int // Residents who offered to work, here or abroad
+
+	// away are residents commuting to another region this tick, guests the
+	// stand-ins for commuters from other regions working here, and vacancies
+	// the jobs left unfilled, all managed by the world
+	away      map[int]bool
+	guests    []*entities.Person
+	vacancies int
+
+	// sales counts each industry's units sold per product this tick, for
+	// forecasting
+	sales map[saleKey]float32
+	// reserved are the shares of scarce shared inputs set aside for this
+	// production phase
+	reserved *production.Reservations
+	// owed are the wages industries still owe this tick's workers, settled
+	// after sales
+	owed []owedWages
+	// jobs are the industries each resident worked for this tick, and
+	// lastJobs last tick's, by person ID, to tell who was hired and fired
+	jobs     map[int][]string
+	lastJobs map[int][]string
+
+	// revenue is each industry's sales income this tick, by industry ID, for
+	// the bank's lending covenant
+	revenue map[int]float32
+
+	// LastTick is the result of the latest tick (nil before the first)
+	LastTick *TickResult
+
+	// WarmUp is the number of ticks left out of the summary and exports
+	// while the economy settles, MeasureTicks how many ticks after it are
+	// measured (0 = the rest of the run). measured holds the results of the
+	// ticks measured so far.
+	WarmUp       int
+	MeasureTicks int
+	measured     []*TickResult
+
+	// Convergence stops Run once the economy reaches a steady state (nil
+	// runs every tick). ConvergedAt is the tick it converged at, 0 until
+	// then; steady holds the metrics of the latest ticks.
+	Convergence *ConvergenceSettings
+	ConvergedAt int
+	steady      []steadyMetrics
+
+	// Guards checks the state after every tick for NaN, infinite or negative
+	// values: GuardWarn clamps them, GuardAbort stops the run ("" = off).
+	// Clamped counts the values fixed so far and violations are the ones
+	// that stopped the run.
+	Guards     string
+	Clamped    int
+	violations []Violation
+
+	// Assertions are the scenario's expected outcomes, set through
+	// SetAssertions. Failures are those that didn't hold, failed marks them
+	// by index.
+	Assertions []Assertion
+	Failures   []AssertionFailure
+	failed     []bool
+
+	// Changes are the parameters changed between ticks through
+	// SetParameter, in order
+	Changes []ParameterChange
+
+	// Trajectories follows a sample of people in full detail, tick by tick
+	// (nil = nobody), set through TrackPeople
+	Trajectories *Trajectories
+	// Cohorts follows groups of people by where they started (nil = off),
+	// set through TrackCohorts
+	Cohorts *CohortReport
+	// Matrices records output, prices and unmet needs by tick for heatmaps
+	// (nil = off), set through TrackMatrices
+	Matrices *Heatmaps
+	// History keeps headline figures of every tick for charting the run
+	// (nil = off), set through TrackHistory
+	History *History
+
+	// phases are the phases run each tick, in order (nil = every phase in
+	// the default order), set through SetPhases
+	phases []tickPhase
+
+	// lastMarket is the product market result of the latest tick, read by
+	// the world's trade phase
+	lastMarket *market.MarketResult
+}
+
+// owedWages is what an industry still owes one shift's workers
+type owedWages struct {
+	industry *entities.Industry
+	workers  []*entities.Person
+	hours    []float32
+	wage     float32 // Per hour still owed
+}
+
+// saleKey is one industry's product
+type saleKey struct {
+	industry int
+	product  int
+}
+
+// InformalEconomy configures the untaxed black market that serves part of
+// the demand the formal market left unmet
+type InformalEconomy struct {
+	Premium           float32 // Markup over the formal price
+	BaseParticipation float32 // Chance an unmet buyer goes informal at zero tax
+	TaxSensitivity    float32 // Extra participation per unit of sales tax rate
+}
+
+// BarterSettings configures the labor-for-goods barter pass
+type BarterSettings struct {
+	HoursPerUnit   float32 // Labor hours exchanged for one unit of product
+	MoneyThreshold float32 // Only people with less money than this barter
 }
 
-// InitialState captures the starting state of the economy
-type InitialState struct {
-	IndustryMoney map[string]float32
-	PersonMoney   map[string]float32
-	TotalWealth   float32
+// MarketingSettings configures how advertising spend buys awareness
+type MarketingSettings struct {
+	BaseAwareness float32 // Chance a shopper considers an industry that doesn't advertise
+	HalfSpend     float32 // Spend per tick that closes half the gap to full awareness
+}
+
+// InitialState captures the state of the economy the run is measured from:
+// its start, or the end of the warm-up
+type InitialState struct {
+	IndustryMoney map[string]float32
+	PersonMoney   map[string]float32
+	TotalWealth   float32
+}
+
+// captureState records everyone's money in the region
+func captureState(region *entities.Region) *InitialState {
+	state := &InitialState{
+		IndustryMoney: make(map[string]float32),
+		PersonMoney:   make(map[string]float32),
+		TotalWealth:   0,
+	}
+
+	for _, ind := range region.Industries {
+		state.IndustryMoney[ind.Name] = ind.Money
+		state.TotalWealth += ind.Money
+	}
+
+	for _, p := range region.People {
+		state.PersonMoney[p.Name] = p.Wealth()
+		state.TotalWealth += p.Wealth()
+	}
+	return state
+}
+
+// EngineOption sets up an engine as NewEngine creates it
+type EngineOption func(*Engine)
+
+// WithWage sets the hourly wage industries pay (default $10)
+func WithWage(perHour float32) EngineOption {
+	return func(e *Engine) { e.WagePerHour = perHour }
+}
+
+// WithTickLength sets how many weeks a tick lasts and the working hours in
+// each (default 4 weeks of 40 hours)
+func WithTickLength(weeks int, hoursPerWeek float32) EngineOption {
+	return func(e *Engine) {
+		e.WeeksPerTick = weeks
+		e.HoursPerWeek = hoursPerWeek
+	}
+}
+
+// WithLogger logs the run to the given logger instead of a new one printing
+// to the terminal
+func WithLogger(logger *logging.Logger) EngineOption {
+	return func(e *Engine) { e.Logger = logger }
+}
+
+// WithSeed makes the run's random draws reproducible (default: seeded from
+// the clock)
+func WithSeed(seed uint64) EngineOption {
+	return func(e *Engine) { e.SetSeed(seed) }
+}
+
+// NewEngine creates a simulation engine for a region, with the default
+// settings changed by the options
+func NewEngine(region *entities.Region, options ...EngineOption) *Engine {
+	engine := &Engine{
+		Region:       region,
+		Logger:       logging.NewLogger(true),
+		CurrentTick:  0,
+		WagePerHour:  10.0,
+		WeeksPerTick: 4,
+		HoursPerWeek: 40.0,
+		InitialState: captureState(region),
+
+		DemandAdjustmentRate: 0.25,
+		MarketMode:           market.ModePosted,
+		ProfitMargin:         0.10,
+		Bank:                 finance.NewBank(0, 0),
+		Events:               events.NewBus(),
+	}
+	engine.SetSeed(uint64(time.Now().UnixNano()))
+	for _, option := range options {
+		option(engine)
+	}
+	engine.Logger.Subscribe(engine.Events)
+
+	return engine
+}
+
+// CreateNewEngine creates a new simulation engine with default parameters
+func CreateNewEngine(region *entities.Region) *Engine {
+	return NewEngine(region)
+}
+
+// NewEngineWithParams creates a new simulation engine with custom parameters
+//
+// Deprecated: use NewEngine with WithWage and WithTickLength.
+func NewEngineWithParams(
+	region *entities.Region,
+	wagePerHour float32,
+	weeksPerTick int,
+	hoursPerWeek float32,
+) *Engine {
+	return NewEngine(region, WithWage(wagePerHour), WithTickLength(weeksPerTick, hoursPerWeek))
+}
+
+// SetSeed reseeds the engine's random number generator
+func (e *Engine) SetSeed(seed uint64) {
+	e.Seed = seed
+	e.source = rand.NewPCG(seed, seed)
+	e.Rand = rand.New(e.source)
+}
+
+// Step advances the simulation by one tick. A cancelled context stops the
+// tick at the next phase boundary and its error is returned.
+func (e *Engine) Step(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	e.CurrentTick++
+	tickCtx, span := e.startTickSpan(ctx)
+	err := e.processTick(tickCtx)
+	span.End()
+	if err != nil {
+		return err
+	}
+
+	if e.ServeSnapshots {
+		e.snapshots.publish(e)
+	}
+	return nil
+}
+
+// Run executes the simulation for a given number of ticks. Cancelling the
+// context stops the run once the tick in progress finishes, so the state and
+// summary stay consistent, and returns the context's error. Interrupted
+// reports whether the last run stopped early, on cancellation or an error.
+func (e *Engine) Run(ctx context.Context, ticks int) error {
+	// Ticks run to the end even if ctx is cancelled during one
+	tickCtx := context.WithoutCancel(ctx)
+
+	if e.Logger.Enabled() {
+		fmt.Printf("\n🚀 Starting Economy Simulation for %d ticks...\n", ticks)
+		fmt.Printf("Region: %s\n", e.Region.Name)
+		fmt.Printf("Industries: %d, People: %d, Problems: %d\n",
+			len(e.Region.Industries), len(e.Region.People), len(e.Region.Problems))
+		fmt.Printf("Wage Rate: $%.2f/hour, Weeks/Tick: %d, Hours/Week: %.0f\n\n",
+			e.WagePerHour, e.WeeksPerTick, e.HoursPerWeek)
+		if !e.hasWorkforce() {
+			fmt.Println("⚠️  No segment has the workers role and no industry hires from one, nobody will work")
+		}
+	}
+
+	report := e.OnProgress
+	if report == nil && !e.Logger.Enabled() {
+		report = ConsoleProgress(os.Stdout)
+	}
+	startTick, started := e.CurrentTick, time.Now()
+
+	var err error
+	e.Interrupted = false
+	for i := 0; i < ticks; i++ {
+		if err = ctx.Err(); err != nil {
+			e.Interrupted = true
+			break
+		}
+		if err = e.Step(tickCtx); err != nil {
+			e.Interrupted = true
+			break
+		}
+		if report != nil {
+			report(e.progress(startTick, ticks, started))
+		}
+		if e.ConvergedAt > startTick {
+			break
+		}
+
+		// Slow down so detailed logs can be read on the terminal
+		if e.Logger.Level() >= logging.LevelVerbose && e.Logger.ToTerminal() {
+			select {
+			case <-ctx.Done():
+			case <-time.After(300 * time.Millisecond):
+			}
+		}
+	}
+
+	if e.Interrupted {
+		fmt.Printf("\n⏹️  Interrupted after tick %d of %d\n", e.CurrentTick, ticks)
+	} else if e.ConvergedAt > startTick {
+		fmt.Printf("\n🎯 Converged at tick %d of %d, stopping early\n", e.ConvergedAt, ticks)
+	}
+	e.Finish()
+	e.printFinalSummary()
+	return err
+}
+
+// Finish closes a run, checking the assertions due at its end. Run calls it;
+// callers driving the engine through Step call it after the last tick.
+func (e *Engine) Finish() {
+	if len(e.Assertions) > 0 {
+		e.finishAssertions()
+	}
+}
+
+// processTick handles one simulation tick, running the phases of the
+// pipeline in order and stopping between them if the context is cancelled
+func (e *Engine) processTick(ctx context.Context) error {
+	if e.Clock != nil {
+		e.Logger.SetDate(e.Clock.Label(e.CurrentTick))
+	}
+	e.Logger.LogTick(e.CurrentTick)
+
+	// Weekly ticks run the weekly phases once per week; the others run once,
+	// at the start of the tick when they come before the first weekly phase
+	// and in the last week otherwise
+	pipeline := e.pipeline()
+	first := len(pipeline)
+	e.weeks = 1
+	if e.Weekly && e.WeeksPerTick > 1 {
+		if first = firstWeekly(pipeline); first < len(pipeline) {
+			e.weeks = e.WeeksPerTick
+		}
+	}
+	weeksPerRound := e.WeeksPerTick / e.weeks
+
+	t := &tickState{
+		result: &TickResult{Tick: e.CurrentTick},
+		// Calculate hours available this tick, or this week
+		hoursAvailable: float32(weeksPerRound) * e.HoursPerWeek,
+		// Remember opening balances to measure this tick's profits
+		openingMoney: make(map[int]float32, len(e.Region.Industries)),
+	}
+	for _, industry := range e.Region.Industries {
+		t.openingMoney[industry.ID] = industry.Money
+	}
+	solvent := e.solventPeople()
+	if e.Trajectories != nil {
+		e.openTrajectories()
+	}
+	if e.Cohorts != nil {
+		e.openCohorts()
+	}
+
+	for e.week = 0; e.week < e.weeks; e.week++ {
+		// Everyone starts the tick, or the week, with their full labor hours
+		production.ResetHours(e.Region.People, float32(weeksPerRound*workDaysPerWeek), e.MaxOvertime)
+		if e.weeks > 1 {
+			e.Logger.LogEvent(fmt.Sprintf("\n📆 WEEK %d OF %d", e.week+1, e.weeks))
+		}
+
+		for i, phase := range pipeline {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			due := phase.weekly && e.weeks > 1 || i < first && e.week == 0 || i > first && e.week == e.weeks-1
+			if !due || phase.active != nil && !phase.active(e, t) {
+				continue
+			}
+			if phase.title != "" {
+				e.Logger.LogEvent(phase.title)
+			}
+			span := e.startSpan(ctx, phase.span)
+			phase.run(e, t)
+			span.End()
+		}
+	}
+	e.week = 0
+	e.publishBankruptcies(solvent)
+
+	// Invalid values are caught before they reach the tick's statistics
+	if e.Guards != "" {
+		if err := e.checkGuards(); err != nil {
+			return err
+		}
+	}
+
+	if e.CentralBank != nil {
+		record := e.CentralBank.RecordMoneySupply(e.Region, e.CurrentTick)
+		e.Logger.LogEvent(fmt.Sprintf("\n💵 Money supply: $%.2f (injected this tick: $%.2f, policy rate %.2f%%)",
+			record.Supply, record.Injected, e.CentralBank.PolicyRate*100))
+	}
+
+	// Welfare scores the needs the market met, so it needs a market
+	if e.Welfare != nil && t.market != nil {
+		t.result.Welfare = e.measureWelfare(t.market)
+	}
+	if e.Trajectories != nil {
+		e.closeTrajectories(t.market)
+	}
+	if e.Cohorts != nil {
+		e.closeCohorts(t.market)
+	}
+	if e.Matrices != nil {
+		e.recordMatrices(t.result.Production, t.market)
+	}
+	if e.History != nil {
+		e.recordHistory(t.market)
+	}
+
+	t.result.TotalWealth = totalWealth(e.Region)
+	if e.Clock != nil {
+		t.result.Date = e.Clock.Date(e.CurrentTick).Format(clock.DateLayout)
+	}
+	e.LastTick = t.result
+	e.measure(t.result)
+	if e.Convergence != nil {
+		price := pricePerUnit
+		if t.market != nil {
+			price = t.market.AveragePrice(pricePerUnit)
+		}
+		e.trackConvergence(t.result, price)
+	}
+	if len(e.Assertions) > 0 {
+		e.checkAssertions(t.result)
+	}
+	e.logTickSummary(t.result)
+	return nil
+}
+
+// measureWelfare scores everyone's wellbeing for the tick next to its GDP
+func (e *Engine) measureWelfare(result *market.MarketResult) *welfare.Report {
+	report := e.Welfare.Measure(e.Region.People, welfare.Tick{
+		Number: e.CurrentTick,
+		Busy:   e.busy,
+		Met:    market.NeedsMet(e.Region, result),
+		GDP:    e.output * pricePerUnit,
+	})
+	e.WelfareHistory = append(e.WelfareHistory, report)
+	e.Logger.LogEvent(fmt.Sprintf("\n😊 Welfare %.2f (median %.2f, lowest %.2f), GDP $%.2f",
+		report.Average, report.Median, report.Lowest, report.GDP))
+	return &report
+}
+
+// logTickSummary prints the tick's one-line summary
+func (e *Engine) logTickSummary(tick *TickResult) {
+	if e.Logger.Level() < logging.LevelSummary {
+		return
+	}
+	summary := fmt.Sprintf("%d purchases, %d/%d people satisfied, $%.2f spent, total wealth $%.2f",
+		tick.Market.Purchases, tick.Market.PeopleSatisfied, tick.Market.People, tick.Market.Spent, tick.TotalWealth)
+	if tick.Welfare != nil {
+		summary += fmt.Sprintf(", welfare %.2f, GDP $%.2f", tick.Welfare.Average, tick.Welfare.GDP)
+	}
+	e.Logger.LogTickSummary(tick.Tick, summary)
+}
+
+// processProductionPhase handles production and labor payments; students
+// spend the tick in school and are not available to work
+func (e *Engine) processProductionPhase(hoursAvailable float32, students []*entities.Person) ProductionPhaseResult {
+	// Get available workers
+	workforce := e.getAvailableWorkers()
+	market.Requeue(workforce, e.QueueOrder, e.CurrentTick, e.Rand)
+	workforce = append(workforce, e.guests...) // Commuters are hired after residents
+	availableWorkers := withoutPeople(workforce, students)
+
+	// Who worked, who is down and the payroll tax due count over the tick
+	if e.busy == nil {
+		e.busy = make(map[int]bool)
+		e.down = make(map[int]bool)
+		e.occupied = make(map[int]float32)
+	}
+	if e.week == 0 {
+		clear(e.busy)
+		clear(e.down)
+		clear(e.occupied)
+		e.payrollTax = 0
+	}
+	for _, student := range students {
+		e.busy[student.ID] = true
+	}
+
+	// Workers choose their hours at the going wage
+	availableWorkers, hours := e.offerLabor(availableWorkers, hoursAvailable)
+	e.Logger.LogEvent(fmt.Sprintf("Available workers: %d", len(availableWorkers)))
+
+	phase := ProductionPhaseResult{Available: len(availableWorkers)}
+	e.reserveInputs(hoursAvailable)
+
+	for _, industry := range e.Region.Industries {
+		// Retailers restock in the wholesale phase and schools teach instead
+		// of producing
+		if industry.IsRetailer || industry.IsSchool {
+			continue
+		}
+
+		e.Logger.LogEvent(fmt.Sprintf("\n--- %s ---", industry.Name))
+		output := IndustryProduction{Industry: industry.Name}
+
+		// Hours bartered for goods since the last run are owed for this run
+		// only: an industry that is down or has nothing to make forgoes them
+		bartered := industry.BarteredLaborHours
+		industry.BarteredLaborHours = 0
+
+		// Industries down for maintenance or a breakdown produce nothing, for
+		// every week of the tick
+		if e.week > 0 {
+			if e.down[industry.ID] {
+				output.Down = true
+				phase.Industries = append(phase.Industries, output)
+				continue
+			}
+		} else if down, cause := production.CheckOutage(industry, e.CurrentTick, e.Rand); down {
+			if cause != "" {
+				e.Logger.LogEvent(fmt.Sprintf("🔧 Down: %s (%d more ticks)", cause, industry.DownFor))
+			} else {
+				e.Logger.LogEvent(fmt.Sprintf("🔧 Still down (%d more ticks)", industry.DownFor))
+			}
+			e.down[industry.ID] = true
+			output.Down = true
+			phase.Industries = append(phase.Industries, output)
+			continue
+		}
+
+		// Each shift hires a crew of its own, later ones at the night premium
+		target, planned := e.productionTarget(industry)
+		for shift := 0; shift < max(industry.Shifts, 1); shift++ {
+			// Allocate workers from the segments the industry hires from,
+			// only as many as the plan needs when there is one
+			pool, poolHours := production.Eligible(industry, availableWorkers, hours)
+			workers := production.AllocateWorkers(industry, pool)
+			needed := int(industry.LaborNeeded)
+			if planned {
+				needed = production.WorkersFor(industry, target, hoursAvailable)
+				workers = workers[:min(len(workers), needed)]
+			}
+
+			// With multiple jobs the crew's hours are filled instead of its
+			// places, so the crew counts in full-time equivalents
+			workerHours := poolHours[:len(workers)]
+			crew := float32(len(workers))
+			if e.MultipleJobs {
+				workers, workerHours = production.AllocateHours(pool, poolHours, float32(needed)*hoursAvailable)
+				crew = 0
+				for _, h := range workerHours {
+					crew += h / hoursAvailable
+				}
+			}
+
+			// Later shifts only run with a full crew of workers still free
+			wage := e.WagePerHour
+			if shift > 0 {
+				if needed == 0 || crew < float32(needed)-0.001 {
+					break
+				}
+				wage *= 1 + industry.NightPremium
+				e.Logger.LogEvent(fmt.Sprintf("🌙 Shift %d at $%.2f/hour", shift+1, wage))
+			}
+
+			if planned {
+				e.Logger.LogEvent(fmt.Sprintf("🔮 Planning %.0f units for %d workers", target, needed))
+			}
+			phase.Vacancies += max(needed-int(crew+0.001), 0)
+			if e.MultipleJobs {
+				e.Logger.LogEvent(fmt.Sprintf("Allocated %d workers for %.1f full-time (needs %d)", len(workers), crew, needed))
+			} else {
+				e.Logger.LogEvent(fmt.Sprintf("Allocated %d workers (needs %d)", len(workers), needed))
+			}
+
+			if needed == 0 {
+				e.Logger.LogEvent("💤 Enough stock for the plan, not producing")
+				break
+			}
+			if len(workers) == 0 {
+				e.Logger.LogEvent("❌ No workers available")
+				break
+			}
+
+			// Bartered hours count as extra (unpaid) workers in the first shift
+			barterWorkers := bartered / hoursAvailable
+			if barterWorkers > 0 {
+				e.Logger.LogEvent(fmt.Sprintf("🤝 %.0f bartered hours add %.2f workers", bartered, barterWorkers))
+				bartered = 0
+			}
+
+			// Calculate production
+			result := e.productionModel().Produce(industry, crew+barterWorkers, hoursAvailable, wage)
+			production.ApplySkill(industry, result, workers)
+			production.ApplyCommute(e.Region, industry, result, workers, hoursAvailable)
+			if !e.MultipleJobs {
+				production.ApplyHours(industry, result, workerHours, hoursAvailable)
+			}
+			if allowed, reserved := e.reserved.Allowance(industry); reserved {
+				production.ApplyTarget(industry, result, allowed)
+			}
+			if planned {
+				production.ApplyTarget(industry, result, target)
+				target = max(target-result.UnitsProduced, 0)
+			}
+			if shift == 0 && industry.FixedCosts > 0 {
+				production.ApplyFixedCost(result, industry.FixedCosts)
+			}
+
+			e.Logger.LogEvent(fmt.Sprintf("Production capacity: %.1f%% (%.0f/%.0f workers)",
+				(result.LaborUsed/industry.LaborNeeded)*100, result.LaborUsed, industry.LaborNeeded))
+
+			// Industries short of the wage bill borrow the difference
+			upfront := production.UpfrontShare(e.WageTiming, e.WeeksPerTick/max(e.weeks, 1))
+			if e.Bank.Lending != nil && industry.Money < result.LaborCost*upfront {
+				e.borrow(industry, result.LaborCost*upfront-industry.Money)
+			}
+
+			// Under skill pay, workers are paid for their hours weighed by
+			// their skill
+			paidHours := workerHours
+			if e.SkillPay {
+				paidHours = production.ApplySkillPay(result, workers, workerHours)
+			}
+
+			// Pay workers FIRST (before production), or the share of their
+			// wages due before production under the wage timing
+			payments, err := production.PayHours(
+				industry,
+				workers,
+				paidHours,
+				wage*upfront,
+			)
+
+			if err != nil {
+				if !industry.Bankrupt {
+					industry.Bankrupt = true
+					e.Events.Publish(events.IndustryBankrupt{
+						Tick:     e.CurrentTick,
+						Industry: industry.Name,
+						Money:    industry.Money,
+						Reason:   err.Error(),
+					})
+				} else {
+					e.Logger.LogEvent(fmt.Sprintf("❌ %s", err.Error()))
+				}
+				break
+			}
+			industry.Bankrupt = false
+
+			// Consume resources
+			stockBefore := make(map[int]float32, len(industry.InputResources))
+			for _, input := range industry.InputResources {
+				stockBefore[input.ID] = input.Quantity
+			}
+			consumptions, err := production.ConsumeResources(industry, result.UnitsProduced)
+			for _, input := range industry.InputResources {
+				if stockBefore[input.ID] > 0 && input.Quantity <= 0 {
+					e.Events.Publish(events.ResourceDepleted{
+						Tick:     e.CurrentTick,
+						Resource: input.Name,
+						Industry: industry.Name,
+					})
+				}
+			}
+			if err != nil {
+				e.Logger.LogEvent(fmt.Sprintf("❌ Resource shortage: %s", err.Error()))
+				// Refund workers since we can't produce; PayHours pays in
+				// the order of workers
+				for i, payment := range payments {
+					workers[i].Money -= payment.TotalPaid
+					industry.Money += payment.TotalPaid
+				}
+				break
+			}
+
+			// The wages stand now that the shift produces, so the payroll
+			// tax is due and they count as paid
+			if e.hasUnemployment() {
+				e.payrollTax += e.Government.CollectPayroll(industry, workers, paidHours, wage)
+			}
+			if upfront > 0 {
+				e.Events.Publish(events.WagePaid{
+					Tick:     e.CurrentTick,
+					Industry: industry.Name,
+					Workers:  len(workers),
+					Amount:   result.LaborCost * upfront,
+				})
+			}
+			phase.WagesPaid += result.LaborCost * upfront
+
+			e.reserved.Use(industry, result.UnitsProduced)
+			e.occupied[industry.ID] = max(e.occupied[industry.ID], result.UnitsProduced)
+
+			// Log resource consumption
+			for _, consumption := range consumptions {
+				if consumption.Occupied {
+					e.Logger.LogEvent(fmt.Sprintf("📍 Occupying %.2f %s (cost: $%.2f)",
+						consumption.Quantity, consumption.ResourceName, consumption.Cost))
+					continue
+				}
+				e.Logger.LogEvent(fmt.Sprintf("📉 Consumed %.2f %s (cost: $%.2f)",
+					consumption.Quantity, consumption.ResourceName, consumption.Cost))
+			}
+
+			// Produce goods
+			for _, product := range industry.OutputProducts {
+				product.Add(result.UnitsProduced)
+				e.Events.Publish(events.ProductionCompleted{
+					Tick:        e.CurrentTick,
+					Industry:    industry.Name,
+					Product:     product.Name,
+					Units:       result.UnitsProduced,
+					Stock:       product.Quantity,
+					TotalCost:   result.TotalCost,
+					CostPerUnit: result.CostPerUnit,
+				})
+				phase.UnitsProduced += result.UnitsProduced
+			}
+			output.Shifts++
+			output.Workers += len(workers)
+			output.Units += result.UnitsProduced
+			output.Cost += result.TotalCost
+
+			// Log costs
+			e.Logger.LogEvent(fmt.Sprintf("📊 Total cost: $%.2f (Labor: $%.2f, Resources: $%.2f, Fixed: $%.2f, Per unit: $%.2f)",
+				result.TotalCost, result.LaborCost, result.ResourceCost, result.FixedCost, result.CostPerUnit))
+
+			// Record production history for cost tracking
+			industry.RecordProduction(entities.ProductionRecord{
+				Tick:          e.CurrentTick,
+				UnitsProduced: result.UnitsProduced,
+				TotalCost:     result.TotalCost,
+				CostPerUnit:   result.CostPerUnit,
+				LaborCost:     result.LaborCost,
+				ResourceCost:  result.ResourceCost,
+			})
+
+			// The rest of the wages fall due after sales
+			if upfront < 1 {
+				e.owed = append(e.owed, owedWages{
+					industry: industry,
+					workers:  append([]*entities.Person(nil), workers...),
+					hours:    append([]float32(nil), paidHours...),
+					wage:     wage * (1 - upfront),
+				})
+			}
+
+			// Cooperatives take their workers in as members
+			residents := e.withoutGuests(workers)
+			e.recordJobs(industry, residents)
+			if joined := industry.AdmitMembers(residents); joined > 0 {
+				e.Logger.LogEvent(fmt.Sprintf("🤝 %d workers joined the %s cooperative (%d members)",
+					joined, industry.Name, len(industry.Shareholders)))
+			}
+
+			// Remove allocated workers from available pool, their hours used
+			for i, worker := range workers {
+				e.busy[worker.ID] = true
+				worker.HoursLeft -= workerHours[i]
+			}
+			// Workers with hours to spare stay in line for a second job
+			availableWorkers, hours = production.RemainingHours(availableWorkers, hours, workers, workerHours)
+		}
+		phase.Industries = append(phase.Industries, output)
+	}
+	if e.week >= e.weeks-1 {
+		e.releaseLand()
+		e.publishJobChanges()
+	}
+
+	idle := make([]*entities.Person, 0, len(availableWorkers))
+	for _, worker := range availableWorkers {
+		if !e.busy[worker.ID] {
+			idle = append(idle, worker)
+		}
+	}
+	phase.Unemployed = len(idle)
+	e.output = phase.UnitsProduced
+	e.vacancies = phase.Vacancies
+	e.idle = append(e.idle[:0], e.withoutGuests(idle)...)
+	e.laborForce = len(workforce) - len(e.guests) + len(e.away)
+
+	// Summary
+	e.Logger.LogEvent(fmt.Sprintf("\n📈 PRODUCTION SUMMARY: %.2f units produced, $%.2f paid in wages",
+		phase.UnitsProduced, phase.WagesPaid))
+
+	if phase.Unemployed > 0 {
+		e.Logger.LogEvent(fmt.Sprintf("⚠️  %d workers unemployed this tick", phase.Unemployed))
+	}
+	return phase
+}
+
+// releaseLand frees the stock resources each producer holds beyond what its
+// busiest shift of the tick needed, once its output shrinks. Industries down
+// for an outage keep theirs.
+func (e *Engine) releaseLand() {
+	for _, industry := range e.Region.Industries {
+		if len(industry.Holdings) == 0 || e.down[industry.ID] {
+			continue
+		}
+		for _, input := range industry.InputResources {
+			extra := industry.Holding(input) - e.occupied[industry.ID]
+			if freed := industry.Release(input, extra); freed > 0 {
+				e.Logger.LogEvent(fmt.Sprintf("🏞️  %s released %.2f %s of %s", industry.Name, freed, input.Unit, input.Name))
+			}
+		}
+	}
+}
+
+// offerLabor asks each worker how many hours they will work at the current
+// wage and drops those who won't work at all. The hours are returned in the
+// order of the workers kept.
+func (e *Engine) offerLabor(workers []*entities.Person, hoursAvailable float32) ([]*entities.Person, []float32) {
+	willing := make([]*entities.Person, 0, len(workers))
+	hours := make([]float32, 0, len(workers))
+	offered, overtime, spent := float32(0), 0, 0
+	for _, worker := range workers {
+		if worker.HoursLeft <= 0 {
+			spent++
+			continue
+		}
+		h := min(production.OfferHours(worker, e.WagePerHour, hoursAvailable, e.MaxOvertime), worker.HoursLeft)
+		if h <= 0 {
+			continue
+		}
+		willing = append(willing, worker)
+		hours = append(hours, h)
+		offered += h
+		if h > hoursAvailable {
+			overtime++
+		}
+	}
+
+	if spent > 0 {
+		e.Logger.LogEvent(fmt.Sprintf("⌛ %d workers have no hours left", spent))
+	}
+	if stayed := len(workers) - len(willing) - spent; stayed > 0 {
+		e.Logger.LogEvent(fmt.Sprintf("🏠 %d workers won't work for $%.2f/hour", stayed, e.WagePerHour))
+	}
+	if len(willing) > 0 && offered != hoursAvailable*float32(len(willing)) {
+		e.Logger.LogEvent(fmt.Sprintf("⏱️  %.0f hours offered against %.0f standard, %d workers choosing overtime",
+			offered, hoursAvailable*float32(len(willing)), overtime))
+	}
+	return willing, hours
+}
+
+// hasSchools reports whether the region has an education sector
+func (e *Engine) hasSchools() bool {
+	for _, industry := range e.Region.Industries {
+		if industry.IsSchool {
+			return true
+		}
+	}
+	return false
+}
+
+// processSchools enrolls workers into free school seats and advances courses
+func (e *Engine) processSchools() []*entities.Person {
+	result := education.ProcessSchools(e.Region, e.getAvailableWorkers())
+
+	for _, person := range result.Graduated {
+		e.Logger.LogEvent(fmt.Sprintf("🎓 %s graduated (skill %.2f)", person.Name, person.Skill))
+	}
+	e.Logger.LogEvent(fmt.Sprintf("%d studying (%d new, %d graduated, %d dropped out), $%.2f tuition paid",
+		len(result.Students), len(result.Enrolled), len(result.Graduated), len(result.DroppedOut), result.Tuition))
+
+	return result.Students
+}
+
+// withoutPeople returns the people not in the excluded list
+func withoutPeople(people []*entities.Person, excluded []*entities.Person) []*entities.Person {
+	if len(excluded) == 0 {
+		return people
+	}
+	skip := make(map[int]bool, len(excluded))
+	for _, person := range excluded {
+		skip[person.ID] = true
+	}
+	kept := make([]*entities.Person, 0, len(people))
+	for _, person := range people {
+		if !skip[person.ID] {
+			kept = append(kept, person)
+		}
+	}
+	return kept
+}
+
+// withoutGuests drops commuters from other regions from a list of workers
+func (e *Engine) withoutGuests(people []*entities.Person) []*entities.Person {
+	return withoutPeople(people, e.guests)
+}
+
+// processContracts settles forward orders and service subscriptions
+func (e *Engine) processContracts() {
+	result := market.ProcessContracts(e.Region, e.CurrentTick)
+
+	for _, breach := range result.Breaches {
+		e.Logger.LogEvent(fmt.Sprintf("⚠️  %s", breach))
+	}
+
+	e.Logger.LogEvent(fmt.Sprintf("📝 %d deliveries worth $%.2f, $%.2f in penalties, %d contracts ended",
+		len(result.Deliveries), result.TotalPaid, result.Penalties, result.Terminated))
+}
+
+// hasSavers reports whether anyone saves or holds savings
+func (e *Engine) hasSavers() bool {
+	for _, segment := range e.Region.PopulationSegments {
+		if segment.SavingsPropensity > 0 {
+			return true
+		}
+	}
+	for _, person := range e.Region.People {
+		if person.Savings > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// processSavings pays interest on savings and takes in new deposits
+func (e *Engine) processSavings() {
+	result := finance.ProcessSavings(e.Region, e.Bank)
+	e.Logger.LogEvent(fmt.Sprintf("💰 Deposited $%.2f, interest paid $%.2f at %.2f%%, total savings $%.2f",
+		result.Deposited, result.InterestPaid, e.Bank.DepositRate*100, result.TotalSavings))
+}
+
+// processShocks fires this tick's shocks and lets insurers pay the claims
+func (e *Engine) processShocks() {
+	losses := shocks.Apply(e.Region, e.Shocks, e.CurrentTick, pricePerUnit, e.Rand)
+
+	cropLosses, healthLosses := 0, 0
+	for _, loss := range losses {
+		if loss.Industry != nil {
+			cropLosses++
+			e.Logger.LogEvent(fmt.Sprintf("🌾 %s hit by %s: lost %.2f units ($%.2f)",
+				loss.Industry.Name, loss.Type, loss.Units, loss.Amount))
+		} else {
+			healthLosses++
+		}
+	}
+	if healthLosses > 0 {
+		e.Logger.LogEvent(fmt.Sprintf("🤒 %d people hit by health events", healthLosses))
+	}
+	if len(losses) == 0 {
+		e.Logger.LogEvent("No shocks this tick")
+	}
+
+	for _, insurer := range e.Insurers {
+		if insurer.Insolvent {
+			continue
+		}
+		result := insurer.ProcessTick(losses)
+		e.Logger.LogEvent(fmt.Sprintf("🛡️  %s: premiums $%.2f, %d claims paid $%.2f, reserves $%.2f",
+			insurer.Name, result.PremiumsCharged, result.Claims, result.ClaimsPaid, insurer.Reserves))
+		if result.Lapsed > 0 {
+			e.Logger.LogEvent(fmt.Sprintf("   %d policies lapsed for unpaid premiums", result.Lapsed))
+		}
+		if result.FailedThisTick {
+			e.Logger.LogEvent(fmt.Sprintf("❌ %s is insolvent: $%.2f in claims unpaid", insurer.Name, result.UnpaidClaims))
+		}
+	}
 }
 
-// CreateNewEngine creates a new simulation engine with default parameters
-func CreateNewEngine(region *entities.Region) *Engine {
-	return NewEngineWithParams(region, 10.0, 4, 40.0)
+// hasShareholders reports whether any industry pays dividends to owners
+func (e *Engine) hasShareholders() bool {
+	for _, industry := range e.Region.Industries {
+		if industry.DividendPayout > 0 && len(industry.Shareholders) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
-// NewEngineWithParams creates a new simulation engine with custom parameters
-func NewEngineWithParams(
-	region *entities.Region,
-	wagePerHour float32,
-	weeksPerTick int,
-	hoursPerWeek float32,
-) *Engine {
-	// Capture initial state
-	initialState := &InitialState{
-		IndustryMoney: make(map[string]float32),
-		PersonMoney:   make(map[string]float32),
-		TotalWealth:   0,
+// processDividends pays out a share of each industry's profit this tick
+func (e *Engine) processDividends(openingMoney map[int]float32) {
+	profits := make(map[int]float32, len(e.Region.Industries))
+	for _, industry := range e.Region.Industries {
+		profits[industry.ID] = industry.Money - openingMoney[industry.ID]
 	}
 
-	for _, ind := range region.Industries {
-		initialState.IndustryMoney[ind.Name] = ind.Money
-		initialState.TotalWealth += ind.Money
+	payments := finance.DistributeDividends(e.Region, profits)
+	if len(payments) == 0 {
+		e.Logger.LogEvent("No profits to distribute")
+		return
 	}
 
-	for _, p := range region.People {
-		initialState.PersonMoney[p.Name] = p.Money
-		initialState.TotalWealth += p.Money
+	for _, payment := range payments {
+		e.Logger.LogEvent(fmt.Sprintf("💸 %s paid $%.2f of $%.2f profit to %d shareholders",
+			payment.IndustryName, payment.Paid, payment.Profit, payment.Shareholders))
 	}
+}
 
-	return &Engine{
-		Region:       region,
-		Logger:       logging.NewLogger(true),
-		CurrentTick:  0,
-		WagePerHour:  wagePerHour,
-		WeeksPerTick: weeksPerTick,
-		HoursPerWeek: hoursPerWeek,
-		InitialState: initialState,
+// hasRetailers reports whether the region has a distribution sector
+func (e *Engine) hasRetailers() bool {
+	for _, industry := range e.Region.Industries {
+		if industry.IsRetailer {
+			return true
+		}
 	}
+	return false
 }
 
-// Run executes the simulation for a given number of ticks
-func (e *Engine) Run(ticks int) {
-	fmt.Printf("\n🚀 Starting Economy Simulation for %d ticks...\n", ticks)
-	fmt.Printf("Region: %s\n", e.Region.Name)
-	fmt.Printf("Industries: %d, People: %d, Problems: %d\n",
-		len(e.Region.Industries), len(e.Region.People), len(e.Region.Problems))
-	fmt.Printf("Wage Rate: $%.2f/hour, Weeks/Tick: %d, Hours/Week: %.0f\n\n",
-		e.WagePerHour, e.WeeksPerTick, e.HoursPerWeek)
+// reserveInputs splits the inputs producers share, where they cannot cover
+// every producer's full output this round, under the input allocation rule.
+// Auction winners pay the treasury for free resources, when there is a
+// government.
+func (e *Engine) reserveInputs(hoursAvailable float32) {
+	e.reserved = nil
+	if e.InputAllocation == "" {
+		return
+	}
 
-	for i := 0; i < ticks; i++ {
-		e.CurrentTick = i + 1
-		e.processTick()
-		time.Sleep(300 * time.Millisecond) // Slow down for readability
+	claims := make([]production.Claim, 0, len(e.Region.Industries))
+	for _, industry := range e.Region.Industries {
+		if industry.IsRetailer || industry.IsSchool || industry.DownFor > 0 || len(industry.InputResources) == 0 {
+			continue
+		}
+		units := hoursAvailable * float32(max(industry.Shifts, 1))
+		if target, planned := e.productionTarget(industry); planned {
+			units = min(units, target)
+		}
+		if units <= 0 {
+			continue
+		}
+		claims = append(claims, production.Claim{Industry: industry, Units: units, Bid: max(industry.Money, 0) / units})
 	}
 
-	e.printFinalSummary()
+	e.reserved = production.Reserve(e.InputAllocation, claims)
+	for _, claim := range claims {
+		for _, input := range claim.Industry.InputResources {
+			if units, ok := e.reserved.Units[input.ID][claim.Industry.ID]; !ok {
+				continue
+			} else if input.Price > 0 {
+				e.Logger.LogEvent(fmt.Sprintf("🔨 %s won %.0f of %.0f %s wanted at $%.2f", claim.Industry.Name, units, claim.Units, input.Name, input.Price))
+			} else {
+				e.Logger.LogEvent(fmt.Sprintf("📦 %s reserved %.0f of %.0f %s wanted", claim.Industry.Name, units, claim.Units, input.Name))
+			}
+		}
+		owed := e.reserved.Owed[claim.Industry.ID]
+		if owed > 0 && e.Government != nil {
+			claim.Industry.Money -= owed
+			e.Government.Treasury += owed
+		}
+	}
 }
 
-// processTick handles one simulation tick
-func (e *Engine) processTick() {
-	e.Logger.LogTick(e.CurrentTick)
+// productionTarget returns the units an industry plans to produce this tick,
+// from its configured target or else its forecast, and false when it
+// produces at full capacity
+func (e *Engine) productionTarget(industry *entities.Industry) (float32, bool) {
+	if industry.ProductionTarget > 0 {
+		return production.Shortfall(industry, industry.ProductionTarget), true
+	}
+	if e.Forecasting == nil {
+		return 0, false
+	}
+	return e.Forecasting.Target(industry)
+}
+
+// countSale adds units an industry sold of a product to this tick's sales
+func (e *Engine) countSale(industry, product int, units float32) {
+	if e.sales == nil {
+		e.sales = make(map[saleKey]float32)
+	}
+	e.sales[saleKey{industry, product}] += units
+}
+
+// recordSales files this tick's sales in each producer's history: the units
+// sold of its best-selling product, 0 when nothing sold
+func (e *Engine) recordSales(result *market.MarketResult) {
+	for _, purchase := range result.Purchases {
+		e.countSale(purchase.IndustryID, purchase.ProductID, purchase.Quantity)
+	}
+	for _, industry := range e.Region.Industries {
+		if industry.IsRetailer || industry.IsSchool {
+			continue
+		}
+		sold := float32(0)
+		for _, product := range industry.OutputProducts {
+			sold = max(sold, e.sales[saleKey{industry.ID, product.ID}])
+		}
+		industry.RecordSales(sold)
+	}
+	clear(e.sales)
+}
 
-	// Calculate hours available this tick
-	hoursAvailable := float32(e.WeeksPerTick) * e.HoursPerWeek
+// processWagesOwed pays the wages industries held back until after sales.
+// An industry that can't pay in full, even after borrowing, pays what it can
+// and goes bankrupt.
+func (e *Engine) processWagesOwed() {
+	total := float32(0)
+	for _, owed := range e.owed {
+		industry := owed.industry
+		bill := float32(0)
+		for _, h := range owed.hours {
+			bill += h * owed.wage
+		}
+		if e.Bank.Lending != nil && industry.Money < bill {
+			e.borrow(industry, bill-industry.Money)
+		}
 
-	// Phase 1: Production (includes labor payments)
-	e.Logger.LogEvent("📦 PRODUCTION PHASE")
-	e.processProductionPhase(hoursAvailable)
+		payments, unpaid := production.SettleWages(industry, owed.workers, owed.hours, owed.wage)
+		paid := float32(0)
+		for _, payment := range payments {
+			paid += payment.TotalPaid
+		}
+		total += paid
+		e.Events.Publish(events.WagePaid{
+			Tick:     e.CurrentTick,
+			Industry: industry.Name,
+			Workers:  len(owed.workers),
+			Amount:   paid,
+		})
 
-	// Phase 2: Product Market (people buy goods)
-	e.Logger.LogEvent("\n🛒 PRODUCT MARKET PHASE")
-	e.processProductMarket()
+		if unpaid > 0.001 {
+			reason := fmt.Sprintf("industry %s cannot afford wages owed: $%.2f unpaid", industry.Name, unpaid)
+			if industry.Bankrupt {
+				e.Logger.LogEvent(fmt.Sprintf("❌ %s", reason))
+				continue
+			}
+			industry.Bankrupt = true
+			e.Events.Publish(events.IndustryBankrupt{
+				Tick:     e.CurrentTick,
+				Industry: industry.Name,
+				Money:    industry.Money,
+				Reason:   reason,
+			})
+		}
+	}
+	e.owed = e.owed[:0]
+	e.Logger.LogEvent(fmt.Sprintf("💵 $%.2f in wages paid after sales", total))
+}
 
-	// Phase 3: Resource regeneration
-	e.Logger.LogEvent("\n🌱 RESOURCE REGENERATION")
-	e.processResourceRegeneration()
+// countRevenue adds sales income to an industry's revenue this tick
+func (e *Engine) countRevenue(industry int, amount float32) {
+	if e.revenue == nil {
+		e.revenue = make(map[int]float32)
+	}
+	e.revenue[industry] += amount
 }
 
-// processProductionPhase handles production and labor payments
-func (e *Engine) processProductionPhase(hoursAvailable float32) {
-	// Get available workers
-	availableWorkers := e.getAvailableWorkers()
-	e.Logger.LogEvent(fmt.Sprintf("Available workers: %d", len(availableWorkers)))
+// recordRevenue files this tick's sales income, including subsidies owed by
+// the treasury, in each industry's revenue history
+func (e *Engine) recordRevenue(result *market.MarketResult) {
+	for _, purchase := range result.Purchases {
+		e.countRevenue(purchase.IndustryID, purchase.TotalCost+purchase.Subsidy)
+	}
+	for _, industry := range e.Region.Industries {
+		industry.RecordRevenue(e.revenue[industry.ID])
+	}
+	clear(e.revenue)
+}
 
-	totalWagesPaid := float32(0)
-	totalUnitsProduced := float32(0)
+// borrow takes out a bank loan for an industry, unless the bank's covenant
+// refuses it
+func (e *Engine) borrow(industry *entities.Industry, amount float32) {
+	loan, err := e.Bank.Lend(industry, amount, e.CurrentTick)
+	if err != nil {
+		e.Logger.LogEvent(fmt.Sprintf("🚫 Loan of $%.2f refused: %s", amount, err.Error()))
+		return
+	}
+	e.Logger.LogEvent(fmt.Sprintf("💳 Borrowed $%.2f at %.2f%% over %d ticks (debt $%.2f, credit score %.2f)",
+		loan.Principal, loan.Rate*100, loan.Term, industry.Debt(), finance.CreditScore(industry)))
+}
 
+// hasDebt reports whether any industry owes the bank
+func (e *Engine) hasDebt() bool {
 	for _, industry := range e.Region.Industries {
-		e.Logger.LogEvent(fmt.Sprintf("\n--- %s ---", industry.Name))
+		if len(industry.Loans) > 0 {
+			return true
+		}
+	}
+	return false
+}
 
-		// Allocate workers
-		workers := production.AllocateWorkers(industry, availableWorkers)
-		e.Logger.LogEvent(fmt.Sprintf("Allocated %d workers (needs %.0f)", len(workers), industry.LaborNeeded))
+// processDebtService collects interest and installments on industry loans
+func (e *Engine) processDebtService() {
+	result := finance.ServiceDebt(e.Region, e.Bank)
+	for _, payment := range result.Payments {
+		e.Logger.LogEvent(fmt.Sprintf("💳 %s paid $%.2f interest and $%.2f principal, owes $%.2f",
+			payment.IndustryName, payment.Interest, payment.Principal, payment.Debt))
+		if payment.Missed > 0.001 {
+			e.Logger.LogEvent(fmt.Sprintf("⚠️  %s missed $%.2f of payments", payment.IndustryName, payment.Missed))
+		}
+	}
+	e.Logger.LogEvent(fmt.Sprintf("💳 $%.2f interest, $%.2f repaid, $%.2f lent out",
+		result.TotalInterest, result.TotalRepaid, e.Bank.Loans))
+}
 
-		if len(workers) == 0 {
-			e.Logger.LogEvent("❌ No workers available")
-			continue
+// processWholesaleMarket restocks retailers from their suppliers
+func (e *Engine) processWholesaleMarket() {
+	result := market.ProcessWholesaleMarket(e.Region, pricePerUnit, e.CurrentTick)
+	if e.Forecasting != nil {
+		for _, order := range result.Orders {
+			e.countSale(order.SupplierID, order.ProductID, order.Delivered)
 		}
+	}
+	if e.Bank.Lending != nil {
+		for _, order := range result.Orders {
+			e.countRevenue(order.SupplierID, order.TotalCost)
+		}
+	}
+
+	for _, order := range result.Orders {
+		status := "✅"
+		if order.Shortfall() > 0 {
+			status = "⚠️ "
+		}
+		e.Logger.LogEvent(fmt.Sprintf("%s %s ordered %.0f %s from %s, delivered %.0f at $%.2f",
+			status, order.RetailerName, order.Ordered, order.ProductName,
+			order.SupplierName, order.Delivered, order.UnitPrice))
+	}
 
-		// Calculate production
-		result := production.CalculateProduction(
-			industry,
-			float32(len(workers)),
-			hoursAvailable,
-			e.WagePerHour,
-		)
+	e.Logger.LogEvent(fmt.Sprintf("📦 Delivered %.0f units for $%.2f, %d stockouts",
+		result.UnitsDelivered, result.TotalSpent, result.Stockouts))
+}
 
-		e.Logger.LogEvent(fmt.Sprintf("Production capacity: %.1f%% (%.0f/%.0f workers)",
-			(result.LaborUsed/industry.LaborNeeded)*100, result.LaborUsed, industry.LaborNeeded))
+// workDaysPerWeek converts people's daily labor hours into hours per tick
+const workDaysPerWeek = 5
 
-		// Pay workers FIRST (before production)
-		payments, err := production.PayWorkers(
-			industry,
-			workers,
-			hoursAvailable,
-			e.WagePerHour,
-		)
+// pricePerUnit is the market price used for all products
+// Temporary: use simple fixed pricing
+// TODO: Replace with cost-plus pricing based on production costs
+const pricePerUnit = float32(50.0)
 
-		if err != nil {
-			e.Logger.LogEvent(fmt.Sprintf("❌ %s", err.Error()))
-			continue
+// hasOverheads reports whether any industry has fixed operating costs
+func (e *Engine) hasOverheads() bool {
+	for _, industry := range e.Region.Industries {
+		if industry.FixedCosts > 0 {
+			return true
 		}
+	}
+	return false
+}
 
-		e.Logger.LogEvent(fmt.Sprintf("💰 Paid $%.2f in wages to %d workers", result.LaborCost, len(workers)))
-		totalWagesPaid += result.LaborCost
-
-		// Consume resources
-		consumptions, err := production.ConsumeResources(industry, result.UnitsProduced)
-		if err != nil {
-			e.Logger.LogEvent(fmt.Sprintf("❌ Resource shortage: %s", err.Error()))
-			// Refund workers since we can't produce
-			for _, payment := range payments {
-				for _, person := range e.Region.People {
-					if person.Name == payment.PersonName {
-						person.Money -= payment.TotalPaid
-						industry.Money += payment.TotalPaid
-						break
-					}
-				}
-			}
+// processOverheads charges industries their fixed costs. One that can't
+// cover them goes bankrupt like one that can't meet its wage bill.
+func (e *Engine) processOverheads() {
+	result := production.PayOverheads(e.Region)
+	for _, industry := range e.Region.Industries {
+		unpaid, short := result.Unpaid[industry.Name]
+		if !short {
 			continue
 		}
-
-		// Log resource consumption
-		for _, consumption := range consumptions {
-			e.Logger.LogEvent(fmt.Sprintf("📉 Consumed %.2f %s (cost: $%.2f)",
-				consumption.Quantity, consumption.ResourceName, consumption.Cost))
+		if industry.Bankrupt {
+			e.Logger.LogEvent(fmt.Sprintf("❌ %s is $%.2f short of its overheads", industry.Name, unpaid))
+			continue
 		}
-
-		// Produce goods
-		for _, product := range industry.OutputProducts {
-			product.Add(result.UnitsProduced)
-			e.Logger.LogEvent(fmt.Sprintf("✅ Produced %.2f %s (total: %.2f)",
-				result.UnitsProduced, product.Name, product.Quantity))
-			totalUnitsProduced += result.UnitsProduced
-		}
-
-		// Log costs
-		e.Logger.LogEvent(fmt.Sprintf("📊 Total cost: $%.2f (Labor: $%.2f, Resources: $%.2f, Per unit: $%.2f)",
-			result.TotalCost, result.LaborCost, result.ResourceCost, result.CostPerUnit))
-
-		// Record production history for cost tracking
-		industry.RecordProduction(entities.ProductionRecord{
-			Tick:          e.CurrentTick,
-			UnitsProduced: result.UnitsProduced,
-			TotalCost:     result.TotalCost,
-			CostPerUnit:   result.CostPerUnit,
-			LaborCost:     result.LaborCost,
-			ResourceCost:  result.ResourceCost,
+		industry.Bankrupt = true
+		e.Events.Publish(events.IndustryBankrupt{
+			Tick:     e.CurrentTick,
+			Industry: industry.Name,
+			Money:    industry.Money,
+			Reason:   fmt.Sprintf("cannot afford overheads: $%.2f unpaid", unpaid),
 		})
-
-		// Remove allocated workers from available pool
-		availableWorkers = availableWorkers[len(workers):]
 	}
+	e.Logger.LogEvent(fmt.Sprintf("🏢 $%.2f paid in overheads", result.TotalPaid))
+}
 
-	// Summary
-	e.Logger.LogEvent(fmt.Sprintf("\n📈 PRODUCTION SUMMARY: %.2f units produced, $%.2f paid in wages",
-		totalUnitsProduced, totalWagesPaid))
-
-	unemployed := len(e.getAvailableWorkers()) - len(availableWorkers)
-	if unemployed > 0 {
-		e.Logger.LogEvent(fmt.Sprintf("⚠️  %d workers unemployed this tick", len(availableWorkers)))
+// processAdvertising charges industries their marketing spend and updates
+// how many shoppers know of them
+func (e *Engine) processAdvertising() {
+	result := market.ProcessAdvertising(e.Region, e.Marketing.BaseAwareness, e.Marketing.HalfSpend)
+	for _, industry := range e.Region.Industries {
+		if spend, ok := result.Spend[industry.Name]; ok {
+			e.Logger.LogEvent(fmt.Sprintf("📣 %s spent $%.2f on advertising, awareness %.0f%%",
+				industry.Name, spend, industry.Awareness*100))
+		}
 	}
+	e.Logger.LogEvent(fmt.Sprintf("📣 Total advertising: $%.2f", result.TotalSpent))
 }
 
 // processProductMarket handles people buying products
-func (e *Engine) processProductMarket() {
-	// Temporary: use simple fixed pricing
-	// TODO: Replace with cost-plus pricing based on production costs
-	pricePerUnit := float32(50.0)
+func (e *Engine) processProductMarket() *market.MarketResult {
+	// Savers short of cash draw on their savings before shopping
+	if withdrawn := finance.WithdrawForSpending(e.Region, pricePerUnit); withdrawn > 0 {
+		e.Logger.LogEvent(fmt.Sprintf("🏧 $%.2f withdrawn from savings for shopping", withdrawn))
+	}
+
+	var controls market.PriceControls
+	if e.Government != nil {
+		controls = e.Government.PriceControls
+	}
 
-	result := market.ProcessProductMarket(e.Region, pricePerUnit)
+	result := e.marketMechanism().Clear(e.Region, MarketConditions{
+		BasePrice:    pricePerUnit,
+		ProfitMargin: e.ProfitMargin,
+		Controls:     controls,
+		Rand:         e.Rand,
+		Awareness:    e.Marketing != nil,
+		Rationing:    e.Rationing,
+		Queue:        e.QueueOrder,
+		Loyalty:      e.LoyalShoppers,
+		Week:         e.week,
+		WeeksPerTick: e.WeeksPerTick,
+		Weeks:        e.weeks,
+	})
 
 	// Log summary
 	e.Logger.LogEvent(fmt.Sprintf("💰 Total spent: $%.2f", result.TotalSpent))
@@ -227,71 +1478,364 @@ func (e *Engine) processProductMarket() {
 	e.Logger.LogEvent(fmt.Sprintf("🏭 Industry revenue: $%.2f", result.TotalRevenue))
 	e.Logger.LogEvent(fmt.Sprintf("👥 People satisfied: %d, unsatisfied: %d",
 		result.PeopleSatisfied, result.PeopleUnsatisfied))
+	e.logUnmetReasons(result)
+	e.logCompositeNeeds(result)
+	e.logPriceControls(result, controls)
 
-	// Log sample purchases (first 5)
-	if len(result.Purchases) > 0 {
-		e.Logger.LogEvent("\nSample purchases:")
-		count := 0
-		for _, purchase := range result.Purchases {
-			if count >= 5 {
-				e.Logger.LogEvent(fmt.Sprintf("   ... and %d more purchases", len(result.Purchases)-5))
-				break
+	// Publish purchases and failures (the logger samples what it prints)
+	for _, purchase := range result.Purchases {
+		e.Events.Publish(events.PurchaseMade{
+			Tick:      e.CurrentTick,
+			PersonID:  purchase.PersonID,
+			Person:    purchase.PersonName,
+			Industry:  purchase.IndustryName,
+			Product:   purchase.ProductName,
+			Problem:   purchase.ProblemSolved,
+			Quantity:  purchase.Quantity,
+			UnitPrice: purchase.UnitPrice,
+			TotalCost: purchase.TotalCost,
+		})
+	}
+	if len(result.Unmet) > 0 {
+		failures := make([]events.FailedPurchase, len(result.Unmet))
+		for i, unmet := range result.Unmet {
+			failures[i] = events.FailedPurchase{
+				PersonID: unmet.Person.ID,
+				Person:   unmet.Person.Name,
+				Problem:  unmet.Problem.Name,
+				Reason:   unmet.Reason,
+			}
+		}
+		e.Events.Publish(events.PurchasesFailed{Tick: e.CurrentTick, Failures: failures})
+	}
+	e.Logger.LogTally(e.CurrentTick)
+
+	return result
+}
+
+// logUnmetReasons reports, per problem, why shoppers went without
+func (e *Engine) logUnmetReasons(result *market.MarketResult) {
+	for _, problem := range e.Region.Problems {
+		stats, exists := result.NeedStats[problem.ID]
+		if !exists || stats.Unmet()+stats.Unaffordable == 0 {
+			continue
+		}
+		parts := make([]string, 0, len(market.UnmetReasons))
+		for _, reason := range market.UnmetReasons {
+			if count := stats.Reasons[reason]; count > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", count, strings.ReplaceAll(reason, "_", " ")))
 			}
-			e.Logger.LogEvent(fmt.Sprintf("   🛍️  Person #%d bought %.0f %s for $%.2f (solving %s)",
-				purchase.PersonID, purchase.Quantity, purchase.ProductName,
-				purchase.TotalCost, purchase.ProblemSolved))
-			count++
 		}
+		e.Logger.LogEvent(fmt.Sprintf("❓ %s unmet for %d: %s", problem.Name, stats.Unmet()+stats.Unaffordable, strings.Join(parts, ", ")))
 	}
 }
 
-// processResourceRegeneration regenerates renewable resources
-func (e *Engine) processResourceRegeneration() {
-	production.RegenerateResources(e.Region.Resources)
+// hasDynamicNeeds reports whether any need's severity changes per person
+func (e *Engine) hasDynamicNeeds() bool {
+	for _, problem := range e.Region.Problems {
+		if problem.IsDynamic() {
+			return true
+		}
+	}
+	return false
+}
+
+// processSeverity escalates the needs people went without and relieves the
+// ones they met, then reports how severe each dynamic need has become
+func (e *Engine) processSeverity(result *market.MarketResult) {
+	market.UpdateSeverity(e.Region, result)
+	for _, problem := range e.Region.Problems {
+		if !problem.IsDynamic() {
+			continue
+		}
+		average, escalated := market.AverageSeverity(e.Region, problem)
+		e.Logger.LogEvent(fmt.Sprintf("🌡️  %s severity %.2f on average (usually %.2f), %d people feel it more than usual",
+			problem.Name, average, problem.Severity, escalated))
+	}
+}
+
+// logCompositeNeeds reports how well each composite need was met, next to
+// the parts it rolls up
+func (e *Engine) logCompositeNeeds(result *market.MarketResult) {
+	for _, problem := range e.Region.Problems {
+		if !problem.IsComposite() {
+			continue
+		}
+		met, ok := market.Satisfaction(problem, result.NeedStats)
+		if !ok {
+			continue
+		}
+		parts := make([]string, 0, len(problem.Parts))
+		for _, part := range problem.Parts {
+			if partMet, ok := market.Satisfaction(part.Problem, result.NeedStats); ok {
+				parts = append(parts, fmt.Sprintf("%s %.0f%%", part.Problem.Name, partMet*100))
+			}
+		}
+		e.Logger.LogEvent(fmt.Sprintf("🧩 %s %.0f%% met (%s)", problem.Name, met*100, strings.Join(parts, ", ")))
+	}
+}
+
+// processInformalMarket lets unmet buyers turn to off-the-books sellers
+func (e *Engine) processInformalMarket(result *market.MarketResult) {
+	taxRate := float32(0)
+	if e.Government != nil {
+		taxRate = e.Government.SalesTaxRate
+	}
+	participation := market.InformalParticipation(e.Informal.BaseParticipation, e.Informal.TaxSensitivity, taxRate)
+
+	informal := market.ProcessInformalMarket(e.Region, result.Unmet, pricePerUnit, e.Informal.Premium, participation, e.Rand)
+	e.Logger.LogEvent(fmt.Sprintf("🕶️  %d of %d unmet needs served informally for $%.2f (participation %.0f%%)",
+		len(informal.Purchases), len(result.Unmet), informal.TotalSpent, participation*100))
+}
+
+// processBarter lets unmet buyers without money trade labor for goods
+func (e *Engine) processBarter(result *market.MarketResult) {
+	barter := market.ProcessBarter(e.Region, result.Unmet, e.Barter.HoursPerUnit, e.Barter.MoneyThreshold)
+	e.Logger.LogEvent(fmt.Sprintf("🤝 %d barter trades: %.0f units for %.0f labor hours",
+		len(barter.Trades), barter.UnitsBartered, barter.HoursBartered))
+}
+
+// processTaxes collects sales tax on the formal market's purchases
+func (e *Engine) processTaxes(result *market.MarketResult) {
+	taxes := e.Government.CollectSalesTax(e.Region, result.Purchases)
+	e.Logger.LogEvent(fmt.Sprintf("🏛️  Sales tax collected: $%.2f at %.0f%%, treasury $%.2f",
+		taxes.Total, e.Government.SalesTaxRate*100, e.Government.Treasury))
+
+	subsidies := e.Government.FundPublic(e.Region, result.Purchases)
+	for _, industry := range e.Region.Industries {
+		if paid, ok := subsidies.ByIndustry[industry.Name]; ok {
+			e.Logger.LogEvent(fmt.Sprintf("🏥 Funded public %s: $%.2f", industry.Name, paid))
+		}
+	}
+	if subsidies.Unfunded > 0 {
+		e.Logger.LogEvent(fmt.Sprintf("⚠️  Treasury short by $%.2f for public services", subsidies.Unfunded))
+	}
+}
+
+// logPriceControls reports the shortage or surplus of each controlled product
+// and whether its producers still sold above cost
+func (e *Engine) logPriceControls(result *market.MarketResult, controls market.PriceControls) {
+	for _, effect := range market.ControlEffects(e.Region, result, controls) {
+		limits := make([]string, 0, 2)
+		if effect.Ceiling > 0 {
+			limits = append(limits, fmt.Sprintf("ceiling $%.2f", effect.Ceiling))
+		}
+		if effect.Floor > 0 {
+			limits = append(limits, fmt.Sprintf("floor $%.2f", effect.Floor))
+		}
+		limit := strings.Join(limits, ", ")
+		e.Logger.LogEvent(fmt.Sprintf("🏷️  %s (%s): %.0f sold, %.0f unsold, %d shoppers unserved, margin $%.2f",
+			effect.Product, limit, effect.Sold, effect.Unsold, effect.Unmet, effect.Margin))
+	}
+}
+
+// hasUnemployment reports whether the government runs unemployment insurance
+func (e *Engine) hasUnemployment() bool {
+	return e.Government != nil && e.Government.Unemployment != nil
+}
+
+// processUnemployment pays benefits to idle workers and reports the
+// program's cost against the payroll tax that funds it
+func (e *Engine) processUnemployment() {
+	result := e.Government.PayUnemployment(e.idle)
+	e.Logger.LogEvent(fmt.Sprintf("🧾 %d claimants paid $%.2f (%d never worked, %d exhausted), payroll tax $%.2f, treasury $%.2f",
+		result.Claimants, result.Paid, result.Ineligible, result.Exhausted, e.payrollTax, e.Government.Treasury))
+	if result.Shortfall > 0 {
+		e.Logger.LogEvent(fmt.Sprintf("⚠️  Benefits short by $%.2f", result.Shortfall))
+	}
+}
+
+// processHouseholds pools each household's cash among its members
+func (e *Engine) processHouseholds() {
+	moved := float32(0)
+	for _, household := range e.Region.Households {
+		moved += household.Pool()
+	}
+	e.Logger.LogEvent(fmt.Sprintf("🏠 %d households shared $%.2f between their members", len(e.Region.Households), moved))
+}
+
+// processTransitions moves people between segments and reports how many
+// took each path
+func (e *Engine) processTransitions() {
+	moves := e.Transitions.Apply(e.Region, e.idle, e.CurrentTick, e.Rand)
+	e.publishRetirements(moves)
+	if len(moves) == 0 {
+		e.Logger.LogEvent("Nobody changed segment")
+		return
+	}
+
+	counts := make(map[[2]string]int)
+	paths := make([][2]string, 0)
+	for _, move := range moves {
+		path := [2]string{move.From, move.To}
+		if counts[path] == 0 {
+			paths = append(paths, path)
+		}
+		counts[path]++
+	}
+	for _, path := range paths {
+		e.Logger.LogEvent(fmt.Sprintf("🔀 %d moved from %s to %s", counts[path], path[0], path[1]))
+	}
+}
+
+// processPensions pays retirees from the treasury or their savings
+func (e *Engine) processPensions() {
+	result := e.Government.PayPensions(e.Region)
+	e.Logger.LogEvent(fmt.Sprintf("👵 %d retirees paid $%.2f from the treasury and $%.2f from savings, treasury $%.2f",
+		result.Retirees, result.FromTreasury, result.FromSavings, e.Government.Treasury))
+	if result.Shortfall > 0 {
+		e.Logger.LogEvent(fmt.Sprintf("⚠️  Pensions short by $%.2f", result.Shortfall))
+	}
+}
+
+// hasReserve reports whether the government keeps a strategic reserve
+func (e *Engine) hasReserve() bool {
+	return e.Government != nil && e.Government.Reserve != nil
+}
+
+// processReserveRelease puts reserve stock on the shelves during shortages
+func (e *Engine) processReserveRelease() {
+	result := e.Government.ReleaseReserve(e.Region, e.lastMarket.NeedStats, pricePerUnit)
+	if len(result.Released) == 0 {
+		return
+	}
+	e.Logger.LogEvent("\n🌾 RESERVE RELEASE")
+	for _, level := range e.Government.Reserve.Level() {
+		if units, ok := result.Released[level.Product]; ok {
+			e.Logger.LogEvent(fmt.Sprintf("🌾 Released %.0f %s into the shortage, %.0f left", units, level.Product, level.Units))
+		}
+	}
+	e.Logger.LogEvent(fmt.Sprintf("🌾 Producers paid $%.2f for released stock, treasury $%.2f",
+		result.Earned, e.Government.Treasury))
+}
+
+// processReserveStock buys surplus basic-need products into the reserve
+func (e *Engine) processReserveStock(result *market.MarketResult) {
+	bought := e.Government.StockReserve(e.Region, result.NeedStats, pricePerUnit)
+	for _, level := range e.Government.Reserve.Level() {
+		e.Logger.LogEvent(fmt.Sprintf("🌾 %s reserve: %.0f units (+%.0f bought)",
+			level.Product, level.Units, bought.Bought[level.Product]))
+	}
+	e.Logger.LogEvent(fmt.Sprintf("🌾 Spent $%.2f on the reserve, treasury $%.2f", bought.Spent, e.Government.Treasury))
+}
+
+// processDemandUpdate recomputes problem demand from the last market result
+func (e *Engine) processDemandUpdate(result *market.MarketResult) {
+	priceLevel := result.AveragePrice(pricePerUnit)
+	changes := market.RecalculateDemand(e.Region, result, priceLevel, e.DemandAdjustmentRate)
+	if len(changes) == 0 {
+		e.Logger.LogEvent("Demand unchanged")
+		return
+	}
+
+	for _, change := range changes {
+		e.Logger.LogEvent(fmt.Sprintf("📊 %s demand %.2f → %.2f (unmet %d of %d needy)",
+			change.ProblemName, change.OldDemand, change.NewDemand, change.Unmet, change.Needy))
+	}
+}
 
-	regenerated := 0
+// processResourceRegeneration regenerates renewable resources
+func (e *Engine) processResourceRegeneration() RegenerationResult {
+	result := RegenerationResult{Regenerated: make(map[string]float32)}
 	for _, resource := range e.Region.Resources {
-		if resource.RegenerationRate > 0 {
-			e.Logger.LogEvent(fmt.Sprintf("🌿 %s regenerated +%.2f %s (total: %.2f)",
-				resource.Name, resource.RegenerationRate, resource.Unit, resource.Quantity))
-			regenerated++
+		if resource.RegenerationRate <= 0 {
+			continue
 		}
+		rate := resource.RegenerationRate
+		grown := production.Regenerate(resource)
+		if resource.RegenerationRate < rate {
+			e.Logger.LogEvent(fmt.Sprintf("🪦 %s overharvested: regrowth down to %.2f %s/tick at best",
+				resource.Name, resource.RegenerationRate, resource.Unit))
+		}
+		e.Logger.LogEvent(fmt.Sprintf("🌿 %s regenerated +%.2f %s (total: %.2f)",
+			resource.Name, grown, resource.Unit, resource.Quantity))
+		result.Regenerated[resource.Name] = grown
 	}
 
-	if regenerated == 0 {
+	if len(result.Regenerated) == 0 {
 		e.Logger.LogEvent("No renewable resources")
 	}
+	return result
 }
 
-// getAvailableWorkers returns all people in the "Workers" segment
+// getAvailableWorkers returns all people in a worker segment or in any other
+// segment an industry hires from. The slice is the engine's reusable buffer
+// and is only valid until the next call.
 func (e *Engine) getAvailableWorkers() []*entities.Person {
-	workers := make([]*entities.Person, 0)
+	workers := e.workers[:0]
 
-	// Find worker population segment
-	for _, segment := range e.Region.PopulationSegments {
-		if segment.Name == "Workers" {
-			// Get all people in this segment
-			for _, person := range e.Region.People {
-				for _, personSegment := range person.Segments {
-					if personSegment.Name == segment.Name {
-						workers = append(workers, person)
-						break
-					}
-				}
+	// Find the segments industries hire from
+	hiring := make(map[string]bool)
+	for _, industry := range e.Region.Industries {
+		for _, name := range industry.HiresFrom {
+			hiring[name] = true
+		}
+	}
+
+	// Get all people of working age in them
+	for _, person := range e.Region.People {
+		if person.Retired() || person.Child || e.away[person.ID] {
+			continue
+		}
+		for _, segment := range person.Segments {
+			if segment.IsWorkers() || hiring[segment.Name] {
+				workers = append(workers, person)
+				break
 			}
-			break
 		}
 	}
 
+	e.workers = workers
 	return workers
 }
 
+// hasWorkforce reports whether any segment supplies workers, through its role
+// or an industry hiring from it
+func (e *Engine) hasWorkforce() bool {
+	for _, segment := range e.Region.PopulationSegments {
+		if segment.IsWorkers() {
+			return true
+		}
+	}
+	for _, industry := range e.Region.Industries {
+		if len(industry.HiresFrom) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// printSatisfaction summarizes how well people's needs have been met lately
+func (e *Engine) printSatisfaction() {
+	if len(e.Region.People) == 0 {
+		return
+	}
+	total, lowest := float32(0), float32(-1)
+	struggling := 0
+	for _, person := range e.Region.People {
+		satisfaction := person.Satisfaction()
+		total += satisfaction
+		if lowest < 0 || satisfaction < lowest {
+			lowest = satisfaction
+		}
+		if satisfaction < 0.5 {
+			struggling++
+		}
+	}
+	fmt.Printf("\n😊 SATISFACTION: average %.0f%%, lowest %.0f%%, %d of %d people below 50%%\n",
+		total/float32(len(e.Region.People))*100, lowest*100, struggling, len(e.Region.People))
+}
+
 // printFinalSummary prints statistics at the end of simulation
 func (e *Engine) printFinalSummary() {
 	fmt.Printf("\n\n" + "═══════════════════════════════════════\n")
 	fmt.Printf("📊 FINAL SIMULATION SUMMARY\n")
 	fmt.Printf("═══════════════════════════════════════\n\n")
 
+	if e.Clock != nil && e.CurrentTick > 0 {
+		fmt.Printf("📅 %s to %s, %d ticks\n\n", e.Clock.Label(1), e.Clock.Label(e.CurrentTick), e.CurrentTick)
+	}
+
 	// Industry summary
 	fmt.Printf("🏭 INDUSTRIES:\n")
 	for _, industry := range e.Region.Industries {
@@ -299,6 +1843,18 @@ func (e *Engine) printFinalSummary() {
 		change := industry.Money - start
 		fmt.Printf("  %s:\n", industry.Name)
 		fmt.Printf("    Money: $%.2f (Start: $%.2f, Change: %+.2f)\n", industry.Money, start, change)
+		if industry.Cooperative {
+			fmt.Printf("    Cooperative: %d members, $%.2f distributed\n", len(industry.Shareholders), industry.TotalDistributed)
+		} else if len(industry.Shareholders) > 0 {
+			fmt.Printf("    Shareholders: %d, $%.2f paid in dividends\n", len(industry.Shareholders), industry.TotalDistributed)
+		}
+		if industry.DowntimeTicks > 0 {
+			fmt.Printf("    Downtime: %d of %d ticks\n", industry.DowntimeTicks, e.CurrentTick)
+		}
+		if debt := industry.Debt(); debt > 0 || industry.InterestPaid > 0 {
+			fmt.Printf("    Debt: $%.2f, $%.2f paid in interest, credit score %.2f\n",
+				debt, industry.InterestPaid, finance.CreditScore(industry))
+		}
 		fmt.Printf("    Products:\n")
 		for _, product := range industry.OutputProducts {
 			fmt.Printf("      - %s: %.2f %s\n", product.Name, product.Quantity, product.Unit)
@@ -321,14 +1877,30 @@ func (e *Engine) printFinalSummary() {
 			break
 		}
 		start := e.InitialState.PersonMoney[person.Name]
-		change := person.Money - start
-		fmt.Printf("  %s: $%.2f (Start: $%.2f, Change: %+.2f)\n", person.Name, person.Money, start, change)
+		change := person.Wealth() - start
+		fmt.Printf("  %s: $%.2f (Start: $%.2f, Change: %+.2f)", person.Name, person.Wealth(), start, change)
+		if person.Savings > 0 {
+			fmt.Printf(" [cash $%.2f, savings $%.2f]", person.Money, person.Savings)
+		}
+		fmt.Printf("\n")
+	}
+
+	if e.HistoryLength > 0 {
+		e.printSatisfaction()
+	}
+	if e.Welfare != nil && len(e.WelfareHistory) > 0 {
+		first, last := e.WelfareHistory[0], e.WelfareHistory[len(e.WelfareHistory)-1]
+		if len(e.measured) > 0 && e.measured[0].Welfare != nil {
+			first = *e.measured[0].Welfare
+		}
+		fmt.Printf("\n😊 WELFARE: %.2f (Start: %.2f, median %.2f, lowest %.2f), GDP $%.2f last tick\n",
+			last.Average, first.Average, last.Median, last.Lowest, last.GDP)
 	}
 
 	// Calculate total wealth
 	totalWealth := float32(0.0)
 	for _, person := range e.Region.People {
-		totalWealth += person.Money
+		totalWealth += person.Wealth()
 	}
 	for _, industry := range e.Region.Industries {
 		totalWealth += industry.Money
@@ -338,18 +1910,76 @@ func (e *Engine) printFinalSummary() {
 
 	fmt.Printf("\n💰 TOTAL WEALTH: $%.2f (Start: $%.2f, Change: %+.2f)\n", totalWealth, e.InitialState.TotalWealth, wealthChange)
 
+	if window := e.Window(); window != nil {
+		fmt.Printf("📏 MEASURED: ticks %d-%d, per tick %.2f units, $%.2f wages, %.1f unemployed, %.1f vacancies, $%.2f spent, %.1f people satisfied\n",
+			window.From, window.To, window.UnitsProduced, window.WagesPaid, window.Unemployed, window.Vacancies,
+			window.Spent, window.PeopleSatisfied)
+		if window.Welfare > 0 {
+			fmt.Printf("   Welfare %.2f, GDP $%.2f on average\n", window.Welfare, window.GDP)
+		}
+	}
+
+	if e.Government != nil {
+		fmt.Printf("🏛️  Treasury: $%.2f (tax collected: $%.2f)\n", e.Government.Treasury, e.Government.TotalTaxCollected)
+		if u := e.Government.Unemployment; u != nil {
+			fmt.Printf("🧾 Unemployment insurance: $%.2f payroll tax, $%.2f benefits paid\n", u.TotalTax, u.TotalPaid)
+		}
+	}
+
+	for _, insurer := range e.Insurers {
+		status := "solvent"
+		if insurer.Insolvent {
+			status = "INSOLVENT"
+		}
+		fmt.Printf("🛡️  %s: reserves $%.2f (%s)\n", insurer.Name, insurer.Reserves, status)
+	}
+
+	if e.CentralBank != nil {
+		fmt.Printf("🏦 Money created by central bank: $%.2f, final policy rate %.2f%%\n",
+			e.CentralBank.TotalInjected, e.CentralBank.PolicyRate*100)
+	}
+	if e.Bank.Lending != nil {
+		fmt.Printf("💳 Bank loans outstanding: $%.2f, $%.2f earned in interest\n", e.Bank.Loans, e.Bank.InterestEarned)
+	}
+
 	// Resource summary
 	fmt.Printf("\n📦 RESOURCES:\n")
 	for _, resource := range e.Region.Resources {
 		status := ""
+		if e.Units != nil {
+			if base, unit := e.Units.Base(resource.Quantity, resource.Unit); !strings.EqualFold(unit, resource.Unit) {
+				status = fmt.Sprintf(" (%.2f %s)", base, unit)
+			}
+		}
 		if resource.IsFree {
-			status = " (free resource)"
+			status += " (free resource)"
 		}
-		if resource.RegenerationRate > 0 {
+		if resource.RegenerationRate > 0 && resource.Capacity > 0 {
+			status += fmt.Sprintf(" (regenerates up to +%.0f/tick, capacity %.0f)", resource.RegenerationRate, resource.Capacity)
+		} else if resource.RegenerationRate > 0 {
 			status += fmt.Sprintf(" (regenerates +%.0f/tick)", resource.RegenerationRate)
 		}
 		fmt.Printf("  %s: %.2f %s%s\n", resource.Name, resource.Quantity, resource.Unit, status)
 	}
 
-	fmt.Printf("\n✅ Simulation completed successfully!\n\n")
+	if e.Clamped > 0 {
+		fmt.Printf("\n⚠️  Guards clamped %d invalid values to 0\n", e.Clamped)
+	}
+
+	if len(e.Assertions) > 0 {
+		fmt.Printf("\n🧪 ASSERTIONS: %d of %d held\n", len(e.Assertions)-len(e.Failures), len(e.Assertions))
+		for _, failure := range e.Failures {
+			if failure.Reason != "" {
+				fmt.Printf("  ❌ %s: %s\n", failure.Assertion, failure.Reason)
+			} else {
+				fmt.Printf("  ❌ %s: %.4g at tick %d\n", failure.Assertion, failure.Value, failure.Tick)
+			}
+		}
+	}
+
+	if e.Interrupted {
+		fmt.Printf("\n⏹️  Simulation stopped early, after tick %d\n\n", e.CurrentTick)
+	} else {
+		fmt.Printf("\n✅ Simulation completed successfully!\n\n")
+	}
 }
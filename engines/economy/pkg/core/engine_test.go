@@ -1,8 +1,23 @@
 package core
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
 	"testing"
 	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/events"
+	"westex/engines/economy/pkg/government"
+	"westex/engines/economy/pkg/insurance"
+	"westex/engines/economy/pkg/logging"
+	"westex/engines/economy/pkg/market"
+	"westex/engines/economy/pkg/production"
+	"westex/engines/economy/pkg/scenarios"
+	"westex/engines/economy/pkg/transitions"
 )
 
 func TestCreateNewEngine(t *testing.T) {
@@ -74,6 +89,32 @@ func TestNewEngineWithParams(t *testing.T) {
 	}
 }
 
+func TestNewEngine_Options(t *testing.T) {
+	// Arrange
+	region := entities.NewRegion("TestRegion")
+	logger := logging.NewLogger(false)
+
+	// Act
+	engine := NewEngine(region, WithWage(12), WithTickLength(2, 35), WithLogger(logger), WithSeed(42))
+
+	// Assert
+	if engine.WagePerHour != 12 {
+		t.Errorf("Expected WagePerHour to be 12.00, got %.2f", engine.WagePerHour)
+	}
+	if engine.WeeksPerTick != 2 || engine.HoursPerWeek != 35 {
+		t.Errorf("Expected ticks of 2 weeks of 35 hours, got %d weeks of %.2f", engine.WeeksPerTick, engine.HoursPerWeek)
+	}
+	if engine.Logger != logger {
+		t.Error("Expected the engine to log to the given logger")
+	}
+	if engine.Seed != 42 {
+		t.Errorf("Expected Seed to be 42, got %d", engine.Seed)
+	}
+	if engine.MarketMode != market.ModePosted {
+		t.Errorf("Expected the default market mode, got %s", engine.MarketMode)
+	}
+}
+
 func TestInitialState_CapturesIndustryMoney(t *testing.T) {
 	// Arrange
 	region := entities.NewRegion("TestRegion")
@@ -210,6 +251,35 @@ func TestGetAvailableWorkers_NoWorkersSegment(t *testing.T) {
 	}
 }
 
+func TestGetAvailableWorkers_SegmentRole(t *testing.T) {
+	// Arrange
+	region := entities.NewRegion("TestRegion")
+
+	arbeiter := &entities.PopulationSegment{Name: "Arbeiter", Role: entities.RoleWorkers}
+	otherSegment := &entities.PopulationSegment{Name: "Other"}
+	region.AddPopulationSegment(arbeiter)
+	region.AddPopulationSegment(otherSegment)
+
+	for i := 0; i < 5; i++ {
+		person := entities.NewPerson("Worker", 50.0, 8.0)
+		person.AddSegment(arbeiter)
+		region.AddPerson(person)
+		other := entities.NewPerson("Other", 50.0, 8.0)
+		other.AddSegment(otherSegment)
+		region.AddPerson(other)
+	}
+
+	engine := CreateNewEngine(region)
+
+	// Act
+	workers := engine.getAvailableWorkers()
+
+	// Assert
+	if len(workers) != 5 {
+		t.Errorf("Expected the 5 members of the workers role segment, got %d", len(workers))
+	}
+}
+
 func TestEngine_ProcessTick_DoesNotPanic(t *testing.T) {
 	// Arrange
 	region := entities.NewRegion("TestRegion")
@@ -252,5 +322,1332 @@ func TestEngine_ProcessTick_DoesNotPanic(t *testing.T) {
 		}
 	}()
 
-	engine.processTick()
+	engine.processTick(context.Background())
+}
+
+func TestEngine_ProcessTick_PublishesEvents(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+
+	problem := entities.NewProblem("Food", "Need food", 0.9)
+	problem.UpdateDemand(1.0)
+	region.AddProblem(problem)
+
+	// Exactly one tick's worth of input: 2 workers × 160 hours at full capacity
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 160
+	region.AddResource(resource)
+
+	product := entities.NewResource("Food", "kg")
+	industry := entities.CreateIndustry("TestIndustry").
+		SetupIndustry([]*entities.Problem{problem}, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(industry)
+
+	workersSegment := entities.NewPopulationSegment("Workers", []*entities.Problem{problem}, 2)
+	region.AddPopulationSegment(workersSegment)
+	for i := 0; i < 2; i++ {
+		person := entities.NewPerson("Worker", 0, 40.0)
+		person.AddSegment(workersSegment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	recorder := events.NewRecorder(engine.Events)
+
+	engine.CurrentTick = 1
+	engine.processTick(context.Background())
+
+	if events.Count[events.WagePaid](recorder) != 1 {
+		t.Errorf("Expected 1 wage event, got %d", events.Count[events.WagePaid](recorder))
+	}
+	if events.Count[events.ProductionCompleted](recorder) != 1 {
+		t.Errorf("Expected 1 production event, got %d", events.Count[events.ProductionCompleted](recorder))
+	}
+	if events.Count[events.ResourceDepleted](recorder) != 1 {
+		t.Errorf("Expected RawMaterial depletion event, got %d", events.Count[events.ResourceDepleted](recorder))
+	}
+	// Workers earned $1600 each and buy one unit of food
+	if events.Count[events.PurchaseMade](recorder) != 2 {
+		t.Errorf("Expected 2 purchase events, got %d", events.Count[events.PurchaseMade](recorder))
+	}
+}
+
+func TestEngine_PersonLifecycleEvents(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	product := entities.NewResource("Goods", "units")
+	factory := entities.CreateIndustry("Factory").
+		SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(factory)
+
+	workersSegment := &entities.PopulationSegment{Name: "Workers"}
+	retired := &entities.PopulationSegment{Name: "Retirees", Retired: true}
+	region.AddPopulationSegment(workersSegment)
+	region.AddPopulationSegment(retired)
+	people := []*entities.Person{
+		entities.NewPerson("Ada", 0, 8.0), entities.NewPerson("Bo", 0, 8.0), entities.NewPerson("Cy", 0, 8.0),
+	}
+	for _, person := range people {
+		person.AddSegment(workersSegment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 1
+	recorder := events.NewRecorder(engine.Events)
+	for tick, labor := range []float32{2, 1} {
+		engine.CurrentTick = tick + 1
+		factory.LaborNeeded = labor
+		for _, person := range people {
+			person.HoursLeft = 10
+		}
+		engine.processProductionPhase(10, nil)
+	}
+	if events.Count[events.PersonHired](recorder) != 2 || events.Count[events.PersonFired](recorder) != 1 {
+		t.Errorf("Expected 2 hired then 1 fired, got %d and %d",
+			events.Count[events.PersonHired](recorder), events.Count[events.PersonFired](recorder))
+	}
+
+	// Going broke, retiring, dying and migrating are each published once
+	solvent := engine.solventPeople()
+	people[0].Money = 0
+	engine.publishBankruptcies(solvent)
+	engine.publishRetirements([]transitions.Move{{Person: people[1], From: "Workers", To: "Retirees"}})
+	engine.RemovePerson(people[1], "")
+	engine.RemovePerson(people[2], "Elsewhere")
+	if engine.RemovePerson(people[2], "") {
+		t.Error("Expected removing someone no longer in the region to fail")
+	}
+
+	trajectory := make(map[int][]string)
+	for _, event := range recorder.Events {
+		if personal, ok := event.(events.PersonEvent); ok {
+			trajectory[personal.EventPerson()] = append(trajectory[personal.EventPerson()], fmt.Sprintf("%T", event))
+		}
+	}
+	want := map[int]string{
+		people[0].ID: "[events.PersonHired events.PersonBankrupt]",
+		people[1].ID: "[events.PersonHired events.PersonFired events.PersonRetired events.PersonDied]",
+		people[2].ID: "[events.PersonMigrated]",
+	}
+	for id, path := range want {
+		if got := fmt.Sprint(trajectory[id]); got != path {
+			t.Errorf("Expected person %d's trajectory %s, got %s", id, path, got)
+		}
+	}
+	if len(region.People) != 1 {
+		t.Errorf("Expected 1 person left in the region, got %d", len(region.People))
+	}
+}
+
+func TestEngine_TrackPeople(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	problem := entities.NewProblem("Food", "Need food", 0.9)
+	problem.UpdateDemand(1.0)
+	region.AddProblem(problem)
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	product := entities.NewResource("Food", "kg")
+	industry := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{problem}, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(industry)
+
+	workersSegment := entities.NewPopulationSegment("Workers", []*entities.Problem{problem}, 4)
+	region.AddPopulationSegment(workersSegment)
+	for i := 0; i < 4; i++ {
+		person := entities.NewPerson(fmt.Sprintf("Worker-%d", i), 0, 40.0)
+		person.AddSegment(workersSegment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.SetSeed(3)
+	engine.TrackPeople(2)
+	for tick := 1; tick <= 2; tick++ {
+		engine.CurrentTick = tick
+		engine.processTick(context.Background())
+	}
+
+	people := engine.Trajectories.People
+	if len(people) != 2 || people[0].PersonID == people[1].PersonID {
+		t.Fatalf("Expected 2 different people followed, got %d", len(people))
+	}
+	for _, trajectory := range people {
+		if len(trajectory.Ticks) != 2 || trajectory.Segments[0] != "Workers" {
+			t.Fatalf("Expected 2 ticks of a worker, got %d", len(trajectory.Ticks))
+		}
+		person := region.PersonByID(trajectory.PersonID)
+		last := trajectory.Ticks[1]
+		if last.Money != person.Money || last.Income != last.Money-trajectory.Ticks[0].Money+last.Spent {
+			t.Errorf("Expected %s's money and income to add up, got %+v", person.Name, last)
+		}
+		spent := float32(0)
+		for _, purchase := range last.Purchases {
+			spent += purchase.Quantity * purchase.UnitPrice
+		}
+		if math.Abs(float64(spent-last.Spent)) > 0.01 {
+			t.Errorf("Expected purchases to add up to %.2f spent, got %.2f", last.Spent, spent)
+		}
+	}
+
+	// The same seed samples the same people
+	again := CreateNewEngine(region)
+	again.SetSeed(3)
+	again.TrackPeople(2)
+	again.processTick(context.Background())
+	if again.Trajectories.People[0].PersonID != people[0].PersonID {
+		t.Errorf("Expected the seed to pick the same people, got %d and %d",
+			again.Trajectories.People[0].PersonID, people[0].PersonID)
+	}
+}
+
+func TestEngine_Cohorts(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	problem := entities.NewProblem("Food", "Need food", 0.9)
+	problem.UpdateDemand(1.0)
+	region.AddProblem(problem)
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	product := entities.NewResource("Food", "kg")
+	industry := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{problem}, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(industry)
+
+	workersSegment := entities.NewPopulationSegment("Workers", []*entities.Problem{problem}, 5)
+	region.AddPopulationSegment(workersSegment)
+	for i := 0; i < 5; i++ {
+		person := entities.NewPerson(fmt.Sprintf("Worker-%d", i), float32(400-100*i), 40.0)
+		person.AddSegment(workersSegment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.TrackCohorts()
+	for tick := 1; tick <= 2; tick++ {
+		engine.CurrentTick = tick
+		engine.processTick(context.Background())
+	}
+
+	cohorts := engine.Results().Cohorts
+	if len(cohorts) != 6 || cohorts[0].Name != "Workers" || cohorts[0].Size != 5 {
+		t.Fatalf("Expected a Workers cohort of 5 and 5 quintiles, got %d cohorts", len(cohorts))
+	}
+	poorest, richest := cohorts[1], cohorts[5]
+	if poorest.Name != "Q1" || richest.Name != "Q5" || poorest.Size != 1 {
+		t.Fatalf("Expected quintiles Q1 to Q5 of one person each, got %s to %s", poorest.Name, richest.Name)
+	}
+	for _, cohort := range cohorts {
+		if len(cohort.Ticks) != 2 || cohort.Ticks[1].People != cohort.Size {
+			t.Fatalf("Expected 2 ticks of %s with everyone in it, got %+v", cohort.Name, cohort.Ticks)
+		}
+	}
+	// Everyone earns the same wages, so the starting gap carries over
+	if poorest.Ticks[1].Wealth >= richest.Ticks[1].Wealth {
+		t.Errorf("Expected Q1 to stay poorer than Q5, got %.2f and %.2f", poorest.Ticks[1].Wealth, richest.Ticks[1].Wealth)
+	}
+
+	path := t.TempDir() + "/cohorts.csv"
+	if err := SaveCohortsCSV(path, engine.Cohorts); err != nil {
+		t.Fatalf("Failed to save cohorts: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read cohorts: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 13 {
+		t.Errorf("Expected a header and 12 rows, got %d lines", lines)
+	}
+}
+
+func TestEngine_Matrices(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	problem := entities.NewProblem("Food", "Need food", 0.9)
+	problem.UpdateDemand(1.0)
+	region.AddProblem(problem)
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	product := entities.NewResource("Food", "kg")
+	farm := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{problem}, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(farm)
+	workersSegment := entities.NewPopulationSegment("Workers", []*entities.Problem{problem}, 2)
+	region.AddPopulationSegment(workersSegment)
+	for i := 0; i < 2; i++ {
+		person := entities.NewPerson("Worker", 0, 40.0)
+		person.AddSegment(workersSegment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.TrackMatrices()
+	engine.CurrentTick = 1
+	engine.processTick(context.Background())
+
+	// A mill opening in the second tick gets a column, missing before
+	mill := entities.CreateIndustry("Mill").
+		SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{entities.NewResource("Flour", "kg")}).
+		UpdateLabor(1.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(mill)
+	engine.CurrentTick = 2
+	engine.processTick(context.Background())
+
+	output := engine.Matrices.Output
+	if fmt.Sprint(output.Columns, output.Ticks) != "[Farm Mill] [1 2]" {
+		t.Fatalf("Expected Farm and Mill columns over ticks 1 and 2, got %v and %v", output.Columns, output.Ticks)
+	}
+	if output.Values[0][0] <= 0 || !math.IsNaN(float64(output.Values[0][1])) {
+		t.Errorf("Expected the farm's output and no mill in tick 1, got %v", output.Values[0])
+	}
+	if price := engine.Matrices.Price.Values[0][0]; price <= 0 {
+		t.Errorf("Expected the farm's price in tick 1, got %.2f", price)
+	}
+	if unmet := engine.Matrices.Unmet.Values[0]; len(unmet) != 1 || unmet[0] < 0 {
+		t.Errorf("Expected unmet Food units in tick 1, got %v", unmet)
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		t.Fatalf("Failed to encode matrix: %v", err)
+	}
+	if !strings.Contains(string(data), ",null]") {
+		t.Errorf("Expected the missing mill output as null, got %s", data)
+	}
+}
+
+func TestEngine_Checkpoint(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	problem := entities.NewProblem("Food", "Need food", 0.9)
+	problem.UpdateDemand(0.7)
+	region.AddProblem(problem)
+
+	product := entities.NewResource("Food", "kg")
+	product.Quantity = 12
+	region.AddIndustry(entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{problem}, nil, []*entities.Resource{product}).
+		SetInitialCapital(500.0))
+
+	person := entities.NewPerson("Person-1", 30.0, 40.0)
+	person.Savings = 20.0
+	region.AddPerson(person)
+
+	engine := CreateNewEngine(region)
+	engine.SetSeed(9)
+	engine.CurrentTick = 4
+
+	checkpoint := engine.Checkpoint()
+
+	if checkpoint.Tick != 4 || checkpoint.Seed != 9 {
+		t.Errorf("Expected tick 4 / seed 9, got %d / %d", checkpoint.Tick, checkpoint.Seed)
+	}
+	if len(checkpoint.Industries) != 1 || checkpoint.Industries[0].Stock["Food"] != 12 || checkpoint.Industries[0].Money != 500 {
+		t.Errorf("Expected Farm with $500 and 12 Food, got %+v", checkpoint.Industries)
+	}
+	if len(checkpoint.People) != 1 || checkpoint.People[0].Savings != 20 {
+		t.Errorf("Expected person with $20 savings, got %+v", checkpoint.People)
+	}
+	if checkpoint.Demand["Food"] != 0.7 {
+		t.Errorf("Expected Food demand 0.7, got %.2f", checkpoint.Demand["Food"])
+	}
+}
+
+func TestEngine_Step_StopsWhenCancelled(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	engine := CreateNewEngine(region)
+
+	if err := engine.Step(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if engine.CurrentTick != 1 {
+		t.Errorf("Expected tick 1 after one step, got %d", engine.CurrentTick)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := engine.Step(ctx); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if engine.CurrentTick != 1 {
+		t.Errorf("Expected a cancelled step not to advance the clock, got tick %d", engine.CurrentTick)
+	}
+
+	if err := engine.Run(ctx, 5); err != context.Canceled || !engine.Interrupted {
+		t.Errorf("Expected Run to stop with context.Canceled, got %v (interrupted %v)", err, engine.Interrupted)
+	}
+
+	// The next run starts afresh
+	if err := engine.Run(context.Background(), 1); err != nil || engine.Interrupted {
+		t.Errorf("Expected a later run to complete, got %v (interrupted %v)", err, engine.Interrupted)
+	}
+	if engine.CurrentTick != 2 {
+		t.Errorf("Expected the later run to advance to tick 2, got tick %d", engine.CurrentTick)
+	}
+}
+
+// cancellingTracer cancels a run's context when a phase starts
+type cancellingTracer struct {
+	*PhaseTimings
+	phase  string
+	cancel context.CancelFunc
+}
+
+func (c cancellingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	if name == c.phase {
+		c.cancel()
+	}
+	return c.PhaseTimings.Start(ctx, name)
+}
+
+func TestEngine_Run_FinishesTickWhenCancelled(t *testing.T) {
+	engine := CreateNewEngine(scenarios.Standard(50))
+	engine.Logger.SetEnabled(false)
+	engine.OnProgress = func(Progress) {}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timings := NewPhaseTimings()
+	engine.Tracer = cancellingTracer{PhaseTimings: timings, phase: "market", cancel: cancel}
+
+	if err := engine.Run(ctx, 5); err != context.Canceled || !engine.Interrupted {
+		t.Fatalf("Expected Run to stop with context.Canceled, got %v (interrupted %v)", err, engine.Interrupted)
+	}
+	if engine.CurrentTick != 1 {
+		t.Errorf("Expected the run to stop after tick 1, got tick %d", engine.CurrentTick)
+	}
+	// The phases after the market still ran
+	for _, timing := range timings.Timings() {
+		if timing.Name == "regeneration" && timing.Count == 1 {
+			return
+		}
+	}
+	t.Error("Expected the cancelled tick to run to its last phase")
+}
+
+func TestEngine_SnapshotReadableDuringRun(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	person := entities.NewPerson("Person-1", 30.0, 40.0)
+	region.AddPerson(person)
+
+	engine := CreateNewEngine(region)
+	if engine.Snapshot() != nil {
+		t.Error("Expected no snapshot before snapshots are served")
+	}
+	engine.ServeSnapshots = true
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 3; i++ {
+			if err := engine.Step(context.Background()); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}
+	}()
+
+	// Readers only ever see whole ticks
+	for snapshot := engine.Snapshot(); snapshot == nil || snapshot.Tick < 3; snapshot = engine.Snapshot() {
+		if snapshot != nil && len(snapshot.People) != 1 {
+			t.Fatalf("Expected a complete snapshot, got %d people", len(snapshot.People))
+		}
+	}
+	<-done
+
+	snapshot := engine.Snapshot()
+	person.Money = 999
+	if snapshot.People[0].Money != 30.0 {
+		t.Errorf("Expected the snapshot to be independent of live state, got %.2f", snapshot.People[0].Money)
+	}
+}
+
+func TestEngine_Fork_BranchesIndependently(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	farm := entities.CreateIndustry("Farm").SetInitialCapital(1000.0)
+	region.AddIndustry(farm)
+	person := entities.NewPerson("Person-1", 100.0, 40.0)
+	region.AddPerson(person)
+
+	engine := CreateNewEngine(region)
+	engine.SetSeed(3)
+	engine.Government = government.NewGovernment(0, 0.1)
+	engine.Insurers = []*insurance.Insurer{insurance.NewInsurer("Mutual", 500)}
+	engine.Insurers[0].AddPolicy(&insurance.Policy{Person: person, Premium: 5})
+	engine.CurrentTick = 10
+	food := entities.NewProblem("Food", "", 0.5)
+	region.AddProblem(food)
+	engine.lastMarket = &market.MarketResult{
+		NeedStats: map[int]*market.NeedStats{food.ID: {ProblemID: food.ID, Seeking: 1, Reasons: map[string]int{market.ReasonOutOfStock: 1}}},
+		Unmet:     []market.UnmetNeed{{Person: person, Problem: food, Reason: market.ReasonOutOfStock}},
+	}
+
+	fork := engine.Fork()
+
+	if fork.CurrentTick != 10 || fork.Region == region {
+		t.Fatal("Expected the fork to start at tick 10 with its own region")
+	}
+	if fork.Insurers[0].Policies[0].Person != fork.Region.People[0] {
+		t.Error("Expected the forked policy to cover the forked person")
+	}
+	if fork.Rand.Uint64() != engine.Rand.Uint64() {
+		t.Error("Expected both branches to continue the same random stream")
+	}
+
+	unmet := fork.lastMarket.Unmet[0]
+	if unmet.Person != fork.Region.People[0] || unmet.Problem != fork.Region.Problems[0] {
+		t.Error("Expected the fork's last market to point at the forked person and problem")
+	}
+	if fork.Logger == engine.Logger {
+		t.Error("Expected the fork to log through a logger of its own")
+	}
+
+	fork.Government.SalesTaxRate = 0.3
+	fork.Region.People[0].Money = 0
+	fork.lastMarket.NeedStats[food.ID].Reasons[market.ReasonOutOfStock]++
+	if engine.Government.SalesTaxRate != 0.1 || person.Money != 100.0 {
+		t.Error("Expected changes in the fork not to affect the original")
+	}
+	if engine.lastMarket.NeedStats[food.ID].Reasons[market.ReasonOutOfStock] != 1 {
+		t.Error("Expected the fork's need stats to be a copy")
+	}
+}
+
+func TestEngine_Run_ReportsProgress(t *testing.T) {
+	engine := CreateNewEngine(scenarios.Standard(50))
+	engine.Logger.SetEnabled(false)
+
+	reports := make([]Progress, 0)
+	engine.OnProgress = func(p Progress) {
+		reports = append(reports, p)
+	}
+	if err := engine.Run(context.Background(), 3); err != nil {
+		t.Fatalf("Expected run to succeed, got %v", err)
+	}
+
+	if len(reports) != 3 {
+		t.Fatalf("Expected 3 progress reports, got %d", len(reports))
+	}
+	last := reports[2]
+	if last.Tick != 3 || last.Total != 3 || last.Fraction() != 1 {
+		t.Errorf("Expected tick 3 of 3, got %d of %d", last.Tick, last.Total)
+	}
+	if last.ETA != 0 {
+		t.Errorf("Expected no time left, got %v", last.ETA)
+	}
+	if last.PeopleTotal != 50 || last.TotalWealth <= 0 {
+		t.Errorf("Expected metrics for 50 people, got %+v", last)
+	}
+}
+
+func TestEngine_Step_TracesPhases(t *testing.T) {
+	engine := CreateNewEngine(scenarios.Standard(50))
+	engine.Logger.SetEnabled(false)
+	timings := NewPhaseTimings()
+	engine.Tracer = timings
+
+	for i := 0; i < 2; i++ {
+		if err := engine.Step(context.Background()); err != nil {
+			t.Fatalf("Expected step to succeed, got %v", err)
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, timing := range timings.Timings() {
+		counts[timing.Name] = timing.Count
+	}
+	for _, name := range []string{"tick", "production", "market", "demand", "regeneration"} {
+		if counts[name] != 2 {
+			t.Errorf("Expected 2 %s spans, got %d", name, counts[name])
+		}
+	}
+	if _, traced := counts["taxes"]; traced {
+		t.Error("Expected no taxes span without a government")
+	}
+}
+
+func BenchmarkEngine_Step(b *testing.B) {
+	engine := CreateNewEngine(scenarios.Standard(1000))
+	engine.Logger.SetEnabled(false)
+	engine.SetSeed(1)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := engine.Step(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEngine_ProductionPhase_RunsNightShift(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	product := entities.NewResource("Food", "kg")
+
+	industry := entities.CreateIndustry("TestIndustry").
+		SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	industry.Shifts = 3
+	industry.NightPremium = 0.5
+	region.AddIndustry(industry)
+
+	workersSegment := &entities.PopulationSegment{Name: "Workers"}
+	region.AddPopulationSegment(workersSegment)
+	for i := 0; i < 5; i++ {
+		person := entities.NewPerson("Worker", 50.0, 8.0)
+		person.HoursLeft = 160
+		person.AddSegment(workersSegment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 10
+	engine.processProductionPhase(10, nil)
+
+	// Two full crews of 2 out of 5 workers, the third shift can't be staffed
+	if product.Quantity != 20 {
+		t.Errorf("Expected 20 units from two shifts, got %.2f", product.Quantity)
+	}
+	if len(engine.idle) != 1 {
+		t.Errorf("Expected 1 idle worker, got %d", len(engine.idle))
+	}
+	// Day shift 2 × 10h × $10, night shift at $15
+	if spent := 10000 - industry.Money; spent != 500 {
+		t.Errorf("Expected $500 in wages, got %.2f", spent)
+	}
+}
+
+func TestEngine_ProductionPhase_ShortageRefundsWagesAndPayrollTax(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 5 // A crew of 2 working 10 hours needs 20
+	region.AddResource(resource)
+	product := entities.NewResource("Food", "kg")
+
+	industry := entities.CreateIndustry("TestIndustry").
+		SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(industry)
+
+	workersSegment := &entities.PopulationSegment{Name: "Workers"}
+	region.AddPopulationSegment(workersSegment)
+	workers := make([]*entities.Person, 2)
+	for i := range workers {
+		workers[i] = entities.NewPerson("Worker", 50.0, 8.0)
+		workers[i].HoursLeft = 160
+		workers[i].AddSegment(workersSegment)
+		region.AddPerson(workers[i])
+	}
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 10
+	engine.Government = government.NewGovernment(0, 0)
+	engine.Government.Unemployment = government.NewUnemployment(0.1, 0.5, 2)
+	recorder := events.NewRecorder(engine.Events)
+	phase := engine.processProductionPhase(10, nil)
+
+	if industry.Money != 10000 || engine.Government.Treasury != 0 || engine.payrollTax != 0 {
+		t.Errorf("Expected wages and payroll tax refunded, got industry $%.2f, treasury $%.2f, tax $%.2f",
+			industry.Money, engine.Government.Treasury, engine.payrollTax)
+	}
+	for _, worker := range workers {
+		if worker.Money != 50 {
+			t.Errorf("Expected the refunded worker back at $50, got %.2f", worker.Money)
+		}
+	}
+	if phase.WagesPaid != 0 || events.Count[events.WagePaid](recorder) != 0 {
+		t.Errorf("Expected no wages reported paid, got $%.2f in %d events",
+			phase.WagesPaid, events.Count[events.WagePaid](recorder))
+	}
+}
+
+func TestEngine_ProcessTick_ConsumesLaborHours(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	product := entities.NewResource("Food", "kg")
+	industry := entities.CreateIndustry("TestIndustry").
+		SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(industry)
+
+	workersSegment := &entities.PopulationSegment{Name: "Workers"}
+	region.AddPopulationSegment(workersSegment)
+	fullTime := entities.NewPerson("Full", 0, 8.0)
+	partTime := entities.NewPerson("Part", 0, 4.0)
+	for _, person := range []*entities.Person{fullTime, partTime} {
+		person.AddSegment(workersSegment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 1
+	engine.processTick(context.Background())
+
+	// 4 weeks of 5 days: 160 hours full time, 80 part time
+	if fullTime.Money != 160 || partTime.Money != 80 {
+		t.Errorf("Expected $160 and $80 in wages, got %.2f and %.2f", fullTime.Money, partTime.Money)
+	}
+	if fullTime.HoursLeft != 0 || partTime.HoursLeft != 0 {
+		t.Errorf("Expected every hour used, got %.2f and %.2f left", fullTime.HoursLeft, partTime.HoursLeft)
+	}
+	if product.Quantity != 120 {
+		t.Errorf("Expected 120 units from 240 of 320 crew hours, got %.2f", product.Quantity)
+	}
+}
+
+func TestEngine_ProductionPhase_FillsHoursAcrossJobs(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	product := entities.NewResource("Food", "kg")
+	for _, name := range []string{"Farm", "Mill"} {
+		industry := entities.CreateIndustry(name).
+			SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{product}).
+			UpdateLabor(1.0).
+			SetInitialCapital(10000.0)
+		region.AddIndustry(industry)
+	}
+
+	workersSegment := &entities.PopulationSegment{Name: "Workers", TargetIncome: 150}
+	region.AddPopulationSegment(workersSegment)
+	first := entities.NewPerson("First", 0, 8.0)
+	second := entities.NewPerson("Second", 0, 8.0)
+	for _, person := range []*entities.Person{first, second} {
+		person.HoursLeft = 20
+		person.AddSegment(workersSegment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 10
+	engine.MaxOvertime = 0.5
+	engine.MultipleJobs = true
+	engine.processProductionPhase(10, nil)
+
+	// Both want 15 hours: the first works 10 then 5 at the second job,
+	// sharing it with the second worker
+	if product.Quantity != 20 {
+		t.Errorf("Expected 20 units from two full crews, got %.2f", product.Quantity)
+	}
+	if first.Money != 150 || second.Money != 50 {
+		t.Errorf("Expected $150 and $50 in wages, got %.2f and %.2f", first.Money, second.Money)
+	}
+	if first.HoursLeft != 5 {
+		t.Errorf("Expected 5 hours left, got %.2f", first.HoursLeft)
+	}
+	if len(engine.idle) != 0 {
+		t.Errorf("Expected no idle workers, got %d", len(engine.idle))
+	}
+}
+
+func TestEngine_ProductionPhase_SplitsScarceSharedInputs(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 10
+	region.AddResource(resource)
+	products := make([]*entities.Resource, 2)
+	for i, name := range []string{"Farm", "Mill"} {
+		products[i] = entities.NewResource(name+" Goods", "units")
+		industry := entities.CreateIndustry(name).
+			SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{products[i]}).
+			UpdateLabor(1.0).
+			SetInitialCapital(10000.0)
+		region.AddIndustry(industry)
+	}
+
+	workersSegment := &entities.PopulationSegment{Name: "Workers"}
+	region.AddPopulationSegment(workersSegment)
+	for _, name := range []string{"First", "Second"} {
+		person := entities.NewPerson(name, 0, 8.0)
+		person.HoursLeft = 10
+		person.AddSegment(workersSegment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 1
+	engine.InputAllocation = production.AllocateProRata
+	engine.processProductionPhase(10, nil)
+
+	// Both want 10 units of the 10 there are, so each gets half
+	if products[0].Quantity != 5 || products[1].Quantity != 5 {
+		t.Errorf("Expected 5 units each, got %.2f and %.2f", products[0].Quantity, products[1].Quantity)
+	}
+	if resource.Quantity != 0 {
+		t.Errorf("Expected the input used up, got %.2f left", resource.Quantity)
+	}
+}
+
+func TestEngine_ProductionPhase_OccupiesLand(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	land := entities.NewResource("Land", "acres")
+	land.Quantity = 100
+	land.Kind = entities.StockResource
+	region.AddResource(land)
+	product := entities.NewResource("Food", "kg")
+	farm := entities.CreateIndustry("Farm").
+		SetupIndustry(nil, []*entities.Resource{land}, []*entities.Resource{product}).
+		UpdateLabor(1.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(farm)
+
+	workersSegment := &entities.PopulationSegment{Name: "Workers"}
+	region.AddPopulationSegment(workersSegment)
+	worker := entities.NewPerson("Worker", 0, 8.0)
+	worker.AddSegment(workersSegment)
+	region.AddPerson(worker)
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 1
+	for _, hours := range []float32{10, 10, 4} {
+		worker.HoursLeft = hours
+		engine.processProductionPhase(hours, nil)
+	}
+
+	// Land is held, not used up: the same 10 acres serve two ticks, and 6
+	// are freed when output shrinks to 4
+	if product.Quantity != 24 {
+		t.Errorf("Expected 24 units produced, got %.2f", product.Quantity)
+	}
+	if land.Quantity != 96 || farm.Holding(land) != 4 {
+		t.Errorf("Expected 96 acres free and 4 held, got %.2f and %.2f", land.Quantity, farm.Holding(land))
+	}
+}
+
+func TestEngine_ProductionPhase_SkillPay(t *testing.T) {
+	for _, skillPay := range []bool{false, true} {
+		region := entities.NewRegion("TestRegion")
+		resource := entities.NewResource("RawMaterial", "units")
+		resource.Quantity = 1000
+		region.AddResource(resource)
+		product := entities.NewResource("Goods", "units")
+		factory := entities.CreateIndustry("Factory").
+			SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{product}).
+			UpdateLabor(2.0).
+			SetInitialCapital(10000.0)
+		region.AddIndustry(factory)
+
+		workersSegment := &entities.PopulationSegment{Name: "Workers"}
+		region.AddPopulationSegment(workersSegment)
+		novice, expert := entities.NewPerson("Novice", 0, 8.0), entities.NewPerson("Expert", 0, 8.0)
+		novice.Skill, expert.Skill = 0.5, 1.5
+		for _, person := range []*entities.Person{novice, expert} {
+			person.AddSegment(workersSegment)
+			person.HoursLeft = 10
+			region.AddPerson(person)
+		}
+
+		engine := CreateNewEngine(region)
+		engine.WagePerHour = 1
+		engine.SkillPay = skillPay
+		phase := engine.processProductionPhase(10, nil)
+
+		want := [2]float32{10, 10}
+		if skillPay {
+			want = [2]float32{5, 15}
+		}
+		if novice.Money != want[0] || expert.Money != want[1] {
+			t.Errorf("skill pay %v: expected wages of %.0f and %.0f, got %.2f and %.2f",
+				skillPay, want[0], want[1], novice.Money, expert.Money)
+		}
+		if phase.WagesPaid != 20 {
+			t.Errorf("skill pay %v: expected a wage bill of 20, got %.2f", skillPay, phase.WagesPaid)
+		}
+	}
+}
+
+func TestEngine_ProductionPhase_HiresFromSegments(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	product := entities.NewResource("Care", "visits")
+
+	hospital := entities.CreateIndustry("Hospital").
+		SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	hospital.HiresFrom = []string{"Medical Professionals"}
+	factory := entities.CreateIndustry("Factory").
+		SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(hospital)
+	region.AddIndustry(factory)
+
+	medical := &entities.PopulationSegment{Name: "Medical Professionals"}
+	workersSegment := &entities.PopulationSegment{Name: "Workers"}
+	region.AddPopulationSegment(medical)
+	region.AddPopulationSegment(workersSegment)
+	doctor := entities.NewPerson("Doctor", 0, 8.0)
+	doctor.AddSegment(medical)
+	for _, person := range []*entities.Person{doctor, entities.NewPerson("Worker", 0, 8.0), entities.NewPerson("Worker", 0, 8.0)} {
+		if person != doctor {
+			person.AddSegment(workersSegment)
+		}
+		person.HoursLeft = 10
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 10
+	engine.processProductionPhase(10, nil)
+
+	// The hospital only finds the doctor, the factory both workers
+	if spent := 10000 - hospital.Money; spent != 100 {
+		t.Errorf("Expected the hospital to pay $100 for the doctor, got %.2f", spent)
+	}
+	if spent := 10000 - factory.Money; spent != 200 {
+		t.Errorf("Expected the factory to pay $200 for both workers, got %.2f", spent)
+	}
+	if engine.vacancies != 1 {
+		t.Errorf("Expected 1 vacancy at the hospital, got %d", engine.vacancies)
+	}
+}
+
+func TestEngine_ProcessTick_RecordsTickResult(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	resource.RegenerationRate = 5
+	region.AddResource(resource)
+	product := entities.NewResource("Food", "kg")
+	industry := entities.CreateIndustry("TestIndustry").
+		SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(industry)
+
+	workersSegment := &entities.PopulationSegment{Name: "Workers"}
+	region.AddPopulationSegment(workersSegment)
+	for i := 0; i < 3; i++ {
+		person := entities.NewPerson("Worker", 0, 8.0)
+		person.AddSegment(workersSegment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 1
+	if err := engine.Step(context.Background()); err != nil {
+		t.Fatalf("Expected the tick to run, got %v", err)
+	}
+
+	tick := engine.LastTick
+	if tick == nil || tick.Tick != 1 {
+		t.Fatalf("Expected the result of tick 1, got %+v", tick)
+	}
+	production := tick.Production
+	if production.Available != 3 || production.Unemployed != 1 || len(production.Industries) != 1 {
+		t.Errorf("Expected 3 available, 1 unemployed and 1 industry, got %+v", production)
+	}
+	if output := production.Industries[0]; output.Workers != 2 || output.Units != product.Quantity {
+		t.Errorf("Expected 2 workers making %.2f units, got %+v", product.Quantity, output)
+	}
+	if tick.Market.People != 3 {
+		t.Errorf("Expected 3 people in the market, got %d", tick.Market.People)
+	}
+	if tick.Regeneration.Regenerated["RawMaterial"] != 5 {
+		t.Errorf("Expected 5 units of RawMaterial regenerated, got %v", tick.Regeneration.Regenerated)
+	}
+	if engine.Results().LastTick != tick || engine.Checkpoint().LastTick != tick {
+		t.Error("Expected results and checkpoints to carry the tick's result")
+	}
+}
+
+func TestEngine_SetPhases(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	problem := entities.NewProblem("Food", "Need food", 0.9)
+	region.AddProblem(problem)
+	product := entities.NewResource("Food", "kg")
+	industry := entities.CreateIndustry("TestIndustry").
+		SetupIndustry([]*entities.Problem{problem}, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(1.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(industry)
+
+	segment := &entities.PopulationSegment{Name: "Workers", Problems: []*entities.Problem{problem}}
+	region.AddPopulationSegment(segment)
+	person := entities.NewPerson("Worker", 1000, 8.0)
+	person.AddSegment(segment)
+	region.AddPerson(person)
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 1
+	for _, phases := range [][]string{
+		{"production", "lottery"},
+		{"production", "production"},
+		{"taxes", "product_market"},
+	} {
+		if err := engine.SetPhases(phases); err == nil {
+			t.Errorf("Expected an error for phases %v", phases)
+		}
+	}
+	engine.WageTiming = "after_sales"
+	if err := engine.SetPhases([]string{"production", "product_market"}); err == nil {
+		t.Error("Expected an error for wages paid after sales without wages_due")
+	}
+	engine.WageTiming = ""
+
+	// Without a market the tick's output stays on the shelves
+	if err := engine.SetPhases([]string{"production", "regeneration"}); err != nil {
+		t.Fatalf("Expected valid phases, got %v", err)
+	}
+	if err := engine.Step(context.Background()); err != nil {
+		t.Fatalf("Expected the tick to run, got %v", err)
+	}
+	if product.Quantity != 160 {
+		t.Errorf("Expected all 160 units unsold, got %.2f", product.Quantity)
+	}
+	if engine.LastTick.Market.Purchases != 0 {
+		t.Errorf("Expected no purchases, got %d", engine.LastTick.Market.Purchases)
+	}
+}
+
+func TestEngine_Window_SkipsWarmUp(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	product := entities.NewResource("Food", "kg")
+	industry := entities.CreateIndustry("TestIndustry").
+		SetupIndustry(nil, []*entities.Resource{resource}, []*entities.Resource{product}).
+		UpdateLabor(1.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(industry)
+
+	segment := &entities.PopulationSegment{Name: "Workers"}
+	region.AddPopulationSegment(segment)
+	person := entities.NewPerson("Worker", 0, 8.0)
+	person.AddSegment(segment)
+	region.AddPerson(person)
+
+	engine := CreateNewEngine(region)
+	engine.WagePerHour = 1
+	engine.WarmUp = 1
+	engine.MeasureTicks = 1
+
+	ctx := context.Background()
+	if err := engine.Step(ctx); err != nil {
+		t.Fatalf("Expected the tick to run, got %v", err)
+	}
+	if engine.Window() != nil {
+		t.Error("Expected no window during the warm-up")
+	}
+	warmedUp := engine.LastTick.TotalWealth
+	for i := 0; i < 2; i++ {
+		if err := engine.Step(ctx); err != nil {
+			t.Fatalf("Expected the tick to run, got %v", err)
+		}
+	}
+
+	window := engine.Window()
+	if window == nil || window.From != 2 || window.To != 2 || window.Ticks != 1 {
+		t.Fatalf("Expected tick 2 alone measured, got %+v", window)
+	}
+	if window.UnitsProduced != 160 {
+		t.Errorf("Expected 160 units per tick, got %.2f", window.UnitsProduced)
+	}
+	if engine.InitialState.TotalWealth != warmedUp {
+		t.Errorf("Expected the run measured from $%.2f, got %.2f", warmedUp, engine.InitialState.TotalWealth)
+	}
+	if engine.Results().Window == nil {
+		t.Error("Expected the window exported with the results")
+	}
+}
+
+func TestEngine_Run_StopsAtConvergence(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	segment := &entities.PopulationSegment{Name: "Savers"}
+	region.AddPopulationSegment(segment)
+	for _, money := range []float32{0, 100} {
+		person := entities.NewPerson("Person", money, 0)
+		person.AddSegment(segment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.Logger.SetEnabled(false)
+	engine.OnProgress = func(Progress) {}
+	engine.Convergence = &ConvergenceSettings{Window: 3, Tolerance: 0.01}
+
+	if gini := wealthGini(engine); gini != 0.5 {
+		t.Errorf("Expected a Gini of 0.5, got %.2f", gini)
+	}
+
+	// Nothing ever changes, so the first full window is steady
+	if err := engine.Run(context.Background(), 20); err != nil {
+		t.Fatalf("Expected run to succeed, got %v", err)
+	}
+	if engine.ConvergedAt != 3 || engine.CurrentTick != 3 {
+		t.Errorf("Expected the run to stop at tick 3, converged at %d, stopped at %d", engine.ConvergedAt, engine.CurrentTick)
+	}
+}
+
+func TestEngine_Step_GuardsInvalidValues(t *testing.T) {
+	newEngine := func(guards string) (*Engine, *entities.Person) {
+		region := entities.NewRegion("TestRegion")
+		segment := &entities.PopulationSegment{Name: "Savers"}
+		region.AddPopulationSegment(segment)
+		person := entities.NewPerson("Broken", float32(math.NaN()), 0)
+		person.AddSegment(segment)
+		region.AddPerson(person)
+		engine := CreateNewEngine(region)
+		engine.Logger.SetEnabled(false)
+		engine.Guards = guards
+		return engine, person
+	}
+
+	engine, person := newEngine(GuardWarn)
+	if err := engine.Step(context.Background()); err != nil {
+		t.Fatalf("Expected the warning guard to keep going, got %v", err)
+	}
+	if person.Money != 0 || engine.Clamped != 1 {
+		t.Errorf("Expected the NaN clamped to 0 once, got %v after %d clamps", person.Money, engine.Clamped)
+	}
+
+	engine, _ = newEngine(GuardAbort)
+	err := engine.Step(context.Background())
+	var divergence *DivergenceError
+	if !errors.As(err, &divergence) || divergence.Tick != 1 || divergence.Violations[0].Field != "money" {
+		t.Fatalf("Expected a divergence in Broken's money at tick 1, got %v", err)
+	}
+	checkpoint := engine.Checkpoint()
+	if len(checkpoint.Violations) != 1 {
+		t.Errorf("Expected the violation in the checkpoint, got %v", checkpoint.Violations)
+	}
+	if _, err := json.Marshal(checkpoint); err != nil {
+		t.Errorf("Expected the checkpoint to export, got %v", err)
+	}
+}
+
+func TestEngine_Run_ChecksAssertions(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	segment := &entities.PopulationSegment{Name: "Savers"}
+	region.AddPopulationSegment(segment)
+	for _, money := range []float32{0, 100} {
+		person := entities.NewPerson("Person", money, 0)
+		person.AddSegment(segment)
+		region.AddPerson(person)
+	}
+
+	engine := CreateNewEngine(region)
+	engine.Logger.SetEnabled(false)
+	engine.OnProgress = func(Progress) {}
+
+	if err := engine.SetAssertions([]Assertion{{Metric: "wealth", Op: "<", Value: 1}}); err == nil {
+		t.Error("Expected an unknown metric to be rejected")
+	}
+	err := engine.SetAssertions([]Assertion{
+		{Metric: "total_wealth", Op: ">=", Value: 100, From: 1},
+		{Metric: "gini", Op: "<", Value: 0.5, At: 2},
+		{Metric: "total_wealth", Op: "==", Value: 100},
+	})
+	if err == nil {
+		t.Error("Expected an unknown comparison to be rejected")
+	}
+	err = engine.SetAssertions([]Assertion{
+		{Metric: "total_wealth", Op: ">=", Value: 100, From: 1},
+		{Metric: "gini", Op: "<", Value: 0.5, At: 2},
+		{Metric: "unemployment", Op: "<", Value: 0.2, At: 10},
+	})
+	if err != nil {
+		t.Fatalf("Expected assertions to be accepted, got %v", err)
+	}
+
+	if err := engine.Run(context.Background(), 3); err != nil {
+		t.Fatalf("Expected run to succeed, got %v", err)
+	}
+	if len(engine.Failures) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", engine.Failures)
+	}
+	if failure := engine.Failures[0]; failure.Assertion != "gini < 0.5 at tick 2" || failure.Tick != 2 || failure.Value != 0.5 {
+		t.Errorf("Expected the Gini assertion to fail at tick 2, got %+v", failure)
+	}
+	if failure := engine.Failures[1]; failure.Reason != "run ended at tick 3" {
+		t.Errorf("Expected the tick 10 assertion to fail unreached, got %+v", failure)
+	}
+}
+
+// stubMarket sells nothing and counts the ticks it clears
+type stubMarket struct {
+	cleared    int
+	conditions MarketConditions
+}
+
+func (m *stubMarket) Clear(region *entities.Region, conditions MarketConditions) *market.MarketResult {
+	m.cleared++
+	m.conditions = conditions
+	return &market.MarketResult{PeopleUnsatisfied: len(region.People)}
+}
+
+// stubProduction makes a fixed number of units per shift
+type stubProduction struct {
+	industries []string
+}
+
+func (p *stubProduction) Produce(industry *entities.Industry, workers, hours, wage float32) *production.ProductionResult {
+	p.industries = append(p.industries, industry.Name)
+	return &production.ProductionResult{UnitsProduced: 7, LaborUsed: workers, LaborCost: workers * hours * wage}
+}
+
+func TestEngine_Step_UsesInjectedStrategies(t *testing.T) {
+	region := scenarios.Standard(50)
+	engine := CreateNewEngine(region)
+	engine.Logger.SetEnabled(false)
+	engine.Rationing = market.RationLottery
+	stubs := &stubMarket{}
+	model := &stubProduction{}
+	engine.Market = stubs
+	engine.Production = model
+
+	if err := engine.Step(context.Background()); err != nil {
+		t.Fatalf("Expected the tick to succeed, got %v", err)
+	}
+	if stubs.cleared != 1 || stubs.conditions.Rationing != market.RationLottery || stubs.conditions.Rand != engine.Rand {
+		t.Errorf("Expected the market to clear once with the engine's settings, got %d times with %+v", stubs.cleared, stubs.conditions)
+	}
+	if engine.LastTick.Market.Purchases != 0 || engine.LastTick.Market.PeopleUnsatisfied != len(region.People) {
+		t.Errorf("Expected the tick to record the stub market's result, got %+v", engine.LastTick.Market)
+	}
+	if len(model.industries) == 0 {
+		t.Fatal("Expected industries to produce with the injected model")
+	}
+	if units := engine.LastTick.Production.UnitsProduced; units <= 0 {
+		t.Errorf("Expected the model's units to be produced, got %.2f", units)
+	}
+}
+
+// weekProduction produces like the default model and records the hours of
+// each call
+type weekProduction struct {
+	hours []float32
+}
+
+func (p *weekProduction) Produce(industry *entities.Industry, workers, hours, wage float32) *production.ProductionResult {
+	p.hours = append(p.hours, hours)
+	return linearProduction{}.Produce(industry, workers, hours, wage)
+}
+
+func TestEngine_Step_RunsWeeklyTicks(t *testing.T) {
+	region := scenarios.Standard(50)
+	engine := CreateNewEngine(region)
+	engine.Logger.SetEnabled(false)
+	engine.Weekly = true
+	model := &weekProduction{}
+	engine.Production = model
+
+	if err := engine.Step(context.Background()); err != nil {
+		t.Fatalf("Expected the tick to succeed, got %v", err)
+	}
+	if len(model.hours) == 0 || len(model.hours)%engine.WeeksPerTick != 0 {
+		t.Fatalf("Expected every industry to produce once a week, got %d calls", len(model.hours))
+	}
+	for _, hours := range model.hours {
+		if hours != engine.HoursPerWeek {
+			t.Fatalf("Expected a week's %.0f hours per call, got %.0f", engine.HoursPerWeek, hours)
+		}
+	}
+	tick := engine.LastTick.Market
+	if tick.PeopleSatisfied+tick.PeopleUnsatisfied != len(region.People) {
+		t.Errorf("Expected everyone to shop once over the weeks, got %d satisfied and %d not of %d",
+			tick.PeopleSatisfied, tick.PeopleUnsatisfied, len(region.People))
+	}
+	if engine.LastTick.Production.UnitsProduced <= 0 || tick.Purchases == 0 {
+		t.Errorf("Expected the weeks' production and sales to add up, got %+v and %+v", engine.LastTick.Production, tick)
+	}
+}
+
+func TestEngine_SetParameter(t *testing.T) {
+	engine := CreateNewEngine(scenarios.Standard(50))
+	engine.Logger.SetEnabled(false)
+	recorder := events.NewRecorder(engine.Events)
+	if err := engine.Step(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := engine.SetParameter("sales_tax", 0.1); err == nil {
+		t.Error("Expected a sales tax without a government to be rejected")
+	}
+	engine.Government = government.NewGovernment(0, 0)
+	for _, bad := range []struct {
+		name  string
+		value float32
+	}{
+		{"wage", 0},
+		{"sales_tax", 1.5},
+		{"wage", float32(math.NaN())},
+		{"pension", float32(math.Inf(1))},
+		{"price_floor.Food", float32(math.Inf(-1))},
+		{"price_ceiling", 5},
+		{"price_ceiling.Nothing", 5},
+		{"wage.Food", 5},
+		{"interest", 0.1},
+	} {
+		if err := engine.SetParameter(bad.name, bad.value); err == nil {
+			t.Errorf("Expected %s = %g to be rejected", bad.name, bad.value)
+		}
+	}
+
+	for _, good := range []struct {
+		name  string
+		value float32
+	}{
+		{"wage", 12},
+		{"sales_tax", 0.2},
+		{"price_floor.Food", 3},
+		{"price_ceiling.Food", 8},
+	} {
+		if err := engine.SetParameter(good.name, good.value); err != nil {
+			t.Fatalf("Failed to set %s: %v", good.name, err)
+		}
+	}
+	if err := engine.SetParameter("price_ceiling.Food", 2); err == nil {
+		t.Error("Expected a ceiling under the floor to be rejected")
+	}
+
+	if engine.WagePerHour != 12 || engine.Government.SalesTaxRate != 0.2 {
+		t.Errorf("Expected wage 12 and sales tax 0.2, got %.2f and %.2f", engine.WagePerHour, engine.Government.SalesTaxRate)
+	}
+	if control := engine.Government.PriceControls["Food"]; control.Floor != 3 || control.Ceiling != 8 {
+		t.Errorf("Expected Food between 3 and 8, got %+v", control)
+	}
+	if len(engine.Changes) != 4 || engine.Changes[0] != (ParameterChange{Tick: 1, Parameter: "wage", From: 10, To: 12}) {
+		t.Errorf("Expected four changes starting with the wage, got %+v", engine.Changes)
+	}
+	if got := events.Count[events.ParameterChanged](recorder); got != 4 {
+		t.Errorf("Expected 4 change events, got %d", got)
+	}
+	if results := engine.Results(); len(results.Changes) != 4 {
+		t.Errorf("Expected the results to carry the changes, got %+v", results.Changes)
+	}
+
+	// Clearing both bounds drops the control
+	engine.SetParameter("price_floor.Food", 0)
+	engine.SetParameter("price_ceiling.Food", 0)
+	if _, ok := engine.Government.PriceControls["Food"]; ok {
+		t.Error("Expected Food's price control dropped with both bounds cleared")
+	}
 }
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"maps"
+	"math/rand/v2"
+
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/events"
+	"westex/engines/economy/pkg/finance"
+	"westex/engines/economy/pkg/insurance"
+	"westex/engines/economy/pkg/market"
+	"westex/engines/economy/pkg/shocks"
+	"westex/engines/economy/pkg/welfare"
+)
+
+// Fork returns an independent copy of the engine at the current tick. The
+// fork gets a deep copy of the region and of every subsystem, continues the
+// same random stream and has its own event bus and logger, so each branch can
+// be given a different policy and run forward without affecting the other.
+// The fork's logger starts with the engine's settings and output; give it an
+// output of its own with SetOutput to keep the branches' logs apart.
+func (e *Engine) Fork() *Engine {
+	region := e.Region.Clone()
+
+	fork := &Engine{
+		Region:       region,
+		Logger:       e.Logger.Clone(),
+		CurrentTick:  e.CurrentTick,
+		WagePerHour:  e.WagePerHour,
+		WeeksPerTick: e.WeeksPerTick,
+		HoursPerWeek: e.HoursPerWeek,
+		InitialState: e.InitialState,
+
+		DemandAdjustmentRate: e.DemandAdjustmentRate,
+		MarketMode:           e.MarketMode,
+		Clock:                e.Clock,
+		Units:                e.Units,
+		Market:               e.Market,
+		Production:           e.Production,
+		ProfitMargin:         e.ProfitMargin,
+		Rationing:            e.Rationing,
+		QueueOrder:           e.QueueOrder,
+		MaxOvertime:          e.MaxOvertime,
+		WageTiming:           e.WageTiming,
+		SkillPay:             e.SkillPay,
+		MultipleJobs:         e.MultipleJobs,
+		InputAllocation:      e.InputAllocation,
+		Weekly:               e.Weekly,
+		phases:               e.phases,
+		WarmUp:               e.WarmUp,
+		MeasureTicks:         e.MeasureTicks,
+		measured:             append([]*TickResult(nil), e.measured...),
+		LastTick:             e.LastTick,
+		ConvergedAt:          e.ConvergedAt,
+		Guards:               e.Guards,
+		Clamped:              e.Clamped,
+		Assertions:           e.Assertions,
+		Failures:             append([]AssertionFailure(nil), e.Failures...),
+		Changes:              append([]ParameterChange(nil), e.Changes...),
+		failed:               append([]bool(nil), e.failed...),
+		steady:               append([]steadyMetrics(nil), e.steady...),
+		HistoryLength:        e.HistoryLength,
+		LoyalShoppers:        e.LoyalShoppers,
+		ServeSnapshots:       e.ServeSnapshots,
+		Seed:                 e.Seed,
+		Events:               events.NewBus(),
+	}
+	fork.Logger.Subscribe(fork.Events)
+	fork.lastJobs = maps.Clone(e.lastJobs)
+
+	source := *e.source
+	fork.source = &source
+	fork.Rand = rand.New(fork.source)
+
+	bank := *e.Bank
+	fork.Bank = &bank
+	if e.CentralBank != nil {
+		centralBank := *e.CentralBank
+		centralBank.Interventions = append([]finance.Intervention(nil), e.CentralBank.Interventions...)
+		centralBank.History = append([]finance.MoneySupplyRecord(nil), e.CentralBank.History...)
+		if e.CentralBank.Bank == e.Bank {
+			centralBank.Bank = fork.Bank
+		} else {
+			centralBankBank := *e.CentralBank.Bank
+			centralBank.Bank = &centralBankBank
+		}
+		fork.CentralBank = &centralBank
+	}
+	if e.Government != nil {
+		government := *e.Government
+		if e.Government.Reserve != nil {
+			government.Reserve = e.Government.Reserve.Clone()
+		}
+		if e.Government.PriceControls != nil {
+			government.PriceControls = make(market.PriceControls, len(e.Government.PriceControls))
+			for product, control := range e.Government.PriceControls {
+				government.PriceControls[product] = control
+			}
+		}
+		if e.Government.Unemployment != nil {
+			government.Unemployment = e.Government.Unemployment.Clone()
+		}
+		if e.Government.Pension != nil {
+			pension := *e.Government.Pension
+			government.Pension = &pension
+		}
+		fork.Government = &government
+	}
+	if e.Informal != nil {
+		informal := *e.Informal
+		fork.Informal = &informal
+	}
+	if e.Barter != nil {
+		barter := *e.Barter
+		fork.Barter = &barter
+	}
+	if e.Transitions != nil {
+		fork.Transitions = e.Transitions.Clone()
+	}
+	if e.Forecasting != nil {
+		forecaster := *e.Forecasting
+		fork.Forecasting = &forecaster
+	}
+	if e.Welfare != nil {
+		utility := *e.Welfare
+		fork.Welfare = &utility
+		fork.WelfareHistory = append([]welfare.Report(nil), e.WelfareHistory...)
+	}
+	if e.Marketing != nil {
+		marketing := *e.Marketing
+		fork.Marketing = &marketing
+	}
+	if e.Convergence != nil {
+		convergence := *e.Convergence
+		fork.Convergence = &convergence
+	}
+	if e.Trajectories != nil {
+		fork.Trajectories = e.Trajectories.Clone()
+	}
+	if e.Cohorts != nil {
+		fork.Cohorts = e.Cohorts.Clone()
+	}
+	if e.Matrices != nil {
+		fork.Matrices = e.Matrices.Clone()
+	}
+	if e.History != nil {
+		fork.History = e.History.Clone()
+	}
+
+	industries := make(map[int]*entities.Industry, len(region.Industries))
+	for _, industry := range region.Industries {
+		industries[industry.ID] = industry
+	}
+	people := make(map[int]*entities.Person, len(region.People))
+	for _, person := range region.People {
+		people[person.ID] = person
+	}
+
+	// The last market is read by the reserve release and by trade between
+	// regions in the fork's first tick
+	if e.lastMarket != nil {
+		problems := make(map[int]*entities.Problem, len(region.Problems))
+		for _, problem := range region.Problems {
+			problems[problem.ID] = problem
+		}
+		fork.lastMarket = e.lastMarket.Clone(people, problems)
+	}
+
+	fork.Shocks = make([]*shocks.Shock, len(e.Shocks))
+	for i, shock := range e.Shocks {
+		copied := *shock
+		if shock.Industry != nil {
+			copied.Industry = industries[shock.Industry.ID]
+		}
+		if shock.Segment != nil {
+			copied.Segment = region.GetPopulationSegment(shock.Segment.Name)
+		}
+		fork.Shocks[i] = &copied
+	}
+
+	fork.Insurers = make([]*insurance.Insurer, len(e.Insurers))
+	for i, insurer := range e.Insurers {
+		copied := *insurer
+		copied.Policies = make([]*insurance.Policy, len(insurer.Policies))
+		for j, policy := range insurer.Policies {
+			policyCopy := *policy
+			if policy.Person != nil {
+				policyCopy.Person = people[policy.Person.ID]
+			}
+			if policy.Industry != nil {
+				policyCopy.Industry = industries[policy.Industry.ID]
+			}
+			copied.Policies[j] = &policyCopy
+		}
+		fork.Insurers[i] = &copied
+	}
+
+	return fork
+}
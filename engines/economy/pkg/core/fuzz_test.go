@@ -0,0 +1,135 @@
+package core
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"westex/engines/economy/pkg/config"
+	"westex/engines/economy/pkg/logging"
+	"westex/engines/economy/pkg/production"
+)
+
+// FuzzSimulation runs short simulations of random generated regions, with
+// edge cases mixed in by the flags, and checks the invariants every run must
+// keep: no panics, no NaN, infinite or negative money and stock, and no money
+// made or lost outside the known sources and sinks. Run it with
+// go test -fuzz Simulation ./pkg/core.
+func FuzzSimulation(f *testing.F) {
+	f.Add(uint64(1), float32(0.5), float32(0.5), uint16(0))
+	f.Add(uint64(2), float32(0), float32(0), uint16(0xffff))
+	f.Add(uint64(3), float32(1), float32(1), uint16(0x0155))
+	f.Add(uint64(4), float32(0.3), float32(0.8), uint16(0x02aa))
+	f.Add(uint64(5), float32(0.6), float32(0.4), uint16(0x0900))
+	f.Add(uint64(6), float32(0.2), float32(0.1), uint16(0x1004))
+
+	f.Fuzz(func(t *testing.T, seed uint64, development, richness float32, flags uint16) {
+		development = float32(math.Abs(math.Mod(float64(development), 1)))
+		richness = float32(math.Abs(math.Mod(float64(richness), 1)))
+		if math.IsNaN(float64(development)) || math.IsNaN(float64(richness)) {
+			t.Skip()
+		}
+		cfg, err := config.Generate(config.GenerateOptions{
+			Population:  20 + int(seed%80),
+			Development: development,
+			Richness:    richness,
+			Seed:        seed,
+		})
+		if err != nil {
+			t.Fatalf("Failed to generate a region: %v", err)
+		}
+		mutateConfig(cfg, flags)
+
+		region, err := config.BuildRegionFromConfig(cfg)
+		if err != nil {
+			t.Fatalf("Failed to build a generated region: %v", err)
+		}
+		engine := NewEngine(region,
+			WithWage(cfg.Simulation.WagePerHour),
+			WithTickLength(cfg.Simulation.WeeksPerTick, cfg.Simulation.HoursPerWeek),
+			WithLogger(logging.NewLogger(false)),
+			WithSeed(seed),
+		)
+		engine.Guards = GuardAbort
+		configureEngine(engine, flags)
+
+		money := engine.moneyHeld()
+		for tick := 1; tick <= 8; tick++ {
+			if err := engine.Step(context.Background()); err != nil {
+				t.Fatalf("Tick %d (flags %#x): %v", tick, flags, err)
+			}
+			held := engine.moneyHeld()
+			if drift := math.Abs(float64(held - money)); drift > 1e-3*math.Max(1, float64(money)) {
+				t.Fatalf("Tick %d (flags %#x): money went from %.2f to %.2f with no source or sink", tick, flags, money, held)
+			}
+			money = held
+		}
+	})
+}
+
+// mutateConfig mixes edge cases into a generated config, one per flag bit
+func mutateConfig(cfg *config.RegionConfig, flags uint16) {
+	if flags&0x01 != 0 && len(cfg.Industries) > 0 {
+		cfg.Industries[0].LaborNeeded = 0
+	}
+	if flags&0x02 != 0 {
+		cfg.Population.Segments = append(cfg.Population.Segments, config.PopulationSegmentConfig{Name: "Empty"})
+	}
+	if flags&0x04 != 0 {
+		for i := range cfg.Industries {
+			cfg.Industries[i].InitialCapital = 0
+		}
+	}
+	if flags&0x08 != 0 {
+		for i := range cfg.Population.Segments {
+			cfg.Population.Segments[i].InitialMoney = 0
+		}
+	}
+	if flags&0x10 != 0 {
+		for i := range cfg.Resources {
+			cfg.Resources[i].InitialQuantity = 0
+			cfg.Resources[i].RegenerationRate = 0
+		}
+	}
+	if flags&0x20 != 0 && len(cfg.Industries) > 1 {
+		cfg.Industries[1].Shifts = 3
+	}
+}
+
+// configureEngine switches on the engine modes named by the flags' high bits
+func configureEngine(engine *Engine, flags uint16) {
+	if flags&0x40 != 0 {
+		engine.MarketMode = "orderbook"
+	}
+	if flags&0x80 != 0 {
+		engine.Rationing = "lottery"
+	}
+	if flags&0x100 != 0 {
+		engine.WageTiming = production.PayAfterSales
+	}
+	if flags&0x200 != 0 {
+		engine.MultipleJobs = true
+	}
+	if flags&0x400 != 0 {
+		engine.MaxOvertime = 0.5
+	}
+	if flags&0x800 != 0 {
+		engine.Weekly = true
+	}
+	if flags&0x1000 != 0 {
+		engine.InputAllocation = production.AllocateAuction
+	}
+}
+
+// moneyHeld is all the money in the region: industries' and people's money
+// and people's savings in the bank
+func (e *Engine) moneyHeld() float32 {
+	held := float32(0)
+	for _, industry := range e.Region.Industries {
+		held += industry.Money
+	}
+	for _, person := range e.Region.People {
+		held += person.Money + person.Savings
+	}
+	return held
+}
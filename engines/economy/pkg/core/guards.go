@@ -0,0 +1,79 @@
+package core
+
+import (
+	"fmt"
+	"math"
+)
+
+// Guard modes, for values that go NaN, infinite or negative where they can't
+const (
+	GuardWarn  = "warn"  // Clamp them to 0 and log a warning
+	GuardAbort = "abort" // Stop the run with the list of them
+)
+
+// Violation is a value found NaN, infinite or negative at the end of a tick
+type Violation struct {
+	Entity string `json:"entity"`
+	Field  string `json:"field"`
+	Value  string `json:"value"` // As printed, since JSON has no NaN or Inf
+}
+
+// DivergenceError stops a run whose state went invalid under GuardAbort
+type DivergenceError struct {
+	Tick       int
+	Violations []Violation
+}
+
+func (e *DivergenceError) Error() string {
+	first := e.Violations[0]
+	return fmt.Sprintf("tick %d: %d invalid values, first %s %s = %s",
+		e.Tick, len(e.Violations), first.Entity, first.Field, first.Value)
+}
+
+// checkGuards looks for invalid values in the state after a tick and clamps
+// them to 0. Under GuardAbort it returns them as a DivergenceError; the
+// clamping keeps the state exportable for diagnosis.
+func (e *Engine) checkGuards() error {
+	var violations []Violation
+	check := func(entity, field string, value *float32, canBeNegative bool) {
+		v := float64(*value)
+		if !math.IsNaN(v) && !math.IsInf(v, 0) && (canBeNegative || v >= 0) {
+			return
+		}
+		violations = append(violations, Violation{Entity: entity, Field: field, Value: fmt.Sprint(*value)})
+		*value = 0
+	}
+
+	for _, industry := range e.Region.Industries {
+		check(industry.Name, "money", &industry.Money, false)
+		for _, product := range industry.OutputProducts {
+			check(industry.Name, product.Name+" stock", &product.Quantity, false)
+		}
+	}
+	for _, person := range e.Region.People {
+		check(person.Name, "money", &person.Money, false)
+		check(person.Name, "savings", &person.Savings, false)
+	}
+	for _, resource := range e.Region.Resources {
+		check(resource.Name, "quantity", &resource.Quantity, false)
+	}
+	for _, problem := range e.Region.Problems {
+		check(problem.Name, "demand", &problem.Demand, false)
+	}
+	if e.Government != nil {
+		check("government", "treasury", &e.Government.Treasury, true)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	e.Clamped += len(violations)
+	if e.Guards == GuardAbort {
+		e.violations = violations
+		return &DivergenceError{Tick: e.CurrentTick, Violations: violations}
+	}
+	for _, v := range violations {
+		e.Logger.LogWarning(fmt.Sprintf("Tick %d: %s %s was %s, clamped to 0", e.CurrentTick, v.Entity, v.Field, v.Value))
+	}
+	return nil
+}
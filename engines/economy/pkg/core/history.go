@@ -0,0 +1,93 @@
+package core
+
+import (
+	"maps"
+	"slices"
+
+	"westex/engines/economy/pkg/market"
+)
+
+// History keeps a few headline figures for every tick, enough to chart how a
+// run went after it finished
+type History struct {
+	Ticks []HistoryTick `json:"ticks"`
+}
+
+// HistoryTick is the state of the economy at the end of one tick
+type HistoryTick struct {
+	Tick          int                `json:"tick"`
+	TotalWealth   float32            `json:"total_wealth"`
+	PeopleWealth  float32            `json:"people_wealth"`
+	IndustryMoney float32            `json:"industry_money"`
+	People        int                `json:"people"`
+	Employed      float32            `json:"employed"`               // Share of people who worked this tick
+	Satisfaction  *float32           `json:"satisfaction,omitempty"` // Average share of needs met, in ticks with a product market
+	Prices        map[string]float32 `json:"prices,omitempty"`       // Average price each industry sold at, by industry
+}
+
+// TrackHistory records the headline figures of every tick from the next on
+func (e *Engine) TrackHistory() {
+	e.History = &History{}
+}
+
+// Clone returns an independent copy of the history so far
+func (h *History) Clone() *History {
+	clone := &History{Ticks: slices.Clone(h.Ticks)}
+	for i, tick := range clone.Ticks {
+		clone.Ticks[i].Prices = maps.Clone(tick.Prices)
+		if tick.Satisfaction != nil {
+			satisfaction := *tick.Satisfaction
+			clone.Ticks[i].Satisfaction = &satisfaction
+		}
+	}
+	return clone
+}
+
+// recordHistory adds the tick's wealth, employment, satisfaction and prices
+func (e *Engine) recordHistory(result *market.MarketResult) {
+	row := HistoryTick{Tick: e.CurrentTick, People: len(e.Region.People)}
+	for _, industry := range e.Region.Industries {
+		row.IndustryMoney += industry.Money
+	}
+	for _, person := range e.Region.People {
+		row.PeopleWealth += person.Wealth()
+		if len(e.lastJobs[person.ID]) > 0 {
+			row.Employed++
+		}
+	}
+	row.TotalWealth = row.PeopleWealth + row.IndustryMoney
+
+	if result != nil {
+		row.Prices = averagePrices(result)
+		satisfaction := float32(0)
+		for _, met := range market.NeedsMet(e.Region, result) {
+			satisfaction += met
+		}
+		if row.People > 0 {
+			satisfaction /= float32(row.People)
+		}
+		row.Satisfaction = &satisfaction
+	}
+	if row.People > 0 {
+		row.Employed /= float32(row.People)
+	}
+	e.History.Ticks = append(e.History.Ticks, row)
+}
+
+// averagePrices returns the average price each industry sold at in the
+// market, leaving out industries that sold nothing
+func averagePrices(result *market.MarketResult) map[string]float32 {
+	units := make(map[string]float32)
+	spent := make(map[string]float32)
+	for _, purchase := range result.Purchases {
+		units[purchase.IndustryName] += purchase.Quantity
+		spent[purchase.IndustryName] += purchase.TotalCost
+	}
+	prices := make(map[string]float32, len(units))
+	for industry, sold := range units {
+		if sold > 0 {
+			prices[industry] = spent[industry] / sold
+		}
+	}
+	return prices
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"slices"
+
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/events"
+	"westex/engines/economy/pkg/transitions"
+)
+
+// recordJobs notes that residents worked for an industry this tick
+func (e *Engine) recordJobs(industry *entities.Industry, residents []*entities.Person) {
+	if e.jobs == nil {
+		e.jobs = make(map[int][]string)
+	}
+	for _, person := range residents {
+		if !slices.Contains(e.jobs[person.ID], industry.Name) {
+			e.jobs[person.ID] = append(e.jobs[person.ID], industry.Name)
+		}
+	}
+}
+
+// publishJobChanges publishes who started working for an industry this tick
+// and who stopped, against last tick, then keeps this tick's jobs to compare
+// the next with
+func (e *Engine) publishJobChanges() {
+	for _, person := range e.Region.People {
+		now, before := e.jobs[person.ID], e.lastJobs[person.ID]
+		for _, industry := range now {
+			if !slices.Contains(before, industry) {
+				e.Events.Publish(events.PersonHired{
+					Tick:     e.CurrentTick,
+					PersonID: person.ID,
+					Person:   person.Name,
+					Industry: industry,
+				})
+			}
+		}
+		for _, industry := range before {
+			if !slices.Contains(now, industry) {
+				e.Events.Publish(events.PersonFired{
+					Tick:     e.CurrentTick,
+					PersonID: person.ID,
+					Person:   person.Name,
+					Industry: industry,
+				})
+			}
+		}
+	}
+	e.lastJobs, e.jobs = e.jobs, e.lastJobs
+	clear(e.jobs)
+}
+
+// solventPeople returns the IDs of the people with any wealth, to tell at the
+// end of a tick who went broke during it
+func (e *Engine) solventPeople() map[int]bool {
+	solvent := make(map[int]bool, len(e.Region.People))
+	for _, person := range e.Region.People {
+		if person.Wealth() > 0 {
+			solvent[person.ID] = true
+		}
+	}
+	return solvent
+}
+
+// publishBankruptcies publishes everyone who had wealth at the start of the
+// tick and has none left
+func (e *Engine) publishBankruptcies(solvent map[int]bool) {
+	for _, person := range e.Region.People {
+		if solvent[person.ID] && person.Wealth() <= 0 {
+			e.Events.Publish(events.PersonBankrupt{
+				Tick:     e.CurrentTick,
+				PersonID: person.ID,
+				Person:   person.Name,
+				Money:    person.Money,
+			})
+		}
+	}
+}
+
+// publishRetirements publishes the segment moves that retired someone
+func (e *Engine) publishRetirements(moves []transitions.Move) {
+	for _, move := range moves {
+		to, from := e.Region.GetPopulationSegment(move.To), e.Region.GetPopulationSegment(move.From)
+		if to == nil || !to.Retired || from != nil && from.Retired {
+			continue
+		}
+		e.Events.Publish(events.PersonRetired{
+			Tick:     e.CurrentTick,
+			PersonID: move.Person.ID,
+			Person:   move.Person.Name,
+			From:     move.From,
+			To:       move.To,
+		})
+	}
+}
+
+// RemovePerson takes a person out of the region for good: they died, or
+// they migrated to the region named by to. Their cash and savings leave
+// with them. Returns false if the person is not in the region.
+func (e *Engine) RemovePerson(person *entities.Person, to string) bool {
+	wealth := person.Wealth()
+	if !e.Region.RemovePerson(person) {
+		return false
+	}
+	delete(e.jobs, person.ID)
+	delete(e.lastJobs, person.ID)
+	e.idle = withoutPeople(e.idle, []*entities.Person{person})
+
+	if to == "" {
+		e.Events.Publish(events.PersonDied{
+			Tick:     e.CurrentTick,
+			PersonID: person.ID,
+			Person:   person.Name,
+			Wealth:   wealth,
+		})
+		return true
+	}
+	e.Events.Publish(events.PersonMigrated{
+		Tick:     e.CurrentTick,
+		PersonID: person.ID,
+		Person:   person.Name,
+		From:     e.Region.Name,
+		To:       to,
+		Wealth:   wealth,
+	})
+	return true
+}
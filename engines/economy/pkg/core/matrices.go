@@ -0,0 +1,177 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"westex/engines/economy/pkg/market"
+)
+
+// Files the heatmap matrices are exported to, one per measure
+const (
+	OutputMatrixFile = "output_by_industry.csv"
+	PriceMatrixFile  = "price_by_industry.csv"
+	UnmetMatrixFile  = "unmet_by_problem.csv"
+)
+
+// Heatmaps records measures tick by tick for every industry or problem,
+// laid out for plotting tools to draw as heatmaps
+type Heatmaps struct {
+	Output *Matrix `json:"output"` // Units each industry produced
+	Price  *Matrix `json:"price"`  // Average price each industry sold at, missing when it sold nothing
+	Unmet  *Matrix `json:"unmet"`  // Units of each need shoppers wanted but went without
+}
+
+// Matrix is one measure with a row per tick and a column per industry or
+// problem. Missing values are NaN, exported as null in JSON and empty cells
+// in CSV.
+type Matrix struct {
+	Columns []string
+	Ticks   []int
+	Values  [][]float32 // Values[row][column]
+}
+
+// TrackMatrices records the heatmap matrices from the next tick on
+func (e *Engine) TrackMatrices() {
+	e.Matrices = &Heatmaps{Output: &Matrix{}, Price: &Matrix{}, Unmet: &Matrix{}}
+}
+
+// Clone returns an independent copy of the matrices so far
+func (h *Heatmaps) Clone() *Heatmaps {
+	return &Heatmaps{Output: h.Output.clone(), Price: h.Price.clone(), Unmet: h.Unmet.clone()}
+}
+
+func (m *Matrix) clone() *Matrix {
+	clone := &Matrix{Columns: slices.Clone(m.Columns), Ticks: slices.Clone(m.Ticks)}
+	for _, row := range m.Values {
+		clone.Values = append(clone.Values, slices.Clone(row))
+	}
+	return clone
+}
+
+// add appends a tick's row, adding columns for names not seen before.
+// Columns the row has no value for are missing.
+func (m *Matrix) add(tick int, names []string, values []float32) {
+	row := make([]float32, len(m.Columns))
+	for i := range row {
+		row[i] = float32(math.NaN())
+	}
+	for i, name := range names {
+		column := slices.Index(m.Columns, name)
+		if column < 0 {
+			m.Columns = append(m.Columns, name)
+			for j := range m.Values {
+				m.Values[j] = append(m.Values[j], float32(math.NaN()))
+			}
+			row = append(row, 0)
+			column = len(row) - 1
+		}
+		row[column] = values[i]
+	}
+	m.Ticks = append(m.Ticks, tick)
+	m.Values = append(m.Values, row)
+}
+
+// recordMatrices adds the tick's output and prices by industry and unmet
+// needs by problem. Without a product market this tick, prices and unmet
+// needs are missing.
+func (e *Engine) recordMatrices(production ProductionPhaseResult, result *market.MarketResult) {
+	names := make([]string, len(production.Industries))
+	output := make([]float32, len(production.Industries))
+	for i, industry := range production.Industries {
+		names[i], output[i] = industry.Industry, industry.Units
+	}
+	e.Matrices.Output.add(e.CurrentTick, names, output)
+
+	names = make([]string, len(e.Region.Industries))
+	prices := make([]float32, len(e.Region.Industries))
+	var sold map[string]float32
+	if result != nil {
+		sold = averagePrices(result)
+	}
+	for i, industry := range e.Region.Industries {
+		names[i], prices[i] = industry.Name, float32(math.NaN())
+		if price, ok := sold[industry.Name]; ok {
+			prices[i] = price
+		}
+	}
+	e.Matrices.Price.add(e.CurrentTick, names, prices)
+
+	names = make([]string, len(e.Region.Problems))
+	unmet := make([]float32, len(e.Region.Problems))
+	for i, problem := range e.Region.Problems {
+		names[i], unmet[i] = problem.Name, float32(math.NaN())
+		if result == nil {
+			continue
+		}
+		unmet[i] = 0
+		if stats, ok := result.NeedStats[problem.ID]; ok {
+			unmet[i] = max(float32(stats.UnitsWanted)-stats.UnitsMet, 0)
+		}
+	}
+	e.Matrices.Unmet.add(e.CurrentTick, names, unmet)
+}
+
+// MarshalJSON writes the matrix with missing values as null
+func (m *Matrix) MarshalJSON() ([]byte, error) {
+	values := make([][]*float32, len(m.Values))
+	for i, row := range m.Values {
+		values[i] = make([]*float32, len(row))
+		for j := range row {
+			if !math.IsNaN(float64(row[j])) {
+				values[i][j] = &row[j]
+			}
+		}
+	}
+	return json.Marshal(struct {
+		Columns []string     `json:"columns"`
+		Ticks   []int        `json:"ticks"`
+		Values  [][]*float32 `json:"values"`
+	}{m.Columns, m.Ticks, values})
+}
+
+// SaveMatricesCSV writes each matrix into its own CSV file in a directory,
+// with a tick column and a column per industry or problem
+func SaveMatricesCSV(dir string, heatmaps *Heatmaps) error {
+	files := []string{OutputMatrixFile, PriceMatrixFile, UnmetMatrixFile}
+	for i, matrix := range []*Matrix{heatmaps.Output, heatmaps.Price, heatmaps.Unmet} {
+		if err := saveMatrixCSV(filepath.Join(dir, files[i]), matrix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func saveMatrixCSV(path string, matrix *Matrix) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Base(path), err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	w.Write(append([]string{"tick"}, matrix.Columns...))
+	for i, row := range matrix.Values {
+		record := make([]string, 0, len(row)+1)
+		record = append(record, strconv.Itoa(matrix.Ticks[i]))
+		for _, value := range row {
+			if math.IsNaN(float64(value)) {
+				record = append(record, "")
+				continue
+			}
+			record = append(record, formatFloat(value))
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"westex/engines/economy/pkg/events"
+	"westex/engines/economy/pkg/market"
+)
+
+// ParameterChange is a setting changed between ticks
+type ParameterChange struct {
+	Tick      int     `json:"tick"` // The last tick run with the old value
+	Parameter string  `json:"parameter"`
+	From      float32 `json:"from"`
+	To        float32 `json:"to"`
+}
+
+// Parameter is a setting that can be changed between ticks. Price controls
+// take the product after a dot, as in price_ceiling.Bread.
+type Parameter struct {
+	Name        string
+	Description string
+	Min, Max    float32 // Range of valid values; Max 0 = no upper bound
+
+	get func(e *Engine, product string) (float32, error)
+	set func(e *Engine, product string, value float32)
+}
+
+// Parameters lists the settings SetParameter can change
+var Parameters = []Parameter{
+	{
+		Name: "wage", Description: "Hourly wage rate", Min: 0.01,
+		get: func(e *Engine, _ string) (float32, error) { return e.WagePerHour, nil },
+		set: func(e *Engine, _ string, value float32) { e.WagePerHour = value },
+	},
+	{
+		Name: "profit_margin", Description: "Markup over cost industries ask in order-book mode",
+		get: func(e *Engine, _ string) (float32, error) { return e.ProfitMargin, nil },
+		set: func(e *Engine, _ string, value float32) { e.ProfitMargin = value },
+	},
+	{
+		Name: "demand_adjustment", Description: "Share of the gap to target demand closed each tick", Max: 1,
+		get: func(e *Engine, _ string) (float32, error) { return e.DemandAdjustmentRate, nil },
+		set: func(e *Engine, _ string, value float32) { e.DemandAdjustmentRate = value },
+	},
+	{
+		Name: "sales_tax", Description: "Share of formal sales revenue paid as tax", Max: 1,
+		get: func(e *Engine, _ string) (float32, error) {
+			if e.Government == nil {
+				return 0, fmt.Errorf("there is no government to tax sales")
+			}
+			return e.Government.SalesTaxRate, nil
+		},
+		set: func(e *Engine, _ string, value float32) { e.Government.SalesTaxRate = value },
+	},
+	{
+		Name: "payroll_tax", Description: "Share of wage bills paid for unemployment insurance", Max: 1,
+		get: func(e *Engine, _ string) (float32, error) {
+			if e.Government == nil || e.Government.Unemployment == nil {
+				return 0, fmt.Errorf("there is no unemployment insurance to fund")
+			}
+			return e.Government.Unemployment.PayrollTaxRate, nil
+		},
+		set: func(e *Engine, _ string, value float32) { e.Government.Unemployment.PayrollTaxRate = value },
+	},
+	{
+		Name: "pension", Description: "Pension paid to each retiree per tick",
+		get: func(e *Engine, _ string) (float32, error) {
+			if e.Government == nil || e.Government.Pension == nil {
+				return 0, fmt.Errorf("there is no pension")
+			}
+			return e.Government.Pension.Amount, nil
+		},
+		set: func(e *Engine, _ string, value float32) { e.Government.Pension.Amount = value },
+	},
+	{
+		Name: "price_ceiling", Description: "Highest price a product may sell at (0 = none)",
+		get: func(e *Engine, product string) (float32, error) {
+			control, err := e.priceControl(product)
+			return control.Ceiling, err
+		},
+		set: func(e *Engine, product string, value float32) {
+			control, _ := e.priceControl(product)
+			control.Ceiling = value
+			e.setPriceControl(product, control)
+		},
+	},
+	{
+		Name: "price_floor", Description: "Lowest price a product may sell at (0 = none)",
+		get: func(e *Engine, product string) (float32, error) {
+			control, err := e.priceControl(product)
+			return control.Floor, err
+		},
+		set: func(e *Engine, product string, value float32) {
+			control, _ := e.priceControl(product)
+			control.Floor = value
+			e.setPriceControl(product, control)
+		},
+	},
+}
+
+// lookupParameter finds a parameter by name, splitting off the product of a
+// price control
+func lookupParameter(name string) (*Parameter, string, error) {
+	base, product, _ := strings.Cut(name, ".")
+	for i := range Parameters {
+		parameter := &Parameters[i]
+		if parameter.Name != base {
+			continue
+		}
+		perProduct := strings.HasPrefix(base, "price_")
+		if perProduct && product == "" {
+			return nil, "", fmt.Errorf("%s needs a product, as in %s.Bread", base, base)
+		}
+		if !perProduct && product != "" {
+			return nil, "", fmt.Errorf("%s does not take a product", base)
+		}
+		return parameter, product, nil
+	}
+	return nil, "", fmt.Errorf("unknown parameter %s", name)
+}
+
+// GetParameter returns a parameter's current value
+func (e *Engine) GetParameter(name string) (float32, error) {
+	parameter, product, err := lookupParameter(name)
+	if err != nil {
+		return 0, err
+	}
+	return parameter.get(e, product)
+}
+
+// SetParameter changes a parameter from the next tick on, after checking the
+// value is a finite number in range. Every change is kept in Changes,
+// exported with the results, and published on the bus.
+func (e *Engine) SetParameter(name string, value float32) error {
+	parameter, product, err := lookupParameter(name)
+	if err != nil {
+		return err
+	}
+	from, err := parameter.get(e, product)
+	if err != nil {
+		return err
+	}
+	if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+		return fmt.Errorf("%s must be a finite number, got %g", name, value)
+	}
+	if value < parameter.Min || parameter.Max > 0 && value > parameter.Max {
+		if parameter.Max > 0 {
+			return fmt.Errorf("%s must be between %g and %g, got %g", name, parameter.Min, parameter.Max, value)
+		}
+		return fmt.Errorf("%s must be at least %g, got %g", name, parameter.Min, value)
+	}
+	if err := e.checkPriceControl(parameter.Name, product, value); err != nil {
+		return err
+	}
+
+	parameter.set(e, product, value)
+	change := ParameterChange{Tick: e.CurrentTick, Parameter: name, From: from, To: value}
+	e.Changes = append(e.Changes, change)
+	e.Events.Publish(events.ParameterChanged{Tick: e.CurrentTick, Parameter: name, From: from, To: value})
+	return nil
+}
+
+// priceControl returns the controls on a product the region makes
+func (e *Engine) priceControl(product string) (market.PriceControl, error) {
+	if e.Government == nil {
+		return market.PriceControl{}, fmt.Errorf("there is no government to control prices")
+	}
+	for _, industry := range e.Region.Industries {
+		if industry.OutputProducts.Find(product) != nil {
+			return e.Government.PriceControls[product], nil
+		}
+	}
+	return market.PriceControl{}, fmt.Errorf("no industry makes %s", product)
+}
+
+// setPriceControl stores a product's controls, dropping them once neither
+// bound is set
+func (e *Engine) setPriceControl(product string, control market.PriceControl) {
+	if control.Ceiling == 0 && control.Floor == 0 {
+		delete(e.Government.PriceControls, product)
+		return
+	}
+	if e.Government.PriceControls == nil {
+		e.Government.PriceControls = make(market.PriceControls)
+	}
+	e.Government.PriceControls[product] = control
+}
+
+// checkPriceControl keeps a product's floor at or below its ceiling
+func (e *Engine) checkPriceControl(name, product string, value float32) error {
+	if product == "" {
+		return nil
+	}
+	control, _ := e.priceControl(product)
+	if name == "price_ceiling" {
+		control.Ceiling = value
+	} else {
+		control.Floor = value
+	}
+	if control.Ceiling > 0 && control.Floor > control.Ceiling {
+		return fmt.Errorf("%s's price floor %g would be above its ceiling %g", product, control.Floor, control.Ceiling)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/market"
+	"westex/engines/economy/pkg/production"
+)
+
+// tickState carries what phases hand on to later ones within a tick
+type tickState struct {
+	result         *TickResult
+	hoursAvailable float32
+	students       []*entities.Person
+	openingMoney   map[int]float32 // Industries' money at the start of the tick
+	market         *market.MarketResult
+}
+
+// tickPhase is one step of the tick pipeline
+type tickPhase struct {
+	name  string
+	title string   // Logged when the phase runs (empty = nothing)
+	span  string   // Name of the phase's tracing span
+	needs []string // Phases that must run earlier in the same tick
+	// weekly phases run once per week of the tick when the engine runs
+	// weekly ticks
+	weekly bool
+	// active reports whether the phase has anything to do this tick (nil =
+	// always)
+	active func(e *Engine, t *tickState) bool
+	run    func(e *Engine, t *tickState)
+}
+
+// tickPhases are every phase, in the default order
+var tickPhases = []tickPhase{
+	{
+		name: "monetary_policy", title: "🏦 MONETARY POLICY", span: "monetary_policy",
+		active: func(e *Engine, _ *tickState) bool { return e.CentralBank != nil },
+		run: func(e *Engine, _ *tickState) {
+			e.Logger.LogEvents(e.CentralBank.ExecuteInterventions(e.Region, e.CurrentTick))
+		},
+	},
+	{
+		name: "education", title: "🎓 EDUCATION PHASE", span: "education",
+		active: func(e *Engine, _ *tickState) bool { return e.hasSchools() },
+		run:    func(e *Engine, t *tickState) { t.students = e.processSchools() },
+	},
+	{
+		name: "production", title: "📦 PRODUCTION PHASE", span: "production",
+		weekly: true,
+		run: func(e *Engine, t *tickState) {
+			week := e.processProductionPhase(t.hoursAvailable, t.students)
+			if e.week > 0 {
+				week = t.result.Production.add(week)
+				e.output = week.UnitsProduced
+			}
+			t.result.Production = week
+		},
+	},
+	{
+		name: "unemployment", title: "\n🧾 UNEMPLOYMENT BENEFITS", span: "unemployment",
+		needs:  []string{"production"},
+		active: func(e *Engine, _ *tickState) bool { return e.hasUnemployment() },
+		run:    func(e *Engine, _ *tickState) { e.processUnemployment() },
+	},
+	{
+		name: "overheads", title: "\n🏢 OVERHEADS", span: "overheads",
+		active: func(e *Engine, _ *tickState) bool { return e.hasOverheads() },
+		run:    func(e *Engine, _ *tickState) { e.processOverheads() },
+	},
+	{
+		name: "shocks", title: "\n⚡ SHOCKS & INSURANCE", span: "shocks",
+		active: func(e *Engine, _ *tickState) bool { return len(e.Shocks) > 0 || len(e.Insurers) > 0 },
+		run:    func(e *Engine, _ *tickState) { e.processShocks() },
+	},
+	{
+		name: "contracts", title: "\n📝 CONTRACTS PHASE", span: "contracts",
+		active: func(e *Engine, _ *tickState) bool { return len(e.Region.Contracts) > 0 },
+		run:    func(e *Engine, _ *tickState) { e.processContracts() },
+	},
+	{
+		name: "wholesale", title: "\n🚚 WHOLESALE PHASE", span: "wholesale",
+		weekly: true,
+		active: func(e *Engine, _ *tickState) bool { return e.hasRetailers() },
+		run:    func(e *Engine, _ *tickState) { e.processWholesaleMarket() },
+	},
+	{
+		// Releases stock if the last tick ran short
+		name: "reserve_release", span: "reserve_release",
+		active: func(e *Engine, _ *tickState) bool { return e.hasReserve() && e.lastMarket != nil },
+		run:    func(e *Engine, _ *tickState) { e.processReserveRelease() },
+	},
+	{
+		name: "pensions", title: "\n👵 PENSIONS", span: "pensions",
+		active: func(e *Engine, _ *tickState) bool { return e.Government != nil && e.Government.Pension != nil },
+		run:    func(e *Engine, _ *tickState) { e.processPensions() },
+	},
+	{
+		name: "households", title: "\n🏠 HOUSEHOLDS", span: "households",
+		active: func(e *Engine, _ *tickState) bool { return len(e.Region.Households) > 0 },
+		run:    func(e *Engine, _ *tickState) { e.processHouseholds() },
+	},
+	{
+		name: "advertising", title: "\n📣 ADVERTISING", span: "advertising",
+		active: func(e *Engine, _ *tickState) bool { return e.Marketing != nil },
+		run:    func(e *Engine, _ *tickState) { e.processAdvertising() },
+	},
+	{
+		name: "product_market", title: "\n🛒 PRODUCT MARKET PHASE", span: "market",
+		weekly: true,
+		run: func(e *Engine, t *tickState) {
+			result := e.processProductMarket()
+			// Weekly markets add up to the tick's, read once the last week
+			// has shopped
+			if e.weeks > 1 {
+				if e.week == 0 {
+					t.market = &market.MarketResult{}
+				}
+				t.market.Combine(result)
+				if e.week < e.weeks-1 {
+					return
+				}
+				result = t.market
+			}
+			e.lastMarket = result
+			t.market = result
+			t.result.Market = newMarketPhaseResult(result, len(e.Region.People))
+			if e.HistoryLength > 0 {
+				market.RecordHistory(e.Region, result, e.CurrentTick, e.HistoryLength)
+			}
+			if e.hasDynamicNeeds() {
+				e.processSeverity(result)
+			}
+			if e.Forecasting != nil {
+				e.recordSales(result)
+			}
+			if e.Bank.Lending != nil {
+				e.recordRevenue(result)
+			}
+		},
+	},
+	{
+		name: "informal", title: "\n🕶️  INFORMAL ECONOMY", span: "informal",
+		needs:  []string{"product_market"},
+		active: func(e *Engine, _ *tickState) bool { return e.Informal != nil },
+		run:    func(e *Engine, t *tickState) { e.processInformalMarket(t.market) },
+	},
+	{
+		name: "barter", title: "\n🤝 BARTER", span: "barter",
+		needs:  []string{"product_market"},
+		active: func(e *Engine, _ *tickState) bool { return e.Barter != nil },
+		run:    func(e *Engine, t *tickState) { e.processBarter(t.market) },
+	},
+	{
+		name: "taxes", title: "\n🏛️  TAXES", span: "taxes",
+		needs:  []string{"product_market"},
+		active: func(e *Engine, _ *tickState) bool { return e.Government != nil },
+		run:    func(e *Engine, t *tickState) { e.processTaxes(t.market) },
+	},
+	{
+		name: "reserve", title: "\n🌾 STRATEGIC RESERVE", span: "reserve",
+		needs:  []string{"product_market"},
+		active: func(e *Engine, _ *tickState) bool { return e.hasReserve() },
+		run:    func(e *Engine, t *tickState) { e.processReserveStock(t.market) },
+	},
+	{
+		// Wages held back until sales are paid now
+		name: "wages_due", title: "\n💵 WAGES DUE", span: "wages_due",
+		needs:  []string{"production"},
+		active: func(e *Engine, _ *tickState) bool { return len(e.owed) > 0 },
+		run:    func(e *Engine, _ *tickState) { e.processWagesOwed() },
+	},
+	{
+		name: "banking", title: "\n🏦 BANKING PHASE", span: "banking",
+		active: func(e *Engine, _ *tickState) bool { return e.hasSavers() },
+		run:    func(e *Engine, _ *tickState) { e.processSavings() },
+	},
+	{
+		name: "debt", title: "\n💳 DEBT SERVICE", span: "debt",
+		active: func(e *Engine, _ *tickState) bool { return e.hasDebt() },
+		run:    func(e *Engine, _ *tickState) { e.processDebtService() },
+	},
+	{
+		name: "dividends", title: "\n💼 DIVIDENDS", span: "dividends",
+		active: func(e *Engine, _ *tickState) bool { return e.hasShareholders() },
+		run:    func(e *Engine, t *tickState) { e.processDividends(t.openingMoney) },
+	},
+	{
+		name: "demand", title: "\n📈 DEMAND UPDATE", span: "demand",
+		needs: []string{"product_market"},
+		run:   func(e *Engine, t *tickState) { e.processDemandUpdate(t.market) },
+	},
+	{
+		name: "regeneration", title: "\n🌱 RESOURCE REGENERATION", span: "regeneration",
+		run: func(e *Engine, t *tickState) { t.result.Regeneration = e.processResourceRegeneration() },
+	},
+	{
+		// People change segments for the next tick
+		name: "transitions", title: "\n🔀 SEGMENT TRANSITIONS", span: "transitions",
+		active: func(e *Engine, _ *tickState) bool { return e.Transitions != nil },
+		run:    func(e *Engine, _ *tickState) { e.processTransitions() },
+	},
+}
+
+// PhaseNames returns the names of every tick phase, in the default order
+func PhaseNames() []string {
+	names := make([]string, len(tickPhases))
+	for i, phase := range tickPhases {
+		names[i] = phase.name
+	}
+	return names
+}
+
+// SetPhases runs only the named phases each tick, in the given order (nil or
+// empty restores every phase in the default order). Phases that work on the
+// results of others must come after them, and production that pays wages
+// after sales needs wages_due, so set WageTiming first.
+func (e *Engine) SetPhases(names []string) error {
+	if len(names) == 0 {
+		e.phases = nil
+		return nil
+	}
+
+	byName := make(map[string]tickPhase, len(tickPhases))
+	for _, phase := range tickPhases {
+		byName[phase.name] = phase
+	}
+	pipeline := make([]tickPhase, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		phase, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unknown phase %s (phases: %s)", name, strings.Join(PhaseNames(), ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("phase %s is listed twice", name)
+		}
+		for _, need := range phase.needs {
+			if !seen[need] {
+				return fmt.Errorf("phase %s must come after %s", name, need)
+			}
+		}
+		seen[name] = true
+		pipeline = append(pipeline, phase)
+	}
+	if seen["production"] && !seen["wages_due"] && e.WageTiming != "" && e.WageTiming != production.PayBeforeProduction {
+		return fmt.Errorf("wage timing %s needs the wages_due phase", e.WageTiming)
+	}
+
+	e.phases = pipeline
+	return nil
+}
+
+// firstWeekly returns the index of the first weekly phase in a pipeline, or
+// its length when none is
+func firstWeekly(pipeline []tickPhase) int {
+	for i, phase := range pipeline {
+		if phase.weekly {
+			return i
+		}
+	}
+	return len(pipeline)
+}
+
+// pipeline returns the phases to run each tick
+func (e *Engine) pipeline() []tickPhase {
+	if e.phases == nil {
+		return tickPhases
+	}
+	return e.phases
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Progress describes how far a run has come, reported after every tick
+type Progress struct {
+	Tick    int
+	Total   int
+	Elapsed time.Duration
+	ETA     time.Duration // Estimated time left, from the average tick so far
+
+	// Key metrics at the end of the tick
+	TotalWealth     float32
+	PeopleSatisfied int
+	PeopleTotal     int
+}
+
+// Fraction returns the share of the run completed, between 0 and 1
+func (p Progress) Fraction() float64 {
+	if p.Total <= 0 {
+		return 1
+	}
+	return float64(p.Tick) / float64(p.Total)
+}
+
+// progress builds the report for the tick just finished in a run of total
+// ticks that started with startTick completed
+func (e *Engine) progress(startTick, total int, started time.Time) Progress {
+	done := e.CurrentTick - startTick
+	elapsed := time.Since(started)
+	p := Progress{
+		Tick:        done,
+		Total:       total,
+		Elapsed:     elapsed,
+		PeopleTotal: len(e.Region.People),
+	}
+	if done > 0 {
+		p.ETA = elapsed / time.Duration(done) * time.Duration(total-done)
+	}
+	if e.LastTick != nil {
+		p.TotalWealth = e.LastTick.TotalWealth
+		p.PeopleSatisfied = e.LastTick.Market.PeopleSatisfied
+	} else {
+		p.TotalWealth = totalWealth(e.Region)
+	}
+	return p
+}
+
+// progressBarWidth is the number of cells in the console progress bar
+const progressBarWidth = 30
+
+// ConsoleProgress returns a progress callback that redraws a one-line bar
+// on w, ending the line when the run completes
+func ConsoleProgress(w io.Writer) func(Progress) {
+	return func(p Progress) {
+		filled := int(p.Fraction() * progressBarWidth)
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+		fmt.Fprintf(w, "\r%s %3.0f%% tick %d/%d  ETA %s  wealth $%.0f  satisfied %d/%d ",
+			bar, p.Fraction()*100, p.Tick, p.Total, p.ETA.Round(time.Second),
+			p.TotalWealth, p.PeopleSatisfied, p.PeopleTotal)
+		if p.Tick >= p.Total {
+			fmt.Fprintln(w)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package core
+
+// Results is the end-of-run state of the economy, in a form that can be
+// exported alongside the run's manifest
+type Results struct {
+	Region        string             `json:"region"`
+	Ticks         int                `json:"ticks"`
+	StartWealth   float32            `json:"start_wealth"`
+	TotalWealth   float32            `json:"total_wealth"`
+	IndustryMoney map[string]float32 `json:"industry_money"`
+	PeopleWealth  float32            `json:"people_wealth"`
+	Treasury      float32            `json:"treasury,omitempty"`
+	Satisfaction  float32            `json:"satisfaction,omitempty"` // Average remembered satisfaction, with histories on
+	Welfare       float32            `json:"welfare,omitempty"`      // Average utility in the last tick, with welfare on
+	GDP           float32            `json:"gdp,omitempty"`          // Value produced in the last tick, with welfare on
+	Resources     map[string]float32 `json:"resources"`
+	LastTick      *TickResult        `json:"last_tick,omitempty"`    // What happened in the final tick
+	Window        *WindowStats       `json:"window,omitempty"`       // Averages over the measurement window, with one set
+	ConvergedAt   int                `json:"converged_at,omitempty"` // Tick the economy reached a steady state
+	Failures      []AssertionFailure `json:"assertion_failures,omitempty"`
+	Cohorts       []*Cohort          `json:"cohorts,omitempty"`           // Outcomes by starting segment and wealth, with cohorts on
+	Changes       []ParameterChange  `json:"parameter_changes,omitempty"` // Settings changed between ticks
+}
+
+// Results collects the current state of the economy
+func (e *Engine) Results() *Results {
+	results := &Results{
+		Region:        e.Region.Name,
+		Ticks:         e.CurrentTick,
+		StartWealth:   e.InitialState.TotalWealth,
+		IndustryMoney: make(map[string]float32, len(e.Region.Industries)),
+		Resources:     make(map[string]float32, len(e.Region.Resources)),
+		LastTick:      e.LastTick,
+		Window:        e.Window(),
+		ConvergedAt:   e.ConvergedAt,
+		Failures:      e.Failures,
+		Changes:       e.Changes,
+	}
+
+	for _, industry := range e.Region.Industries {
+		results.IndustryMoney[industry.Name] = industry.Money
+		results.TotalWealth += industry.Money
+	}
+	for _, person := range e.Region.People {
+		results.PeopleWealth += person.Wealth()
+	}
+	results.TotalWealth += results.PeopleWealth
+	if e.HistoryLength > 0 && len(e.Region.People) > 0 {
+		for _, person := range e.Region.People {
+			results.Satisfaction += person.Satisfaction()
+		}
+		results.Satisfaction /= float32(len(e.Region.People))
+	}
+
+	if len(e.WelfareHistory) > 0 {
+		last := e.WelfareHistory[len(e.WelfareHistory)-1]
+		results.Welfare = last.Average
+		results.GDP = last.GDP
+	}
+
+	if e.Government != nil {
+		results.Treasury = e.Government.Treasury
+	}
+	if e.Cohorts != nil {
+		results.Cohorts = e.Cohorts.Cohorts
+	}
+	for _, resource := range e.Region.Resources {
+		results.Resources[resource.Name] = resource.Quantity
+	}
+
+	return results
+}
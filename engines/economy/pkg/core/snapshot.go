@@ -0,0 +1,22 @@
+package core
+
+import "sync/atomic"
+
+// snapshots holds the latest published state for concurrent readers
+type snapshots struct {
+	latest atomic.Pointer[Checkpoint]
+}
+
+// publish stores a fresh copy of the engine's state
+func (s *snapshots) publish(e *Engine) {
+	s.latest.Store(e.Checkpoint())
+}
+
+// Snapshot returns the state as of the end of the last completed tick. It is
+// safe to call from other goroutines while the engine is running, because
+// the engine never touches a snapshot after publishing it. Readers must treat
+// it as read-only. Returns nil until a snapshot is published, which needs
+// ServeSnapshots enabled.
+func (e *Engine) Snapshot() *Checkpoint {
+	return e.snapshots.latest.Load()
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"math/rand/v2"
+
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/market"
+	"westex/engines/economy/pkg/production"
+)
+
+// MarketMechanism clears the product market each tick: it matches people's
+// needs with industries' stock, moves the money and goods, and reports what
+// sold. The engine uses the posted-price market or the order book, by
+// MarketMode, unless Engine.Market is set. With weekly ticks it is cleared
+// once a week, and the conditions say which week.
+type MarketMechanism interface {
+	Clear(region *entities.Region, conditions MarketConditions) *market.MarketResult
+}
+
+// MarketConditions are the settings the engine hands the market each tick
+type MarketConditions struct {
+	BasePrice    float32 // Price of a unit before markups
+	ProfitMargin float32 // Markup over production cost asked in the order book
+	Controls     market.PriceControls
+	Rand         *rand.Rand
+	Awareness    bool   // Shoppers consider sellers by advertising awareness
+	Rationing    string // How basic needs in short supply are shared out
+	Queue        string // Order people take their turn in
+	Loyalty      bool   // Shoppers return to the seller that served them
+	Week         int    // Week of the tick being cleared, from 0
+	WeeksPerTick int    // Weeks of need shoppers buy for
+	Weeks        int    // Weeks the tick is cleared in (1 = all at once)
+}
+
+// ProductionModel turns an industry's labor and hours into units and costs.
+// The engine uses one unit per hour of effective labor unless
+// Engine.Production is set; skill, commuting, hours worked, targets and
+// overheads are applied to the result afterwards.
+type ProductionModel interface {
+	Produce(industry *entities.Industry, workers, hours, wage float32) *production.ProductionResult
+}
+
+// postedMarket is the posted-price market, keeping its buffers between ticks
+type postedMarket struct {
+	market *market.ProductMarket
+}
+
+func (m *postedMarket) Clear(region *entities.Region, conditions MarketConditions) *market.MarketResult {
+	m.market.Rand = conditions.Rand
+	m.market.Awareness = conditions.Awareness
+	m.market.Rationing = conditions.Rationing
+	m.market.Queue = conditions.Queue
+	m.market.Loyalty = conditions.Loyalty
+	m.market.Controls = conditions.Controls
+	m.market.Week = conditions.Week
+	m.market.WeeksPerTick = conditions.WeeksPerTick
+	m.market.Weeks = conditions.Weeks
+	return m.market.Process(region, conditions.BasePrice)
+}
+
+// orderBookMarket clears bids against asks, once a tick: with weekly ticks
+// the book is cleared in the last week
+type orderBookMarket struct{}
+
+func (orderBookMarket) Clear(region *entities.Region, conditions MarketConditions) *market.MarketResult {
+	if conditions.Week < conditions.Weeks-1 {
+		return &market.MarketResult{NeedStats: make(map[int]*market.NeedStats)}
+	}
+	return market.ProcessOrderBookMarket(region, conditions.BasePrice, conditions.ProfitMargin, conditions.Controls)
+}
+
+// linearProduction makes one unit per hour of effective labor
+type linearProduction struct{}
+
+func (linearProduction) Produce(industry *entities.Industry, workers, hours, wage float32) *production.ProductionResult {
+	return production.CalculateProduction(industry, workers, hours, wage)
+}
+
+// marketMechanism returns the market to clear this tick
+func (e *Engine) marketMechanism() MarketMechanism {
+	switch {
+	case e.Market != nil:
+		return e.Market
+	case e.MarketMode == market.ModeOrderBook:
+		return orderBookMarket{}
+	}
+	if e.productMarket == nil {
+		e.productMarket = &postedMarket{market: market.NewProductMarket()}
+	}
+	return e.productMarket
+}
+
+// productionModel returns the model industries produce with
+func (e *Engine) productionModel() ProductionModel {
+	if e.Production != nil {
+		return e.Production
+	}
+	return linearProduction{}
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/market"
+	"westex/engines/economy/pkg/welfare"
+)
+
+// TickResult is what happened in one tick, gathered from the results the
+// phases return. It drives the tick summary and progress reports, and is
+// exported with the run's results and served with snapshots.
+type TickResult struct {
+	Tick         int                   `json:"tick"`
+	Date         string                `json:"date,omitempty"` // Day the tick started, with a clock
+	Production   ProductionPhaseResult `json:"production"`
+	Market       MarketPhaseResult     `json:"market"`
+	Regeneration RegenerationResult    `json:"regeneration"`
+	TotalWealth  float32               `json:"total_wealth"` // Industries' money and people's wealth at the end of the tick
+	Welfare      *welfare.Report       `json:"welfare,omitempty"`
+}
+
+// ProductionPhaseResult is the output and employment of the production phase
+type ProductionPhaseResult struct {
+	UnitsProduced float32              `json:"units_produced"`
+	WagesPaid     float32              `json:"wages_paid"` // Wages paid before production
+	Available     int                  `json:"available"`  // Workers who offered hours
+	Unemployed    int                  `json:"unemployed"` // Of those, workers no industry took on
+	Vacancies     int                  `json:"vacancies"`
+	Industries    []IndustryProduction `json:"industries"`
+}
+
+// IndustryProduction is one industry's production in a tick, over all its
+// shifts
+type IndustryProduction struct {
+	Industry string  `json:"industry"`
+	Shifts   int     `json:"shifts"` // Shifts that produced
+	Workers  int     `json:"workers"`
+	Units    float32 `json:"units"`
+	Cost     float32 `json:"cost"`
+	Down     bool    `json:"down,omitempty"` // Down for maintenance or a breakdown
+}
+
+// add combines two weeks of a tick's production: output and wages add up,
+// the labor market figures are the later week's, and each industry counts
+// its busiest week's shifts and workers
+func (p ProductionPhaseResult) add(week ProductionPhaseResult) ProductionPhaseResult {
+	week.UnitsProduced += p.UnitsProduced
+	week.WagesPaid += p.WagesPaid
+	for i := range week.Industries {
+		industry := &week.Industries[i]
+		for _, earlier := range p.Industries {
+			if earlier.Industry != industry.Industry {
+				continue
+			}
+			industry.Shifts = max(industry.Shifts, earlier.Shifts)
+			industry.Workers = max(industry.Workers, earlier.Workers)
+			industry.Units += earlier.Units
+			industry.Cost += earlier.Cost
+			industry.Down = industry.Down || earlier.Down
+		}
+	}
+	return week
+}
+
+// MarketPhaseResult is the outcome of the product market
+type MarketPhaseResult struct {
+	Purchases         int     `json:"purchases"`
+	Spent             float32 `json:"spent"`
+	Revenue           float32 `json:"revenue"`
+	PeopleSatisfied   int     `json:"people_satisfied"`
+	PeopleUnsatisfied int     `json:"people_unsatisfied"`
+	People            int     `json:"people"`
+}
+
+// newMarketPhaseResult summarizes the product market's result for a region
+// of people
+func newMarketPhaseResult(result *market.MarketResult, people int) MarketPhaseResult {
+	return MarketPhaseResult{
+		Purchases:         len(result.Purchases),
+		Spent:             result.TotalSpent,
+		Revenue:           result.TotalRevenue,
+		PeopleSatisfied:   result.PeopleSatisfied,
+		PeopleUnsatisfied: result.PeopleUnsatisfied,
+		People:            people,
+	}
+}
+
+// RegenerationResult is how much of each renewable resource grew back
+type RegenerationResult struct {
+	Regenerated map[string]float32 `json:"regenerated"` // Units added by resource name
+}
+
+// totalWealth is the money held by industries plus people's wealth
+func totalWealth(region *entities.Region) float32 {
+	wealth := float32(0)
+	for _, industry := range region.Industries {
+		wealth += industry.Money
+	}
+	for _, person := range region.People {
+		wealth += person.Wealth()
+	}
+	return wealth
+}
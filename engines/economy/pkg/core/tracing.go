@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Tracer starts spans for the engine's ticks and phases. It mirrors the
+// shape of an OpenTelemetry tracer, so exporting over OTLP only takes a small
+// adapter around one, while the engine itself needs no tracing dependency.
+type Tracer interface {
+	// Start opens a span as a child of any span in ctx and returns a context
+	// carrying the new one
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is one timed operation
+type Span interface {
+	SetAttribute(key string, value any)
+	End()
+}
+
+// noopSpan is used when no tracer is set
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, any) {}
+func (noopSpan) End()                     {}
+
+// startTickSpan opens the span covering a whole tick
+func (e *Engine) startTickSpan(ctx context.Context) (context.Context, Span) {
+	if e.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+	ctx, span := e.Tracer.Start(ctx, "tick")
+	span.SetAttribute("tick", e.CurrentTick)
+	span.SetAttribute("region", e.Region.Name)
+	span.SetAttribute("people", len(e.Region.People))
+	return ctx, span
+}
+
+// startSpan opens the span of one phase inside the tick
+func (e *Engine) startSpan(ctx context.Context, phase string) Span {
+	if e.Tracer == nil {
+		return noopSpan{}
+	}
+	_, span := e.Tracer.Start(ctx, phase)
+	return span
+}
+
+// PhaseTimings is a Tracer that adds up the time spent in each span name,
+// for a quick breakdown without a tracing backend. It is safe to share
+// between engines running concurrently.
+type PhaseTimings struct {
+	mu     sync.Mutex
+	totals map[string]time.Duration
+	counts map[string]int
+}
+
+// NewPhaseTimings creates an empty timing tracer
+func NewPhaseTimings() *PhaseTimings {
+	return &PhaseTimings{
+		totals: make(map[string]time.Duration),
+		counts: make(map[string]int),
+	}
+}
+
+// PhaseTiming is the time spent in one kind of span
+type PhaseTiming struct {
+	Name  string
+	Total time.Duration
+	Count int
+}
+
+// Start begins timing a span
+func (p *PhaseTimings) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, &timedSpan{timings: p, name: name, start: time.Now()}
+}
+
+// Timings returns the totals, slowest first
+func (p *PhaseTimings) Timings() []PhaseTiming {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	timings := make([]PhaseTiming, 0, len(p.totals))
+	for name, total := range p.totals {
+		timings = append(timings, PhaseTiming{Name: name, Total: total, Count: p.counts[name]})
+	}
+	sort.Slice(timings, func(a, b int) bool {
+		if timings[a].Total != timings[b].Total {
+			return timings[a].Total > timings[b].Total
+		}
+		return timings[a].Name < timings[b].Name
+	})
+	return timings
+}
+
+// timedSpan records its duration in PhaseTimings when it ends
+type timedSpan struct {
+	timings *PhaseTimings
+	name    string
+	start   time.Time
+}
+
+func (s *timedSpan) SetAttribute(string, any) {}
+
+func (s *timedSpan) End() {
+	elapsed := time.Since(s.start)
+	s.timings.mu.Lock()
+	s.timings.totals[s.name] += elapsed
+	s.timings.counts[s.name]++
+	s.timings.mu.Unlock()
+}
@@ -0,0 +1,194 @@
+package core
+
+import (
+	"math/rand/v2"
+
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/events"
+	"westex/engines/economy/pkg/market"
+)
+
+// trajectoryStream is the stream of the engine's seed the sample is drawn
+// from, so tracking people leaves the simulation's own draws as they were
+const trajectoryStream = 0x7472616a
+
+// Trajectories follows a random sample of people tick by tick in full
+// detail, which shows what happens to individuals without the cost of
+// logging everyone
+type Trajectories struct {
+	Sample int           `json:"sample"` // People to follow
+	People []*Trajectory `json:"people"`
+
+	index      map[int]*Trajectory
+	opening    map[int]float32 // Sampled people's wealth at the start of the tick
+	subscribed *events.Bus     // Bus the tracker listens to for purchases and life events
+}
+
+// Trajectory is what happened to one sampled person
+type Trajectory struct {
+	PersonID int          `json:"person_id"`
+	Person   string       `json:"person"`
+	Segments []string     `json:"segments"`
+	Ticks    []PersonTick `json:"ticks"`
+	Left     int          `json:"left,omitempty"` // Tick they died or migrated in
+}
+
+// PersonTick is one sampled person's tick
+type PersonTick struct {
+	Tick      int              `json:"tick"`
+	Money     float32          `json:"money"`
+	Savings   float32          `json:"savings"`
+	Income    float32          `json:"income"` // Everything gained: the change in wealth plus spending
+	Spent     float32          `json:"spent"`  // In the formal market
+	NeedsMet  float32          `json:"needs_met"`
+	Jobs      []string         `json:"jobs,omitempty"`
+	Purchases []PersonPurchase `json:"purchases,omitempty"`
+	Changes   []string         `json:"changes,omitempty"` // Hired, fired, retired and the like
+}
+
+// PersonPurchase is one purchase a sampled person made
+type PersonPurchase struct {
+	Product   string  `json:"product"`
+	Problem   string  `json:"problem"`
+	Quantity  float32 `json:"quantity"`
+	UnitPrice float32 `json:"unit_price"`
+}
+
+// TrackPeople follows a random sample of k people in full detail from the
+// next tick on, drawn with the engine's seed. k <= 0 stops tracking.
+func (e *Engine) TrackPeople(k int) {
+	e.Trajectories = nil
+	if k > 0 {
+		e.Trajectories = &Trajectories{Sample: k}
+	}
+}
+
+// Clone returns an independent copy of the trajectories so far, which
+// listens to the bus of whichever engine ticks it next
+func (t *Trajectories) Clone() *Trajectories {
+	clone := &Trajectories{Sample: t.Sample, index: make(map[int]*Trajectory, len(t.People))}
+	for _, trajectory := range t.People {
+		copied := *trajectory
+		copied.Ticks = append([]PersonTick(nil), trajectory.Ticks...)
+		clone.People = append(clone.People, &copied)
+		clone.index[copied.PersonID] = &copied
+	}
+	return clone
+}
+
+// openTrajectories draws the sample on the first tick and starts every
+// sampled person's record of the tick
+func (e *Engine) openTrajectories() {
+	t := e.Trajectories
+	if t.index == nil {
+		t.index = make(map[int]*Trajectory, t.Sample)
+		rng := rand.New(rand.NewPCG(e.Seed, trajectoryStream))
+		for _, i := range rng.Perm(len(e.Region.People))[:min(t.Sample, len(e.Region.People))] {
+			person := e.Region.People[i]
+			trajectory := &Trajectory{PersonID: person.ID, Person: person.Name}
+			for _, segment := range person.Segments {
+				trajectory.Segments = append(trajectory.Segments, segment.Name)
+			}
+			t.People = append(t.People, trajectory)
+			t.index[person.ID] = trajectory
+		}
+	}
+	if t.subscribed != e.Events {
+		t.subscribed = e.Events
+		e.Events.Subscribe(func(event events.Event) { t.record(event) })
+	}
+
+	t.opening = make(map[int]float32, len(t.index))
+	for id, trajectory := range t.index {
+		if trajectory.Left > 0 {
+			continue
+		}
+		if person := e.Region.PersonByID(id); person != nil {
+			t.opening[id] = person.Wealth()
+			trajectory.Ticks = append(trajectory.Ticks, PersonTick{Tick: e.CurrentTick})
+		}
+	}
+}
+
+// record adds a purchase or life event to the sampled person it happened to
+func (t *Trajectories) record(event events.Event) {
+	if purchase, ok := event.(events.PurchaseMade); ok {
+		if row := t.current(purchase.PersonID); row != nil {
+			row.Spent += purchase.TotalCost
+			row.Purchases = append(row.Purchases, PersonPurchase{
+				Product:   purchase.Product,
+				Problem:   purchase.Problem,
+				Quantity:  purchase.Quantity,
+				UnitPrice: purchase.UnitPrice,
+			})
+		}
+		return
+	}
+	personal, ok := event.(events.PersonEvent)
+	if !ok {
+		return
+	}
+	var change string
+	switch e := event.(type) {
+	case events.PersonHired:
+		change = "hired by " + e.Industry
+	case events.PersonFired:
+		change = "left " + e.Industry
+	case events.PersonRetired:
+		change = "retired to " + e.To
+	case events.PersonBankrupt:
+		change = "went broke"
+	case events.PersonDied:
+		change = "died"
+		t.leave(e.PersonID, e.Tick)
+	case events.PersonMigrated:
+		change = "migrated to " + e.To
+		t.leave(e.PersonID, e.Tick)
+	}
+	if row := t.current(personal.EventPerson()); row != nil {
+		row.Changes = append(row.Changes, change)
+	}
+}
+
+// leave marks the tick a sampled person left the region in
+func (t *Trajectories) leave(id, tick int) {
+	if trajectory, ok := t.index[id]; ok {
+		trajectory.Left = tick
+	}
+}
+
+// current returns a sampled person's record of the tick being run, or nil
+// for anyone not sampled or not in this tick
+func (t *Trajectories) current(id int) *PersonTick {
+	if _, open := t.opening[id]; !open {
+		return nil
+	}
+	trajectory := t.index[id]
+	return &trajectory.Ticks[len(trajectory.Ticks)-1]
+}
+
+// closeTrajectories fills in each sampled person's wealth, income, jobs and
+// needs met at the end of the tick
+func (e *Engine) closeTrajectories(result *market.MarketResult) {
+	t := e.Trajectories
+	people := make([]*entities.Person, 0, len(t.opening))
+	for id := range t.opening {
+		if person := e.Region.PersonByID(id); person != nil {
+			people = append(people, person)
+		}
+	}
+	var met map[int]float32
+	if result != nil {
+		met = market.NeedsMetFor(people, result)
+	}
+
+	for _, person := range people {
+		row := t.current(person.ID)
+		row.Money = person.Money
+		row.Savings = person.Savings
+		row.Income = person.Wealth() - t.opening[person.ID] + row.Spent
+		row.NeedsMet = met[person.ID]
+		row.Jobs = append([]string(nil), e.lastJobs[person.ID]...)
+	}
+	t.opening = nil
+}
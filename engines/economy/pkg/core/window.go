@@ -0,0 +1,84 @@
+package core
+
+import "fmt"
+
+// WindowStats averages the ticks in the measurement window, the ticks after
+// the warm-up that summary statistics are taken over
+type WindowStats struct {
+	From  int `json:"from"` // First tick measured
+	To    int `json:"to"`   // Last tick measured
+	Ticks int `json:"ticks"`
+
+	// Averages per tick
+	UnitsProduced   float32 `json:"units_produced"`
+	WagesPaid       float32 `json:"wages_paid"`
+	Unemployed      float32 `json:"unemployed"`
+	Vacancies       float32 `json:"vacancies"`
+	Purchases       float32 `json:"purchases"`
+	Spent           float32 `json:"spent"`
+	PeopleSatisfied float32 `json:"people_satisfied"`
+	TotalWealth     float32 `json:"total_wealth"`
+	Welfare         float32 `json:"welfare,omitempty"` // With welfare on
+	GDP             float32 `json:"gdp,omitempty"`     // With welfare on
+}
+
+// measuring reports whether the tick falls in the measurement window
+func (e *Engine) measuring(tick int) bool {
+	return tick > e.WarmUp && (e.MeasureTicks <= 0 || tick <= e.WarmUp+e.MeasureTicks)
+}
+
+// measure keeps the tick's result if it falls in the measurement window. The
+// tick that ends the warm-up becomes the state the run is measured from.
+func (e *Engine) measure(tick *TickResult) {
+	if e.WarmUp > 0 && tick.Tick == e.WarmUp {
+		e.InitialState = captureState(e.Region)
+		e.Logger.LogEvent(fmt.Sprintf("\n🌡️  Warm-up over, measuring from tick %d", tick.Tick+1))
+	}
+	if e.measuring(tick.Tick) {
+		e.measured = append(e.measured, tick)
+	}
+	if e.MeasureTicks > 0 && tick.Tick == e.WarmUp+e.MeasureTicks {
+		e.Logger.LogEvent(fmt.Sprintf("\n📏 Measurement window closed after tick %d", tick.Tick))
+	}
+}
+
+// Window returns the averages over the ticks measured so far, or nil when
+// the run has no warm-up or measurement window or hasn't reached it yet
+func (e *Engine) Window() *WindowStats {
+	if (e.WarmUp <= 0 && e.MeasureTicks <= 0) || len(e.measured) == 0 {
+		return nil
+	}
+
+	window := &WindowStats{
+		From:  e.measured[0].Tick,
+		To:    e.measured[len(e.measured)-1].Tick,
+		Ticks: len(e.measured),
+	}
+	for _, tick := range e.measured {
+		window.UnitsProduced += tick.Production.UnitsProduced
+		window.WagesPaid += tick.Production.WagesPaid
+		window.Unemployed += float32(tick.Production.Unemployed)
+		window.Vacancies += float32(tick.Production.Vacancies)
+		window.Purchases += float32(tick.Market.Purchases)
+		window.Spent += tick.Market.Spent
+		window.PeopleSatisfied += float32(tick.Market.PeopleSatisfied)
+		window.TotalWealth += tick.TotalWealth
+		if tick.Welfare != nil {
+			window.Welfare += tick.Welfare.Average
+			window.GDP += tick.Welfare.GDP
+		}
+	}
+
+	n := float32(window.Ticks)
+	window.UnitsProduced /= n
+	window.WagesPaid /= n
+	window.Unemployed /= n
+	window.Vacancies /= n
+	window.Purchases /= n
+	window.Spent /= n
+	window.PeopleSatisfied /= n
+	window.TotalWealth /= n
+	window.Welfare /= n
+	window.GDP /= n
+	return window
+}
@@ -0,0 +1,261 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/finance"
+	"westex/engines/economy/pkg/logging"
+	"westex/engines/economy/pkg/units"
+)
+
+// World runs several regions as one simulation. Each tick every region runs
+// its own phases, concurrently when Parallel is set, and once all of them
+// have finished, the trade phase lets regions left with unmet demand import
+// the product from regions that still have it in stock. Along commute
+// routes, workers a region left idle take the jobs a neighbor left unfilled
+// the tick before, and send their wages home after the tick.
+type World struct {
+	Regions     []*Engine
+	Exchange    *finance.ExchangeMarket // Converts payments between currencies
+	Units       *units.Registry         // Converts shipments between units (nil = units must match)
+	Parallel    bool                    // Run the regions' phases on separate goroutines
+	Logger      *logging.Logger
+	CurrentTick int
+
+	// Commutes are the routes workers may take to jobs in other regions
+	Commutes []CommuteRoute
+
+	// Shipments are the cross-region sales of the last tick and LaborFlows
+	// its commuting
+	Shipments  []Shipment
+	LaborFlows []LaborFlow
+
+	commuters []commuter
+	tallies   map[string]*tally
+}
+
+// Shipment is one cross-region sale made in the trade phase
+type Shipment struct {
+	From    string // Exporting region
+	To      string // Importing region
+	Product string
+	Units   float32 // In the exporter's unit
+	Cost    float32 // Paid by the importer, in the exporter's currency
+	Paid    float32 // The same payment in the importer's currency
+}
+
+// NewWorld creates an empty world trading through the given exchange market
+func NewWorld(exchange *finance.ExchangeMarket) *World {
+	return &World{
+		Regions:  make([]*Engine, 0),
+		Exchange: exchange,
+		Logger:   logging.NewLogger(true),
+	}
+}
+
+// AddRegion adds a region's engine to the world
+func (w *World) AddRegion(engine *Engine) {
+	w.Regions = append(w.Regions, engine)
+}
+
+// Step advances every region by one tick and then runs the trade phase
+func (w *World) Step(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w.CurrentTick++
+	w.Logger.LogTick(w.CurrentTick)
+
+	w.assignCommuters()
+	if err := w.stepRegions(ctx); err != nil {
+		return err
+	}
+
+	w.Logger.LogEvent("🌍 TRADE PHASE")
+	flows, err := w.remitWages()
+	if err != nil {
+		return err
+	}
+	w.LaborFlows = flows
+	w.Shipments = w.trade()
+	for _, shipment := range w.Shipments {
+		w.Logger.LogEvent(fmt.Sprintf("🚢 %s → %s: %.0f %s for %.2f",
+			shipment.From, shipment.To, shipment.Units, shipment.Product, shipment.Cost))
+	}
+	w.logLaborFlows()
+	w.Logger.LogEvent(fmt.Sprintf("%d shipments between %d regions", len(w.Shipments), len(w.Regions)))
+	w.tally()
+	return nil
+}
+
+// Run advances the world for a number of ticks, stopping at the first error
+func (w *World) Run(ctx context.Context, ticks int) error {
+	for i := 0; i < ticks; i++ {
+		if err := w.Step(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stepRegions runs one tick in every region. In parallel mode each region
+// gets its own goroutine and the wait is the barrier before trade; regions
+// share no state until then.
+func (w *World) stepRegions(ctx context.Context) error {
+	if !w.Parallel {
+		for _, region := range w.Regions {
+			if err := region.Step(ctx); err != nil {
+				return fmt.Errorf("region %s: %w", region.Region.Name, err)
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, len(w.Regions))
+	var wg sync.WaitGroup
+	for i, region := range w.Regions {
+		wg.Add(1)
+		go func(i int, region *Engine) {
+			defer wg.Done()
+			if err := region.Step(ctx); err != nil {
+				errs[i] = fmt.Errorf("region %s: %w", region.Region.Name, err)
+			}
+		}(i, region)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// trade ships products to regions whose market left needs unmet. The
+// importing region's producer of the need buys from industries in other
+// regions selling a product of the same name, at the posted price converted
+// into its own currency, up to the shortfall left after its own stock and
+// what it can afford.
+func (w *World) trade() []Shipment {
+	shipments := make([]Shipment, 0)
+
+	for _, importer := range w.Regions {
+		if importer.lastMarket == nil {
+			continue
+		}
+		for _, problem := range importer.Region.Problems {
+			stats, exists := importer.lastMarket.NeedStats[problem.ID]
+			if !exists || stats.Unmet() <= 0 {
+				continue
+			}
+			buyer := producerFor(importer.Region, problem)
+			if buyer == nil {
+				continue
+			}
+
+			// Needs that went unmet while the product was on the shelf were
+			// about money, not supply
+			product := buyer.ProductFor(problem)
+			wanted := float32(stats.Unmet()) - product.Quantity
+			for _, exporter := range w.Regions {
+				if exporter == importer || wanted <= 0 {
+					continue
+				}
+				seller, stock := sellerOf(exporter.Region, product.Name)
+				if seller == nil {
+					continue
+				}
+				shipment, ok := w.ship(importer.Region, exporter.Region, buyer, seller, product, stock, wanted)
+				if !ok {
+					continue
+				}
+				shipments = append(shipments, shipment)
+				wanted = float32(stats.Unmet()) - product.Quantity
+			}
+		}
+	}
+
+	if w.Exchange != nil {
+		w.Exchange.UpdateRates()
+	}
+	return shipments
+}
+
+// ship moves up to wanted units of the seller's stock into the buyer's
+// product, paid in the seller's currency. It fails if the currencies can't
+// be converted or nothing is affordable.
+func (w *World) ship(
+	to, from *entities.Region,
+	buyer, seller *entities.Industry,
+	product, stock *entities.Resource,
+	wanted float32,
+) (Shipment, bool) {
+	unitCost := pricePerUnit
+	if to.Currency != from.Currency {
+		if w.Exchange == nil {
+			return Shipment{}, false
+		}
+		converted, err := w.Exchange.Convert(pricePerUnit, from.Currency, to.Currency)
+		if err != nil {
+			return Shipment{}, false
+		}
+		unitCost = converted
+	}
+
+	// Regions counting the product in different units ship it converted
+	rate := float32(1)
+	if !strings.EqualFold(stock.Unit, product.Unit) {
+		if w.Units == nil {
+			return Shipment{}, false
+		}
+		converted, err := w.Units.Convert(1, stock.Unit, product.Unit)
+		if err != nil || converted <= 0 {
+			return Shipment{}, false
+		}
+		rate = converted
+	}
+
+	units := min(wanted/rate, float32(int(stock.Quantity)), float32(int(buyer.Money/unitCost)))
+	if units < 1 {
+		return Shipment{}, false
+	}
+
+	cost := units * pricePerUnit
+	buyer.Money -= units * unitCost
+	seller.Money += cost
+	entities.TransferAt(stock, product, units, rate)
+	if w.Exchange != nil && to.Currency != from.Currency {
+		w.Exchange.RecordTrade(cost, from.Currency, to.Currency)
+	}
+
+	return Shipment{
+		From:    from.Name,
+		To:      to.Name,
+		Product: stock.Name,
+		Units:   units,
+		Cost:    cost,
+		Paid:    units * unitCost,
+	}, true
+}
+
+// producerFor returns the first retail-facing industry solving a problem
+func producerFor(region *entities.Region, problem *entities.Problem) *entities.Industry {
+	for _, industry := range region.Industries {
+		if !industry.SellsWholesale && industry.ProductFor(problem) != nil {
+			return industry
+		}
+	}
+	return nil
+}
+
+// sellerOf finds an industry with at least one unit of the named product
+func sellerOf(region *entities.Region, product string) (*entities.Industry, *entities.Resource) {
+	for _, industry := range region.Industries {
+		for _, output := range industry.OutputProducts {
+			if output.Name == product && output.Quantity >= 1 {
+				return industry, output
+			}
+		}
+	}
+	return nil, nil
+}
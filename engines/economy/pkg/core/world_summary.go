@@ -0,0 +1,167 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// RegionSummary compares one region's run with the rest of the world. Money
+// is in the exchange market's base currency so regions can be ranked.
+type RegionSummary struct {
+	Rank            int     `json:"rank"`
+	Region          string  `json:"region"`
+	Currency        string  `json:"currency"`
+	People          int     `json:"people"`
+	GDP             float32 `json:"gdp"`               // Value produced over the run
+	WealthPerCapita float32 `json:"wealth_per_capita"` // People's wealth at the end, per person
+	Unemployment    float32 `json:"unemployment"`      // Share of the labor force left idle over the run
+	NeedsMet        float32 `json:"needs_met"`         // Share of needy people served over the run
+	TradeBalance    float32 `json:"trade_balance"`     // Exports less imports over the run
+}
+
+// tally accumulates a region's figures from tick to tick
+type tally struct {
+	gdp        float32
+	idle       int
+	laborForce int
+	needy      int
+	served     int
+	exports    float32
+	imports    float32
+}
+
+// tally adds the tick that just ran to every region's running figures
+func (w *World) tally() {
+	if w.tallies == nil {
+		w.tallies = make(map[string]*tally, len(w.Regions))
+	}
+	for _, engine := range w.Regions {
+		t := w.tallyOf(engine.Region.Name)
+		t.gdp += w.inBase(engine.output*pricePerUnit, engine.Region.Currency)
+		t.idle += len(engine.idle)
+		t.laborForce += engine.laborForce
+		if engine.lastMarket != nil {
+			for _, stats := range engine.lastMarket.NeedStats {
+				t.needy += stats.Needy
+				t.served += stats.Satisfied
+			}
+		}
+	}
+	for _, shipment := range w.Shipments {
+		exporter := w.region(shipment.From)
+		value := w.inBase(shipment.Cost, exporter.Region.Currency)
+		w.tallyOf(shipment.From).exports += value
+		w.tallyOf(shipment.To).imports += value
+	}
+}
+
+// tallyOf returns a region's running figures, starting them if needed
+func (w *World) tallyOf(region string) *tally {
+	t, exists := w.tallies[region]
+	if !exists {
+		t = &tally{}
+		w.tallies[region] = t
+	}
+	return t
+}
+
+// inBase converts an amount into the base currency, leaving it unchanged
+// without an exchange market or a known rate
+func (w *World) inBase(amount float32, currency string) float32 {
+	if w.Exchange == nil {
+		return amount
+	}
+	converted, err := w.Exchange.Convert(amount, currency, w.Exchange.Base)
+	if err != nil {
+		return amount
+	}
+	return converted
+}
+
+// Summary ranks the regions by GDP over the run so far
+func (w *World) Summary() []RegionSummary {
+	summaries := make([]RegionSummary, 0, len(w.Regions))
+	for _, engine := range w.Regions {
+		s := RegionSummary{
+			Region:   engine.Region.Name,
+			Currency: engine.Region.Currency,
+			People:   len(engine.Region.People),
+		}
+		if s.People > 0 {
+			s.WealthPerCapita = w.inBase(engine.Results().PeopleWealth, s.Currency) / float32(s.People)
+		}
+		if t, exists := w.tallies[s.Region]; exists {
+			s.GDP = t.gdp
+			s.TradeBalance = t.exports - t.imports
+			if t.laborForce > 0 {
+				s.Unemployment = float32(t.idle) / float32(t.laborForce)
+			}
+			if t.needy > 0 {
+				s.NeedsMet = float32(t.served) / float32(t.needy)
+			}
+		}
+		summaries = append(summaries, s)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].GDP > summaries[j].GDP })
+	for i := range summaries {
+		summaries[i].Rank = i + 1
+	}
+	return summaries
+}
+
+// PrintSummary writes the league table
+func PrintSummary(out io.Writer, summaries []RegionSummary) {
+	fmt.Fprintf(out, "%-4s %-20s %8s %8s %14s %12s %8s %8s %14s\n",
+		"RANK", "REGION", "CURRENCY", "PEOPLE", "GDP", "WEALTH/CAP", "UNEMP", "NEEDS", "TRADE")
+	for _, s := range summaries {
+		fmt.Fprintf(out, "%-4d %-20s %8s %8d %14.2f %12.2f %7.1f%% %7.1f%% %+14.2f\n",
+			s.Rank, s.Region, s.Currency, s.People, s.GDP, s.WealthPerCapita,
+			s.Unemployment*100, s.NeedsMet*100, s.TradeBalance)
+	}
+}
+
+// SaveSummaryJSON writes the league table as a JSON array
+func SaveSummaryJSON(path string, summaries []RegionSummary) error {
+	data, err := json.MarshalIndent(summaries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal world summary: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write world summary: %w", err)
+	}
+	return nil
+}
+
+// SaveSummaryCSV writes the league table with a header row
+func SaveSummaryCSV(path string, summaries []RegionSummary) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create world summary: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	w.Write([]string{"rank", "region", "currency", "people", "gdp", "wealth_per_capita", "unemployment", "needs_met", "trade_balance"})
+	for _, s := range summaries {
+		w.Write([]string{
+			strconv.Itoa(s.Rank), s.Region, s.Currency, strconv.Itoa(s.People),
+			formatFloat(s.GDP), formatFloat(s.WealthPerCapita),
+			formatFloat(s.Unemployment), formatFloat(s.NeedsMet), formatFloat(s.TradeBalance),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write world summary: %w", err)
+	}
+	return nil
+}
+
+func formatFloat(value float32) string {
+	return strconv.FormatFloat(float64(value), 'f', 4, 32)
+}
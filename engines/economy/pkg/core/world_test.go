@@ -0,0 +1,223 @@
+package core
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/finance"
+	"westex/engines/economy/pkg/units"
+)
+
+// newTradingWorld builds an importer whose two buyers find no food at home
+// and an exporter with food in stock but nobody to sell it to
+func newTradingWorld(parallel bool) (*World, *entities.Industry, *entities.Industry) {
+	importRegion := entities.NewRegion("Importer")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	food.UpdateDemand(1.0)
+	importRegion.AddProblem(food)
+	segment := entities.NewPopulationSegment("General", []*entities.Problem{food}, 2)
+	for i := 0; i < 2; i++ {
+		person := entities.NewPerson("Buyer", 500.0, 0)
+		person.AddSegment(segment)
+		importRegion.AddPerson(person)
+	}
+	buyer := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{entities.NewResource("Grain", "kg")}).
+		SetInitialCapital(1000.0)
+	importRegion.AddIndustry(buyer)
+
+	exportRegion := entities.NewRegion("Exporter")
+	exportRegion.Currency = "EUR"
+	abroad := entities.NewProblem("Food", "Need food", 0.9)
+	exportRegion.AddProblem(abroad)
+	grain := entities.NewResource("Grain", "kg")
+	grain.Quantity = 10
+	seller := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{abroad}, nil, []*entities.Resource{grain})
+	exportRegion.AddIndustry(seller)
+
+	exchange := finance.NewExchangeMarket("USD", 0).AddCurrency("EUR", 2.0, finance.FixedRate)
+	world := NewWorld(exchange)
+	world.Parallel = parallel
+	world.Logger.SetEnabled(false)
+	for _, region := range []*entities.Region{importRegion, exportRegion} {
+		engine := CreateNewEngine(region)
+		engine.Logger.SetEnabled(false)
+		world.AddRegion(engine)
+	}
+	return world, buyer, seller
+}
+
+func TestWorld_Step_TradesUnmetDemand(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		world, buyer, seller := newTradingWorld(parallel)
+
+		if err := world.Step(context.Background()); err != nil {
+			t.Fatalf("Expected step to succeed, got %v", err)
+		}
+
+		if len(world.Shipments) != 1 {
+			t.Fatalf("Expected 1 shipment (parallel=%v), got %d", parallel, len(world.Shipments))
+		}
+		shipment := world.Shipments[0]
+		if shipment.From != "Exporter" || shipment.To != "Importer" || shipment.Units != 2 {
+			t.Errorf("Expected 2 units from Exporter to Importer, got %+v", shipment)
+		}
+		if buyer.OutputProducts[0].Quantity != 2 || seller.OutputProducts[0].Quantity != 8 {
+			t.Errorf("Expected stocks 2 and 8, got %.0f and %.0f",
+				buyer.OutputProducts[0].Quantity, seller.OutputProducts[0].Quantity)
+		}
+		// 2 units at 50 EUR, one EUR worth two USD
+		if buyer.Money != 800.0 || seller.Money != 100.0 {
+			t.Errorf("Expected buyer 800.00 USD and seller 100.00 EUR, got %.2f and %.2f", buyer.Money, seller.Money)
+		}
+		if world.CurrentTick != 1 || world.Regions[0].CurrentTick != 1 || world.Regions[1].CurrentTick != 1 {
+			t.Errorf("Expected every clock at tick 1, got world %d", world.CurrentTick)
+		}
+	}
+}
+
+func TestWorld_Step_ConvertsShipmentUnits(t *testing.T) {
+	world, buyer, seller := newTradingWorld(false)
+	buyer.OutputProducts[0].Unit = "tons"
+	if err := world.Step(context.Background()); err != nil {
+		t.Fatalf("Expected step to succeed, got %v", err)
+	}
+	if len(world.Shipments) != 0 {
+		t.Errorf("Expected no trade between kg and tons without a unit registry, got %+v", world.Shipments)
+	}
+
+	world, buyer, seller = newTradingWorld(false)
+	buyer.OutputProducts[0].Unit = "tons"
+	world.Units = units.NewRegistry()
+	if err := world.Step(context.Background()); err != nil {
+		t.Fatalf("Expected step to succeed, got %v", err)
+	}
+	// The importer wants 2 tons and can afford all 10 kg at 50 EUR each
+	if len(world.Shipments) != 1 || world.Shipments[0].Units != 10 {
+		t.Fatalf("Expected 10 kg shipped, got %+v", world.Shipments)
+	}
+	if got := buyer.OutputProducts[0].Quantity; math.Abs(float64(got)-0.01) > 1e-6 || seller.OutputProducts[0].Quantity != 0 {
+		t.Errorf("Expected 0.01 tons received and none left, got %.4f and %.2f", got, seller.OutputProducts[0].Quantity)
+	}
+}
+
+// newCommutingWorld builds a town of idle workers next to a city with a
+// factory it can't staff
+func newCommutingWorld(parallel bool) (*World, []*entities.Person) {
+	town := entities.NewRegion("Town")
+	workers := entities.NewPopulationSegment("Workers", nil, 3)
+	town.AddPopulationSegment(workers)
+	residents := make([]*entities.Person, 3)
+	for i := range residents {
+		residents[i] = entities.NewPerson("Commuter", 0, 8)
+		residents[i].AddSegment(workers)
+		town.AddPerson(residents[i])
+	}
+
+	city := entities.NewRegion("City")
+	city.AddPopulationSegment(entities.NewPopulationSegment("Workers", nil, 0))
+	goods := entities.NewProblem("Goods", "Need goods", 0.5)
+	city.AddProblem(goods)
+	city.AddIndustry(entities.CreateIndustry("Factory").
+		SetupIndustry([]*entities.Problem{goods}, nil, []*entities.Resource{entities.NewResource("Widget", "unit")}).
+		UpdateLabor(2).
+		SetInitialCapital(1e6))
+
+	world := NewWorld(nil)
+	world.Parallel = parallel
+	world.Logger.SetEnabled(false)
+	world.Commutes = []CommuteRoute{{Home: "Town", Work: "City", Cost: 100}}
+	for _, region := range []*entities.Region{town, city} {
+		engine := CreateNewEngine(region)
+		engine.Logger.SetEnabled(false)
+		world.AddRegion(engine)
+	}
+	return world, residents
+}
+
+func TestWorld_Step_CommutersFillJobsNextDoor(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		world, residents := newCommutingWorld(parallel)
+
+		// The first tick finds the idle workers and the empty jobs
+		for tick := 0; tick < 2; tick++ {
+			if err := world.Step(context.Background()); err != nil {
+				t.Fatalf("Expected step to succeed, got %v", err)
+			}
+		}
+
+		if len(world.LaborFlows) != 1 {
+			t.Fatalf("Expected one labor flow (parallel=%v), got %d", parallel, len(world.LaborFlows))
+		}
+		flow := world.LaborFlows[0]
+		if flow.From != "Town" || flow.To != "City" || flow.Workers != 2 {
+			t.Errorf("Expected 2 commuters from Town to City, got %+v", flow)
+		}
+		if flow.Wages <= 0 || flow.CommuteCost != 200 || flow.Remitted != flow.Wages-200 {
+			t.Errorf("Expected wages sent home less $200 commuting, got %+v", flow)
+		}
+		if residents[0].Money <= 0 || residents[2].Money != 0 {
+			t.Errorf("Expected only the two commuters to earn, got %.2f and %.2f", residents[0].Money, residents[2].Money)
+		}
+	}
+}
+
+func TestWorld_Step_CommutersExportLaborHome(t *testing.T) {
+	world, _ := newCommutingWorld(false)
+	world.Regions[0].Region.Currency = "EUR"
+	world.Exchange = finance.NewExchangeMarket("USD", 0.1).AddCurrency("EUR", 2.0, finance.FloatingRate)
+
+	for tick := 0; tick < 2; tick++ {
+		if err := world.Step(context.Background()); err != nil {
+			t.Fatalf("Expected step to succeed, got %v", err)
+		}
+	}
+
+	// Wages earned in dollars go home as half as many euros
+	flow := world.LaborFlows[0]
+	if flow.Remitted+flow.CommuteCost != flow.Wages/2 {
+		t.Errorf("Expected wages converted at 2 USD to the euro, got %+v", flow)
+	}
+	// The town only exports labor, so the euro rises by the full sensitivity
+	if rate := world.Exchange.Currencies["EUR"].Rate; math.Abs(float64(rate)-2.2) > 1e-5 {
+		t.Errorf("Expected the euro to rise to 2.2 USD, got %.4f", rate)
+	}
+
+	// A home currency the exchange doesn't list stops the step
+	world, _ = newCommutingWorld(false)
+	world.Regions[0].Region.Currency = "GBP"
+	world.Exchange = finance.NewExchangeMarket("USD", 0)
+	err := world.Step(context.Background())
+	if err == nil {
+		err = world.Step(context.Background())
+	}
+	if err == nil {
+		t.Error("Expected remitting wages into an unknown currency to fail")
+	}
+}
+
+func TestWorld_Summary_RanksRegionsAndCountsTrade(t *testing.T) {
+	world, _, _ := newTradingWorld(false)
+	if err := world.Step(context.Background()); err != nil {
+		t.Fatalf("Expected step to succeed, got %v", err)
+	}
+
+	summary := world.Summary()
+	if len(summary) != 2 {
+		t.Fatalf("Expected 2 regions in the summary, got %d", len(summary))
+	}
+	balance := map[string]float32{}
+	for i, s := range summary {
+		if s.Rank != i+1 {
+			t.Errorf("Expected rank %d, got %d", i+1, s.Rank)
+		}
+		balance[s.Region] = s.TradeBalance
+	}
+	// 2 units at 50 EUR, one EUR worth two USD
+	if balance["Exporter"] != 200 || balance["Importer"] != -200 {
+		t.Errorf("Expected trade balances of +200 and -200 USD, got %v", balance)
+	}
+}
@@ -0,0 +1,102 @@
+package education
+
+import (
+	"sort"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Result summarises one tick of schooling
+type Result struct {
+	Enrolled   []*entities.Person // Started a course this tick
+	Graduated  []*entities.Person // Finished a course this tick
+	DroppedOut []*entities.Person // Could not pay tuition
+	Students   []*entities.Person // Studied this tick (kept out of the workforce)
+	Tuition    float32            // Fees paid to schools
+}
+
+// ProcessSchools fills free school seats from the candidates (least skilled
+// first), charges tuition and advances every course by one tick. Students
+// spend the tick studying, so the caller keeps them out of the workforce.
+// Graduates gain the school's SkillGain.
+func ProcessSchools(region *entities.Region, candidates []*entities.Person) *Result {
+	result := &Result{
+		Enrolled:   make([]*entities.Person, 0),
+		Graduated:  make([]*entities.Person, 0),
+		DroppedOut: make([]*entities.Person, 0),
+		Students:   make([]*entities.Person, 0),
+	}
+
+	enroll(region, candidates, result)
+
+	for _, person := range region.People {
+		school := person.School
+		if school == nil {
+			continue
+		}
+
+		if person.Money < school.Tuition {
+			person.School = nil
+			person.CourseTicksLeft = 0
+			result.DroppedOut = append(result.DroppedOut, person)
+			continue
+		}
+		person.Money -= school.Tuition
+		school.Money += school.Tuition
+		result.Tuition += school.Tuition
+		result.Students = append(result.Students, person)
+
+		person.CourseTicksLeft--
+		if person.CourseTicksLeft <= 0 {
+			person.Skill += school.SkillGain
+			person.School = nil
+			person.CourseTicksLeft = 0
+			result.Graduated = append(result.Graduated, person)
+		}
+	}
+
+	return result
+}
+
+// enroll gives each school's free seats to the least skilled candidates who
+// can afford the first tick's tuition
+func enroll(region *entities.Region, candidates []*entities.Person, result *Result) {
+	pool := make([]*entities.Person, 0, len(candidates))
+	for _, person := range candidates {
+		if !person.IsEnrolled() {
+			pool = append(pool, person)
+		}
+	}
+	sort.SliceStable(pool, func(i, j int) bool {
+		return pool[i].Skill < pool[j].Skill
+	})
+
+	for _, school := range region.Industries {
+		if !school.IsSchool {
+			continue
+		}
+
+		free := school.Seats - EnrolledIn(region, school)
+		for i := 0; i < len(pool) && free > 0; i++ {
+			person := pool[i]
+			if person.IsEnrolled() || person.Money < school.Tuition {
+				continue
+			}
+			person.School = school
+			person.CourseTicksLeft = max(school.CourseTicks, 1)
+			result.Enrolled = append(result.Enrolled, person)
+			free--
+		}
+	}
+}
+
+// EnrolledIn counts the people currently enrolled in a school
+func EnrolledIn(region *entities.Region, school *entities.Industry) int {
+	count := 0
+	for _, person := range region.People {
+		if person.School == school {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,66 @@
+package education
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func newSchoolRegion(people int, money float32) (*entities.Region, *entities.Industry) {
+	region := entities.NewRegion("TestRegion")
+	school := entities.CreateIndustry("School")
+	school.IsSchool = true
+	school.Seats = 2
+	school.CourseTicks = 2
+	school.SkillGain = 0.5
+	school.Tuition = 10.0
+	region.AddIndustry(school)
+
+	for i := 0; i < people; i++ {
+		region.AddPerson(entities.NewPerson("Person", money, 40.0))
+	}
+	return region, school
+}
+
+func TestProcessSchools_GraduatesGainSkill(t *testing.T) {
+	region, school := newSchoolRegion(3, 100.0)
+	region.People[2].Skill = 0.5 // Least skilled gets a seat first
+
+	first := ProcessSchools(region, region.People)
+	if len(first.Enrolled) != 2 || len(first.Students) != 2 {
+		t.Fatalf("Expected 2 students (2 seats), got %d enrolled / %d studying", len(first.Enrolled), len(first.Students))
+	}
+	if !region.People[2].IsEnrolled() {
+		t.Error("Expected the least skilled person to be enrolled")
+	}
+	if school.Money != 20.0 {
+		t.Errorf("Expected $20 tuition, got %.2f", school.Money)
+	}
+
+	second := ProcessSchools(region, region.People)
+	if len(second.Enrolled) != 0 {
+		t.Errorf("Expected no new enrollment while seats are full, got %d", len(second.Enrolled))
+	}
+	if len(second.Graduated) != 2 {
+		t.Fatalf("Expected 2 graduates after 2 ticks, got %d", len(second.Graduated))
+	}
+	if region.People[2].Skill != 1.0 || region.People[2].IsEnrolled() {
+		t.Errorf("Expected graduate skill 1.0 and left school, got %.2f", region.People[2].Skill)
+	}
+	if region.People[2].Money != 80.0 {
+		t.Errorf("Expected $80 left after two ticks of tuition, got %.2f", region.People[2].Money)
+	}
+}
+
+func TestProcessSchools_DropOutWithoutTuition(t *testing.T) {
+	region, _ := newSchoolRegion(1, 15.0)
+
+	ProcessSchools(region, region.People)
+	result := ProcessSchools(region, region.People)
+
+	if len(result.DroppedOut) != 1 || region.People[0].IsEnrolled() {
+		t.Errorf("Expected the student to drop out, got %d dropouts", len(result.DroppedOut))
+	}
+	if region.People[0].Skill != 1.0 {
+		t.Errorf("Expected no skill gain for a dropout, got %.2f", region.People[0].Skill)
+	}
+}
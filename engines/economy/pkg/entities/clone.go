@@ -0,0 +1,281 @@
+package entities
+
+import "maps"
+
+// Clone returns a fully independent deep copy of the region. Every entity is
+// copied once, keeping its ID, and references between entities (industry
+// inputs, suppliers, shareholders, segments, contracts, zones...) point into
+// the copy, so mutating the clone never touches the original.
+func (r *Region) Clone() *Region {
+	c := newCloner()
+
+	clone := *r
+	clone.index = nil
+	clone.Industries = make([]*Industry, len(r.Industries))
+	for i, industry := range r.Industries {
+		clone.Industries[i] = c.industry(industry)
+	}
+	clone.People = make([]*Person, len(r.People))
+	for i, person := range r.People {
+		clone.People[i] = c.person(person)
+	}
+	clone.PopulationSegments = make([]*PopulationSegment, len(r.PopulationSegments))
+	for i, segment := range r.PopulationSegments {
+		clone.PopulationSegments[i] = c.segment(segment)
+	}
+	clone.Resources = c.resources(r.Resources)
+	clone.Problems = c.problems(r.Problems)
+	clone.Contracts = make([]*Contract, len(r.Contracts))
+	for i, contract := range r.Contracts {
+		clone.Contracts[i] = c.contract(contract)
+	}
+	if r.Households != nil {
+		clone.Households = make([]*Household, len(r.Households))
+		for i, household := range r.Households {
+			clone.Households[i] = c.household(household)
+		}
+	}
+	clone.Zones = make([]*Zone, len(r.Zones))
+	for i, zone := range r.Zones {
+		clone.Zones[i] = c.zone(zone)
+	}
+	if r.Transport != nil {
+		transport := *r.Transport
+		clone.Transport = &transport
+	}
+
+	return &clone
+}
+
+// cloner remembers the copy of every entity it has made so shared references
+// stay shared in the clone
+type cloner struct {
+	industriesMap map[*Industry]*Industry
+	peopleMap     map[*Person]*Person
+	segmentsMap   map[*PopulationSegment]*PopulationSegment
+	resourcesMap  map[*Resource]*Resource
+	problemsMap   map[*Problem]*Problem
+	zonesMap      map[*Zone]*Zone
+	householdsMap map[*Household]*Household
+}
+
+func newCloner() *cloner {
+	return &cloner{
+		industriesMap: make(map[*Industry]*Industry),
+		peopleMap:     make(map[*Person]*Person),
+		segmentsMap:   make(map[*PopulationSegment]*PopulationSegment),
+		resourcesMap:  make(map[*Resource]*Resource),
+		problemsMap:   make(map[*Problem]*Problem),
+		zonesMap:      make(map[*Zone]*Zone),
+		householdsMap: make(map[*Household]*Household),
+	}
+}
+
+func (c *cloner) industry(orig *Industry) *Industry {
+	if orig == nil {
+		return nil
+	}
+	if copied, ok := c.industriesMap[orig]; ok {
+		return copied
+	}
+
+	clone := *orig
+	c.industriesMap[orig] = &clone
+
+	clone.OwnedProblems = c.problems(orig.OwnedProblems)
+	clone.InputResources = c.resources(orig.InputResources)
+	clone.OutputProducts = c.resources(orig.OutputProducts)
+	clone.ProductionHistory = append([]ProductionRecord(nil), orig.ProductionHistory...)
+	clone.SalesHistory = append([]float32(nil), orig.SalesHistory...)
+	clone.RevenueHistory = append([]float32(nil), orig.RevenueHistory...)
+	clone.HiresFrom = cloneTags(orig.HiresFrom)
+	clone.Holdings = maps.Clone(orig.Holdings)
+	if orig.Loans != nil {
+		clone.Loans = make([]*Loan, len(orig.Loans))
+		for i, loan := range orig.Loans {
+			copied := *loan
+			clone.Loans[i] = &copied
+		}
+	}
+	clone.Zone = c.zone(orig.Zone)
+	clone.Tags = cloneTags(orig.Tags)
+	if orig.Suppliers != nil {
+		clone.Suppliers = make([]*Industry, len(orig.Suppliers))
+		for i, supplier := range orig.Suppliers {
+			clone.Suppliers[i] = c.industry(supplier)
+		}
+	}
+	if orig.Shareholders != nil {
+		clone.Shareholders = make([]*Shareholding, len(orig.Shareholders))
+		for i, holding := range orig.Shareholders {
+			clone.Shareholders[i] = &Shareholding{Owner: c.person(holding.Owner), Shares: holding.Shares}
+		}
+	}
+
+	return &clone
+}
+
+func (c *cloner) person(orig *Person) *Person {
+	if orig == nil {
+		return nil
+	}
+	if copied, ok := c.peopleMap[orig]; ok {
+		return copied
+	}
+
+	clone := *orig
+	c.peopleMap[orig] = &clone
+
+	clone.Segments = make([]*PopulationSegment, len(orig.Segments))
+	for i, segment := range orig.Segments {
+		clone.Segments[i] = c.segment(segment)
+	}
+	clone.School = c.industry(orig.School)
+	clone.Zone = c.zone(orig.Zone)
+	clone.Household = c.household(orig.Household)
+	clone.Tags = cloneTags(orig.Tags)
+	if orig.CoveredProblems != nil {
+		clone.CoveredProblems = make(map[int]bool, len(orig.CoveredProblems))
+		for id, covered := range orig.CoveredProblems {
+			clone.CoveredProblems[id] = covered
+		}
+	}
+	if orig.Severities != nil {
+		clone.Severities = make(map[int]float32, len(orig.Severities))
+		for id, severity := range orig.Severities {
+			clone.Severities[id] = severity
+		}
+	}
+	if orig.History != nil {
+		clone.History = orig.History.clone()
+	}
+
+	return &clone
+}
+
+func (c *cloner) segment(orig *PopulationSegment) *PopulationSegment {
+	if orig == nil {
+		return nil
+	}
+	if copied, ok := c.segmentsMap[orig]; ok {
+		return copied
+	}
+
+	clone := *orig
+	c.segmentsMap[orig] = &clone
+	clone.Problems = c.problems(orig.Problems)
+	if orig.members != nil {
+		clone.members = make([]*Person, len(orig.members))
+		for i, member := range orig.members {
+			clone.members[i] = c.person(member)
+		}
+	}
+	return &clone
+}
+
+func (c *cloner) resource(orig *Resource) *Resource {
+	if orig == nil {
+		return nil
+	}
+	if copied, ok := c.resourcesMap[orig]; ok {
+		return copied
+	}
+
+	clone := *orig
+	c.resourcesMap[orig] = &clone
+	clone.Tags = cloneTags(orig.Tags)
+	if orig.Complements != nil {
+		clone.Complements = c.resources(orig.Complements)
+	}
+	if orig.Solves != nil {
+		clone.Solves = c.problems(orig.Solves)
+	}
+	return &clone
+}
+
+func (c *cloner) resources(orig []*Resource) []*Resource {
+	if orig == nil {
+		return nil
+	}
+	clones := make([]*Resource, len(orig))
+	for i, resource := range orig {
+		clones[i] = c.resource(resource)
+	}
+	return clones
+}
+
+func (c *cloner) problem(orig *Problem) *Problem {
+	if orig == nil {
+		return nil
+	}
+	if copied, ok := c.problemsMap[orig]; ok {
+		return copied
+	}
+
+	clone := *orig
+	c.problemsMap[orig] = &clone
+	if orig.Parts != nil {
+		clone.Parts = make([]ProblemPart, len(orig.Parts))
+		for i, part := range orig.Parts {
+			clone.Parts[i] = ProblemPart{Problem: c.problem(part.Problem), Weight: part.Weight}
+		}
+	}
+	return &clone
+}
+
+func (c *cloner) problems(orig []*Problem) []*Problem {
+	if orig == nil {
+		return nil
+	}
+	clones := make([]*Problem, len(orig))
+	for i, problem := range orig {
+		clones[i] = c.problem(problem)
+	}
+	return clones
+}
+
+func (c *cloner) contract(orig *Contract) *Contract {
+	clone := *orig
+	clone.Seller = c.industry(orig.Seller)
+	clone.Product = c.resource(orig.Product)
+	clone.BuyerIndustry = c.industry(orig.BuyerIndustry)
+	clone.BuyerSegment = c.segment(orig.BuyerSegment)
+	return &clone
+}
+
+func cloneTags(orig []string) []string {
+	if orig == nil {
+		return nil
+	}
+	return append([]string(nil), orig...)
+}
+
+func (c *cloner) household(orig *Household) *Household {
+	if orig == nil {
+		return nil
+	}
+	if copied, ok := c.householdsMap[orig]; ok {
+		return copied
+	}
+
+	clone := *orig
+	c.householdsMap[orig] = &clone
+	clone.Members = make([]*Person, len(orig.Members))
+	for i, member := range orig.Members {
+		clone.Members[i] = c.person(member)
+	}
+	return &clone
+}
+
+func (c *cloner) zone(orig *Zone) *Zone {
+	if orig == nil {
+		return nil
+	}
+	if copied, ok := c.zonesMap[orig]; ok {
+		return copied
+	}
+
+	clone := *orig
+	c.zonesMap[orig] = &clone
+	return &clone
+}
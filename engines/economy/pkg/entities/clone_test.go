@@ -0,0 +1,68 @@
+package entities
+
+import "testing"
+
+func TestRegionClone_IsIndependent(t *testing.T) {
+	region := NewRegion("TestRegion")
+	food := NewProblem("Food", "Need food", 0.9)
+	region.AddProblem(food)
+
+	grain := NewResource("Grain", "kg")
+	grain.Quantity = 100
+	region.AddResource(grain)
+
+	bread := NewResource("Bread", "loaves")
+	farm := CreateIndustry("Farm").
+		SetupIndustry([]*Problem{food}, []*Resource{grain}, []*Resource{bread}).
+		SetInitialCapital(500)
+	region.AddIndustry(farm)
+
+	segment := NewPopulationSegment("General", []*Problem{food}, 1)
+	region.AddPopulationSegment(segment)
+	owner := NewPerson("Owner", 50, 40)
+	owner.AddSegment(segment)
+	region.AddPerson(owner)
+	farm.IssueShares(owner, 10)
+
+	clone := region.Clone()
+	clonedFarm := clone.GetIndustry("Farm")
+
+	// References inside the clone point at cloned entities
+	if clonedFarm == farm || clonedFarm.InputResources[0] != clone.GetResource("Grain") {
+		t.Error("Expected the cloned farm to use the cloned grain")
+	}
+	if clonedFarm.Shareholders[0].Owner != clone.People[0] {
+		t.Error("Expected the cloned shareholding to point at the cloned owner")
+	}
+	if clone.People[0].Segments[0] != clone.PopulationSegments[0] {
+		t.Error("Expected the cloned person to belong to the cloned segment")
+	}
+
+	// Mutating the clone leaves the original untouched
+	clonedFarm.Money = 0
+	clonedFarm.InputResources[0].Quantity = 10
+	clone.People[0].Money = 80
+	clone.Problems[0].Demand = 0.1
+	if farm.Money != 500 || grain.Quantity != 100 || owner.Money != 50 || food.Demand != 0.5 {
+		t.Error("Expected the original region to be unchanged")
+	}
+
+	changes := Diff(region, clone)
+	if len(changes) != 4 {
+		t.Fatalf("Expected 4 changes, got %d: %v", len(changes), changes)
+	}
+	if changes[0].Kind != KindIndustry || changes[0].Field != "money" || changes[0].After != 0 {
+		t.Errorf("Expected farm money change first, got %v", changes[0])
+	}
+
+	clone.AddPerson(NewPerson("Newcomer", 0, 40))
+	found := false
+	for _, change := range Diff(region, clone) {
+		if change.Kind == KindPerson && change.Field == "added" && change.Name == "Newcomer" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected the newcomer to be reported as added")
+	}
+}
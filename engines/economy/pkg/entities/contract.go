@@ -0,0 +1,68 @@
+package entities
+
+var contractIDCounter = 0
+
+// Contract is a multi-tick agreement to deliver a product at a locked price.
+// Either BuyerIndustry is set (forward order for an input) or BuyerSegment is
+// set (service subscription paid by every member of the segment).
+type Contract struct {
+	ID            int
+	Seller        *Industry
+	Product       *Resource // Seller's product being delivered
+	BuyerIndustry *Industry
+	BuyerSegment  *PopulationSegment
+	UnitsPerTick  float32 // Per buyer industry, or per member for subscriptions
+	Price         float32 // Locked unit price
+	StartTick     int
+	EndTick       int     // Last tick of the contract, 0 for open-ended
+	PenaltyRate   float32 // Share of undelivered value the seller compensates
+	MaxBreaches   int     // Seller breaches tolerated before termination
+	Breaches      int
+	Active        bool
+}
+
+// NewForwardOrder creates a contract for an industry buying an input every tick
+func NewForwardOrder(seller *Industry, product *Resource, buyer *Industry, unitsPerTick, price float32) *Contract {
+	contractIDCounter++
+	return &Contract{
+		ID:            contractIDCounter,
+		Seller:        seller,
+		Product:       product,
+		BuyerIndustry: buyer,
+		UnitsPerTick:  unitsPerTick,
+		Price:         price,
+		MaxBreaches:   3,
+		Active:        true,
+	}
+}
+
+// NewSubscription creates a contract for every member of a segment receiving
+// a service every tick
+func NewSubscription(seller *Industry, product *Resource, segment *PopulationSegment, unitsPerTick, price float32) *Contract {
+	contractIDCounter++
+	return &Contract{
+		ID:           contractIDCounter,
+		Seller:       seller,
+		Product:      product,
+		BuyerSegment: segment,
+		UnitsPerTick: unitsPerTick,
+		Price:        price,
+		MaxBreaches:  3,
+		Active:       true,
+	}
+}
+
+// SetTerm limits the contract to the ticks [start, end]; end 0 is open-ended
+func (c *Contract) SetTerm(startTick, endTick int) *Contract {
+	c.StartTick = startTick
+	c.EndTick = endTick
+	return c
+}
+
+// InForce reports whether the contract should be honoured at the given tick
+func (c *Contract) InForce(tick int) bool {
+	if !c.Active || tick < c.StartTick {
+		return false
+	}
+	return c.EndTick == 0 || tick <= c.EndTick
+}
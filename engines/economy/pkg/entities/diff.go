@@ -0,0 +1,125 @@
+package entities
+
+import "fmt"
+
+// Entity kinds reported by Diff
+const (
+	KindIndustry = "industry"
+	KindPerson   = "person"
+	KindResource = "resource"
+	KindProblem  = "problem"
+)
+
+// Change is one entity-level difference between two region states. Added and
+// removed entities have Field "added" or "removed" and no values.
+type Change struct {
+	Kind   string
+	ID     int
+	Name   string
+	Field  string
+	Before float32
+	After  float32
+}
+
+// String formats the change for logs and test failures
+func (c Change) String() string {
+	if c.Field == "added" || c.Field == "removed" {
+		return fmt.Sprintf("%s %s #%d %s", c.Kind, c.Name, c.ID, c.Field)
+	}
+	return fmt.Sprintf("%s %s #%d %s: %.2f -> %.2f", c.Kind, c.Name, c.ID, c.Field, c.Before, c.After)
+}
+
+// Diff reports what changed from region a to region b, matching entities by
+// ID: people's money, savings, skill and labor hours, industries' money and
+// product stock, resource quantities and problem demand, plus entities that
+// were added or removed.
+func Diff(a, b *Region) []Change {
+	changes := make([]Change, 0)
+
+	industriesA := make(map[int]*Industry, len(a.Industries))
+	for _, industry := range a.Industries {
+		industriesA[industry.ID] = industry
+	}
+	for _, after := range b.Industries {
+		before, exists := industriesA[after.ID]
+		if !exists {
+			changes = append(changes, Change{Kind: KindIndustry, ID: after.ID, Name: after.Name, Field: "added"})
+			continue
+		}
+		delete(industriesA, after.ID)
+		changes = appendChange(changes, KindIndustry, after.ID, after.Name, "money", before.Money, after.Money)
+		stockBefore := make(map[string]float32, len(before.OutputProducts))
+		for _, product := range before.OutputProducts {
+			stockBefore[product.Name] = product.Quantity
+		}
+		for _, product := range after.OutputProducts {
+			changes = appendChange(changes, KindIndustry, after.ID, after.Name, "stock:"+product.Name, stockBefore[product.Name], product.Quantity)
+		}
+	}
+	for _, industry := range a.Industries {
+		if _, removed := industriesA[industry.ID]; removed {
+			changes = append(changes, Change{Kind: KindIndustry, ID: industry.ID, Name: industry.Name, Field: "removed"})
+		}
+	}
+
+	peopleA := make(map[int]*Person, len(a.People))
+	for _, person := range a.People {
+		peopleA[person.ID] = person
+	}
+	for _, after := range b.People {
+		before, exists := peopleA[after.ID]
+		if !exists {
+			changes = append(changes, Change{Kind: KindPerson, ID: after.ID, Name: after.Name, Field: "added"})
+			continue
+		}
+		delete(peopleA, after.ID)
+		changes = appendChange(changes, KindPerson, after.ID, after.Name, "money", before.Money, after.Money)
+		changes = appendChange(changes, KindPerson, after.ID, after.Name, "savings", before.Savings, after.Savings)
+		changes = appendChange(changes, KindPerson, after.ID, after.Name, "skill", before.Skill, after.Skill)
+		changes = appendChange(changes, KindPerson, after.ID, after.Name, "labor_hours", before.LaborHours, after.LaborHours)
+	}
+	for _, person := range a.People {
+		if _, removed := peopleA[person.ID]; removed {
+			changes = append(changes, Change{Kind: KindPerson, ID: person.ID, Name: person.Name, Field: "removed"})
+		}
+	}
+
+	resourcesA := make(map[int]*Resource, len(a.Resources))
+	for _, resource := range a.Resources {
+		resourcesA[resource.ID] = resource
+	}
+	for _, after := range b.Resources {
+		before, exists := resourcesA[after.ID]
+		if !exists {
+			changes = append(changes, Change{Kind: KindResource, ID: after.ID, Name: after.Name, Field: "added"})
+			continue
+		}
+		delete(resourcesA, after.ID)
+		changes = appendChange(changes, KindResource, after.ID, after.Name, "quantity", before.Quantity, after.Quantity)
+	}
+	for _, resource := range a.Resources {
+		if _, removed := resourcesA[resource.ID]; removed {
+			changes = append(changes, Change{Kind: KindResource, ID: resource.ID, Name: resource.Name, Field: "removed"})
+		}
+	}
+
+	problemsA := make(map[int]*Problem, len(a.Problems))
+	for _, problem := range a.Problems {
+		problemsA[problem.ID] = problem
+	}
+	for _, after := range b.Problems {
+		if before, exists := problemsA[after.ID]; exists {
+			changes = appendChange(changes, KindProblem, after.ID, after.Name, "demand", before.Demand, after.Demand)
+		}
+	}
+
+	return changes
+}
+
+// appendChange records a field change if the value moved
+func appendChange(changes []Change, kind string, id int, name, field string, before, after float32) []Change {
+	if before == after {
+		return changes
+	}
+	return append(changes, Change{Kind: kind, ID: id, Name: name, Field: field, Before: before, After: after})
+}
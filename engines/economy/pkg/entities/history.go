@@ -0,0 +1,115 @@
+package entities
+
+// PurchaseRecord is one purchase a person remembers
+type PurchaseRecord struct {
+	Tick         int
+	ProblemID    int
+	IndustryID   int
+	Units        float32
+	Satisfaction float32
+}
+
+// NeedMemory is how well a person remembers a need being met
+type NeedMemory struct {
+	Satisfaction float32 // Moving average of the share of the need met per tick
+	Seller       int     // Industry last bought from (0 = none yet)
+	Loyalty      int     // Consecutive ticks the need was bought from Seller
+}
+
+// History is a person's memory of recent purchases and of how well each of
+// their needs has been met. Hooks can read it to react to individual
+// experience; the market uses it for loyal seller choice.
+type History struct {
+	Length int                 // Purchases kept, and ticks the satisfaction average spans
+	Recent []PurchaseRecord    // Latest purchases, oldest first
+	Needs  map[int]*NeedMemory // Per problem ID
+}
+
+// NewHistory creates an empty history remembering the given number of
+// purchases and ticks
+func NewHistory(length int) *History {
+	return &History{
+		Length: max(length, 1),
+		Needs:  make(map[int]*NeedMemory),
+	}
+}
+
+// Remember adds a purchase, forgetting the oldest beyond Length
+func (h *History) Remember(record PurchaseRecord) {
+	if len(h.Recent) >= h.Length {
+		copy(h.Recent, h.Recent[1:])
+		h.Recent = h.Recent[:len(h.Recent)-1]
+	}
+	h.Recent = append(h.Recent, record)
+}
+
+// RecordNeed folds one tick's experience of a need into its memory: the
+// share of the need met (0 when unmet) and the seller bought from (0 when
+// none). The satisfaction average weighs the latest tick by 1/Length.
+func (h *History) RecordNeed(problemID int, satisfaction float32, seller int) {
+	memory, exists := h.Needs[problemID]
+	if !exists {
+		memory = &NeedMemory{Satisfaction: satisfaction}
+		h.Needs[problemID] = memory
+	} else {
+		memory.Satisfaction += (satisfaction - memory.Satisfaction) / float32(h.Length)
+	}
+
+	switch {
+	case seller == 0:
+		memory.Loyalty = 0
+	case seller == memory.Seller:
+		memory.Loyalty++
+	default:
+		memory.Seller = seller
+		memory.Loyalty = 1
+	}
+}
+
+// Satisfaction returns the remembered satisfaction of a need (0 if never met)
+func (h *History) Satisfaction(problemID int) float32 {
+	if memory, exists := h.Needs[problemID]; exists {
+		return memory.Satisfaction
+	}
+	return 0
+}
+
+// LastSeller returns the industry the need was last bought from (0 if none)
+func (h *History) LastSeller(problemID int) int {
+	if memory, exists := h.Needs[problemID]; exists {
+		return memory.Seller
+	}
+	return 0
+}
+
+// Satisfaction is the person's average remembered satisfaction across
+// their needs, between 0 (nothing met) and about 1 (everything met). It is
+// 0 without a history.
+func (p *Person) Satisfaction() float32 {
+	if p.History == nil {
+		return 0
+	}
+	needs := p.GetAllProblems()
+	if len(needs) == 0 {
+		return 0
+	}
+	total := float32(0)
+	for _, need := range needs {
+		total += p.History.Satisfaction(need.ID)
+	}
+	return total / float32(len(needs))
+}
+
+// clone returns a deep copy of the history
+func (h *History) clone() *History {
+	clone := &History{
+		Length: h.Length,
+		Recent: append([]PurchaseRecord(nil), h.Recent...),
+		Needs:  make(map[int]*NeedMemory, len(h.Needs)),
+	}
+	for id, memory := range h.Needs {
+		copied := *memory
+		clone.Needs[id] = &copied
+	}
+	return clone
+}
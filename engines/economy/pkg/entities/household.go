@@ -0,0 +1,84 @@
+package entities
+
+var householdIDCounter = 0
+
+// Household is a group of people living together who pool their cash, so
+// earners pay for the needs of members without an income. Children have
+// needs but never work.
+type Household struct {
+	ID      int
+	Name    string
+	Members []*Person
+}
+
+// NewHousehold creates an empty household
+func NewHousehold(name string) *Household {
+	householdIDCounter++
+	return &Household{
+		ID:      householdIDCounter,
+		Name:    name,
+		Members: make([]*Person, 0),
+	}
+}
+
+// AddMember moves a person into the household
+func (h *Household) AddMember(person *Person) {
+	if person.Household != nil {
+		person.Household.RemoveMember(person)
+	}
+	h.Members = append(h.Members, person)
+	person.Household = h
+}
+
+// RemoveMember takes a person out of the household, returning false if they
+// did not live there
+func (h *Household) RemoveMember(person *Person) bool {
+	if person.Household != h {
+		return false
+	}
+	h.Members = removeFrom(h.Members, person)
+	person.Household = nil
+	return true
+}
+
+// Money returns the members' combined cash
+func (h *Household) Money() float32 {
+	total := float32(0)
+	for _, member := range h.Members {
+		total += member.Money
+	}
+	return total
+}
+
+// Children returns how many members are children
+func (h *Household) Children() int {
+	children := 0
+	for _, member := range h.Members {
+		if member.Child {
+			children++
+		}
+	}
+	return children
+}
+
+// Pool splits the members' cash evenly between them and returns how much
+// changed hands. Savings stay with whoever deposited them.
+func (h *Household) Pool() float32 {
+	if len(h.Members) < 2 {
+		return 0
+	}
+	share := h.Money() / float32(len(h.Members))
+	moved := float32(0)
+	for _, member := range h.Members {
+		if member.Money > share {
+			moved += member.Money - share
+		}
+		member.Money = share
+	}
+	return moved
+}
+
+// AddHousehold adds a household to the region
+func (r *Region) AddHousehold(household *Household) {
+	r.Households = append(r.Households, household)
+}
@@ -0,0 +1,42 @@
+package entities
+
+import "testing"
+
+func TestHousehold_PoolAndMembership(t *testing.T) {
+	region := NewRegion("TestRegion")
+	earner := NewPerson("Earner", 90, 40)
+	child := NewPerson("Child", 0, 0)
+	child.Child = true
+	household := NewHousehold("Family")
+	household.AddMember(earner)
+	household.AddMember(child)
+	region.AddHousehold(household)
+	region.AddPerson(earner)
+	region.AddPerson(child)
+
+	if moved := household.Pool(); moved != 45 {
+		t.Errorf("Expected $45 to change hands, got %.2f", moved)
+	}
+	if earner.Money != 45 || child.Money != 45 {
+		t.Errorf("Expected both members to hold $45, got %.2f and %.2f", earner.Money, child.Money)
+	}
+	if household.Children() != 1 {
+		t.Errorf("Expected 1 child, got %d", household.Children())
+	}
+
+	clone := region.Clone()
+	if clone.People[0].Household != clone.Households[0] || clone.Households[0].Members[1] != clone.People[1] {
+		t.Error("Expected the cloned household to hold the cloned people")
+	}
+
+	// Moving out leaves the old household
+	other := NewHousehold("Flat")
+	other.AddMember(child)
+	if len(household.Members) != 1 || child.Household != other {
+		t.Error("Expected the child to move to the new household")
+	}
+	region.RemovePerson(earner)
+	if len(household.Members) != 0 || earner.Household != nil {
+		t.Error("Expected a removed person to leave their household")
+	}
+}
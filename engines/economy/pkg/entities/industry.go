@@ -1,20 +1,88 @@
 package entities
 
+import "slices"
+
 var industryIDCounter = 0
 
 // Industry represents a business entity that produces goods/services
 type Industry struct {
-	ID                int
-	Name              string
-	OwnedProblems     []*Problem  // Problems this industry solves (1-2 problems)
-	InputResources    []*Resource // Resources needed for production
-	OutputProducts    []*Resource // Products produced
-	LaborNeeded       float32     // Hours of labor needed per time unit
-	ConsumptionRate   float32     // Rate at which input resources are consumed per unit labor week
-	ProductionRate    float32     // Rate at which output products are produced per unit labor hour
-	Money             float32     // Money owned by the industry
-	LaborEmployed     float32     // Number of laborers employed per tick
-	ProductionHistory []ProductionRecord
+	ID                 int
+	Name               string
+	OwnedProblems      []*Problem  // Problems this industry solves (1-2 problems)
+	InputResources     []*Resource // Resources needed for production
+	OutputProducts     Inventory   // Products produced, held for sale
+	LaborNeeded        float32     // Hours of labor needed per time unit
+	ConsumptionRate    float32     // Rate at which input resources are consumed per unit labor week
+	ProductionRate     float32     // Rate at which output products are produced per unit labor hour
+	Money              float32     // Money owned by the industry
+	LaborEmployed      float32     // Number of laborers employed per tick
+	Bankrupt           bool        // Could not meet its last wage bill
+	BarteredLaborHours float32     // Unpaid hours owed by people who bartered for goods
+	ProductionHistory  []ProductionRecord
+	SalesHistory       []float32 // Units sold per tick of the best-selling product, oldest first
+	ProductionTarget   float32   // Units to have for sale each tick (0 = forecast or full capacity)
+	Shifts             int       // Production rounds per tick, each with its own crew (0 = 1)
+	NightPremium       float32   // Extra wage share paid for shifts after the first, e.g. 0.5
+	FixedCosts         float32   // Overheads (rent, administration) paid every tick regardless of output
+	HiresFrom          []string  // Segments it hires workers from (empty = worker segments)
+	InputPriority      int       // Rank for scarce shared inputs under the priority rule, highest first
+
+	// Land and other stock resources
+	Holdings map[int]float32 // Units it occupies, by resource ID
+
+	// Borrowing
+	Loans          []*Loan   // Outstanding bank loans
+	RevenueHistory []float32 // Sales income per tick, oldest first
+	InterestPaid   float32   // Interest paid on loans so far
+	PaymentsMade   int       // Ticks its debt service was paid in full
+	PaymentsMissed int       // Ticks it fell short on its debt service
+
+	// Maintenance and breakdowns
+	Reliability   *Reliability // Outage schedule (nil = never down)
+	DownFor       int          // Ticks left of the current outage after this one
+	DowntimeTicks int          // Ticks spent down so far
+
+	// Distribution sector
+	IsRetailer      bool        // Buys in bulk from suppliers instead of producing
+	Suppliers       []*Industry // Producers a retailer restocks from
+	TargetInventory float32     // Stock level a retailer orders up to each tick
+	Markup          float32     // Retail markup over the wholesale price
+	SellsWholesale  bool        // Producer sells only to retailers, not to people
+
+	// Location and transport
+	Zone        *Zone // Where the industry operates (nil = no location)
+	IsTransport bool  // Output is transport capacity that lowers zone frictions
+
+	// Education sector
+	IsSchool    bool    // Teaches enrolled people instead of producing goods
+	Seats       int     // Students the school can teach at once
+	CourseTicks int     // Ticks a student spends in school before graduating
+	SkillGain   float32 // Skill a graduate gains
+	Tuition     float32 // Fee each student pays per tick
+
+	// Marketing
+	MarketingSpend float32 // Money spent on advertising each tick
+	Awareness      float32 // Chance a shopper considers the industry among its rivals
+
+	// Public sector
+	Public  bool    // Run for the government, which funds its sales
+	Subsidy float32 // Share of the price the treasury pays (1 = free to shoppers)
+
+	// Ownership
+	Shareholders     []*Shareholding // People owning the industry
+	DividendPayout   float32         // Share of each tick's profit paid out as dividends
+	TotalDistributed float32         // Dividends paid out so far
+	Cooperative      bool            // Worker-owned: its workers join as members with one share each
+
+	Tags []string // Free-form labels for analyses, e.g. "export"
+}
+
+// Reliability schedules an industry's maintenance and how often it breaks
+// down. Production halts while it is down.
+type Reliability struct {
+	Chance   float32 // Chance of a breakdown each tick
+	Every    int     // Scheduled maintenance every Every ticks (0 = none)
+	Duration int     // Ticks an outage lasts (0 = 1)
 }
 
 // ProductionRecord tracks historical production data for cost analysis
@@ -40,6 +108,16 @@ func CreateIndustry(name string) *Industry {
 	}
 }
 
+// HasTag reports whether the industry carries the tag
+func (i *Industry) HasTag(tag string) bool {
+	return hasTag(i.Tags, tag)
+}
+
+// AddTag labels the industry with a tag, once
+func (i *Industry) AddTag(tag string) {
+	i.Tags = addTag(i.Tags, tag)
+}
+
 // SetupIndustry sets OwnedProblems, InputResources, OutputProducts
 func (i *Industry) SetupIndustry(problems []*Problem, inputs []*Resource, outputs []*Resource) *Industry {
 	i.OwnedProblems = problems
@@ -48,6 +126,30 @@ func (i *Industry) SetupIndustry(problems []*Problem, inputs []*Resource, output
 	return i
 }
 
+// Solves reports whether the industry solves a problem
+func (i *Industry) Solves(problem *Problem) bool {
+	for _, p := range i.OwnedProblems {
+		if p.ID == problem.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// ProductFor returns the product the industry sells for a problem: the
+// first output that solves it, or nil when the industry doesn't solve it
+func (i *Industry) ProductFor(problem *Problem) *Resource {
+	if !i.Solves(problem) {
+		return nil
+	}
+	for _, product := range i.OutputProducts {
+		if product.SolvesProblem(problem) {
+			return product
+		}
+	}
+	return nil
+}
+
 // UpdateIndustryRates sets LaborNeeded, ConsumptionRate, ProductionRate
 func (i *Industry) UpdateIndustryRates(laborNeeded, consumptionRate, productionRate float32) *Industry {
 	i.LaborNeeded = laborNeeded
@@ -69,6 +171,15 @@ func (i *Industry) UpdateProductionrate(productionRate float32) {
 	i.ProductionRate = productionRate
 }
 
+// AddSupplier makes this industry a retailer restocking from a producer,
+// which from then on sells only wholesale
+func (i *Industry) AddSupplier(supplier *Industry) *Industry {
+	i.IsRetailer = true
+	i.Suppliers = append(i.Suppliers, supplier)
+	supplier.SellsWholesale = true
+	return i
+}
+
 // UpdateIndustryMoney updates the industry's cash balance
 func (i *Industry) UpdateIndustryMoney(amount float32) *Industry {
 	i.Money += amount
@@ -91,6 +202,16 @@ func (i *Industry) RecordProduction(record ProductionRecord) {
 	}
 }
 
+// RecordSales adds a tick's units sold to the sales history
+func (i *Industry) RecordSales(units float32) {
+	i.SalesHistory = append(i.SalesHistory, units)
+
+	// Keep only last 10 ticks, like production history
+	if len(i.SalesHistory) > 10 {
+		i.SalesHistory = i.SalesHistory[1:]
+	}
+}
+
 // GetAverageCostPerUnit calculates the average cost per unit from recent production
 func (i *Industry) GetAverageCostPerUnit() float32 {
 	if len(i.ProductionHistory) == 0 {
@@ -112,3 +233,14 @@ func (i *Industry) GetLastProductionCost() float32 {
 	}
 	return i.ProductionHistory[len(i.ProductionHistory)-1].CostPerUnit
 }
+
+// Hires reports whether the industry hires from one of the person's
+// segments: those it names, or the worker segments when it names none
+func (i *Industry) Hires(person *Person) bool {
+	for _, segment := range person.Segments {
+		if len(i.HiresFrom) == 0 && segment.IsWorkers() || slices.Contains(i.HiresFrom, segment.Name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package entities
+
+// Inventory is the stock of products an industry holds for sale. Every
+// industry owns its inventory: a stock is never shared with the region's
+// natural resources or with another industry making the same product, and
+// goods only move between stocks through Transfer. An industry using the
+// product as an input without a contract draws on the stock directly.
+type Inventory []*Resource
+
+// Find returns the stock of the named product, or nil if the inventory has
+// none
+func (inv Inventory) Find(name string) *Resource {
+	for _, stock := range inv {
+		if stock.Name == name {
+			return stock
+		}
+	}
+	return nil
+}
+
+// NewStock creates an empty stock of the same product, carrying its product
+// attributes, for another industry's inventory
+func (r *Resource) NewStock() *Resource {
+	stock := NewResource(r.Name, r.Unit)
+	stock.Efficiency = r.Efficiency
+	stock.Complements = r.Complements
+	stock.Solves = r.Solves
+	stock.Satisfies = r.Satisfies
+	stock.Tags = append([]string(nil), r.Tags...)
+	return stock
+}
+
+// Transfer moves up to units of a product from one stock to another, such as
+// from a supplier's inventory to a retailer's, and returns the units moved
+func Transfer(from, to *Resource, units float32) float32 {
+	return TransferAt(from, to, units, 1)
+}
+
+// TransferAt moves up to units of a product into a stock counted in another
+// unit, rate being the receiving stock's units per unit moved, and returns
+// the units moved, in the sending stock's unit
+func TransferAt(from, to *Resource, units, rate float32) float32 {
+	moved := min(max(units, 0), max(from.Quantity, 0))
+	from.Quantity -= moved
+	to.Quantity += moved * rate
+	return moved
+}
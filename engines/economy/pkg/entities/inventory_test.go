@@ -0,0 +1,22 @@
+package entities
+
+import "testing"
+
+func TestTransfer_MovesOnlyWhatTheStockHolds(t *testing.T) {
+	supply := NewResource("Food", "units")
+	supply.Quantity = 30
+	stock := supply.NewStock()
+
+	if moved := Transfer(supply, stock, 20); moved != 20 {
+		t.Errorf("Expected 20 units moved, got %.0f", moved)
+	}
+	if moved := Transfer(supply, stock, 20); moved != 10 {
+		t.Errorf("Expected the last 10 units moved, got %.0f", moved)
+	}
+	if supply.Quantity != 0 || stock.Quantity != 30 {
+		t.Errorf("Expected 0 and 30 units left, got %.0f and %.0f", supply.Quantity, stock.Quantity)
+	}
+	if Inventory([]*Resource{stock}).Find("Food") != stock || stock == supply {
+		t.Error("Expected a separate stock found by name")
+	}
+}
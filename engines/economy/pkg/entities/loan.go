@@ -0,0 +1,57 @@
+package entities
+
+// Loan is money an industry borrowed from the bank. The principal is repaid
+// in equal installments over the term, with interest on the balance still
+// owed.
+type Loan struct {
+	Principal float32 // Amount borrowed
+	Balance   float32 // Principal still owed
+	Rate      float32 // Interest per tick on the balance, fixed when the loan is made
+	Term      int     // Ticks the principal is repaid over
+	TakenAt   int     // Tick the loan was made
+}
+
+// Interest returns the interest due this tick
+func (l *Loan) Interest() float32 {
+	return l.Balance * l.Rate
+}
+
+// Installment returns the principal due this tick
+func (l *Loan) Installment() float32 {
+	if l.Term <= 0 {
+		return l.Balance
+	}
+	return min(l.Principal/float32(l.Term), l.Balance)
+}
+
+// Debt returns the principal the industry still owes on all its loans
+func (i *Industry) Debt() float32 {
+	total := float32(0)
+	for _, loan := range i.Loans {
+		total += loan.Balance
+	}
+	return total
+}
+
+// RecordRevenue adds a tick's sales income to the revenue history
+func (i *Industry) RecordRevenue(amount float32) {
+	i.RevenueHistory = append(i.RevenueHistory, amount)
+
+	// Keep only last 10 ticks, like sales history
+	if len(i.RevenueHistory) > 10 {
+		i.RevenueHistory = i.RevenueHistory[1:]
+	}
+}
+
+// AverageRevenue returns the industry's mean revenue per tick over its
+// revenue history
+func (i *Industry) AverageRevenue() float32 {
+	if len(i.RevenueHistory) == 0 {
+		return 0
+	}
+	total := float32(0)
+	for _, amount := range i.RevenueHistory {
+		total += amount
+	}
+	return total / float32(len(i.RevenueHistory))
+}
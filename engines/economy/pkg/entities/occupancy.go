@@ -0,0 +1,42 @@
+package entities
+
+// IsStock reports whether production occupies the resource, like land,
+// rather than using it up
+func (r *Resource) IsStock() bool {
+	return r.Kind == StockResource
+}
+
+// Holding returns the units of a stock resource the industry occupies
+func (i *Industry) Holding(resource *Resource) float32 {
+	return i.Holdings[resource.ID]
+}
+
+// Occupy takes up to units of a stock resource out of its free quantity for
+// the industry to hold, and returns the units taken
+func (i *Industry) Occupy(resource *Resource, units float32) float32 {
+	taken := min(max(units, 0), max(resource.Quantity, 0))
+	if taken == 0 {
+		return 0
+	}
+	if i.Holdings == nil {
+		i.Holdings = make(map[int]float32)
+	}
+	resource.Quantity -= taken
+	i.Holdings[resource.ID] += taken
+	return taken
+}
+
+// Release frees up to units of a stock resource the industry holds, and
+// returns the units freed
+func (i *Industry) Release(resource *Resource, units float32) float32 {
+	freed := min(max(units, 0), i.Holdings[resource.ID])
+	if freed == 0 {
+		return 0
+	}
+	resource.Quantity += freed
+	i.Holdings[resource.ID] -= freed
+	if i.Holdings[resource.ID] <= 0 {
+		delete(i.Holdings, resource.ID)
+	}
+	return freed
+}
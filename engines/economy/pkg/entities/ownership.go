@@ -0,0 +1,91 @@
+package entities
+
+import "fmt"
+
+// Shareholding records how many shares of an industry a person owns
+type Shareholding struct {
+	Owner  *Person
+	Shares float32
+}
+
+// IssueShares gives a person newly issued shares of the industry
+func (i *Industry) IssueShares(owner *Person, shares float32) *Industry {
+	if holding := i.holdingOf(owner); holding != nil {
+		holding.Shares += shares
+		return i
+	}
+	i.Shareholders = append(i.Shareholders, &Shareholding{Owner: owner, Shares: shares})
+	return i
+}
+
+// AdmitMembers makes a cooperative's workers members with one share each,
+// returning how many joined. Existing members keep their single share.
+func (i *Industry) AdmitMembers(workers []*Person) int {
+	if !i.Cooperative {
+		return 0
+	}
+	joined := 0
+	for _, worker := range workers {
+		if i.holdingOf(worker) == nil {
+			i.Shareholders = append(i.Shareholders, &Shareholding{Owner: worker, Shares: 1})
+			joined++
+		}
+	}
+	return joined
+}
+
+// TotalShares returns the number of shares outstanding
+func (i *Industry) TotalShares() float32 {
+	total := float32(0)
+	for _, holding := range i.Shareholders {
+		total += holding.Shares
+	}
+	return total
+}
+
+// SharesOf returns how many shares a person holds
+func (i *Industry) SharesOf(owner *Person) float32 {
+	if holding := i.holdingOf(owner); holding != nil {
+		return holding.Shares
+	}
+	return 0
+}
+
+// TransferShares sells shares from one person to another at a price per share
+func (i *Industry) TransferShares(from, to *Person, shares, pricePerShare float32) error {
+	holding := i.holdingOf(from)
+	if holding == nil || holding.Shares < shares {
+		return fmt.Errorf("%s does not hold %.2f shares of %s", from.Name, shares, i.Name)
+	}
+
+	cost := shares * pricePerShare
+	if to.Money < cost {
+		return fmt.Errorf("%s cannot afford %.2f shares of %s for %.2f", to.Name, shares, i.Name, cost)
+	}
+
+	to.Money -= cost
+	from.Money += cost
+	holding.Shares -= shares
+	i.IssueShares(to, shares)
+
+	// Drop holders who sold out
+	if holding.Shares == 0 {
+		for idx, h := range i.Shareholders {
+			if h == holding {
+				i.Shareholders = append(i.Shareholders[:idx], i.Shareholders[idx+1:]...)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (i *Industry) holdingOf(owner *Person) *Shareholding {
+	for _, holding := range i.Shareholders {
+		if holding.Owner == owner {
+			return holding
+		}
+	}
+	return nil
+}
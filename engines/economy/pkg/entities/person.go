@@ -8,7 +8,33 @@ var personIDCounter = 0
 type PopulationSegment struct {
 	Name     string
 	Problems []*Problem // Problems this segment faces
-	Size     int        // Number of people in this segment
+	Size     int        // Number of people in this segment, kept to the members once any join
+	Role     string     // What the segment is to the engine, e.g. RoleWorkers
+
+	SavingsPropensity float32 // Share of leftover cash members deposit each tick
+
+	// Labor supply choices (0 = work the standard hours at any wage)
+	ReservationWage float32 // Lowest hourly wage members work for
+	TargetIncome    float32 // Wage income per tick members work for
+
+	Retired bool // Members no longer work and may draw a pension
+
+	members []*Person // People who joined through AddSegment, in joining order
+}
+
+// Segment roles
+const (
+	RoleWorkers = "workers" // Members look for jobs every tick
+)
+
+// legacyWorkersSegment is the name that makes a segment without a role a
+// worker segment, as before segments had roles
+const legacyWorkersSegment = "Workers"
+
+// IsWorkers reports whether the segment's members look for jobs: it has the
+// workers role, or no role and the name "Workers"
+func (s *PopulationSegment) IsWorkers() bool {
+	return s.Role == RoleWorkers || s.Role == "" && s.Name == legacyWorkersSegment
 }
 
 // NewPopulationSegment creates a new population segment
@@ -22,11 +48,34 @@ func NewPopulationSegment(name string, problems []*Problem, size int) *Populatio
 
 // Person represents an individual in the economy
 type Person struct {
-	ID         int
-	Name       string
-	Segments   []*PopulationSegment // A person can belong to multiple segments
-	Money      float32              // Personal wealth
-	LaborHours float32              // Available labor hours per time unit
+	ID           int
+	Name         string
+	Segments     []*PopulationSegment // A person can belong to multiple segments
+	Money        float32              // Personal wealth
+	Savings      float32              // Money deposited in the bank
+	LaborHours   float32              // Hours a working day the person can work
+	HoursLeft    float32              // Labor hours left to work this tick
+	PledgedHours float32              // Hours owed for barter, taken from the next tick's hours
+	Skill        float32              // Productivity multiplier when working (1.0 = baseline)
+	Zone         *Zone                // Where the person lives (nil = no location)
+	Household    *Household           // Who the person pools cash with (nil = lives alone)
+	Child        bool                 // Has needs but never works
+
+	// School the person is enrolled in and ticks until they graduate
+	School          *Industry
+	CourseTicksLeft int
+
+	// CoveredProblems marks needs already served this tick by a subscription
+	CoveredProblems map[int]bool
+
+	// Severities holds how critical each dynamic need currently is for the
+	// person, by problem ID (missing = the problem's usual severity)
+	Severities map[int]float32
+
+	// History remembers recent purchases and satisfaction (nil = not tracked)
+	History *History
+
+	Tags []string // Free-form labels for analyses, e.g. "informal"
 }
 
 // NewPerson creates a new Person instance
@@ -38,30 +87,172 @@ func NewPerson(name string, initialMoney, laborHours float32) *Person {
 		Segments:   make([]*PopulationSegment, 0),
 		Money:      initialMoney,
 		LaborHours: laborHours,
+		Skill:      1.0,
 	}
 }
 
-// AddSegment adds a population segment to this person
+// AddSegment adds a population segment to this person, and the person to
+// the segment's members
 func (p *Person) AddSegment(segment *PopulationSegment) {
+	if p.HasSegment(segment) {
+		return
+	}
 	p.Segments = append(p.Segments, segment)
+	segment.members = append(segment.members, p)
+	segment.Size = len(segment.members)
 }
 
-// GetAllProblems returns all unique problems from all segments
-func (p *Person) GetAllProblems() []*Problem {
-	problemMap := make(map[string]*Problem)
+// RemoveSegment takes the person out of a population segment, returning
+// false if they were not in it
+func (p *Person) RemoveSegment(segment *PopulationSegment) bool {
+	if !p.HasSegment(segment) {
+		return false
+	}
+	p.Segments = removeFrom(p.Segments, segment)
+	segment.members = removeFrom(segment.members, p)
+	segment.Size = len(segment.members)
+	return true
+}
+
+// HasSegment reports whether the person belongs to the given segment
+func (p *Person) HasSegment(segment *PopulationSegment) bool {
+	for _, s := range p.Segments {
+		if s == segment {
+			return true
+		}
+	}
+	return false
+}
+
+// SavingsPropensity returns the highest propensity to save among the
+// person's segments
+func (p *Person) SavingsPropensity() float32 {
+	propensity := float32(0)
 	for _, segment := range p.Segments {
-		for _, problem := range segment.Problems {
-			problemMap[problem.Name] = problem
+		propensity = max(propensity, segment.SavingsPropensity)
+	}
+	return propensity
+}
+
+// HasTag reports whether the person carries the tag
+func (p *Person) HasTag(tag string) bool {
+	return hasTag(p.Tags, tag)
+}
+
+// AddTag labels the person with a tag, once
+func (p *Person) AddTag(tag string) {
+	p.Tags = addTag(p.Tags, tag)
+}
+
+// IsEnrolled reports whether the person is currently in school
+func (p *Person) IsEnrolled() bool {
+	return p.School != nil
+}
+
+// Wealth returns cash plus savings
+func (p *Person) Wealth() float32 {
+	return p.Money + p.Savings
+}
+
+// ReservationWage returns the highest reservation wage among the person's
+// segments
+func (p *Person) ReservationWage() float32 {
+	wage := float32(0)
+	for _, segment := range p.Segments {
+		wage = max(wage, segment.ReservationWage)
+	}
+	return wage
+}
+
+// Retired reports whether any of the person's segments is retired
+func (p *Person) Retired() bool {
+	for _, segment := range p.Segments {
+		if segment.Retired {
+			return true
 		}
 	}
+	return false
+}
+
+// TargetIncome returns the highest income target among the person's segments
+func (p *Person) TargetIncome() float32 {
+	target := float32(0)
+	for _, segment := range p.Segments {
+		target = max(target, segment.TargetIncome)
+	}
+	return target
+}
+
+// Severity returns how critical a need currently is for the person
+func (p *Person) Severity(problem *Problem) float32 {
+	if severity, tracked := p.Severities[problem.ID]; tracked {
+		return severity
+	}
+	return problem.Severity
+}
 
-	problems := make([]*Problem, 0, len(problemMap))
-	for _, problem := range problemMap {
-		problems = append(problems, problem)
+// Escalate makes a need that went unmet more severe, up to 1
+func (p *Person) Escalate(problem *Problem) {
+	if p.Severities == nil {
+		p.Severities = make(map[int]float32)
+	}
+	p.Severities[problem.ID] = min(p.Severity(problem)+problem.Escalation, 1)
+}
+
+// Relieve lets a need that was met decay back towards its usual severity
+func (p *Person) Relieve(problem *Problem) {
+	severity, tracked := p.Severities[problem.ID]
+	if !tracked {
+		return
+	}
+	severity -= (severity - problem.Severity) * problem.Decay
+	if severity-problem.Severity < 0.001 {
+		delete(p.Severities, problem.ID)
+		return
+	}
+	p.Severities[problem.ID] = severity
+}
+
+// UnitsWanted returns how many units the person buys for a need this tick:
+// the problem's units, scaled up by how much more severe than usual the
+// need has grown (a need at its usual severity buys the configured units)
+func (p *Person) UnitsWanted(problem *Problem) int {
+	units := problem.UnitsWanted()
+	severity, tracked := p.Severities[problem.ID]
+	if !tracked || severity <= problem.Severity {
+		return units
+	}
+	return max(int(float32(units)*(1+severity-problem.Severity)+0.5), units)
+}
+
+// GetAllProblems returns all unique problems from all segments, with
+// composite needs replaced by the sub-problems they are made of
+func (p *Person) GetAllProblems() []*Problem {
+	// Collect in segment order, so shopping order is the same every run
+	seen := make(map[string]bool)
+	problems := make([]*Problem, 0)
+	add := func(problem *Problem) {
+		if !seen[problem.Name] {
+			seen[problem.Name] = true
+			problems = append(problems, problem)
+		}
+	}
+	for _, segment := range p.Segments {
+		for _, problem := range segment.Problems {
+			if problem.IsComposite() {
+				for _, leaf := range problem.Leaves() {
+					add(leaf)
+				}
+				continue
+			}
+			add(problem)
+		}
 	}
 	return problems
 }
 
-func (s *PopulationSegment) UpdateSize(size int) {
-	s.Size = size
+// Members returns the segment's people in the order they joined. The slice
+// belongs to the segment; use AddSegment and RemoveSegment to change it.
+func (s *PopulationSegment) Members() []*Person {
+	return s.members
 }
@@ -0,0 +1,44 @@
+package entities
+
+import "testing"
+
+func TestPopulationSegment_Members(t *testing.T) {
+	region := NewRegion("TestRegion")
+	students := NewPopulationSegment("Students", nil, 100) // Planned size until people join
+	workers := NewPopulationSegment("Workers", nil, 0)
+	region.AddPopulationSegment(students)
+	region.AddPopulationSegment(workers)
+
+	alice := NewPerson("Alice", 10, 40)
+	bob := NewPerson("Bob", 10, 40)
+	for _, person := range []*Person{alice, bob} {
+		person.AddSegment(students)
+		region.AddPerson(person)
+	}
+	alice.AddSegment(students)
+	if students.Size != 2 || len(alice.Segments) != 1 {
+		t.Fatalf("Expected 2 students and no double membership, got %d", students.Size)
+	}
+
+	// Alice graduates
+	if !alice.RemoveSegment(students) || alice.RemoveSegment(students) {
+		t.Error("Expected Alice to leave the students exactly once")
+	}
+	alice.AddSegment(workers)
+	if students.Size != 1 || students.Members()[0] != bob {
+		t.Errorf("Expected only Bob to remain a student, got %d", students.Size)
+	}
+	if workers.Size != 1 || !alice.HasSegment(workers) || alice.HasSegment(students) {
+		t.Error("Expected Alice to be a worker only")
+	}
+
+	clone := region.Clone()
+	if clone.PopulationSegments[0].Members()[0] != clone.People[1] {
+		t.Error("Expected the cloned segment's members to be the cloned people")
+	}
+
+	region.RemovePerson(bob)
+	if students.Size != 0 || len(students.Members()) != 0 {
+		t.Errorf("Expected a removed person to leave their segments, got %d", students.Size)
+	}
+}
@@ -8,9 +8,30 @@ type Problem struct {
 	ID          int
 	Name        string
 	Description string
-	Severity    float32 // 0.0 to 1.0, how critical this problem is
+	Severity    float32 // 0.0 to 1.0, how critical this problem usually is
 	Demand      float32 // Calculated demand based on population sentiments
+	BaseDemand  float32 // Configured demand that the demand phase adjusts around
+	BaseNeedy   int     // People with the problem when demand was first recalculated (0 = not yet)
 	IsBasicNeed bool    // true for survival needs (food, water), false for pleasures (entertainment)
+
+	// UnitsPerPerson is how many units a shopper wants each tick (0 = 1)
+	UnitsPerPerson int
+
+	// Escalation is how much more severe the need grows for a person each
+	// tick they go without, and Decay the share of that extra severity lost
+	// each tick it is met (both 0 = severity stays fixed)
+	Escalation float32
+	Decay      float32
+
+	// Parts make the problem a composite need: people who have it shop for
+	// its parts instead, and its satisfaction is their weighted average
+	Parts []ProblemPart
+}
+
+// ProblemPart is a sub-problem of a composite need and its weight in it
+type ProblemPart struct {
+	Problem *Problem
+	Weight  float32
 }
 
 // NewProblem creates a new Problem instance
@@ -22,13 +43,52 @@ func NewProblem(name, description string, severity float32) *Problem {
 		Description: description,
 		Severity:    severity,
 		Demand:      0.5,
+		BaseDemand:  0.5,
+	}
+}
+
+// UnitsWanted returns how many units one shopper buys for the problem in a
+// tick, at least one
+func (p *Problem) UnitsWanted() int {
+	return max(p.UnitsPerPerson, 1)
+}
+
+// IsDynamic reports whether the need's severity changes per person
+func (p *Problem) IsDynamic() bool {
+	return p.Escalation > 0 || p.Decay > 0
+}
+
+// AddPart makes a sub-problem part of this composite need
+func (p *Problem) AddPart(sub *Problem, weight float32) *Problem {
+	p.Parts = append(p.Parts, ProblemPart{Problem: sub, Weight: weight})
+	return p
+}
+
+// IsComposite reports whether the problem is made of sub-problems
+func (p *Problem) IsComposite() bool {
+	return len(p.Parts) > 0
+}
+
+// Leaves returns the problems people actually shop for: the problem itself,
+// or the leaves of its parts when it is composite
+func (p *Problem) Leaves() []*Problem {
+	if !p.IsComposite() {
+		return []*Problem{p}
+	}
+	leaves := make([]*Problem, 0, len(p.Parts))
+	for _, part := range p.Parts {
+		leaves = append(leaves, part.Problem.Leaves()...)
 	}
+	return leaves
 }
 
 func (p *Problem) getName() string {
 	return p.Name
 }
 
+// UpdateDemand sets the configured demand, which also becomes the baseline
+// the demand phase adjusts around
 func (p *Problem) UpdateDemand(demand float32) {
 	p.Demand = demand
+	p.BaseDemand = demand
 }
@@ -0,0 +1,199 @@
+package entities
+
+import "sort"
+
+// Query selects subsets of a region's entities without hand-rolled loops.
+// Filters chain and narrow the selection; they never modify the region:
+//
+//	region.Query().People().WithTag("informal").Richest(10)
+type Query struct {
+	region *Region
+}
+
+// Query starts a query over the region's entities
+func (r *Region) Query() *Query {
+	return &Query{region: r}
+}
+
+// People selects every person in the region
+func (q *Query) People() *PersonQuery {
+	return &PersonQuery{people: q.region.People}
+}
+
+// Industries selects every industry in the region
+func (q *Query) Industries() *IndustryQuery {
+	return &IndustryQuery{industries: q.region.Industries}
+}
+
+// Resources selects every resource in the region
+func (q *Query) Resources() *ResourceQuery {
+	return &ResourceQuery{resources: q.region.Resources}
+}
+
+// PersonQuery is a selection of people
+type PersonQuery struct {
+	people []*Person
+}
+
+// Where keeps the people the predicate accepts
+func (q *PersonQuery) Where(keep func(*Person) bool) *PersonQuery {
+	selected := make([]*Person, 0)
+	for _, person := range q.people {
+		if keep(person) {
+			selected = append(selected, person)
+		}
+	}
+	return &PersonQuery{people: selected}
+}
+
+// WithTag keeps the people carrying the tag
+func (q *PersonQuery) WithTag(tag string) *PersonQuery {
+	return q.Where(func(p *Person) bool { return p.HasTag(tag) })
+}
+
+// InSegment keeps the members of the named population segment
+func (q *PersonQuery) InSegment(name string) *PersonQuery {
+	return q.Where(func(p *Person) bool {
+		for _, segment := range p.Segments {
+			if segment.Name == name {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Richest returns up to n people with the most wealth, richest first
+func (q *PersonQuery) Richest(n int) []*Person {
+	return q.top(n, func(a, b *Person) bool { return a.Wealth() > b.Wealth() })
+}
+
+// Poorest returns up to n people with the least wealth, poorest first
+func (q *PersonQuery) Poorest(n int) []*Person {
+	return q.top(n, func(a, b *Person) bool { return a.Wealth() < b.Wealth() })
+}
+
+// top sorts a copy of the selection, keeping the region's order among ties
+func (q *PersonQuery) top(n int, before func(a, b *Person) bool) []*Person {
+	sorted := append([]*Person(nil), q.people...)
+	sort.SliceStable(sorted, func(i, j int) bool { return before(sorted[i], sorted[j]) })
+	return sorted[:min(n, len(sorted))]
+}
+
+// All returns the selected people
+func (q *PersonQuery) All() []*Person {
+	return q.people
+}
+
+// Count returns the number of selected people
+func (q *PersonQuery) Count() int {
+	return len(q.people)
+}
+
+// TotalWealth sums the selected people's cash and savings
+func (q *PersonQuery) TotalWealth() float32 {
+	total := float32(0)
+	for _, person := range q.people {
+		total += person.Wealth()
+	}
+	return total
+}
+
+// IndustryQuery is a selection of industries
+type IndustryQuery struct {
+	industries []*Industry
+}
+
+// Where keeps the industries the predicate accepts
+func (q *IndustryQuery) Where(keep func(*Industry) bool) *IndustryQuery {
+	selected := make([]*Industry, 0)
+	for _, industry := range q.industries {
+		if keep(industry) {
+			selected = append(selected, industry)
+		}
+	}
+	return &IndustryQuery{industries: selected}
+}
+
+// WithTag keeps the industries carrying the tag
+func (q *IndustryQuery) WithTag(tag string) *IndustryQuery {
+	return q.Where(func(i *Industry) bool { return i.HasTag(tag) })
+}
+
+// Solving keeps the industries that solve the named problem
+func (q *IndustryQuery) Solving(problem string) *IndustryQuery {
+	return q.Where(func(i *Industry) bool {
+		for _, p := range i.OwnedProblems {
+			if p.Name == problem {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Richest returns up to n industries with the most money, richest first
+func (q *IndustryQuery) Richest(n int) []*Industry {
+	sorted := append([]*Industry(nil), q.industries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Money > sorted[j].Money })
+	return sorted[:min(n, len(sorted))]
+}
+
+// All returns the selected industries
+func (q *IndustryQuery) All() []*Industry {
+	return q.industries
+}
+
+// Count returns the number of selected industries
+func (q *IndustryQuery) Count() int {
+	return len(q.industries)
+}
+
+// ResourceQuery is a selection of resources
+type ResourceQuery struct {
+	resources []*Resource
+}
+
+// Where keeps the resources the predicate accepts
+func (q *ResourceQuery) Where(keep func(*Resource) bool) *ResourceQuery {
+	selected := make([]*Resource, 0)
+	for _, resource := range q.resources {
+		if keep(resource) {
+			selected = append(selected, resource)
+		}
+	}
+	return &ResourceQuery{resources: selected}
+}
+
+// WithTag keeps the resources carrying the tag
+func (q *ResourceQuery) WithTag(tag string) *ResourceQuery {
+	return q.Where(func(r *Resource) bool { return r.HasTag(tag) })
+}
+
+// All returns the selected resources
+func (q *ResourceQuery) All() []*Resource {
+	return q.resources
+}
+
+// Count returns the number of selected resources
+func (q *ResourceQuery) Count() int {
+	return len(q.resources)
+}
+
+// hasTag reports whether tags contains tag
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// addTag appends tag unless it is already there
+func addTag(tags []string, tag string) []string {
+	if hasTag(tags, tag) {
+		return tags
+	}
+	return append(tags, tag)
+}
@@ -0,0 +1,70 @@
+package entities
+
+import "testing"
+
+func TestQuery_PeopleWithTagRichest(t *testing.T) {
+	region := NewRegion("TestRegion")
+	for i, money := range []float32{10, 50, 30, 70, 20} {
+		person := NewPerson("Person", money, 40)
+		if i%2 == 0 {
+			person.AddTag("informal")
+			person.AddTag("informal")
+		}
+		region.AddPerson(person)
+	}
+
+	informal := region.Query().People().WithTag("informal")
+	if informal.Count() != 3 {
+		t.Fatalf("Expected 3 informal people, got %d", informal.Count())
+	}
+	if len(region.People[0].Tags) != 1 {
+		t.Errorf("Expected a tag to be added once, got %v", region.People[0].Tags)
+	}
+
+	richest := informal.Richest(2)
+	if len(richest) != 2 || richest[0].Money != 30 || richest[1].Money != 20 {
+		t.Errorf("Expected the richest informal people to have 30 and 20, got %v", richest)
+	}
+	if poorest := informal.Poorest(10); len(poorest) != 3 || poorest[0].Money != 10 {
+		t.Errorf("Expected all 3 informal people, poorest first, got %v", poorest)
+	}
+	if total := informal.TotalWealth(); total != 60 {
+		t.Errorf("Expected informal wealth 60, got %.2f", total)
+	}
+	if region.People[0].Money != 10 {
+		t.Error("Expected queries to leave the region's order alone")
+	}
+}
+
+func TestQuery_IndustriesAndResources(t *testing.T) {
+	region := NewRegion("TestRegion")
+	food := NewProblem("Food", "Need food", 0.9)
+	region.AddProblem(food)
+
+	water := NewResource("Water", "liters")
+	water.AddTag("natural")
+	region.AddResource(water)
+	region.AddResource(NewResource("Bread", "loaves"))
+
+	farm := CreateIndustry("Farm").SetupIndustry([]*Problem{food}, nil, nil).SetInitialCapital(100)
+	farm.AddTag("export")
+	mill := CreateIndustry("Mill").SetInitialCapital(300)
+	region.AddIndustry(farm)
+	region.AddIndustry(mill)
+
+	if got := region.Query().Resources().WithTag("natural").All(); len(got) != 1 || got[0] != water {
+		t.Errorf("Expected only water to be natural, got %v", got)
+	}
+	if got := region.Query().Industries().Solving("Food").WithTag("export").Count(); got != 1 {
+		t.Errorf("Expected 1 exporting food industry, got %d", got)
+	}
+	if got := region.Query().Industries().Richest(1); got[0] != mill {
+		t.Errorf("Expected the mill to be richest, got %s", got[0].Name)
+	}
+
+	clone := region.Clone()
+	clone.GetIndustry("Farm").Tags[0] = "local"
+	if !farm.HasTag("export") {
+		t.Error("Expected the clone's tags to be independent")
+	}
+}
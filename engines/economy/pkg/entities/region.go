@@ -1,35 +1,55 @@
 package entities
 
+// DefaultCurrency is used by regions that don't declare their own
+const DefaultCurrency = "USD"
+
 // Region represents a geographic/economic area containing all entities
 type Region struct {
 	Name               string
+	Currency           string // Currency code money in this region is denominated in
 	Industries         []*Industry
 	People             []*Person
 	PopulationSegments []*PopulationSegment // Different segments of the population
 	Resources          []*Resource          // Shared/available resources in the region
 	Problems           []*Problem           // All problems present in the region
+	Contracts          []*Contract          // Multi-tick delivery agreements
+	Zones              []*Zone              // Locations inside the region
+	Households         []*Household         // People pooling their cash (empty = everyone on their own)
+	Transport          *TransportNetwork    // Costs of moving between zones (nil = frictionless)
+
+	index *regionIndex // Lookups by ID, built on first use
 }
 
 // NewRegion creates a new Region instance
 func NewRegion(name string) *Region {
 	return &Region{
 		Name:               name,
+		Currency:           DefaultCurrency,
 		Industries:         make([]*Industry, 0),
 		People:             make([]*Person, 0),
 		Resources:          make([]*Resource, 0),
 		Problems:           make([]*Problem, 0),
 		PopulationSegments: make([]*PopulationSegment, 0),
+		Contracts:          make([]*Contract, 0),
+		Zones:              make([]*Zone, 0),
+		Households:         make([]*Household, 0),
 	}
 }
 
 // AddIndustry adds an industry to the region
 func (r *Region) AddIndustry(industry *Industry) {
 	r.Industries = append(r.Industries, industry)
+	if r.index != nil {
+		r.index.industries[industry.ID] = industry
+	}
 }
 
 // AddPerson adds a person to the region
 func (r *Region) AddPerson(person *Person) {
 	r.People = append(r.People, person)
+	if r.index != nil {
+		r.index.people[person.ID] = person
+	}
 }
 
 func (r *Region) AddPopulationSegment(pSeg *PopulationSegment) {
@@ -39,6 +59,9 @@ func (r *Region) AddPopulationSegment(pSeg *PopulationSegment) {
 // AddResource adds a resource to the region
 func (r *Region) AddResource(resource *Resource) {
 	r.Resources = append(r.Resources, resource)
+	if r.index != nil {
+		r.index.resources[resource.ID] = resource
+	}
 }
 
 // AddProblem adds a problem to the region
@@ -46,6 +69,11 @@ func (r *Region) AddProblem(problem *Problem) {
 	r.Problems = append(r.Problems, problem)
 }
 
+// AddContract registers a contract between agents in the region
+func (r *Region) AddContract(contract *Contract) {
+	r.Contracts = append(r.Contracts, contract)
+}
+
 // GetResource finds a resource by name
 func (r *Region) GetResource(name string) *Resource {
 	for _, resource := range r.Resources {
@@ -65,3 +93,23 @@ func (r *Region) GetProblem(name string) *Problem {
 	}
 	return nil
 }
+
+// GetIndustry finds an industry by name
+func (r *Region) GetIndustry(name string) *Industry {
+	for _, industry := range r.Industries {
+		if industry.Name == name {
+			return industry
+		}
+	}
+	return nil
+}
+
+// GetPopulationSegment finds a population segment by name
+func (r *Region) GetPopulationSegment(name string) *PopulationSegment {
+	for _, segment := range r.PopulationSegments {
+		if segment.Name == name {
+			return segment
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package entities
+
+// regionIndex maps IDs to the region's people, industries and resources
+type regionIndex struct {
+	people     map[int]*Person
+	industries map[int]*Industry
+	resources  map[int]*Resource
+}
+
+// lookup returns the region's index, rebuilding it when the entity slices
+// were changed without going through the region's methods
+func (r *Region) lookup() *regionIndex {
+	if r.index != nil &&
+		len(r.index.people) == len(r.People) &&
+		len(r.index.industries) == len(r.Industries) &&
+		len(r.index.resources) == len(r.Resources) {
+		return r.index
+	}
+
+	index := &regionIndex{
+		people:     make(map[int]*Person, len(r.People)),
+		industries: make(map[int]*Industry, len(r.Industries)),
+		resources:  make(map[int]*Resource, len(r.Resources)),
+	}
+	for _, person := range r.People {
+		index.people[person.ID] = person
+	}
+	for _, industry := range r.Industries {
+		index.industries[industry.ID] = industry
+	}
+	for _, resource := range r.Resources {
+		index.resources[resource.ID] = resource
+	}
+	r.index = index
+	return index
+}
+
+// PersonByID finds a person by ID
+func (r *Region) PersonByID(id int) *Person {
+	return r.lookup().people[id]
+}
+
+// IndustryByID finds an industry by ID
+func (r *Region) IndustryByID(id int) *Industry {
+	return r.lookup().industries[id]
+}
+
+// ResourceByID finds a resource by ID
+func (r *Region) ResourceByID(id int) *Resource {
+	return r.lookup().resources[id]
+}
+
+// RemovePerson takes a person out of the region, for deaths and emigration.
+// Their shares are cancelled and they leave their population segments and
+// household.
+// Returns false if the person is not in the region.
+func (r *Region) RemovePerson(person *Person) bool {
+	index := r.lookup()
+	if index.people[person.ID] != person {
+		return false
+	}
+	delete(index.people, person.ID)
+	r.People = removeFrom(r.People, person)
+
+	for _, industry := range r.Industries {
+		industry.dropHolder(person)
+	}
+	for _, segment := range append([]*PopulationSegment(nil), person.Segments...) {
+		person.RemoveSegment(segment)
+	}
+	if person.Household != nil {
+		person.Household.RemoveMember(person)
+	}
+	return true
+}
+
+// RemovePersonByID removes the person with the given ID
+func (r *Region) RemovePersonByID(id int) bool {
+	if person := r.PersonByID(id); person != nil {
+		return r.RemovePerson(person)
+	}
+	return false
+}
+
+// RemoveIndustry takes an industry out of the region, for closures after
+// bankruptcy. Retailers stop restocking from it, its students leave school
+// and its contracts end. Returns false if the industry is not in the region.
+func (r *Region) RemoveIndustry(industry *Industry) bool {
+	index := r.lookup()
+	if index.industries[industry.ID] != industry {
+		return false
+	}
+	delete(index.industries, industry.ID)
+	r.Industries = removeFrom(r.Industries, industry)
+
+	for _, other := range r.Industries {
+		other.Suppliers = removeFrom(other.Suppliers, industry)
+	}
+	for _, input := range industry.InputResources {
+		industry.Release(input, industry.Holding(input))
+	}
+	for _, person := range r.People {
+		if person.School == industry {
+			person.School = nil
+			person.CourseTicksLeft = 0
+		}
+	}
+	for _, contract := range r.Contracts {
+		if contract.Seller == industry || contract.BuyerIndustry == industry {
+			contract.Active = false
+		}
+	}
+	return true
+}
+
+// RemoveIndustryByID removes the industry with the given ID
+func (r *Region) RemoveIndustryByID(id int) bool {
+	if industry := r.IndustryByID(id); industry != nil {
+		return r.RemoveIndustry(industry)
+	}
+	return false
+}
+
+// RemoveResource takes a resource out of the region, for cleaning up
+// exhausted resources. Industries stop listing it as an input and products
+// stop requiring it as a complement. Returns false if the resource is not in
+// the region.
+func (r *Region) RemoveResource(resource *Resource) bool {
+	index := r.lookup()
+	if index.resources[resource.ID] != resource {
+		return false
+	}
+	delete(index.resources, resource.ID)
+	r.Resources = removeFrom(r.Resources, resource)
+
+	for _, industry := range r.Industries {
+		industry.InputResources = removeFrom(industry.InputResources, resource)
+		delete(industry.Holdings, resource.ID)
+		for _, product := range industry.OutputProducts {
+			product.Complements = removeFrom(product.Complements, resource)
+		}
+	}
+	return true
+}
+
+// RemoveResourceByID removes the resource with the given ID
+func (r *Region) RemoveResourceByID(id int) bool {
+	if resource := r.ResourceByID(id); resource != nil {
+		return r.RemoveResource(resource)
+	}
+	return false
+}
+
+// dropHolder cancels a person's shares in the industry
+func (i *Industry) dropHolder(owner *Person) {
+	for idx, holding := range i.Shareholders {
+		if holding.Owner == owner {
+			i.Shareholders = append(i.Shareholders[:idx], i.Shareholders[idx+1:]...)
+			return
+		}
+	}
+}
+
+// removeFrom returns items without any occurrence of item. It copies rather
+// than filtering in place, since slices such as complements can be shared.
+func removeFrom[T comparable](items []T, item T) []T {
+	var kept []T
+	for idx, it := range items {
+		if it == item {
+			if kept == nil {
+				kept = append(make([]T, 0, len(items)-1), items[:idx]...)
+			}
+			continue
+		}
+		if kept != nil {
+			kept = append(kept, it)
+		}
+	}
+	if kept == nil {
+		return items
+	}
+	return kept
+}
@@ -0,0 +1,94 @@
+package entities
+
+import "testing"
+
+func TestRegion_RemovePerson(t *testing.T) {
+	region := NewRegion("TestRegion")
+	segment := NewPopulationSegment("General", nil, 3)
+	region.AddPopulationSegment(segment)
+	farm := CreateIndustry("Farm")
+	region.AddIndustry(farm)
+
+	people := make([]*Person, 3)
+	for i := range people {
+		people[i] = NewPerson("Person", 10, 40)
+		people[i].AddSegment(segment)
+		region.AddPerson(people[i])
+	}
+	farm.IssueShares(people[1], 5)
+
+	if region.PersonByID(people[1].ID) != people[1] {
+		t.Fatal("Expected to find the person by ID")
+	}
+	if !region.RemovePersonByID(people[1].ID) {
+		t.Fatal("Expected the person to be removed")
+	}
+	if region.RemovePerson(people[1]) {
+		t.Error("Expected a second removal to fail")
+	}
+	if len(region.People) != 2 || region.People[0] != people[0] || region.People[1] != people[2] {
+		t.Errorf("Expected the others to keep their order, got %d people", len(region.People))
+	}
+	if region.PersonByID(people[1].ID) != nil {
+		t.Error("Expected the removed person to leave the index")
+	}
+	if farm.TotalShares() != 0 {
+		t.Errorf("Expected the removed owner's shares to be cancelled, got %.2f", farm.TotalShares())
+	}
+	if segment.Size != 2 {
+		t.Errorf("Expected the segment to shrink to 2, got %d", segment.Size)
+	}
+
+	// People added straight to the slice are still found
+	late := NewPerson("Late", 0, 0)
+	region.People = append(region.People, late)
+	if region.PersonByID(late.ID) != late {
+		t.Error("Expected the index to pick up people added directly")
+	}
+}
+
+func TestRegion_RemoveIndustryAndResource(t *testing.T) {
+	region := NewRegion("TestRegion")
+	coal := NewResource("Coal", "tons")
+	region.AddResource(coal)
+	steel := NewResource("Steel", "tons")
+	steel.AddComplement(coal)
+
+	mill := CreateIndustry("Mill").SetupIndustry(nil, []*Resource{coal}, []*Resource{steel})
+	shop := CreateIndustry("Shop").AddSupplier(mill)
+	stock := NewResource("Steel", "tons")
+	stock.Complements = steel.Complements // Retail stock shares the product's complements
+	shop.OutputProducts = []*Resource{stock}
+	region.AddIndustry(mill)
+	region.AddIndustry(shop)
+
+	student := NewPerson("Student", 10, 40)
+	student.School = mill
+	student.CourseTicksLeft = 2
+	region.AddPerson(student)
+	contract := &Contract{Seller: mill, Active: true}
+	region.AddContract(contract)
+
+	if !region.RemoveResource(coal) {
+		t.Fatal("Expected coal to be removed")
+	}
+	if len(mill.InputResources) != 0 || len(steel.Complements) != 0 || len(stock.Complements) != 0 {
+		t.Error("Expected coal to be dropped from inputs and complements")
+	}
+
+	if !region.RemoveIndustryByID(mill.ID) {
+		t.Fatal("Expected the mill to be removed")
+	}
+	if region.IndustryByID(mill.ID) != nil || len(region.Industries) != 1 {
+		t.Error("Expected the mill to leave the region")
+	}
+	if len(shop.Suppliers) != 0 {
+		t.Error("Expected the shop to stop restocking from the mill")
+	}
+	if student.School != nil || student.CourseTicksLeft != 0 {
+		t.Error("Expected the mill's student to leave school")
+	}
+	if contract.Active {
+		t.Error("Expected the mill's contract to end")
+	}
+}
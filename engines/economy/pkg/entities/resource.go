@@ -2,6 +2,12 @@ package entities
 
 var resourceIDCounter = 0
 
+// Resource kinds
+const (
+	FlowResource  = "flow"  // Used up by production, like raw material (the default)
+	StockResource = "stock" // Occupied while producing and released, like land
+)
+
 // Resource represents a material or commodity that can be consumed or produced
 type Resource struct {
 	ID               int
@@ -9,17 +15,30 @@ type Resource struct {
 	Quantity         float32 // Can change over time
 	Unit             string  // e.g., "kg", "liters", "units"
 	IsFree           bool    // true for government-controlled resources (land, water, minerals)
+	Kind             string  // FlowResource ("" too) or StockResource; a stock's Quantity is what is free
 	RegenerationRate float32 // units regenerated per tick (e.g., forests regrow)
+	Capacity         float32 // Carrying capacity for logistic regrowth (0 = constant regeneration)
+	CollapseBelow    float32 // Share of capacity below which overharvest cuts future regrowth (0 = never)
+	Price            float32 // Price per unit the last input auction cleared at (0 = the default cost)
+
+	// Product attributes (only meaningful for industry outputs)
+	Efficiency  float32     // How well one unit satisfies a need relative to substitutes (default 1.0)
+	Complements []*Resource // Products that must be bought alongside this one (bread needs fuel)
+	Solves      []*Problem  // Problems the product is sold for (nil = every problem its industry solves)
+	Satisfies   float32     // Share of one person's weekly need a unit meets (0 = a unit is one of the need's units)
+
+	Tags []string // Free-form labels for analyses, e.g. "imported"
 }
 
 // NewResource creates a new Resource instance
 func NewResource(name string, unit string) *Resource {
 	resourceIDCounter++
 	return &Resource{
-		ID:       resourceIDCounter,
-		Name:     name,
-		Quantity: 0,
-		Unit:     unit,
+		ID:         resourceIDCounter,
+		Name:       name,
+		Quantity:   0,
+		Unit:       unit,
+		Efficiency: 1.0,
 	}
 }
 
@@ -37,3 +56,43 @@ func (r *Resource) Consume(amount float32) bool {
 	}
 	return false
 }
+
+// HasTag reports whether the resource carries the tag
+func (r *Resource) HasTag(tag string) bool {
+	return hasTag(r.Tags, tag)
+}
+
+// AddTag labels the resource with a tag, once
+func (r *Resource) AddTag(tag string) {
+	r.Tags = addTag(r.Tags, tag)
+}
+
+// SolvesProblem reports whether the product is sold for a problem its
+// industry solves: any of them without a Solves list, else those listed
+func (r *Resource) SolvesProblem(problem *Problem) bool {
+	if len(r.Solves) == 0 {
+		return true
+	}
+	for _, p := range r.Solves {
+		if p.ID == problem.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedUnits returns how many of a problem's units one unit of the product
+// is worth to a shopper buying for a tick of the given weeks: one, or with
+// Satisfies its share of a week's need
+func (r *Resource) NeedUnits(problem *Problem, weeks int) float32 {
+	if r.Satisfies <= 0 {
+		return 1
+	}
+	return r.Satisfies * float32(problem.UnitsWanted()) / float32(max(weeks, 1))
+}
+
+// AddComplement registers a product that has to be bought together with this one
+func (r *Resource) AddComplement(complement *Resource) *Resource {
+	r.Complements = append(r.Complements, complement)
+	return r
+}
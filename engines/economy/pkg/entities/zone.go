@@ -0,0 +1,88 @@
+package entities
+
+import "math"
+
+// Zone is a location inside a region. People live in a zone and industries
+// operate in one; moving goods or workers between zones costs money and time.
+type Zone struct {
+	Name string
+	X, Y float32
+}
+
+// NewZone creates a zone at the given map coordinates
+func NewZone(name string, x, y float32) *Zone {
+	return &Zone{Name: name, X: x, Y: y}
+}
+
+// Distance returns the straight-line distance to another zone
+func (z *Zone) Distance(other *Zone) float32 {
+	dx := float64(z.X - other.X)
+	dy := float64(z.Y - other.Y)
+	return float32(math.Sqrt(dx*dx + dy*dy))
+}
+
+// TransportNetwork prices movement between zones. Transport industries
+// shrink both frictions as their output grows.
+type TransportNetwork struct {
+	CostPerDistance  float32 // Shipping cost per unit of goods per unit of distance
+	HoursPerDistance float32 // Labor hours a commuter loses per unit of distance
+	CapacityScale    float32 // Transport output that halves the frictions
+}
+
+// TransportFriction returns the multiplier applied to shipping costs and
+// commute times: 1 with no transport sector, falling as transport industries
+// produce more
+func (r *Region) TransportFriction() float32 {
+	if r.Transport == nil || r.Transport.CapacityScale <= 0 {
+		return 1.0
+	}
+
+	capacity := float32(0)
+	for _, industry := range r.Industries {
+		if industry.IsTransport && len(industry.ProductionHistory) > 0 {
+			capacity += industry.ProductionHistory[len(industry.ProductionHistory)-1].UnitsProduced
+		}
+	}
+	return 1.0 / (1.0 + capacity/r.Transport.CapacityScale)
+}
+
+// ShippingCost returns the cost of moving one unit of goods between zones
+func (r *Region) ShippingCost(from, to *Zone) float32 {
+	if r.Transport == nil || from == nil || to == nil || from == to {
+		return 0
+	}
+	return from.Distance(to) * r.Transport.CostPerDistance * r.TransportFriction()
+}
+
+// CommuteHours returns the labor hours lost travelling between zones
+func (r *Region) CommuteHours(from, to *Zone) float32 {
+	if r.Transport == nil || from == nil || to == nil || from == to {
+		return 0
+	}
+	return from.Distance(to) * r.Transport.HoursPerDistance * r.TransportFriction()
+}
+
+// Carrier returns the transport industry that collects shipping fees, if any
+func (r *Region) Carrier() *Industry {
+	for _, industry := range r.Industries {
+		if industry.IsTransport {
+			return industry
+		}
+	}
+	return nil
+}
+
+// AddZone adds a zone to the region
+func (r *Region) AddZone(zone *Zone) {
+	r.Zones = append(r.Zones, zone)
+}
+
+// GetZone finds a zone by name
+func (r *Region) GetZone(name string) *Zone {
+	for _, zone := range r.Zones {
+		if zone.Name == name {
+			return zone
+		}
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package events
+
+// Event is anything published on the bus during a tick
+type Event interface {
+	EventTick() int
+}
+
+// ProductionCompleted is published when an industry finishes a production run
+type ProductionCompleted struct {
+	Tick        int
+	Industry    string
+	Product     string
+	Units       float32
+	Stock       float32 // Product stock after production
+	TotalCost   float32
+	CostPerUnit float32
+}
+
+// PurchaseMade is published for every formal market purchase
+type PurchaseMade struct {
+	Tick      int
+	PersonID  int
+	Person    string
+	Industry  string
+	Product   string
+	Problem   string
+	Quantity  float32
+	UnitPrice float32
+	TotalCost float32
+}
+
+// PurchasesFailed is published once per tick with every need a shopper went
+// to the formal market for and came back without. Batching keeps large
+// populations from paying for an event per failure.
+type PurchasesFailed struct {
+	Tick     int
+	Failures []FailedPurchase
+}
+
+// FailedPurchase is one need that went unmet in the formal market
+type FailedPurchase struct {
+	PersonID int
+	Person   string
+	Problem  string
+	Reason   string // One of the market package's Reason constants
+}
+
+// WagePaid is published when an industry pays its workers for the tick
+type WagePaid struct {
+	Tick     int
+	Industry string
+	Workers  int
+	Amount   float32
+}
+
+// IndustryBankrupt is published when an industry can no longer meet its wage bill
+type IndustryBankrupt struct {
+	Tick     int
+	Industry string
+	Money    float32
+	Reason   string
+}
+
+// ResourceDepleted is published when a resource stock runs out
+type ResourceDepleted struct {
+	Tick     int
+	Resource string
+	Industry string // Industry whose consumption used up the stock
+}
+
+// PersonEvent is a change in one person's life, published so analyses can
+// follow individual trajectories
+type PersonEvent interface {
+	Event
+	EventPerson() int // ID of the person it happened to
+}
+
+// PersonHired is published when a person works for an industry they did not
+// work for last tick
+type PersonHired struct {
+	Tick     int
+	PersonID int
+	Person   string
+	Industry string
+}
+
+// PersonFired is published when a person no longer works for an industry
+// they worked for last tick
+type PersonFired struct {
+	Tick     int
+	PersonID int
+	Person   string
+	Industry string
+}
+
+// PersonRetired is published when a person moves into a retired segment
+type PersonRetired struct {
+	Tick     int
+	PersonID int
+	Person   string
+	From     string // Segment they left
+	To       string // Retired segment they joined
+}
+
+// PersonDied is published when a person dies and leaves the region, with the
+// wealth that leaves with them
+type PersonDied struct {
+	Tick     int
+	PersonID int
+	Person   string
+	Wealth   float32
+}
+
+// PersonMigrated is published when a person leaves the region for another
+type PersonMigrated struct {
+	Tick     int
+	PersonID int
+	Person   string
+	From     string // Region they left
+	To       string // Region they went to
+	Wealth   float32
+}
+
+// PersonBankrupt is published when a person who had money at the start of a
+// tick ends it with none, cash and savings together
+type PersonBankrupt struct {
+	Tick     int
+	PersonID int
+	Person   string
+	Money    float32 // Cash at the end of the tick, which may be negative
+}
+
+// ParameterChanged is published when a setting is changed between ticks; Tick
+// is the last tick run with the old value
+type ParameterChanged struct {
+	Tick      int
+	Parameter string
+	From      float32
+	To        float32
+}
+
+func (e ProductionCompleted) EventTick() int { return e.Tick }
+func (e PurchaseMade) EventTick() int        { return e.Tick }
+func (e PurchasesFailed) EventTick() int     { return e.Tick }
+func (e WagePaid) EventTick() int            { return e.Tick }
+func (e IndustryBankrupt) EventTick() int    { return e.Tick }
+func (e ResourceDepleted) EventTick() int    { return e.Tick }
+func (e PersonHired) EventTick() int         { return e.Tick }
+func (e PersonFired) EventTick() int         { return e.Tick }
+func (e PersonRetired) EventTick() int       { return e.Tick }
+func (e PersonDied) EventTick() int          { return e.Tick }
+func (e PersonMigrated) EventTick() int      { return e.Tick }
+func (e PersonBankrupt) EventTick() int      { return e.Tick }
+func (e ParameterChanged) EventTick() int    { return e.Tick }
+
+func (e PersonHired) EventPerson() int    { return e.PersonID }
+func (e PersonFired) EventPerson() int    { return e.PersonID }
+func (e PersonRetired) EventPerson() int  { return e.PersonID }
+func (e PersonDied) EventPerson() int     { return e.PersonID }
+func (e PersonMigrated) EventPerson() int { return e.PersonID }
+func (e PersonBankrupt) EventPerson() int { return e.PersonID }
+
+// Bus delivers published events to subscribers, synchronously and in
+// subscription order
+type Bus struct {
+	handlers []func(Event)
+}
+
+// NewBus creates an event bus with no subscribers
+func NewBus() *Bus {
+	return &Bus{handlers: make([]func(Event), 0)}
+}
+
+// Subscribe registers a handler for every event
+func (b *Bus) Subscribe(handler func(Event)) {
+	b.handlers = append(b.handlers, handler)
+}
+
+// On registers a handler for one event type
+func On[T Event](b *Bus, handler func(T)) {
+	b.Subscribe(func(event Event) {
+		if typed, ok := event.(T); ok {
+			handler(typed)
+		}
+	})
+}
+
+// Publish delivers an event to every subscriber
+func (b *Bus) Publish(event Event) {
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+}
+
+// Recorder keeps every event it sees, for metrics, persistence and tests
+type Recorder struct {
+	Events []Event
+}
+
+// NewRecorder creates a recorder subscribed to the bus
+func NewRecorder(bus *Bus) *Recorder {
+	recorder := &Recorder{Events: make([]Event, 0)}
+	bus.Subscribe(func(event Event) {
+		recorder.Events = append(recorder.Events, event)
+	})
+	return recorder
+}
+
+// Count returns how many events of type T were recorded
+func Count[T Event](r *Recorder) int {
+	count := 0
+	for _, event := range r.Events {
+		if _, ok := event.(T); ok {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,28 @@
+package events
+
+import "testing"
+
+func TestBus_TypedSubscribers(t *testing.T) {
+	bus := NewBus()
+	recorder := NewRecorder(bus)
+
+	wages := float32(0)
+	On(bus, func(e WagePaid) {
+		wages += e.Amount
+	})
+
+	bus.Publish(WagePaid{Tick: 1, Industry: "Farm", Workers: 2, Amount: 800})
+	bus.Publish(ResourceDepleted{Tick: 1, Resource: "Water"})
+	bus.Publish(WagePaid{Tick: 2, Industry: "Farm", Workers: 1, Amount: 400})
+
+	if wages != 1200 {
+		t.Errorf("Expected typed handler to see $1200 in wages, got %.2f", wages)
+	}
+	if len(recorder.Events) != 3 {
+		t.Errorf("Expected 3 recorded events, got %d", len(recorder.Events))
+	}
+	if Count[WagePaid](recorder) != 2 || Count[ResourceDepleted](recorder) != 1 {
+		t.Errorf("Expected 2 wage and 1 depletion events, got %d / %d",
+			Count[WagePaid](recorder), Count[ResourceDepleted](recorder))
+	}
+}
@@ -0,0 +1,31 @@
+package finance
+
+// Bank is the region's commercial bank. Its rates follow the central bank's
+// policy rate plus fixed spreads.
+type Bank struct {
+	DepositRate   float32 // Interest paid on deposits per tick
+	LendingRate   float32 // Interest charged on loans per tick
+	DepositSpread float32 // Deposit rate below the policy rate
+	LendingSpread float32 // Lending rate above the policy rate
+
+	Deposits     float32 // Household savings held
+	InterestPaid float32 // Cumulative interest paid to savers
+
+	Lending        *Lending // Loans to industries (nil = no lending)
+	Loans          float32  // Principal owed by industries
+	InterestEarned float32  // Cumulative interest paid by industries
+}
+
+// NewBank creates a bank with the given spreads around the policy rate
+func NewBank(depositSpread, lendingSpread float32) *Bank {
+	return &Bank{
+		DepositSpread: depositSpread,
+		LendingSpread: lendingSpread,
+	}
+}
+
+// ApplyPolicyRate resets the bank's rates from a new policy rate
+func (b *Bank) ApplyPolicyRate(policyRate float32) {
+	b.DepositRate = max(policyRate-b.DepositSpread, 0)
+	b.LendingRate = policyRate + b.LendingSpread
+}
@@ -0,0 +1,146 @@
+package finance
+
+import (
+	"fmt"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Intervention types a central bank can schedule
+const (
+	HelicopterDrop = "helicopter" // Every person receives Amount
+	Bailout        = "bailout"    // Industries below Amount are topped up to it
+	RateChange     = "rate"       // Policy rate set to Rate
+)
+
+// Intervention is a scheduled monetary policy action
+type Intervention struct {
+	Tick   int
+	Type   string
+	Amount float32
+	Rate   float32
+}
+
+// MoneySupplyRecord tracks money in circulation for one tick
+type MoneySupplyRecord struct {
+	Tick     int
+	Supply   float32 // Cash and savings held by people and industries
+	Injected float32 // New money created by the central bank this tick
+}
+
+// CentralBank is the monetary authority: it sets the policy rate the bank
+// uses, creates money through interventions and tracks the money supply
+type CentralBank struct {
+	PolicyRate    float32
+	Bank          *Bank
+	Interventions []Intervention
+	History       []MoneySupplyRecord
+	TotalInjected float32
+
+	injectedThisTick float32
+}
+
+// NewCentralBank creates a central bank steering the given bank
+func NewCentralBank(policyRate float32, bank *Bank) *CentralBank {
+	cb := &CentralBank{
+		PolicyRate:    policyRate,
+		Bank:          bank,
+		Interventions: make([]Intervention, 0),
+		History:       make([]MoneySupplyRecord, 0),
+	}
+	cb.SetPolicyRate(policyRate)
+	return cb
+}
+
+// Schedule adds an intervention to be executed at its tick
+func (cb *CentralBank) Schedule(intervention Intervention) *CentralBank {
+	cb.Interventions = append(cb.Interventions, intervention)
+	return cb
+}
+
+// SetPolicyRate changes the policy rate and passes it on to the bank
+func (cb *CentralBank) SetPolicyRate(rate float32) {
+	cb.PolicyRate = rate
+	if cb.Bank != nil {
+		cb.Bank.ApplyPolicyRate(rate)
+	}
+}
+
+// HelicopterDrop gives every person in the region the same amount of new money
+func (cb *CentralBank) HelicopterDrop(region *entities.Region, amountPerPerson float32) float32 {
+	total := float32(0)
+	for _, person := range region.People {
+		person.Money += amountPerPerson
+		total += amountPerPerson
+	}
+	cb.inject(total)
+	return total
+}
+
+// Bailout tops up every industry whose money fell below the floor
+func (cb *CentralBank) Bailout(region *entities.Region, floor float32) float32 {
+	total := float32(0)
+	for _, industry := range region.Industries {
+		if industry.Money < floor {
+			total += floor - industry.Money
+			industry.Money = floor
+		}
+	}
+	cb.inject(total)
+	return total
+}
+
+// ExecuteInterventions runs the interventions scheduled for this tick and
+// returns a description of each
+func (cb *CentralBank) ExecuteInterventions(region *entities.Region, tick int) []string {
+	logs := make([]string, 0)
+	for _, intervention := range cb.Interventions {
+		if intervention.Tick != tick {
+			continue
+		}
+
+		switch intervention.Type {
+		case HelicopterDrop:
+			total := cb.HelicopterDrop(region, intervention.Amount)
+			logs = append(logs, fmt.Sprintf("🚁 Helicopter drop: $%.2f per person ($%.2f total)", intervention.Amount, total))
+		case Bailout:
+			total := cb.Bailout(region, intervention.Amount)
+			logs = append(logs, fmt.Sprintf("🛟 Bailout: industries topped up to $%.2f ($%.2f total)", intervention.Amount, total))
+		case RateChange:
+			old := cb.PolicyRate
+			cb.SetPolicyRate(intervention.Rate)
+			logs = append(logs, fmt.Sprintf("📉 Policy rate %.2f%% → %.2f%%", old*100, intervention.Rate*100))
+		}
+	}
+	return logs
+}
+
+// RecordMoneySupply measures the money in circulation at the end of a tick
+func (cb *CentralBank) RecordMoneySupply(region *entities.Region, tick int) MoneySupplyRecord {
+	record := MoneySupplyRecord{
+		Tick:     tick,
+		Supply:   MoneySupply(region),
+		Injected: cb.injectedThisTick,
+	}
+	cb.History = append(cb.History, record)
+	cb.injectedThisTick = 0
+	return record
+}
+
+func (cb *CentralBank) inject(amount float32) {
+	cb.injectedThisTick += amount
+	cb.TotalInjected += amount
+}
+
+// MoneySupply returns all cash and bank savings held by people and
+// industries in the region
+func MoneySupply(region *entities.Region) float32 {
+	total := float32(0)
+	for _, person := range region.People {
+		total += person.Wealth()
+	}
+	for _, industry := range region.Industries {
+		total += industry.Money
+	}
+	return total
+}
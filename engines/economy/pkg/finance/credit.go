@@ -0,0 +1,38 @@
+package finance
+
+import "westex/engines/economy/pkg/entities"
+
+// CreditScore rates how likely an industry is to repay a loan, from 0 to 1.
+// It averages the industry's repayment record, where a borrower without one
+// counts as even odds, and its profitability: revenue against production
+// costs, 0.5 when it breaks even.
+func CreditScore(industry *entities.Industry) float32 {
+	made, missed := float32(industry.PaymentsMade), float32(industry.PaymentsMissed)
+	repayment := (made + 1) / (made + missed + 2)
+	return (repayment + profitability(industry)) / 2
+}
+
+// profitability is average revenue over average revenue plus average
+// production cost per tick, 0.5 without any history
+func profitability(industry *entities.Industry) float32 {
+	revenue := industry.AverageRevenue()
+	cost := averageCost(industry)
+	if revenue+cost <= 0 {
+		return 0.5
+	}
+	return revenue / (revenue + cost)
+}
+
+// averageCost is the industry's production cost per tick over its history
+func averageCost(industry *entities.Industry) float32 {
+	if len(industry.ProductionHistory) == 0 {
+		return 0
+	}
+	total := float32(0)
+	ticks := make(map[int]bool)
+	for _, record := range industry.ProductionHistory {
+		total += record.TotalCost
+		ticks[record.Tick] = true
+	}
+	return total / float32(len(ticks))
+}
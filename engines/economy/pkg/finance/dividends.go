@@ -0,0 +1,42 @@
+package finance
+
+import "westex/engines/economy/pkg/entities"
+
+// DividendPayment records dividends one industry paid for a tick
+type DividendPayment struct {
+	IndustryName string
+	Profit       float32
+	Paid         float32
+	Shareholders int
+}
+
+// DistributeDividends pays each owned industry's payout share of this tick's
+// profit to its shareholders pro-rata. profits is keyed by industry ID.
+func DistributeDividends(region *entities.Region, profits map[int]float32) []DividendPayment {
+	payments := make([]DividendPayment, 0)
+
+	for _, industry := range region.Industries {
+		profit := profits[industry.ID]
+		totalShares := industry.TotalShares()
+		if profit <= 0 || industry.DividendPayout <= 0 || totalShares <= 0 {
+			continue
+		}
+
+		dividend := min(profit*industry.DividendPayout, industry.Money)
+		for _, holding := range industry.Shareholders {
+			amount := dividend * holding.Shares / totalShares
+			holding.Owner.Money += amount
+		}
+		industry.Money -= dividend
+		industry.TotalDistributed += dividend
+
+		payments = append(payments, DividendPayment{
+			IndustryName: industry.Name,
+			Profit:       profit,
+			Paid:         dividend,
+			Shareholders: len(industry.Shareholders),
+		})
+	}
+
+	return payments
+}
@@ -0,0 +1,98 @@
+package finance
+
+import "fmt"
+
+// Exchange rate regimes
+const (
+	FixedRate    = "fixed"    // Rate never moves
+	FloatingRate = "floating" // Rate moves with the currency's trade balance
+)
+
+// Currency is a region's currency and its value in the base currency
+type Currency struct {
+	Code   string
+	Rate   float32 // Value of one unit in the base currency
+	Regime string
+
+	// Trade flows recorded since the last rate update, in this currency
+	exports float32
+	imports float32
+}
+
+// ExchangeMarket converts between regional currencies. Floating currencies
+// appreciate when their region runs a trade surplus and depreciate on a
+// deficit; Sensitivity scales how far one tick's balance moves the rate.
+type ExchangeMarket struct {
+	Base        string
+	Sensitivity float32
+	Currencies  map[string]*Currency
+}
+
+// NewExchangeMarket creates an exchange market quoting against a base currency
+func NewExchangeMarket(base string, sensitivity float32) *ExchangeMarket {
+	em := &ExchangeMarket{
+		Base:        base,
+		Sensitivity: sensitivity,
+		Currencies:  make(map[string]*Currency),
+	}
+	em.AddCurrency(base, 1.0, FixedRate)
+	return em
+}
+
+// AddCurrency lists a currency at its starting rate against the base
+func (em *ExchangeMarket) AddCurrency(code string, rate float32, regime string) *ExchangeMarket {
+	em.Currencies[code] = &Currency{Code: code, Rate: rate, Regime: regime}
+	return em
+}
+
+// Convert turns an amount in one currency into another
+func (em *ExchangeMarket) Convert(amount float32, from, to string) (float32, error) {
+	if from == to {
+		return amount, nil
+	}
+	source, ok := em.Currencies[from]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency: %s", from)
+	}
+	target, ok := em.Currencies[to]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency: %s", to)
+	}
+	return amount * source.Rate / target.Rate, nil
+}
+
+// RecordTrade registers a cross-region sale: the exporter's currency earns
+// value and the importer's spends it. amount is in the exporter's currency.
+func (em *ExchangeMarket) RecordTrade(amount float32, exporter, importer string) error {
+	paid, err := em.Convert(amount, exporter, importer)
+	if err != nil {
+		return err
+	}
+	em.Currencies[exporter].exports += amount
+	em.Currencies[importer].imports += paid
+	return nil
+}
+
+// TradeBalance returns exports minus imports recorded since the last update,
+// in the currency's own units
+func (em *ExchangeMarket) TradeBalance(code string) float32 {
+	currency, ok := em.Currencies[code]
+	if !ok {
+		return 0
+	}
+	return currency.exports - currency.imports
+}
+
+// UpdateRates moves every floating currency by its relative trade balance and
+// resets the recorded flows
+func (em *ExchangeMarket) UpdateRates() {
+	for _, currency := range em.Currencies {
+		volume := currency.exports + currency.imports
+		if currency.Regime == FloatingRate && volume > 0 {
+			balance := (currency.exports - currency.imports) / volume
+			currency.Rate = max(currency.Rate*(1+em.Sensitivity*balance), 0.0001)
+		}
+		currency.exports = 0
+		currency.imports = 0
+	}
+}
@@ -0,0 +1,306 @@
+package finance
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestCentralBank_HelicopterDropAndMoneySupply(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	region.AddPerson(entities.NewPerson("Alice", 100.0, 8.0))
+	region.AddPerson(entities.NewPerson("Bob", 50.0, 8.0))
+	region.AddIndustry(entities.CreateIndustry("Farm").SetInitialCapital(1000.0))
+
+	cb := NewCentralBank(0.01, NewBank(0.005, 0.02)).
+		Schedule(Intervention{Tick: 2, Type: HelicopterDrop, Amount: 25.0})
+
+	cb.ExecuteInterventions(region, 1)
+	if record := cb.RecordMoneySupply(region, 1); record.Supply != 1150.0 || record.Injected != 0 {
+		t.Errorf("Expected supply 1150 with nothing injected, got %+v", record)
+	}
+
+	cb.ExecuteInterventions(region, 2)
+	record := cb.RecordMoneySupply(region, 2)
+	if record.Supply != 1200.0 || record.Injected != 50.0 {
+		t.Errorf("Expected supply 1200 with 50 injected, got %+v", record)
+	}
+	if region.People[0].Money != 125.0 {
+		t.Errorf("Expected Alice to receive 25, has %.2f", region.People[0].Money)
+	}
+}
+
+func TestCentralBank_BailoutTopsUpStrugglingIndustries(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	poor := entities.CreateIndustry("Poor").SetInitialCapital(200.0)
+	rich := entities.CreateIndustry("Rich").SetInitialCapital(5000.0)
+	region.AddIndustry(poor)
+	region.AddIndustry(rich)
+
+	cb := NewCentralBank(0, nil)
+	total := cb.Bailout(region, 1000.0)
+
+	if total != 800.0 || poor.Money != 1000.0 || rich.Money != 5000.0 {
+		t.Errorf("Expected only Poor topped up by 800, got total %.2f", total)
+	}
+}
+
+func TestCentralBank_RateChangeReachesBank(t *testing.T) {
+	bank := NewBank(0.005, 0.02)
+	cb := NewCentralBank(0.01, bank).
+		Schedule(Intervention{Tick: 3, Type: RateChange, Rate: 0.03})
+
+	cb.ExecuteInterventions(entities.NewRegion("TestRegion"), 3)
+
+	if cb.PolicyRate != 0.03 {
+		t.Errorf("Expected policy rate 0.03, got %.3f", cb.PolicyRate)
+	}
+	if !approxEqual(bank.LendingRate, 0.05) || !approxEqual(bank.DepositRate, 0.025) {
+		t.Errorf("Expected lending 0.05 / deposit 0.025, got %.3f / %.3f", bank.LendingRate, bank.DepositRate)
+	}
+}
+
+func approxEqual(a, b float32) bool {
+	diff := a - b
+	return diff < 1e-6 && diff > -1e-6
+}
+
+func TestProcessSavings_DepositsAndPaysInterest(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	thrifty := entities.NewPopulationSegment("Thrifty", nil, 1)
+	thrifty.SavingsPropensity = 0.5
+
+	saver := entities.NewPerson("Saver", 100.0, 8.0)
+	saver.AddSegment(thrifty)
+	spender := entities.NewPerson("Spender", 100.0, 8.0)
+	region.AddPerson(saver)
+	region.AddPerson(spender)
+
+	bank := NewBank(0, 0)
+	bank.DepositRate = 0.1
+
+	result := ProcessSavings(region, bank)
+	if saver.Money != 50.0 || saver.Savings != 50.0 || spender.Savings != 0 {
+		t.Errorf("Expected saver to deposit half, got cash %.2f savings %.2f", saver.Money, saver.Savings)
+	}
+	if result.InterestPaid != 0 {
+		t.Errorf("Expected no interest on the first deposit, got %.2f", result.InterestPaid)
+	}
+
+	result = ProcessSavings(region, bank)
+	// 50 * 10% interest, then half of the 50 cash deposited
+	if !approxEqual(saver.Savings, 80.0) || !approxEqual(result.InterestPaid, 5.0) {
+		t.Errorf("Expected savings 80 with 5 interest, got %.2f / %.2f", saver.Savings, result.InterestPaid)
+	}
+	if !approxEqual(bank.Deposits, 80.0) {
+		t.Errorf("Expected bank deposits 80, got %.2f", bank.Deposits)
+	}
+}
+
+func TestWithdrawForSpending(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	person := entities.NewPerson("Saver", 10.0, 8.0)
+	person.Savings = 100.0
+	region.AddPerson(person)
+
+	withdrawn := WithdrawForSpending(region, 50.0)
+
+	if withdrawn != 40.0 || person.Money != 50.0 || person.Savings != 60.0 {
+		t.Errorf("Expected 40 withdrawn, got %.2f (cash %.2f savings %.2f)", withdrawn, person.Money, person.Savings)
+	}
+}
+
+func TestDistributeDividends_ProRataToShareholders(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	founder := entities.NewPerson("Founder", 0, 8.0)
+	investor := entities.NewPerson("Investor", 0, 8.0)
+	region.AddPerson(founder)
+	region.AddPerson(investor)
+
+	farm := entities.CreateIndustry("Farm").SetInitialCapital(10000.0).
+		IssueShares(founder, 75).
+		IssueShares(investor, 25)
+	farm.DividendPayout = 0.5
+	region.AddIndustry(farm)
+
+	payments := DistributeDividends(region, map[int]float32{farm.ID: 1000.0})
+
+	if len(payments) != 1 || payments[0].Paid != 500.0 {
+		t.Fatalf("Expected $500 paid out, got %+v", payments)
+	}
+	if founder.Money != 375.0 || investor.Money != 125.0 || farm.Money != 9500.0 {
+		t.Errorf("Unexpected split: founder %.2f investor %.2f farm %.2f", founder.Money, investor.Money, farm.Money)
+	}
+
+	// Losses pay nothing
+	if payments := DistributeDividends(region, map[int]float32{farm.ID: -200.0}); len(payments) != 0 {
+		t.Errorf("Expected no dividends on a loss, got %+v", payments)
+	}
+}
+
+func TestTransferShares(t *testing.T) {
+	seller := entities.NewPerson("Seller", 0, 8.0)
+	buyer := entities.NewPerson("Buyer", 150.0, 8.0)
+	farm := entities.CreateIndustry("Farm").IssueShares(seller, 10)
+
+	if err := farm.TransferShares(seller, buyer, 4, 100.0); err == nil {
+		t.Error("Expected error when the buyer cannot afford the shares")
+	}
+
+	if err := farm.TransferShares(seller, buyer, 4, 25.0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if farm.SharesOf(seller) != 6 || farm.SharesOf(buyer) != 4 || seller.Money != 100.0 {
+		t.Errorf("Unexpected holdings: seller %.0f buyer %.0f, seller cash %.2f",
+			farm.SharesOf(seller), farm.SharesOf(buyer), seller.Money)
+	}
+
+	if err := farm.TransferShares(seller, buyer, 6, 0); err != nil || len(farm.Shareholders) != 1 {
+		t.Errorf("Expected the seller to drop off the register after selling out")
+	}
+}
+
+func TestExchangeMarket_ConvertAndFloat(t *testing.T) {
+	em := NewExchangeMarket("USD", 0.5).
+		AddCurrency("INR", 0.0125, FloatingRate).
+		AddCurrency("EUR", 1.1, FixedRate)
+
+	inr, err := em.Convert(100, "USD", "INR")
+	if err != nil || !approxEqual(inr, 8000) {
+		t.Errorf("Expected 100 USD = 8000 INR, got %.2f (%v)", inr, err)
+	}
+	if _, err := em.Convert(1, "USD", "XYZ"); err == nil {
+		t.Error("Expected error for unknown currency")
+	}
+
+	// India exports 8000 INR worth of goods to the US and imports nothing
+	if err := em.RecordTrade(8000, "INR", "USD"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if em.TradeBalance("INR") != 8000 {
+		t.Errorf("Expected INR surplus 8000, got %.2f", em.TradeBalance("INR"))
+	}
+
+	em.UpdateRates()
+
+	// Full surplus appreciates by the sensitivity; fixed currencies don't move
+	if !approxEqual(em.Currencies["INR"].Rate, 0.01875) {
+		t.Errorf("Expected INR to appreciate to 0.01875, got %.5f", em.Currencies["INR"].Rate)
+	}
+	if em.Currencies["USD"].Rate != 1.0 || em.Currencies["EUR"].Rate != 1.1 {
+		t.Error("Expected fixed currencies to keep their rates")
+	}
+	if em.TradeBalance("INR") != 0 {
+		t.Error("Expected trade flows to reset after the update")
+	}
+}
+
+func TestDistributeDividends_CooperativeSharesProfitAmongMembers(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	first := entities.NewPerson("First", 0, 8.0)
+	second := entities.NewPerson("Second", 0, 8.0)
+	region.AddPerson(first)
+	region.AddPerson(second)
+
+	coop := entities.CreateIndustry("Coop").SetInitialCapital(1000.0)
+	coop.Cooperative = true
+	coop.DividendPayout = 1.0
+	region.AddIndustry(coop)
+
+	if joined := coop.AdmitMembers([]*entities.Person{first}); joined != 1 {
+		t.Fatalf("Expected 1 new member, got %d", joined)
+	}
+	if joined := coop.AdmitMembers([]*entities.Person{first, second}); joined != 1 {
+		t.Fatalf("Expected only the second worker to join, got %d", joined)
+	}
+
+	DistributeDividends(region, map[int]float32{coop.ID: 300.0})
+
+	if first.Money != 150.0 || second.Money != 150.0 {
+		t.Errorf("Expected an equal $150 each, got %.2f and %.2f", first.Money, second.Money)
+	}
+	if coop.Money != 700.0 || coop.TotalDistributed != 300.0 {
+		t.Errorf("Expected the whole profit distributed, got money %.2f, distributed %.2f", coop.Money, coop.TotalDistributed)
+	}
+}
+
+func TestBank_LendRespectsCovenant(t *testing.T) {
+	bank := NewBank(0, 0.02)
+	bank.ApplyPolicyRate(0.01)
+	bank.Lending = &Lending{Term: 4, MaxDebtToRevenue: 2}
+	farm := entities.CreateIndustry("Farm")
+	farm.RecordRevenue(500)
+
+	loan, err := bank.Lend(farm, 800, 1)
+	if err != nil {
+		t.Fatalf("Expected loan within 2× revenue, got %v", err)
+	}
+	if loan.Rate != 0.03 || farm.Money != 800 || bank.Loans != 800 {
+		t.Errorf("Expected $800 lent at 3%%, got rate %.2f, money %.2f, bank loans %.2f", loan.Rate, farm.Money, bank.Loans)
+	}
+
+	if _, err := bank.Lend(farm, 300, 1); err == nil {
+		t.Error("Expected covenant to refuse debt above 2× revenue")
+	}
+}
+
+func TestServiceDebt_PaysInterestAndInstallments(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	farm := entities.CreateIndustry("Farm")
+	region.AddIndustry(farm)
+	bank := NewBank(0, 0.1)
+	bank.ApplyPolicyRate(0)
+	bank.Lending = &Lending{Term: 2}
+	bank.Lend(farm, 1000, 1)
+
+	// 100 interest and a 500 installment, with 200 to spare
+	farm.Money = 800
+	result := ServiceDebt(region, bank)
+	if result.TotalInterest != 100 || result.TotalRepaid != 500 || farm.Debt() != 500 {
+		t.Errorf("Expected $100 interest and $500 repaid, got %+v, debt %.2f", result, farm.Debt())
+	}
+
+	// 50 interest is paid but only 10 of the 500 installment
+	farm.Money = 60
+	result = ServiceDebt(region, bank)
+	if result.Payments[0].Missed != 490 || farm.Debt() != 490 {
+		t.Errorf("Expected $490 missed and owed, got %+v, debt %.2f", result.Payments[0], farm.Debt())
+	}
+
+	farm.Money = 1000
+	ServiceDebt(region, bank)
+	if len(farm.Loans) != 0 || bank.Loans > 0.001 {
+		t.Errorf("Expected the loan closed, got %d loans and $%.2f outstanding", len(farm.Loans), bank.Loans)
+	}
+}
+
+func TestCreditScore(t *testing.T) {
+	farm := entities.CreateIndustry("Farm")
+	if score := CreditScore(farm); score != 0.5 {
+		t.Errorf("Expected a new borrower to score 0.5, got %.2f", score)
+	}
+
+	// Revenue of 300 against costs of 100, and 1 of 2 payments missed
+	farm.RecordRevenue(300)
+	farm.RecordProduction(entities.ProductionRecord{Tick: 1, TotalCost: 100})
+	farm.PaymentsMade, farm.PaymentsMissed = 1, 1
+	if score := CreditScore(farm); score != 0.625 {
+		t.Errorf("Expected score 0.625, got %.3f", score)
+	}
+}
+
+func TestBank_LendPricesRisk(t *testing.T) {
+	bank := NewBank(0, 0.02)
+	bank.ApplyPolicyRate(0)
+	bank.Lending = &Lending{Term: 4, MinScore: 0.4, RiskPremium: 0.1}
+
+	farm := entities.CreateIndustry("Farm")
+	loan, err := bank.Lend(farm, 100, 1)
+	if err != nil || loan.Rate != 0.07 {
+		t.Fatalf("Expected a loan at 2%% plus half the 10%% premium, got %+v, %v", loan, err)
+	}
+
+	farm.PaymentsMissed = 4
+	if _, err := bank.Lend(farm, 100, 2); err == nil {
+		t.Error("Expected a borrower who keeps missing payments to be refused")
+	}
+}
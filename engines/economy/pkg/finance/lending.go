@@ -0,0 +1,127 @@
+package finance
+
+import (
+	"fmt"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Lending is the bank's policy for loans to industries
+type Lending struct {
+	Term             int     // Ticks a loan's principal is repaid over
+	MaxDebtToRevenue float32 // Covenant: debt may be at most this many ticks of average revenue (0 = no limit)
+	MinScore         float32 // Risk appetite: lowest credit score the bank lends to (0 = anyone)
+	RiskPremium      float32 // Extra interest per tick charged for a credit score of 0, scaled down to none at 1
+}
+
+// Lend makes the industry a loan of the amount at the bank's lending rate
+// plus its risk premium, unless the bank doesn't lend, the industry's credit
+// score is below the bank's risk appetite or the loan would break the
+// covenant
+func (b *Bank) Lend(industry *entities.Industry, amount float32, tick int) (*entities.Loan, error) {
+	if b.Lending == nil {
+		return nil, fmt.Errorf("the bank does not lend to industries")
+	}
+	score := CreditScore(industry)
+	if score < b.Lending.MinScore {
+		return nil, fmt.Errorf("credit score %.2f is below %.2f", score, b.Lending.MinScore)
+	}
+	if err := b.CheckCovenant(industry, amount); err != nil {
+		return nil, err
+	}
+
+	loan := &entities.Loan{
+		Principal: amount,
+		Balance:   amount,
+		Rate:      b.LendingRate + b.Lending.RiskPremium*(1-score),
+		Term:      b.Lending.Term,
+		TakenAt:   tick,
+	}
+	industry.Loans = append(industry.Loans, loan)
+	industry.Money += amount
+	b.Loans += amount
+	return loan, nil
+}
+
+// CheckCovenant returns an error if borrowing the amount would take the
+// industry's debt past the covenant's multiple of its average revenue
+func (b *Bank) CheckCovenant(industry *entities.Industry, amount float32) error {
+	limit := b.Lending.MaxDebtToRevenue
+	if limit <= 0 {
+		return nil
+	}
+	debt := industry.Debt() + amount
+	revenue := industry.AverageRevenue()
+	if debt > limit*revenue {
+		return fmt.Errorf("debt of %.2f would exceed %.1f× revenue of %.2f", debt, limit, revenue)
+	}
+	return nil
+}
+
+// DebtService is what one industry paid on its loans in a tick
+type DebtService struct {
+	IndustryName string
+	Interest     float32
+	Principal    float32
+	Missed       float32 // Due but unpaid, added to the balance
+	Debt         float32 // Principal still owed afterwards
+}
+
+// DebtResult summarizes one tick of loan repayments
+type DebtResult struct {
+	Payments      []DebtService
+	TotalInterest float32
+	TotalRepaid   float32
+}
+
+// ServiceDebt collects the interest and principal due on every industry's
+// loans. Interest is paid first. What an industry can't pay is added to the
+// loan's balance, and repaid loans are closed.
+func ServiceDebt(region *entities.Region, bank *Bank) *DebtResult {
+	result := &DebtResult{}
+	capitalized := float32(0)
+
+	for _, industry := range region.Industries {
+		if len(industry.Loans) == 0 {
+			continue
+		}
+		service := DebtService{IndustryName: industry.Name}
+		open := industry.Loans[:0]
+		for _, loan := range industry.Loans {
+			interestDue, principalDue := loan.Interest(), loan.Installment()
+
+			interest := min(interestDue, max(industry.Money, 0))
+			industry.Money -= interest
+			service.Interest += interest
+
+			principal := min(principalDue, max(industry.Money, 0))
+			industry.Money -= principal
+			service.Principal += principal
+
+			// Unpaid interest is capitalized; unpaid principal stays owed
+			loan.Balance += interestDue - interest - principal
+			capitalized += interestDue - interest
+			service.Missed += interestDue - interest + principalDue - principal
+
+			if loan.Balance > 0.001 {
+				open = append(open, loan)
+			}
+		}
+		industry.Loans = open
+		if service.Missed > 0.001 {
+			industry.PaymentsMissed++
+		} else {
+			industry.PaymentsMade++
+		}
+		industry.InterestPaid += service.Interest
+		service.Debt = industry.Debt()
+
+		result.Payments = append(result.Payments, service)
+		result.TotalInterest += service.Interest
+		result.TotalRepaid += service.Principal
+	}
+
+	bank.Loans += capitalized - result.TotalRepaid
+	bank.InterestEarned += result.TotalInterest
+	return result
+}
@@ -0,0 +1,54 @@
+package finance
+
+import "westex/engines/economy/pkg/entities"
+
+// SavingsResult summarizes household banking for one tick
+type SavingsResult struct {
+	InterestPaid float32
+	Deposited    float32
+	Withdrawn    float32
+	TotalSavings float32
+}
+
+// WithdrawForSpending lets people whose cash fell below what they need to
+// shop take the difference out of their savings
+func WithdrawForSpending(region *entities.Region, cashNeeded float32) float32 {
+	withdrawn := float32(0)
+	for _, person := range region.People {
+		if person.Savings <= 0 || person.Money >= cashNeeded {
+			continue
+		}
+		amount := min(cashNeeded-person.Money, person.Savings)
+		person.Savings -= amount
+		person.Money += amount
+		withdrawn += amount
+	}
+	return withdrawn
+}
+
+// ProcessSavings pays deposit interest on existing savings and then deposits
+// each person's propensity to save of the cash left after shopping
+func ProcessSavings(region *entities.Region, bank *Bank) *SavingsResult {
+	result := &SavingsResult{}
+
+	for _, person := range region.People {
+		if person.Savings > 0 && bank.DepositRate > 0 {
+			interest := person.Savings * bank.DepositRate
+			person.Savings += interest
+			result.InterestPaid += interest
+		}
+
+		if propensity := person.SavingsPropensity(); propensity > 0 && person.Money > 0 {
+			deposit := person.Money * propensity
+			person.Money -= deposit
+			person.Savings += deposit
+			result.Deposited += deposit
+		}
+
+		result.TotalSavings += person.Savings
+	}
+
+	bank.InterestPaid += result.InterestPaid
+	bank.Deposits = result.TotalSavings
+	return result
+}
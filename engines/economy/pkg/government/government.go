@@ -0,0 +1,103 @@
+package government
+
+import (
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/market"
+)
+
+// Government collects taxes into a treasury that policies can spend
+type Government struct {
+	Treasury     float32
+	SalesTaxRate float32 // Share of formal sales revenue remitted as tax
+
+	// Reserve is the strategic stockpile of basic needs (nil disables it)
+	Reserve *Reserve
+
+	// Pension pays retirees each tick (nil disables it)
+	Pension *Pension
+
+	// Unemployment insures workers against losing their job (nil disables it)
+	Unemployment *Unemployment
+
+	// PriceControls cap or prop up product prices in the market
+	PriceControls market.PriceControls
+
+	TotalTaxCollected float32
+}
+
+// NewGovernment creates a government with a starting treasury
+func NewGovernment(treasury, salesTaxRate float32) *Government {
+	return &Government{
+		Treasury:     treasury,
+		SalesTaxRate: salesTaxRate,
+	}
+}
+
+// TaxResult records the tax each industry paid for a tick
+type TaxResult struct {
+	ByIndustry map[string]float32
+	Total      float32
+}
+
+// CollectSalesTax has every industry remit the sales tax on the formal
+// purchases it made this tick, as far as its money allows
+func (g *Government) CollectSalesTax(region *entities.Region, purchases []market.Purchase) *TaxResult {
+	result := &TaxResult{ByIndustry: make(map[string]float32)}
+	if g.SalesTaxRate <= 0 {
+		return result
+	}
+
+	revenue := make(map[int]float32)
+	for _, purchase := range purchases {
+		revenue[purchase.IndustryID] += purchase.TotalCost
+	}
+
+	for _, industry := range region.Industries {
+		tax := min(revenue[industry.ID]*g.SalesTaxRate, max(industry.Money, 0))
+		if tax <= 0 {
+			continue
+		}
+		industry.Money -= tax
+		g.Treasury += tax
+		result.ByIndustry[industry.Name] += tax
+		result.Total += tax
+	}
+
+	g.TotalTaxCollected += result.Total
+	return result
+}
+
+// SubsidyResult records what the treasury paid public industries for a tick
+type SubsidyResult struct {
+	ByIndustry map[string]float32
+	Total      float32
+	Unfunded   float32 // Owed but not paid because the treasury ran dry
+}
+
+// FundPublic pays public industries the part of their sales price the
+// treasury covers, as far as the treasury allows
+func (g *Government) FundPublic(region *entities.Region, purchases []market.Purchase) *SubsidyResult {
+	result := &SubsidyResult{ByIndustry: make(map[string]float32)}
+
+	owed := make(map[int]float32)
+	for _, purchase := range purchases {
+		owed[purchase.IndustryID] += purchase.Subsidy
+	}
+
+	for _, industry := range region.Industries {
+		if owed[industry.ID] <= 0 {
+			continue
+		}
+		paid := min(owed[industry.ID], max(g.Treasury, 0))
+		result.Unfunded += owed[industry.ID] - paid
+		if paid <= 0 {
+			continue
+		}
+		industry.Money += paid
+		g.Treasury -= paid
+		result.ByIndustry[industry.Name] += paid
+		result.Total += paid
+	}
+
+	return result
+}
@@ -0,0 +1,195 @@
+package government
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/market"
+)
+
+func TestCollectSalesTax(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	farm := entities.CreateIndustry("Farm").SetInitialCapital(1000.0)
+	shop := entities.CreateIndustry("Shop").SetInitialCapital(1000.0)
+	region.AddIndustry(farm)
+	region.AddIndustry(shop)
+
+	purchases := []market.Purchase{
+		{IndustryID: farm.ID, TotalCost: 100.0},
+		{IndustryID: farm.ID, TotalCost: 100.0},
+		{IndustryID: shop.ID, TotalCost: 50.0},
+	}
+
+	gov := NewGovernment(0, 0.1)
+	result := gov.CollectSalesTax(region, purchases)
+
+	if result.Total != 25.0 || gov.Treasury != 25.0 {
+		t.Errorf("Expected $25 tax, got %.2f (treasury %.2f)", result.Total, gov.Treasury)
+	}
+	if farm.Money != 980.0 || shop.Money != 995.0 {
+		t.Errorf("Expected farm 980 / shop 995, got %.2f / %.2f", farm.Money, shop.Money)
+	}
+}
+
+// newReserveRegion builds a region with a farm solving a basic need
+func newReserveRegion(stock float32) (*entities.Region, *entities.Industry, *entities.Problem) {
+	region := entities.NewRegion("TestRegion")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	food.IsBasicNeed = true
+	region.AddProblem(food)
+
+	grain := entities.NewResource("Grain", "kg")
+	grain.Quantity = stock
+	farm := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{grain}).
+		SetInitialCapital(1000.0)
+	region.AddIndustry(farm)
+	return region, farm, food
+}
+
+func TestStockReserve_BuysSurplusOnlyWhenNeedsAreMet(t *testing.T) {
+	region, farm, food := newReserveRegion(100)
+	gov := NewGovernment(1000, 0)
+	gov.Reserve = NewReserve(30, 0.5, 0.1, 0)
+
+	short := map[int]*market.NeedStats{food.ID: {Needy: 10, Seeking: 10, Satisfied: 8}}
+	if result := gov.StockReserve(region, short, 10); result.Spent != 0 {
+		t.Errorf("Expected no buying during a shortage, got $%.2f", result.Spent)
+	}
+
+	met := map[int]*market.NeedStats{food.ID: {Needy: 10, Seeking: 10, Satisfied: 10}}
+	result := gov.StockReserve(region, met, 10)
+
+	// Half the surplus is 50 units, capped at the capacity of 30
+	if gov.Reserve.Stock["Grain"] != 30 || result.Bought["Grain"] != 30 {
+		t.Errorf("Expected 30 units in reserve, got %.0f", gov.Reserve.Stock["Grain"])
+	}
+	if gov.Treasury != 700 || farm.Money != 1300 {
+		t.Errorf("Expected treasury 700 / farm 1300, got %.2f / %.2f", gov.Treasury, farm.Money)
+	}
+	if farm.OutputProducts[0].Quantity != 70 {
+		t.Errorf("Expected 70 units left on the shelf, got %.0f", farm.OutputProducts[0].Quantity)
+	}
+}
+
+func TestReleaseReserve_RefillsShelvesDuringShortage(t *testing.T) {
+	region, farm, food := newReserveRegion(0)
+	gov := NewGovernment(0, 0)
+	gov.Reserve = NewReserve(100, 0.5, 0.1, 0)
+	gov.Reserve.Stock["Grain"] = 50
+
+	// 1 in 10 unserved is not above the threshold
+	mild := map[int]*market.NeedStats{food.ID: {Needy: 10, Seeking: 10, Satisfied: 9}}
+	if result := gov.ReleaseReserve(region, mild, 10); len(result.Released) != 0 {
+		t.Errorf("Expected no release for a mild shortage, got %v", result.Released)
+	}
+
+	severe := map[int]*market.NeedStats{food.ID: {Needy: 10, Seeking: 10, Satisfied: 4}}
+	result := gov.ReleaseReserve(region, severe, 10)
+
+	if result.Released["Grain"] != 6 || farm.OutputProducts[0].Quantity != 6 {
+		t.Errorf("Expected the 6 missing units released, got %.0f", result.Released["Grain"])
+	}
+	if gov.Reserve.Stock["Grain"] != 44 {
+		t.Errorf("Expected 44 units left in reserve, got %.0f", gov.Reserve.Stock["Grain"])
+	}
+	if gov.Treasury != 60 || farm.Money != 940 {
+		t.Errorf("Expected treasury 60 / farm 940, got %.2f / %.2f", gov.Treasury, farm.Money)
+	}
+}
+
+func TestFundPublic_PaysSubsidiesAsFarAsTheTreasuryAllows(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	clinic := entities.CreateIndustry("Clinic")
+	school := entities.CreateIndustry("School")
+	region.AddIndustry(clinic)
+	region.AddIndustry(school)
+
+	purchases := []market.Purchase{
+		{IndustryID: clinic.ID, Subsidy: 30.0},
+		{IndustryID: clinic.ID, Subsidy: 30.0},
+		{IndustryID: school.ID, Subsidy: 60.0},
+	}
+
+	gov := NewGovernment(100, 0)
+	result := gov.FundPublic(region, purchases)
+
+	if result.Total != 100.0 || gov.Treasury != 0 {
+		t.Errorf("Expected the whole $100 treasury paid out, got %.2f (treasury %.2f)", result.Total, gov.Treasury)
+	}
+	if result.Unfunded != 20.0 {
+		t.Errorf("Expected $20 unfunded, got %.2f", result.Unfunded)
+	}
+	if clinic.Money != 60.0 || school.Money != 40.0 {
+		t.Errorf("Expected clinic 60 / school 40, got %.2f / %.2f", clinic.Money, school.Money)
+	}
+}
+
+func TestPayPensions_FromTreasuryAndSavings(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	retirees := entities.NewPopulationSegment("Retirees", nil, 2)
+	retirees.Retired = true
+	workers := entities.NewPopulationSegment("Workers", nil, 1)
+	for i, segment := range []*entities.PopulationSegment{retirees, retirees, workers} {
+		person := entities.NewPerson("Person", 0, 0)
+		person.Savings = float32(i) * 100
+		person.AddSegment(segment)
+		region.AddPerson(person)
+	}
+
+	gov := NewGovernment(150, 0)
+	gov.Pension = &Pension{Amount: 100, Source: PensionFromTreasury}
+	result := gov.PayPensions(region)
+	if result.Retirees != 2 || result.FromTreasury != 150 || result.Shortfall != 50 {
+		t.Errorf("Expected 2 retirees paid $150 with $50 short, got %d, $%.2f, $%.2f",
+			result.Retirees, result.FromTreasury, result.Shortfall)
+	}
+	if region.People[2].Money != 0 {
+		t.Errorf("Expected workers to get no pension, got $%.2f", region.People[2].Money)
+	}
+
+	gov.Pension.Source = PensionFromSavings
+	result = gov.PayPensions(region)
+	if result.FromSavings != 100 || result.Shortfall != 100 {
+		t.Errorf("Expected $100 drawn from savings with $100 short, got $%.2f and $%.2f", result.FromSavings, result.Shortfall)
+	}
+	if region.People[1].Savings != 0 || region.People[1].Money != 150 {
+		t.Errorf("Expected the second retiree to draw down all $100, got savings $%.2f, cash $%.2f",
+			region.People[1].Savings, region.People[1].Money)
+	}
+}
+
+func TestUnemployment_PaysEligibleIdleWorkersForDuration(t *testing.T) {
+	farm := entities.CreateIndustry("Farm").SetInitialCapital(1000.0)
+	veteran := entities.NewPerson("Veteran", 0, 8)
+	newcomer := entities.NewPerson("Newcomer", 0, 8)
+
+	gov := NewGovernment(1000, 0)
+	gov.Unemployment = NewUnemployment(0.1, 0.5, 2)
+
+	tax := gov.CollectPayroll(farm, []*entities.Person{veteran}, []float32{100}, 2)
+	if tax != 20 || farm.Money != 980 {
+		t.Errorf("Expected $20 payroll tax on the $200 wage bill, got $%.2f (farm $%.2f)", tax, farm.Money)
+	}
+
+	idle := []*entities.Person{veteran, newcomer}
+	for tick := 0; tick < 3; tick++ {
+		gov.PayUnemployment(idle)
+	}
+	if veteran.Money != 200 {
+		t.Errorf("Expected two $100 benefits, got $%.2f", veteran.Money)
+	}
+	if newcomer.Money != 0 {
+		t.Errorf("Expected nothing for someone who never worked, got $%.2f", newcomer.Money)
+	}
+
+	result := gov.PayUnemployment(idle)
+	if result.Exhausted != 1 || result.Ineligible != 1 || result.Paid != 0 {
+		t.Errorf("Expected one exhausted and one ineligible claimant, got %+v", result)
+	}
+
+	// Working again renews the entitlement
+	gov.CollectPayroll(farm, []*entities.Person{veteran}, []float32{100}, 2)
+	if result := gov.PayUnemployment(idle); result.Claimants != 1 {
+		t.Errorf("Expected the veteran to claim again after working, got %+v", result)
+	}
+}
@@ -0,0 +1,57 @@
+package government
+
+import "westex/engines/economy/pkg/entities"
+
+// Sources a pension can be paid from
+const (
+	PensionFromTreasury = "treasury" // The government pays every retiree
+	PensionFromSavings  = "savings"  // Retirees draw down their own savings
+)
+
+// Pension is the income retirees receive each tick
+type Pension struct {
+	Amount float32 // Paid to each retiree per tick
+	Source string  // PensionFromTreasury or PensionFromSavings
+}
+
+// PensionResult records one tick's pension payments
+type PensionResult struct {
+	Retirees     int
+	FromTreasury float32
+	FromSavings  float32
+	Shortfall    float32 // Owed to retirees but not paid
+}
+
+// PayPensions moves each retiree's pension into their cash, from the
+// treasury or from their savings. Payments stop short when the treasury or
+// a retiree's savings run out.
+func (g *Government) PayPensions(region *entities.Region) *PensionResult {
+	result := &PensionResult{}
+	p := g.Pension
+	if p == nil || p.Amount <= 0 {
+		return result
+	}
+
+	for _, person := range region.People {
+		if !person.Retired() {
+			continue
+		}
+		result.Retirees++
+
+		var paid float32
+		switch p.Source {
+		case PensionFromSavings:
+			paid = min(p.Amount, max(person.Savings, 0))
+			person.Savings -= paid
+			result.FromSavings += paid
+		default:
+			paid = min(p.Amount, max(g.Treasury, 0))
+			g.Treasury -= paid
+			result.FromTreasury += paid
+		}
+		person.Money += paid
+		result.Shortfall += p.Amount - paid
+	}
+
+	return result
+}
@@ -0,0 +1,186 @@
+package government
+
+import (
+	"sort"
+
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/market"
+)
+
+// Reserve is a strategic stockpile of basic-need products. The government
+// buys part of the unsold surplus while nobody goes without and releases it
+// back to the producers' shelves when a shortage leaves people unserved.
+type Reserve struct {
+	Capacity  float32 // Most units held per product
+	BuyShare  float32 // Share of a product's unsold surplus bought each tick
+	ReleaseAt float32 // Share of needy people left unserved that counts as a shortage
+	Price     float32 // Price per unit, paid when buying and charged when releasing (0 = market price)
+
+	Stock map[string]float32 // Units held per product
+}
+
+// NewReserve creates an empty reserve
+func NewReserve(capacity, buyShare, releaseAt, price float32) *Reserve {
+	return &Reserve{
+		Capacity:  capacity,
+		BuyShare:  buyShare,
+		ReleaseAt: releaseAt,
+		Price:     price,
+		Stock:     make(map[string]float32),
+	}
+}
+
+// Clone returns a copy of the reserve with its own stock
+func (r *Reserve) Clone() *Reserve {
+	clone := *r
+	clone.Stock = make(map[string]float32, len(r.Stock))
+	for product, units := range r.Stock {
+		clone.Stock[product] = units
+	}
+	return &clone
+}
+
+// price is what the reserve pays and charges per unit
+func (r *Reserve) price(marketPrice float32) float32 {
+	if r.Price > 0 {
+		return r.Price
+	}
+	return marketPrice
+}
+
+// Level returns the units held, sorted by product name for stable output
+func (r *Reserve) Level() []ReserveLevel {
+	levels := make([]ReserveLevel, 0, len(r.Stock))
+	for product, units := range r.Stock {
+		levels = append(levels, ReserveLevel{Product: product, Units: units})
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Product < levels[j].Product })
+	return levels
+}
+
+// ReserveLevel is the reserve's holding of one product
+type ReserveLevel struct {
+	Product string
+	Units   float32
+}
+
+// ReserveResult records one tick's reserve interventions
+type ReserveResult struct {
+	Bought   map[string]float32 // Units bought per product
+	Released map[string]float32 // Units released per product
+	Spent    float32            // Paid out of the treasury
+	Earned   float32            // Paid back by producers for released units
+}
+
+func newReserveResult() *ReserveResult {
+	return &ReserveResult{
+		Bought:   make(map[string]float32),
+		Released: make(map[string]float32),
+	}
+}
+
+// StockReserve buys surplus basic-need products into the reserve after the
+// market. A product counts as surplus only if every basic need its producer
+// serves was fully met this tick, so the reserve never competes with
+// shoppers. Purchases are limited by capacity and by the treasury.
+func (g *Government) StockReserve(region *entities.Region, needs map[int]*market.NeedStats, marketPrice float32) *ReserveResult {
+	result := newReserveResult()
+	r := g.Reserve
+	if r == nil || r.BuyShare <= 0 {
+		return result
+	}
+	price := r.price(marketPrice)
+	if price <= 0 {
+		return result
+	}
+
+	for _, industry := range region.Industries {
+		if !servesBasicNeed(industry) || shortOf(industry, needs, 0) {
+			continue
+		}
+		for _, product := range industry.OutputProducts {
+			units := min(product.Quantity*r.BuyShare, r.Capacity-r.Stock[product.Name], g.Treasury/price)
+			if units <= 0 {
+				continue
+			}
+			cost := units * price
+			product.Consume(units)
+			industry.Money += cost
+			g.Treasury -= cost
+			r.Stock[product.Name] += units
+			result.Bought[product.Name] += units
+			result.Spent += cost
+		}
+	}
+
+	return result
+}
+
+// ReleaseReserve puts reserve stock back on the producers' shelves before
+// the market when the last tick left more than ReleaseAt of the people
+// needing a basic need unserved. Each producer buys back at most the units
+// its shoppers went without, as far as its money allows.
+func (g *Government) ReleaseReserve(region *entities.Region, needs map[int]*market.NeedStats, marketPrice float32) *ReserveResult {
+	result := newReserveResult()
+	r := g.Reserve
+	if r == nil {
+		return result
+	}
+	price := r.price(marketPrice)
+
+	for _, industry := range region.Industries {
+		if !servesBasicNeed(industry) || !shortOf(industry, needs, r.ReleaseAt) {
+			continue
+		}
+		shortfall := float32(0)
+		for _, problem := range industry.OwnedProblems {
+			if stats, ok := needs[problem.ID]; ok && problem.IsBasicNeed {
+				shortfall += float32(stats.Unmet())
+			}
+		}
+		for _, product := range industry.OutputProducts {
+			units := min(r.Stock[product.Name], shortfall)
+			if price > 0 {
+				units = min(units, max(industry.Money, 0)/price)
+			}
+			if units <= 0 {
+				continue
+			}
+			cost := units * price
+			product.Add(units)
+			industry.Money -= cost
+			g.Treasury += cost
+			r.Stock[product.Name] -= units
+			shortfall -= units
+			result.Released[product.Name] += units
+			result.Earned += cost
+		}
+	}
+
+	return result
+}
+
+// servesBasicNeed reports whether an industry solves any basic need
+func servesBasicNeed(industry *entities.Industry) bool {
+	for _, problem := range industry.OwnedProblems {
+		if problem.IsBasicNeed {
+			return true
+		}
+	}
+	return false
+}
+
+// shortOf reports whether more than the given share of the people needing
+// one of the industry's basic needs went unserved
+func shortOf(industry *entities.Industry, needs map[int]*market.NeedStats, share float32) bool {
+	for _, problem := range industry.OwnedProblems {
+		stats, ok := needs[problem.ID]
+		if !ok || !problem.IsBasicNeed || stats.Needy == 0 {
+			continue
+		}
+		if float32(stats.Unmet())/float32(stats.Needy) > share {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package government
+
+import "westex/engines/economy/pkg/entities"
+
+// Unemployment insures workers against going unallocated. Employers pay a
+// payroll tax on every wage bill into the treasury, and a worker who was
+// available but found no job is paid a share of their last wage for a
+// limited number of ticks.
+type Unemployment struct {
+	PayrollTaxRate  float32 // Share of each wage bill paid as payroll tax
+	ReplacementRate float32 // Share of the last wage paid as benefit
+	Duration        int     // Ticks a spell of unemployment is paid for (0 = no limit)
+
+	Claims map[int]*Claim // Per person ID, for everyone who has worked
+
+	TotalTax  float32 // Payroll tax collected so far
+	TotalPaid float32 // Benefits paid so far
+}
+
+// Claim is what a worker is entitled to when they lose their job
+type Claim struct {
+	Wage      float32 // Earned in the last tick worked
+	TicksPaid int     // Ticks of benefit paid in the current spell
+}
+
+// NewUnemployment creates a program with no claims yet
+func NewUnemployment(payrollTaxRate, replacementRate float32, duration int) *Unemployment {
+	return &Unemployment{
+		PayrollTaxRate:  payrollTaxRate,
+		ReplacementRate: replacementRate,
+		Duration:        duration,
+		Claims:          make(map[int]*Claim),
+	}
+}
+
+// Clone returns a copy of the program with its own claims
+func (u *Unemployment) Clone() *Unemployment {
+	clone := *u
+	clone.Claims = make(map[int]*Claim, len(u.Claims))
+	for id, claim := range u.Claims {
+		copied := *claim
+		clone.Claims[id] = &copied
+	}
+	return &clone
+}
+
+// BenefitResult records one tick of the unemployment program
+type BenefitResult struct {
+	Claimants  int     // Unallocated workers paid a benefit
+	Ineligible int     // Unallocated workers who never worked
+	Exhausted  int     // Unallocated workers whose benefit ran out
+	Paid       float32 // Benefits paid out of the treasury
+	Shortfall  float32 // Owed to claimants but not paid
+}
+
+// CollectPayroll records what each worker earned, which resets their claim,
+// and has the industry pay the payroll tax on the wage bill as far as its
+// money allows. It returns the tax paid.
+func (g *Government) CollectPayroll(industry *entities.Industry, workers []*entities.Person, hours []float32, wage float32) float32 {
+	u := g.Unemployment
+	if u == nil {
+		return 0
+	}
+
+	bill := float32(0)
+	for i, worker := range workers {
+		earned := hours[i] * wage
+		u.Claims[worker.ID] = &Claim{Wage: earned}
+		bill += earned
+	}
+
+	tax := min(bill*u.PayrollTaxRate, max(industry.Money, 0))
+	if tax <= 0 {
+		return 0
+	}
+	industry.Money -= tax
+	g.Treasury += tax
+	g.TotalTaxCollected += tax
+	u.TotalTax += tax
+	return tax
+}
+
+// PayUnemployment pays a benefit from the treasury to each unallocated
+// worker who has worked before and has not used up the program's duration
+func (g *Government) PayUnemployment(unemployed []*entities.Person) *BenefitResult {
+	result := &BenefitResult{}
+	u := g.Unemployment
+	if u == nil {
+		return result
+	}
+
+	for _, person := range unemployed {
+		claim, exists := u.Claims[person.ID]
+		switch {
+		case !exists || claim.Wage <= 0:
+			result.Ineligible++
+			continue
+		case u.Duration > 0 && claim.TicksPaid >= u.Duration:
+			result.Exhausted++
+			continue
+		}
+
+		benefit := claim.Wage * u.ReplacementRate
+		paid := min(benefit, max(g.Treasury, 0))
+		g.Treasury -= paid
+		person.Money += paid
+		claim.TicksPaid++
+		result.Claimants++
+		result.Paid += paid
+		result.Shortfall += benefit - paid
+	}
+
+	u.TotalPaid += result.Paid
+	return result
+}
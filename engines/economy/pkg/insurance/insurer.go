@@ -0,0 +1,139 @@
+package insurance
+
+import (
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/shocks"
+)
+
+// Policy insures one person or industry against one type of shock
+type Policy struct {
+	Person   *entities.Person
+	Industry *entities.Industry
+	Covers   string  // Shock type covered
+	Coverage float32 // Maximum payout per claim
+	Premium  float32 // Paid every tick
+	Lapsed   bool    // Holder failed to pay a premium
+}
+
+// Insurer collects premiums into reserves and pays out claims. An insurer
+// whose reserves cannot cover its claims fails and all its policies end.
+type Insurer struct {
+	Name      string
+	Reserves  float32
+	Policies  []*Policy
+	Insolvent bool
+}
+
+// TickResult summarizes an insurer's activity for one tick
+type TickResult struct {
+	InsurerName     string
+	PremiumsCharged float32
+	ClaimsPaid      float32
+	Claims          int
+	UnpaidClaims    float32 // Owed but not covered by reserves
+	Lapsed          int
+	FailedThisTick  bool
+}
+
+// NewInsurer creates an insurer with starting reserves
+func NewInsurer(name string, reserves float32) *Insurer {
+	return &Insurer{
+		Name:     name,
+		Reserves: reserves,
+		Policies: make([]*Policy, 0),
+	}
+}
+
+// AddPolicy underwrites a new policy
+func (i *Insurer) AddPolicy(policy *Policy) *Insurer {
+	i.Policies = append(i.Policies, policy)
+	return i
+}
+
+// CollectPremiums charges every active policy; holders who cannot pay lapse
+func (i *Insurer) CollectPremiums(result *TickResult) {
+	for _, policy := range i.Policies {
+		if policy.Lapsed {
+			continue
+		}
+		if !debit(policy, policy.Premium) {
+			policy.Lapsed = true
+			result.Lapsed++
+			continue
+		}
+		i.Reserves += policy.Premium
+		result.PremiumsCharged += policy.Premium
+	}
+}
+
+// PayClaims settles the losses covered by active policies
+func (i *Insurer) PayClaims(losses []shocks.Loss, result *TickResult) {
+	for _, loss := range losses {
+		policy := i.policyFor(loss)
+		if policy == nil {
+			continue
+		}
+
+		claim := min(loss.Amount, policy.Coverage)
+		payout := min(claim, i.Reserves)
+		i.Reserves -= payout
+		credit(policy, payout)
+		result.Claims++
+		result.ClaimsPaid += payout
+
+		if payout < claim {
+			result.UnpaidClaims += claim - payout
+			i.Insolvent = true
+			result.FailedThisTick = true
+		}
+	}
+}
+
+// ProcessTick collects premiums then pays claims for this tick's losses
+func (i *Insurer) ProcessTick(losses []shocks.Loss) TickResult {
+	result := TickResult{InsurerName: i.Name}
+	if i.Insolvent {
+		return result
+	}
+
+	i.CollectPremiums(&result)
+	i.PayClaims(losses, &result)
+	return result
+}
+
+// policyFor finds the active policy covering a loss
+func (i *Insurer) policyFor(loss shocks.Loss) *Policy {
+	for _, policy := range i.Policies {
+		if policy.Lapsed || policy.Covers != loss.Type {
+			continue
+		}
+		if (loss.Person != nil && policy.Person == loss.Person) ||
+			(loss.Industry != nil && policy.Industry == loss.Industry) {
+			return policy
+		}
+	}
+	return nil
+}
+
+func debit(policy *Policy, amount float32) bool {
+	if policy.Person != nil {
+		if policy.Person.Money < amount {
+			return false
+		}
+		policy.Person.Money -= amount
+		return true
+	}
+	if policy.Industry.Money < amount {
+		return false
+	}
+	policy.Industry.Money -= amount
+	return true
+}
+
+func credit(policy *Policy, amount float32) {
+	if policy.Person != nil {
+		policy.Person.Money += amount
+	} else {
+		policy.Industry.Money += amount
+	}
+}
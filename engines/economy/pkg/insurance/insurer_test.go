@@ -0,0 +1,55 @@
+package insurance
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/shocks"
+)
+
+func TestInsurer_PaysClaimsUpToCoverage(t *testing.T) {
+	person := entities.NewPerson("Alice", 100.0, 8.0)
+	insurer := NewInsurer("Mutual", 1000.0).
+		AddPolicy(&Policy{Person: person, Covers: shocks.HealthEvent, Coverage: 150, Premium: 10})
+
+	losses := []shocks.Loss{{Type: shocks.HealthEvent, Person: person, Amount: 200}}
+	result := insurer.ProcessTick(losses)
+
+	if result.PremiumsCharged != 10 || result.ClaimsPaid != 150 {
+		t.Errorf("Expected $10 premium and $150 claim, got %+v", result)
+	}
+	if person.Money != 240.0 || insurer.Reserves != 860.0 {
+		t.Errorf("Expected person 240 / reserves 860, got %.2f / %.2f", person.Money, insurer.Reserves)
+	}
+}
+
+func TestInsurer_FailsWhenReservesRunOut(t *testing.T) {
+	farm := entities.CreateIndustry("Farm").SetInitialCapital(1000.0)
+	insurer := NewInsurer("Mutual", 100.0).
+		AddPolicy(&Policy{Industry: farm, Covers: shocks.CropFailure, Coverage: 5000, Premium: 50})
+
+	result := insurer.ProcessTick([]shocks.Loss{{Type: shocks.CropFailure, Industry: farm, Amount: 1000}})
+
+	if !insurer.Insolvent || !result.FailedThisTick {
+		t.Error("Expected the insurer to fail")
+	}
+	if result.ClaimsPaid != 150 || result.UnpaidClaims != 850 {
+		t.Errorf("Expected 150 paid / 850 unpaid, got %+v", result)
+	}
+
+	// A failed insurer does nothing afterwards
+	if next := insurer.ProcessTick(nil); next.PremiumsCharged != 0 {
+		t.Errorf("Expected no premiums after failure, got %.2f", next.PremiumsCharged)
+	}
+}
+
+func TestInsurer_UnpaidPremiumLapsesPolicy(t *testing.T) {
+	person := entities.NewPerson("Broke", 5.0, 8.0)
+	insurer := NewInsurer("Mutual", 1000.0).
+		AddPolicy(&Policy{Person: person, Covers: shocks.HealthEvent, Coverage: 150, Premium: 10})
+
+	result := insurer.ProcessTick([]shocks.Loss{{Type: shocks.HealthEvent, Person: person, Amount: 100}})
+
+	if result.Lapsed != 1 || result.ClaimsPaid != 0 {
+		t.Errorf("Expected the policy to lapse without paying, got %+v", result)
+	}
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Log file rotation modes
+const (
+	RotatePerTick = "tick" // One file per tick
+	RotateBySize  = "size" // A new file whenever the current one is full
+)
+
+// RotatingFile writes the log into numbered files in a directory, so long
+// runs keep their early output and can be examined after the fact
+type RotatingFile struct {
+	Dir      string
+	Mode     string
+	MaxBytes int64 // File size limit in RotateBySize mode
+
+	file    *os.File
+	written int64
+	index   int
+}
+
+// NewRotatingFile creates the log directory and a writer rotating in the
+// given mode
+func NewRotatingFile(dir, mode string, maxBytes int64) (*RotatingFile, error) {
+	switch mode {
+	case RotatePerTick:
+	case RotateBySize:
+		if maxBytes <= 0 {
+			return nil, fmt.Errorf("size rotation needs a positive file size, got %d", maxBytes)
+		}
+	default:
+		return nil, fmt.Errorf("unknown log rotation %q (must be %q or %q)", mode, RotatePerTick, RotateBySize)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	return &RotatingFile{Dir: dir, Mode: mode, MaxBytes: maxBytes}, nil
+}
+
+// StartTick opens the tick's own file in RotatePerTick mode
+func (r *RotatingFile) StartTick(tick int) error {
+	if r.Mode != RotatePerTick {
+		return nil
+	}
+	return r.open(fmt.Sprintf("tick-%06d.log", tick))
+}
+
+// Write appends to the current file, first moving to a new one if size
+// rotation says it is full
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	full := r.Mode == RotateBySize && r.written > 0 && r.written+int64(len(p)) > r.MaxBytes
+	if r.file == nil || full {
+		r.index++
+		name := fmt.Sprintf("log-%04d.log", r.index)
+		if r.Mode == RotatePerTick {
+			name = "setup.log" // Anything logged before the first tick
+		}
+		if err := r.open(name); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.written += int64(n)
+	return n, err
+}
+
+// Close closes the current file
+func (r *RotatingFile) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// open closes the current file and starts the named one
+func (r *RotatingFile) open(name string) error {
+	if err := r.Close(); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(r.Dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	r.file, r.written = file, 0
+	return nil
+}
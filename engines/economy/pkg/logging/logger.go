@@ -2,38 +2,213 @@ package logging
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
 	"time"
+
+	"westex/engines/economy/pkg/events"
+)
+
+// Level is how much the logger prints
+type Level int
+
+const (
+	LevelQuiet   Level = iota // Nothing during the run
+	LevelSummary              // One summary line per tick
+	LevelVerbose              // Every phase in detail, purchases sampled
+	LevelTrace                // Every transaction
 )
 
+// Sampling decides which of a tick's purchases the logger prints. Failed
+// purchases are always printed.
+type Sampling struct {
+	First int // Print the first N purchases of each tick
+	Every int // After those, print every Kth purchase (0 = none)
+}
+
+// DefaultSampling prints only the first few purchases of each tick
+var DefaultSampling = Sampling{First: SamplePurchases}
+
+// keep reports whether the purchase at index n (from 0) of a tick is printed
+func (s Sampling) keep(n int) bool {
+	if n < s.First {
+		return true
+	}
+	return s.Every > 0 && (n-s.First+1)%s.Every == 0
+}
+
+// Tally counts a tick's transactions, sampled or not
+type Tally struct {
+	Tick      int
+	Purchases int
+	Shown     int // Purchases printed under the sampling policy
+	Failures  int
+}
+
 // Logger handles structured logging for the simulation
 type Logger struct {
-	enabled bool
+	level    Level
+	sampling Sampling
+	tally    Tally
+	out      io.Writer
+	date     string // Calendar date of the current tick ("" = none)
 }
 
-// NewLogger creates a new Logger instance
+// TickWriter is an output that wants to know when a new tick starts, such
+// as a log file rotated per tick
+type TickWriter interface {
+	io.Writer
+	StartTick(tick int) error
+}
+
+// NewLogger creates a new Logger instance, verbose when enabled and quiet
+// otherwise
 func NewLogger(enabled bool) *Logger {
-	return &Logger{enabled: enabled}
+	l := &Logger{sampling: DefaultSampling, out: os.Stdout}
+	l.SetEnabled(enabled)
+	return l
+}
+
+// Clone returns a logger with the same level, sampling and output, counting
+// its own tally, for an engine forked from this logger's
+func (l *Logger) Clone() *Logger {
+	return &Logger{level: l.level, sampling: l.sampling, out: l.out, date: l.date}
+}
+
+// SetOutput sends the log to w instead of standard output
+func (l *Logger) SetOutput(w io.Writer) {
+	l.out = w
+}
+
+// ToTerminal reports whether the log goes to standard output
+func (l *Logger) ToTerminal() bool {
+	return l.out == os.Stdout
+}
+
+// SetSampling sets which purchases are printed at LevelVerbose
+func (l *Logger) SetSampling(sampling Sampling) {
+	l.sampling = sampling
+}
+
+// Tally returns the transaction counts of the latest tick with any
+func (l *Logger) Tally() Tally {
+	return l.tally
+}
+
+// LogTally logs a tick's transaction counts, so sampled output still shows
+// the full volume
+func (l *Logger) LogTally(tick int) {
+	if l.level < LevelVerbose || l.tally.Tick != tick {
+		return
+	}
+	l.LogEvent(fmt.Sprintf("   %d purchases (%d shown), %d failed",
+		l.tally.Purchases, l.tally.Shown, l.tally.Failures))
+}
+
+// count starts a new tally when the tick changes
+func (l *Logger) count(tick int) *Tally {
+	if l.tally.Tick != tick {
+		l.tally = Tally{Tick: tick}
+		l.LogEvent("\nSample purchases:")
+	}
+	return &l.tally
 }
 
-// LogTick logs the start of a new time tick
+// SetEnabled switches between verbose and quiet logging
+func (l *Logger) SetEnabled(enabled bool) {
+	if enabled {
+		l.level = LevelVerbose
+	} else {
+		l.level = LevelQuiet
+	}
+}
+
+// Enabled reports whether the logger prints anything
+func (l *Logger) Enabled() bool {
+	return l.level > LevelQuiet
+}
+
+// FlagLevel maps the command line's -q, -v and -vv flags to a level. The
+// most verbose flag set wins; with none the run prints its tick summaries.
+func FlagLevel(quiet, verbose, trace bool) Level {
+	switch {
+	case trace:
+		return LevelTrace
+	case verbose:
+		return LevelVerbose
+	case quiet:
+		return LevelQuiet
+	}
+	return LevelSummary
+}
+
+// SetLevel sets how much the logger prints
+func (l *Logger) SetLevel(level Level) {
+	l.level = level
+}
+
+// Level returns how much the logger prints
+func (l *Logger) Level() Level {
+	return l.level
+}
+
+// SetDate labels the ticks logged from now on with a calendar date, such as
+// "March 2025" ("" = tick numbers only)
+func (l *Logger) SetDate(date string) {
+	l.date = date
+}
+
+// LogTick logs the start of a new time tick, first telling a TickWriter
+// output about it
 func (l *Logger) LogTick(tick int) {
-	if !l.enabled {
+	if tw, ok := l.out.(TickWriter); ok {
+		if err := tw.StartTick(tick); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ ERROR: %v\n", err)
+		}
+	}
+	if l.level < LevelVerbose {
 		return
 	}
-	fmt.Printf("\n========== TICK %d [%s] ==========\n", tick, time.Now().Format("15:04:05"))
+	if l.date != "" {
+		fmt.Fprintf(l.out, "\n========== TICK %d · %s [%s] ==========\n", tick, l.date, time.Now().Format("15:04:05"))
+		return
+	}
+	fmt.Fprintf(l.out, "\n========== TICK %d [%s] ==========\n", tick, time.Now().Format("15:04:05"))
+}
+
+// LogTickSummary logs the one-line summary of a finished tick
+func (l *Logger) LogTickSummary(tick int, summary string) {
+	if l.level < LevelSummary {
+		return
+	}
+	if l.date != "" {
+		fmt.Fprintf(l.out, "Tick %d (%s): %s\n", tick, l.date, summary)
+		return
+	}
+	fmt.Fprintf(l.out, "Tick %d: %s\n", tick, summary)
 }
 
 // LogEvent logs a general event
 func (l *Logger) LogEvent(message string) {
-	if !l.enabled {
+	if l.level < LevelVerbose {
 		return
 	}
-	fmt.Printf("  %s\n", message)
+	fmt.Fprintf(l.out, "  %s\n", message)
+}
+
+// LogWarning logs something that went wrong but didn't stop the run. Unlike
+// events it is printed at the summary level too.
+func (l *Logger) LogWarning(message string) {
+	if l.level < LevelSummary {
+		return
+	}
+	fmt.Fprintf(l.out, "⚠️  %s\n", message)
 }
 
 // LogEvents logs multiple events
 func (l *Logger) LogEvents(messages []string) {
-	if !l.enabled {
+	if l.level < LevelVerbose {
 		return
 	}
 	for _, msg := range messages {
@@ -43,19 +218,79 @@ func (l *Logger) LogEvents(messages []string) {
 
 // LogSummary logs a summary section
 func (l *Logger) LogSummary(title string, data map[string]interface{}) {
-	if !l.enabled {
+	if l.level < LevelVerbose {
 		return
 	}
-	fmt.Printf("\n--- %s ---\n", title)
+	fmt.Fprintf(l.out, "\n--- %s ---\n", title)
 	for key, value := range data {
-		fmt.Printf("  %s: %v\n", key, value)
+		fmt.Fprintf(l.out, "  %s: %v\n", key, value)
 	}
 }
 
 // LogError logs an error
 func (l *Logger) LogError(err error) {
-	if !l.enabled {
+	if l.level < LevelVerbose {
 		return
 	}
-	fmt.Printf("  ❌ ERROR: %v\n", err)
+	fmt.Fprintf(l.out, "  ❌ ERROR: %v\n", err)
 }
+
+// Subscribe logs the simulation events published on the bus. Purchases are
+// printed according to the sampling policy, or all of them at LevelTrace;
+// failed purchases are always printed.
+func (l *Logger) Subscribe(bus *events.Bus) {
+	bus.Subscribe(func(event events.Event) {
+		switch e := event.(type) {
+		case events.ProductionCompleted:
+			l.LogEvent(fmt.Sprintf("✅ Produced %.2f %s (total: %.2f)", e.Units, e.Product, e.Stock))
+		case events.WagePaid:
+			l.LogEvent(fmt.Sprintf("💰 Paid $%.2f in wages to %d workers", e.Amount, e.Workers))
+		case events.IndustryBankrupt:
+			l.LogEvent(fmt.Sprintf("❌ %s is bankrupt: %s", e.Industry, e.Reason))
+		case events.ResourceDepleted:
+			l.LogEvent(fmt.Sprintf("🪫 %s exhausted by %s", e.Resource, e.Industry))
+		case events.PurchaseMade:
+			tally := l.count(e.Tick)
+			if l.level >= LevelTrace || l.level == LevelVerbose && l.sampling.keep(tally.Purchases) {
+				l.LogEvent(fmt.Sprintf("   🛍️  Person #%d bought %.0f %s for $%.2f (solving %s)",
+					e.PersonID, e.Quantity, e.Product, e.TotalCost, e.Problem))
+				tally.Shown++
+			}
+			tally.Purchases++
+		case events.PurchasesFailed:
+			tally := l.count(e.Tick)
+			if l.level >= LevelVerbose {
+				for _, failure := range e.Failures {
+					l.LogEvent(fmt.Sprintf("   🚫 Person #%d found nothing for %s (%s)",
+						failure.PersonID, failure.Problem, strings.ReplaceAll(failure.Reason, "_", " ")))
+				}
+			}
+			tally.Failures += len(e.Failures)
+		case events.ParameterChanged:
+			l.LogEvent(fmt.Sprintf("⚙️  After tick %d, %s changed from %g to %g", e.Tick, e.Parameter, e.From, e.To))
+		case events.PersonDied:
+			l.LogEvent(fmt.Sprintf("🕯️  %s died, leaving $%.2f", e.Person, e.Wealth))
+		case events.PersonMigrated:
+			l.LogEvent(fmt.Sprintf("🧳 %s left for %s with $%.2f", e.Person, e.To, e.Wealth))
+		case events.PersonHired:
+			if l.level >= LevelTrace {
+				l.LogEvent(fmt.Sprintf("   👷 Person #%d hired by %s", e.PersonID, e.Industry))
+			}
+		case events.PersonFired:
+			if l.level >= LevelTrace {
+				l.LogEvent(fmt.Sprintf("   🚪 Person #%d no longer works for %s", e.PersonID, e.Industry))
+			}
+		case events.PersonRetired:
+			if l.level >= LevelTrace {
+				l.LogEvent(fmt.Sprintf("   👵 Person #%d retired to %s", e.PersonID, e.To))
+			}
+		case events.PersonBankrupt:
+			if l.level >= LevelTrace {
+				l.LogEvent(fmt.Sprintf("   💸 Person #%d went broke", e.PersonID))
+			}
+		}
+	})
+}
+
+// SamplePurchases is how many purchases per tick the logger prints
+const SamplePurchases = 5
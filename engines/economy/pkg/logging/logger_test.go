@@ -0,0 +1,169 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"westex/engines/economy/pkg/events"
+)
+
+func TestSampling_Keep(t *testing.T) {
+	sampling := Sampling{First: 2, Every: 3}
+
+	kept := make([]int, 0)
+	for n := 0; n < 10; n++ {
+		if sampling.keep(n) {
+			kept = append(kept, n)
+		}
+	}
+
+	expected := []int{0, 1, 4, 7}
+	if len(kept) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, kept)
+	}
+	for i := range expected {
+		if kept[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, kept)
+			break
+		}
+	}
+}
+
+func TestLogger_TallyCountsEverything(t *testing.T) {
+	logger := NewLogger(true)
+	logger.SetSampling(Sampling{First: 1})
+	bus := events.NewBus()
+	logger.Subscribe(bus)
+
+	for i := 0; i < 4; i++ {
+		bus.Publish(events.PurchaseMade{Tick: 1, PersonID: i})
+	}
+	bus.Publish(events.PurchasesFailed{Tick: 1, Failures: make([]events.FailedPurchase, 3)})
+
+	tally := logger.Tally()
+	if tally.Purchases != 4 || tally.Shown != 1 || tally.Failures != 3 {
+		t.Errorf("Expected 4 purchases, 1 shown, 3 failures, got %+v", tally)
+	}
+
+	// Quiet loggers still count, but show nothing
+	logger.SetLevel(LevelQuiet)
+	bus.Publish(events.PurchaseMade{Tick: 1})
+	if tally = logger.Tally(); tally.Purchases != 5 || tally.Shown != 1 {
+		t.Errorf("Expected 5 purchases and still 1 shown, got %+v", tally)
+	}
+
+	// A new tick starts a new tally
+	bus.Publish(events.PurchaseMade{Tick: 2})
+	if tally = logger.Tally(); tally.Tick != 2 || tally.Purchases != 1 {
+		t.Errorf("Expected 1 purchase at tick 2, got %+v", tally)
+	}
+}
+
+func TestNewLogger_Levels(t *testing.T) {
+	if level := NewLogger(true).Level(); level != LevelVerbose {
+		t.Errorf("Expected an enabled logger to be verbose, got %d", level)
+	}
+	if logger := NewLogger(false); logger.Level() != LevelQuiet || logger.Enabled() {
+		t.Errorf("Expected a disabled logger to be quiet, got %d", logger.Level())
+	}
+
+	for _, flags := range []struct {
+		quiet, verbose, trace bool
+		level                 Level
+	}{
+		{false, false, false, LevelSummary},
+		{true, false, false, LevelQuiet},
+		{false, true, false, LevelVerbose},
+		{false, false, true, LevelTrace},
+		{true, true, false, LevelVerbose},
+		{true, true, true, LevelTrace},
+	} {
+		if level := FlagLevel(flags.quiet, flags.verbose, flags.trace); level != flags.level {
+			t.Errorf("Expected -q=%v -v=%v -vv=%v to give level %d, got %d",
+				flags.quiet, flags.verbose, flags.trace, flags.level, level)
+		}
+	}
+}
+
+func TestLogger_LevelsPrint(t *testing.T) {
+	for _, level := range []struct {
+		level Level
+		shown []string // Of the lines logged below
+	}{
+		{LevelQuiet, nil},
+		{LevelSummary, []string{"Summary", "Warning"}},
+		{LevelVerbose, []string{"Summary", "Warning", "Event", "Bread"}},
+		{LevelTrace, []string{"Summary", "Warning", "Event", "Bread", "Bakery"}},
+	} {
+		var out strings.Builder
+		logger := NewLogger(false)
+		logger.SetOutput(&out)
+		logger.SetLevel(level.level)
+		bus := events.NewBus()
+		logger.Subscribe(bus)
+
+		logger.LogTickSummary(1, "Summary")
+		logger.LogWarning("Warning")
+		logger.LogEvent("Event")
+		bus.Publish(events.PurchaseMade{Tick: 1, Product: "Bread"}) // The first purchase is sampled
+		bus.Publish(events.PersonHired{Tick: 1, Industry: "Bakery"})
+
+		for _, line := range []string{"Summary", "Warning", "Event", "Bread", "Bakery"} {
+			printed := strings.Contains(out.String(), line)
+			if printed != slices.Contains(level.shown, line) {
+				t.Errorf("Expected level %d to print %s: %v, got %v", level.level, line, !printed, printed)
+			}
+		}
+	}
+}
+
+func TestRotatingFile_PerTick(t *testing.T) {
+	dir := t.TempDir()
+	out, err := NewRotatingFile(dir, RotatePerTick, 0)
+	if err != nil {
+		t.Fatalf("Expected a writer, got error: %v", err)
+	}
+	defer out.Close()
+
+	logger := NewLogger(true)
+	logger.SetOutput(out)
+	for tick := 1; tick <= 2; tick++ {
+		logger.LogTick(tick)
+		logger.LogEvent("hello")
+	}
+
+	for _, name := range []string{"tick-000001.log", "tick-000002.log"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Expected %s, got error: %v", name, err)
+		}
+		if !strings.Contains(string(data), "hello") {
+			t.Errorf("Expected %s to hold the tick's log, got %q", name, data)
+		}
+	}
+}
+
+func TestRotatingFile_BySize(t *testing.T) {
+	dir := t.TempDir()
+	out, err := NewRotatingFile(dir, RotateBySize, 10)
+	if err != nil {
+		t.Fatalf("Expected a writer, got error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		fmt.Fprint(out, "12345678\n")
+	}
+	out.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "log-*.log"))
+	if len(files) != 3 {
+		t.Errorf("Expected 3 files of one line each, got %d", len(files))
+	}
+
+	if _, err := NewRotatingFile(dir, RotateBySize, 0); err == nil {
+		t.Error("Expected an error for size rotation without a size, got nil")
+	}
+}
@@ -0,0 +1,73 @@
+package market
+
+import "westex/engines/economy/pkg/entities"
+
+// BarterTrade records a person swapping labor hours for a product
+type BarterTrade struct {
+	PersonName   string
+	IndustryName string
+	ProductName  string
+	ProblemName  string
+	Units        float32
+	Hours        float32
+}
+
+// BarterResult summarizes the barter pass for one tick
+type BarterResult struct {
+	Trades        []BarterTrade
+	UnitsBartered float32
+	HoursBartered float32
+}
+
+// ProcessBarter runs at the end of the product market: unmet buyers with
+// (almost) no money but spare labor hours swap hoursPerUnit hours of future
+// work for one unit of product. The hours come off the person's next labor
+// hours, and the industry uses them as unpaid labor in its next production
+// run. A person pledges at most one day's labor hours until those are worked.
+//
+// People hold no goods of their own in this model, so barter is always
+// labor-for-goods with an industry rather than person-to-person.
+func ProcessBarter(region *entities.Region, unmet []UnmetNeed, hoursPerUnit, moneyThreshold float32) *BarterResult {
+	result := &BarterResult{
+		Trades: make([]BarterTrade, 0),
+	}
+	if hoursPerUnit <= 0 {
+		return result
+	}
+
+	sellers := make(sellerIndex)
+	for _, need := range unmet {
+		person := need.Person
+		if person.Money >= moneyThreshold {
+			continue
+		}
+		if person.LaborHours-person.PledgedHours < hoursPerUnit {
+			continue
+		}
+
+		for _, industry := range sellers.forProblem(region, need.Problem) {
+			product := industry.ProductFor(need.Problem)
+			if product.Quantity < 1.0 {
+				continue
+			}
+
+			product.Consume(1.0)
+			industry.BarteredLaborHours += hoursPerUnit
+			person.PledgedHours += hoursPerUnit
+
+			result.Trades = append(result.Trades, BarterTrade{
+				PersonName:   person.Name,
+				IndustryName: industry.Name,
+				ProductName:  product.Name,
+				ProblemName:  need.Problem.Name,
+				Units:        1.0,
+				Hours:        hoursPerUnit,
+			})
+			result.UnitsBartered += 1.0
+			result.HoursBartered += hoursPerUnit
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,47 @@
+package market
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestProcessBarter_SwapsLaborForGoods(t *testing.T) {
+	region, food := newMarketRegion(0, 0)
+	general := entities.NewPopulationSegment("General", []*entities.Problem{food}, 2)
+	broke := entities.NewPerson("Broke", 0, 8.0)
+	broke.AddSegment(general)
+	rich := entities.NewPerson("Rich", 500.0, 8.0)
+	rich.AddSegment(general)
+	region.AddPerson(broke)
+	region.AddPerson(rich)
+
+	stock := entities.NewResource("Food", "kg")
+	stock.Quantity = 10
+	farm := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{stock})
+	region.AddIndustry(farm)
+
+	unmet := []UnmetNeed{
+		{Person: broke, Problem: food},
+		{Person: broke, Problem: food},
+		{Person: broke, Problem: food}, // Exceeds the 8 hours available
+		{Person: rich, Problem: food},  // Has money, doesn't barter
+	}
+
+	result := ProcessBarter(region, unmet, 4.0, 1.0)
+
+	if result.UnitsBartered != 2 || result.HoursBartered != 8 {
+		t.Errorf("Expected 2 units for 8 hours, got %.0f / %.0f", result.UnitsBartered, result.HoursBartered)
+	}
+	if stock.Quantity != 8 || farm.BarteredLaborHours != 8 {
+		t.Errorf("Expected stock 8 and 8 hours banked, got %.0f / %.0f", stock.Quantity, farm.BarteredLaborHours)
+	}
+	if broke.PledgedHours != 8 {
+		t.Errorf("Expected the 8 hours owed by the person, got %.0f", broke.PledgedHours)
+	}
+
+	// Until the pledge is worked off, the person has no hours left to barter
+	if again := ProcessBarter(region, unmet[:1], 4.0, 1.0); again.UnitsBartered != 0 {
+		t.Errorf("Expected no barter on hours already pledged, got %.0f units", again.UnitsBartered)
+	}
+}
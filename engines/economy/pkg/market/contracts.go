@@ -0,0 +1,201 @@
+package market
+
+import (
+	"fmt"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// ContractDelivery records one contract's settlement for a tick
+type ContractDelivery struct {
+	ContractID  int
+	SellerName  string
+	BuyerName   string
+	ProductName string
+	Ordered     float32
+	Delivered   float32
+	Paid        float32
+	Penalty     float32 // Compensation paid by the seller for undelivered units
+}
+
+// ContractResult summarizes contract settlement for one tick
+type ContractResult struct {
+	Deliveries []ContractDelivery
+	TotalPaid  float32
+	Penalties  float32
+	Breaches   []string // Human-readable breach notices
+	Terminated int
+}
+
+// ProcessContracts settles every contract in force: sellers deliver from
+// stock at the locked price, sellers that fall short compensate buyers, buyers
+// that cannot pay void their contract (or, for subscriptions, lapse this tick)
+// and contracts whose sellers breach too often are terminated.
+func ProcessContracts(region *entities.Region, tick int) *ContractResult {
+	result := &ContractResult{
+		Deliveries: make([]ContractDelivery, 0),
+		Breaches:   make([]string, 0),
+	}
+
+	// Subscription cover only lasts for the tick it was delivered in
+	for _, person := range region.People {
+		if len(person.CoveredProblems) > 0 {
+			person.CoveredProblems = nil
+		}
+	}
+
+	for _, contract := range region.Contracts {
+		if !contract.InForce(tick) {
+			continue
+		}
+
+		shortfall := false
+		if contract.BuyerIndustry != nil {
+			shortfall = settleForwardOrder(contract, result)
+		} else if contract.BuyerSegment != nil {
+			shortfall = settleSubscription(region, contract, result)
+		}
+
+		if shortfall {
+			contract.Breaches++
+			result.Breaches = append(result.Breaches, fmt.Sprintf("%s fell short on contract #%d for %s (breach %d/%d)",
+				contract.Seller.Name, contract.ID, contract.Product.Name, contract.Breaches, contract.MaxBreaches))
+			if contract.Breaches >= contract.MaxBreaches {
+				contract.Active = false
+			}
+		}
+
+		if !contract.Active {
+			result.Terminated++
+		}
+	}
+
+	return result
+}
+
+// settleForwardOrder delivers an input to the buying industry. Returns true if
+// the seller could not deliver in full.
+func settleForwardOrder(contract *entities.Contract, result *ContractResult) bool {
+	buyer := contract.BuyerIndustry
+	delivered := minFloat(contract.UnitsPerTick, contract.Product.Quantity)
+	cost := delivered * contract.Price
+
+	if buyer.Money < cost {
+		contract.Active = false
+		result.Breaches = append(result.Breaches, fmt.Sprintf("%s cannot pay for contract #%d, contract voided",
+			buyer.Name, contract.ID))
+		return false
+	}
+
+	// Move goods from the seller's inventory into the buyer's input stock
+	if input := findInputByName(buyer, contract.Product.Name); input != nil {
+		entities.Transfer(contract.Product, input, delivered)
+	} else {
+		contract.Product.Consume(delivered)
+	}
+
+	buyer.Money -= cost
+	contract.Seller.Money += cost
+
+	delivery := ContractDelivery{
+		ContractID:  contract.ID,
+		SellerName:  contract.Seller.Name,
+		BuyerName:   buyer.Name,
+		ProductName: contract.Product.Name,
+		Ordered:     contract.UnitsPerTick,
+		Delivered:   delivered,
+		Paid:        cost,
+	}
+	delivery.Penalty = payPenalty(contract.Seller, contract, contract.UnitsPerTick-delivered, func(amount float32) {
+		buyer.Money += amount
+	})
+
+	result.Deliveries = append(result.Deliveries, delivery)
+	result.TotalPaid += cost
+	result.Penalties += delivery.Penalty
+
+	return delivered < contract.UnitsPerTick
+}
+
+// settleSubscription serves every member of the subscribed segment. Returns
+// true if any paying member went without.
+func settleSubscription(region *entities.Region, contract *entities.Contract, result *ContractResult) bool {
+	shortfall := false
+
+	for _, person := range region.People {
+		if !person.HasSegment(contract.BuyerSegment) {
+			continue
+		}
+
+		// Members who cannot pay simply lapse this tick
+		if person.Money < contract.UnitsPerTick*contract.Price {
+			continue
+		}
+
+		delivered := minFloat(contract.UnitsPerTick, contract.Product.Quantity)
+		cost := delivered * contract.Price
+		contract.Product.Consume(delivered)
+		person.Money -= cost
+		contract.Seller.Money += cost
+
+		if delivered > 0 {
+			if person.CoveredProblems == nil {
+				person.CoveredProblems = make(map[int]bool)
+			}
+			for _, problem := range contract.Seller.OwnedProblems {
+				if contract.Product.SolvesProblem(problem) {
+					person.CoveredProblems[problem.ID] = true
+				}
+			}
+		}
+
+		delivery := ContractDelivery{
+			ContractID:  contract.ID,
+			SellerName:  contract.Seller.Name,
+			BuyerName:   person.Name,
+			ProductName: contract.Product.Name,
+			Ordered:     contract.UnitsPerTick,
+			Delivered:   delivered,
+			Paid:        cost,
+		}
+		delivery.Penalty = payPenalty(contract.Seller, contract, contract.UnitsPerTick-delivered, func(amount float32) {
+			person.Money += amount
+		})
+
+		result.Deliveries = append(result.Deliveries, delivery)
+		result.TotalPaid += cost
+		result.Penalties += delivery.Penalty
+
+		if delivered < contract.UnitsPerTick {
+			shortfall = true
+		}
+	}
+
+	return shortfall
+}
+
+// payPenalty has the seller compensate the buyer for undelivered units, as far
+// as the seller's money allows
+func payPenalty(seller *entities.Industry, contract *entities.Contract, undelivered float32, credit func(float32)) float32 {
+	if undelivered <= 0 || contract.PenaltyRate <= 0 {
+		return 0
+	}
+
+	penalty := minFloat(undelivered*contract.Price*contract.PenaltyRate, seller.Money)
+	if penalty <= 0 {
+		return 0
+	}
+	seller.Money -= penalty
+	credit(penalty)
+	return penalty
+}
+
+// findInputByName returns an industry's input resource with the given name
+func findInputByName(industry *entities.Industry, name string) *entities.Resource {
+	for _, input := range industry.InputResources {
+		if input.Name == name {
+			return input
+		}
+	}
+	return nil
+}
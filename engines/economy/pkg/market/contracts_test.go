@@ -0,0 +1,110 @@
+package market
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestProcessContracts_ForwardOrderDeliversAtLockedPrice(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+
+	flour := entities.NewResource("Flour", "kg")
+	flour.Quantity = 100
+	mill := entities.CreateIndustry("Mill").
+		SetupIndustry(nil, nil, []*entities.Resource{flour})
+	region.AddIndustry(mill)
+
+	bakeryFlour := entities.NewResource("Flour", "kg")
+	bakery := entities.CreateIndustry("Bakery").
+		SetupIndustry(nil, []*entities.Resource{bakeryFlour}, nil).
+		SetInitialCapital(1000.0)
+	region.AddIndustry(bakery)
+
+	region.AddContract(entities.NewForwardOrder(mill, flour, bakery, 30, 2.0))
+
+	result := ProcessContracts(region, 1)
+
+	if bakeryFlour.Quantity != 30 || flour.Quantity != 70 {
+		t.Errorf("Expected 30 flour moved, bakery %.0f mill %.0f", bakeryFlour.Quantity, flour.Quantity)
+	}
+	if bakery.Money != 940.0 || mill.Money != 60.0 {
+		t.Errorf("Expected $60 paid, bakery %.2f mill %.2f", bakery.Money, mill.Money)
+	}
+	if result.TotalPaid != 60.0 {
+		t.Errorf("Expected total paid 60, got %.2f", result.TotalPaid)
+	}
+}
+
+func TestProcessContracts_SellerShortfallPaysPenaltyAndTerminates(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+
+	flour := entities.NewResource("Flour", "kg")
+	mill := entities.CreateIndustry("Mill").
+		SetupIndustry(nil, nil, []*entities.Resource{flour}).
+		SetInitialCapital(1000.0)
+	region.AddIndustry(mill)
+
+	bakery := entities.CreateIndustry("Bakery").SetInitialCapital(1000.0)
+	region.AddIndustry(bakery)
+
+	contract := entities.NewForwardOrder(mill, flour, bakery, 10, 5.0)
+	contract.PenaltyRate = 0.5
+	contract.MaxBreaches = 2
+	region.AddContract(contract)
+
+	result := ProcessContracts(region, 1)
+	if result.Penalties != 25.0 || bakery.Money != 1025.0 {
+		t.Errorf("Expected $25 penalty to bakery, got %.2f (bakery %.2f)", result.Penalties, bakery.Money)
+	}
+	if !contract.Active {
+		t.Error("Expected contract to survive the first breach")
+	}
+
+	ProcessContracts(region, 2)
+	if contract.Active {
+		t.Error("Expected contract to be terminated after the second breach")
+	}
+}
+
+func TestProcessContracts_SubscriptionCoversNeed(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	health := entities.NewProblem("Healthcare", "Medical care", 0.5)
+	health.UpdateDemand(1.0)
+	region.AddProblem(health)
+
+	visits := entities.NewResource("Medical", "visits")
+	visits.Quantity = 10
+	clinic := entities.CreateIndustry("Clinic").
+		SetupIndustry([]*entities.Problem{health}, nil, []*entities.Resource{visits})
+	region.AddIndustry(clinic)
+
+	plan := entities.NewPopulationSegment("Insured", []*entities.Problem{health}, 2)
+	for i := 0; i < 2; i++ {
+		person := entities.NewPerson("Member", 100.0, 0)
+		person.AddSegment(plan)
+		region.AddPerson(person)
+	}
+	region.AddContract(entities.NewSubscription(clinic, visits, plan, 1, 15.0))
+
+	ProcessContracts(region, 1)
+
+	if clinic.Money != 30.0 || visits.Quantity != 8 {
+		t.Errorf("Expected two paid visits, clinic %.2f stock %.0f", clinic.Money, visits.Quantity)
+	}
+
+	// Covered members don't shop for the same need again this tick
+	market := ProcessProductMarket(region, 50.0)
+	if len(market.Purchases) != 0 {
+		t.Errorf("Expected covered members not to buy, got %d purchases", len(market.Purchases))
+	}
+}
+
+func TestContract_InForce(t *testing.T) {
+	contract := entities.NewForwardOrder(nil, nil, nil, 1, 1).SetTerm(3, 5)
+
+	for tick, expected := range map[int]bool{2: false, 3: true, 5: true, 6: false} {
+		if contract.InForce(tick) != expected {
+			t.Errorf("Tick %d: expected in force %v", tick, expected)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package market
+
+import "westex/engines/economy/pkg/entities"
+
+// DemandChange records how a problem's demand moved during the demand phase
+type DemandChange struct {
+	ProblemName string
+	OldDemand   float32
+	NewDemand   float32
+	Unmet       int
+	Needy       int
+}
+
+// RecalculateDemand moves each problem's demand towards a target derived from
+// last tick's market: unmet needs push demand up (scarcity), needy people who
+// cannot afford the price pull it down (affluence), and the need spreading to
+// more people than when demand was first recalculated pushes it up in
+// proportion (population).
+// rate is the fraction of the gap closed per tick (0 keeps demand frozen).
+func RecalculateDemand(
+	region *entities.Region,
+	result *MarketResult,
+	pricePerUnit float32,
+	rate float32,
+) []DemandChange {
+	changes := make([]DemandChange, 0)
+	if rate <= 0 || result == nil {
+		return changes
+	}
+
+	for _, problem := range region.Problems {
+		stats, exists := result.NeedStats[problem.ID]
+		if !exists || stats.Needy == 0 {
+			continue
+		}
+
+		// Scarcity: share of the needy population that went without
+		unmetShare := float32(stats.Unmet()) / float32(stats.Needy)
+
+		// Affluence: average money of the needy relative to the price
+		affordability := float32(1.0)
+		if pricePerUnit > 0 {
+			affordability = clamp(stats.MoneyOfNeedy/float32(stats.Needy)/pricePerUnit, 0, 1)
+		}
+
+		// Population: needy people now relative to the first recalculation
+		if problem.BaseNeedy == 0 {
+			problem.BaseNeedy = stats.Needy
+		}
+		population := float32(stats.Needy) / float32(problem.BaseNeedy)
+
+		target := clamp(problem.BaseDemand*(1+unmetShare)*affordability*population, 0, 1)
+		oldDemand := problem.Demand
+		problem.Demand = clamp(oldDemand+rate*(target-oldDemand), 0, 1)
+
+		changes = append(changes, DemandChange{
+			ProblemName: problem.Name,
+			OldDemand:   oldDemand,
+			NewDemand:   problem.Demand,
+			Unmet:       stats.Unmet(),
+			Needy:       stats.Needy,
+		})
+	}
+
+	return changes
+}
+
+func clamp(value, low, high float32) float32 {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
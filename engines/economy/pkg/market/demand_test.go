@@ -0,0 +1,168 @@
+package market
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestRecalculateDemand_UnmetNeedsRaiseDemand(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	food.UpdateDemand(0.5)
+	region.AddProblem(food)
+
+	result := &MarketResult{
+		NeedStats: map[int]*NeedStats{
+			food.ID: {ProblemID: food.ID, Needy: 10, Seeking: 5, Satisfied: 0, MoneyOfNeedy: 1000},
+		},
+	}
+
+	changes := RecalculateDemand(region, result, 50.0, 1.0)
+
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 demand change, got %d", len(changes))
+	}
+
+	// Target: 0.5 * (1 + 5/10) * 1.0 = 0.75
+	if food.Demand < 0.74 || food.Demand > 0.76 {
+		t.Errorf("Expected demand 0.75, got %.2f", food.Demand)
+	}
+
+	if food.BaseDemand != 0.5 {
+		t.Errorf("Expected base demand to stay 0.5, got %.2f", food.BaseDemand)
+	}
+}
+
+func TestRecalculateDemand_PovertyLowersDemand(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	food.UpdateDemand(0.8)
+	region.AddProblem(food)
+
+	// Needy people hold on average half the price
+	result := &MarketResult{
+		NeedStats: map[int]*NeedStats{
+			food.ID: {ProblemID: food.ID, Needy: 10, Seeking: 8, Satisfied: 8, MoneyOfNeedy: 250},
+		},
+	}
+
+	RecalculateDemand(region, result, 50.0, 0.5)
+
+	// Target: 0.8 * 1.0 * 0.5 = 0.4, halfway from 0.8 is 0.6
+	if food.Demand < 0.59 || food.Demand > 0.61 {
+		t.Errorf("Expected demand 0.6, got %.2f", food.Demand)
+	}
+}
+
+func TestRecalculateDemand_ZeroRateKeepsDemand(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	food.UpdateDemand(0.5)
+	region.AddProblem(food)
+
+	result := &MarketResult{
+		NeedStats: map[int]*NeedStats{
+			food.ID: {ProblemID: food.ID, Needy: 10, Seeking: 5},
+		},
+	}
+
+	changes := RecalculateDemand(region, result, 50.0, 0)
+
+	if len(changes) != 0 || food.Demand != 0.5 {
+		t.Errorf("Expected demand to stay 0.5, got %.2f", food.Demand)
+	}
+}
+
+func TestProcessProductMarket_DemandLimitsBuyers(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	food.UpdateDemand(0.5)
+	region.AddProblem(food)
+
+	product := entities.NewResource("Food", "kg")
+	product.Quantity = 100
+	industry := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{product})
+	region.AddIndustry(industry)
+
+	segment := entities.NewPopulationSegment("General", []*entities.Problem{food}, 10)
+	for i := 0; i < 10; i++ {
+		person := entities.NewPerson("Person", 100.0, 0)
+		person.AddSegment(segment)
+		region.AddPerson(person)
+	}
+
+	result := ProcessProductMarket(region, 10.0)
+
+	if len(result.Purchases) != 5 {
+		t.Errorf("Expected 5 purchases at demand 0.5, got %d", len(result.Purchases))
+	}
+
+	stats := result.NeedStats[food.ID]
+	if stats.Needy != 10 || stats.Seeking != 5 || stats.Satisfied != 5 {
+		t.Errorf("Unexpected need stats: %+v", *stats)
+	}
+}
+
+func TestProcessProductMarket_BrokeShoppersDontUseUpDemand(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	food.UpdateDemand(0.5)
+	region.AddProblem(food)
+
+	product := entities.NewResource("Food", "kg")
+	product.Quantity = 100
+	industry := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{product})
+	region.AddIndustry(industry)
+
+	// The first five in the queue are broke, the five behind them can pay
+	segment := entities.NewPopulationSegment("General", []*entities.Problem{food}, 10)
+	for i := 0; i < 10; i++ {
+		money := float32(0)
+		if i >= 5 {
+			money = 100
+		}
+		person := entities.NewPerson("Person", money, 0)
+		person.AddSegment(segment)
+		region.AddPerson(person)
+	}
+
+	result := ProcessProductMarket(region, 10.0)
+
+	if len(result.Purchases) != 5 {
+		t.Errorf("Expected the 5 who can pay to buy, got %d purchases", len(result.Purchases))
+	}
+
+	stats := result.NeedStats[food.ID]
+	if stats.Seeking != 5 || stats.Unaffordable != 5 || stats.Satisfied != 5 || stats.Reasons[ReasonBuyerBroke] != 5 {
+		t.Errorf("Unexpected need stats: %+v", *stats)
+	}
+	// Going without for lack of money isn't scarcity
+	if stats.Unmet() != 0 || len(result.Unmet) != 5 {
+		t.Errorf("Expected 5 broke shoppers unmet for money only, got %d unmet and %d listed", stats.Unmet(), len(result.Unmet))
+	}
+}
+
+func TestRecalculateDemand_PopulationGrowthRaisesDemand(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	food.UpdateDemand(0.4)
+	region.AddProblem(food)
+
+	stats := &NeedStats{ProblemID: food.ID, Needy: 10, Seeking: 4, Satisfied: 4, MoneyOfNeedy: 1000}
+	result := &MarketResult{NeedStats: map[int]*NeedStats{food.ID: stats}}
+
+	// The first recalculation sets the population the need is measured against
+	RecalculateDemand(region, result, 50.0, 1.0)
+	if food.BaseNeedy != 10 || food.Demand < 0.39 || food.Demand > 0.41 {
+		t.Fatalf("Expected base needy 10 and demand 0.4, got %d and %.2f", food.BaseNeedy, food.Demand)
+	}
+
+	// Half as many needy again: target 0.4 * 1.5 = 0.6
+	stats.Needy, stats.MoneyOfNeedy = 15, 1500
+	RecalculateDemand(region, result, 50.0, 1.0)
+	if food.Demand < 0.59 || food.Demand > 0.61 {
+		t.Errorf("Expected demand 0.6, got %.2f", food.Demand)
+	}
+}
@@ -0,0 +1,113 @@
+package market
+
+import "westex/engines/economy/pkg/entities"
+
+// historyKey is one person's need
+type historyKey struct {
+	person  int
+	problem int
+}
+
+// RecordHistory folds a tick's purchases into every person's history,
+// starting one of the given length for people who have none. A need counts
+// as met by the satisfaction of the units bought for it over the units
+// wanted; needs covered by a subscription count as fully met.
+func RecordHistory(region *entities.Region, result *MarketResult, tick, length int) {
+	people := make(map[int]*entities.Person, len(region.People))
+	for _, person := range region.People {
+		if person.History == nil {
+			person.History = entities.NewHistory(length)
+		}
+		people[person.ID] = person
+	}
+
+	met := make(map[historyKey]float32)
+	sellers := make(map[historyKey]int)
+	for _, purchase := range result.Purchases {
+		if purchase.IsComplement {
+			continue
+		}
+		key := historyKey{purchase.PersonID, purchase.ProblemID}
+		met[key] += purchase.Satisfaction
+		sellers[key] = purchase.IndustryID
+		if person, exists := people[purchase.PersonID]; exists {
+			person.History.Remember(entities.PurchaseRecord{
+				Tick:         tick,
+				ProblemID:    purchase.ProblemID,
+				IndustryID:   purchase.IndustryID,
+				Units:        purchase.Quantity,
+				Satisfaction: purchase.Satisfaction,
+			})
+		}
+	}
+
+	for _, person := range region.People {
+		for _, need := range person.GetAllProblems() {
+			if person.CoveredProblems[need.ID] {
+				person.History.RecordNeed(need.ID, 1, 0)
+				continue
+			}
+			key := historyKey{person.ID, need.ID}
+			person.History.RecordNeed(need.ID, met[key]/float32(person.UnitsWanted(need)), sellers[key])
+		}
+	}
+}
+
+// NeedsMet returns, per person ID, the share of their needs the tick's
+// purchases met: each need counts the satisfaction bought for it over the
+// units wanted, capped at 1, and needs covered by a subscription count as
+// met
+func NeedsMet(region *entities.Region, result *MarketResult) map[int]float32 {
+	return NeedsMetFor(region.People, result)
+}
+
+// NeedsMetFor is NeedsMet for just the given people
+func NeedsMetFor(people []*entities.Person, result *MarketResult) map[int]float32 {
+	met := make(map[historyKey]float32)
+	for _, purchase := range result.Purchases {
+		if !purchase.IsComplement {
+			met[historyKey{purchase.PersonID, purchase.ProblemID}] += purchase.Satisfaction
+		}
+	}
+
+	shares := make(map[int]float32, len(people))
+	for _, person := range people {
+		needs := person.GetAllProblems()
+		if len(needs) == 0 {
+			shares[person.ID] = 1
+			continue
+		}
+		total := float32(0)
+		for _, need := range needs {
+			if person.CoveredProblems[need.ID] {
+				total++
+				continue
+			}
+			total += min(met[historyKey{person.ID, need.ID}]/float32(need.UnitsWanted()), 1)
+		}
+		shares[person.ID] = total / float32(len(needs))
+	}
+	return shares
+}
+
+// loyalFirst moves the seller the person last bought the need from to the
+// front of the sellers, when loyalty is on and the seller is among them
+func (m *ProductMarket) loyalFirst(person *entities.Person, need *entities.Problem, sellers []*entities.Industry) []*entities.Industry {
+	if !m.Loyalty || person.History == nil || len(sellers) < 2 {
+		return sellers
+	}
+	last := person.History.LastSeller(need.ID)
+	for i, industry := range sellers {
+		if industry.ID != last {
+			continue
+		}
+		if i == 0 {
+			return sellers
+		}
+		m.loyal = append(m.loyal[:0], industry)
+		m.loyal = append(m.loyal, sellers[:i]...)
+		m.loyal = append(m.loyal, sellers[i+1:]...)
+		return m.loyal
+	}
+	return sellers
+}
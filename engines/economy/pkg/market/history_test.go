@@ -0,0 +1,56 @@
+package market
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestRecordHistory_RemembersPurchasesAndSatisfaction(t *testing.T) {
+	region, food := newShortRegion(2, 1)
+
+	result := ProcessProductMarket(region, 10.0)
+	RecordHistory(region, result, 1, 2)
+
+	fed, hungry := region.People[0], region.People[1]
+	if len(fed.History.Recent) != 1 || fed.History.Recent[0].Tick != 1 {
+		t.Errorf("Expected one purchase remembered at tick 1, got %+v", fed.History.Recent)
+	}
+	if fed.History.Satisfaction(food.ID) != 1 || hungry.History.Satisfaction(food.ID) != 0 {
+		t.Errorf("Expected satisfaction 1 and 0, got %.2f and %.2f",
+			fed.History.Satisfaction(food.ID), hungry.History.Satisfaction(food.ID))
+	}
+
+	// A tick without food halves the memory with a length of 2
+	RecordHistory(region, &MarketResult{}, 2, 2)
+	if fed.Satisfaction() != 0.5 {
+		t.Errorf("Expected satisfaction 0.5 after a hungry tick, got %.2f", fed.Satisfaction())
+	}
+}
+
+func TestProductMarket_LoyalShopperReturnsToLastSeller(t *testing.T) {
+	region, food := newMarketRegion(1, 100.0)
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 10
+	riceFarm := entities.CreateIndustry("RiceFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice})
+	region.AddIndustry(riceFarm)
+
+	wheat := entities.NewResource("Wheat", "kg")
+	wheat.Quantity = 10
+	wheat.Efficiency = 2.0
+	region.AddIndustry(entities.CreateIndustry("WheatFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{wheat}))
+
+	person := region.People[0]
+	person.History = entities.NewHistory(3)
+	person.History.RecordNeed(food.ID, 1, riceFarm.ID)
+
+	m := NewProductMarket()
+	m.Loyalty = true
+	result := m.Process(region, 10.0)
+
+	if len(result.Purchases) != 1 || result.Purchases[0].ProductName != "Rice" {
+		t.Errorf("Expected the loyal shopper to buy Rice again, got %+v", result.Purchases)
+	}
+}
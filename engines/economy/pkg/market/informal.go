@@ -0,0 +1,62 @@
+package market
+
+import (
+	"math/rand/v2"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// InformalResult summarizes black-market activity for one tick. These sales
+// are off the books: they are neither taxed nor reported to the government.
+type InformalResult struct {
+	Purchases     []Purchase
+	TotalSpent    float32
+	Participation float32 // Chance an unmet buyer turned to the informal sector
+}
+
+// InformalParticipation is the chance that a buyer left unserved by the formal
+// market turns to the informal sector; higher taxes push more activity
+// underground
+func InformalParticipation(base, taxSensitivity, taxRate float32) float32 {
+	return clamp(base+taxSensitivity*taxRate, 0, 1)
+}
+
+// ProcessInformalMarket serves part of the unmet demand off the books: each
+// unmet buyer may, with the given participation chance, buy from any industry
+// that still has stock, paying the formal price plus a premium
+func ProcessInformalMarket(
+	region *entities.Region,
+	unmet []UnmetNeed,
+	pricePerUnit float32,
+	premium float32,
+	participation float32,
+	rng *rand.Rand,
+) *InformalResult {
+	result := &InformalResult{
+		Purchases:     make([]Purchase, 0),
+		Participation: participation,
+	}
+	price := pricePerUnit * (1 + premium)
+
+	sellers := make(sellerIndex)
+	for _, need := range unmet {
+		if rng.Float32() >= participation || need.Person.Money < price {
+			continue
+		}
+
+		for _, industry := range sellers.forProblem(region, need.Problem) {
+			product := industry.ProductFor(need.Problem)
+			if product.Quantity < 1.0 {
+				continue
+			}
+
+			purchase := transfer(need.Person, industry, product, need.Problem, 1.0, price)
+			purchase.Satisfaction = product.Efficiency
+			result.Purchases = append(result.Purchases, purchase)
+			result.TotalSpent += purchase.TotalCost
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,65 @@
+package market
+
+import (
+	"math/rand/v2"
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestInformalParticipation_RisesWithTaxes(t *testing.T) {
+	low := InformalParticipation(0.1, 2.0, 0.05)
+	high := InformalParticipation(0.1, 2.0, 0.30)
+
+	if low >= high {
+		t.Errorf("Expected higher taxes to raise participation, got %.2f vs %.2f", low, high)
+	}
+	if InformalParticipation(0.5, 5.0, 0.5) != 1.0 {
+		t.Error("Expected participation to be capped at 1.0")
+	}
+}
+
+func TestProcessInformalMarket_ServesUnmetAtPremium(t *testing.T) {
+	region, food := newMarketRegion(2, 100.0)
+
+	stock := entities.NewResource("Food", "kg")
+	stock.Quantity = 1
+	farm := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{stock})
+	region.AddIndustry(farm)
+
+	unmet := []UnmetNeed{
+		{Person: region.People[0], Problem: food},
+		{Person: region.People[1], Problem: food},
+	}
+	rng := rand.New(rand.NewPCG(1, 1))
+
+	result := ProcessInformalMarket(region, unmet, 50.0, 0.5, 1.0, rng)
+
+	if len(result.Purchases) != 1 {
+		t.Fatalf("Expected the single unit to be sold informally, got %d", len(result.Purchases))
+	}
+	if result.Purchases[0].UnitPrice != 75.0 || farm.Money != 75.0 {
+		t.Errorf("Expected a $75 premium sale, got %.2f (farm %.2f)", result.Purchases[0].UnitPrice, farm.Money)
+	}
+
+	// Nobody goes informal at zero participation
+	stock.Quantity = 5
+	if result := ProcessInformalMarket(region, unmet, 50.0, 0.2, 0, rng); len(result.Purchases) != 0 {
+		t.Errorf("Expected no informal purchases, got %d", len(result.Purchases))
+	}
+}
+
+func TestProcessProductMarket_RecordsUnmetNeeds(t *testing.T) {
+	region, food := newMarketRegion(3, 100.0)
+
+	stock := entities.NewResource("Food", "kg")
+	stock.Quantity = 1
+	region.AddIndustry(entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{stock}))
+
+	result := ProcessProductMarket(region, 10.0)
+
+	if len(result.Unmet) != 2 {
+		t.Errorf("Expected 2 unmet needs, got %d", len(result.Unmet))
+	}
+}
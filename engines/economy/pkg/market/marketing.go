@@ -0,0 +1,51 @@
+package market
+
+import "westex/engines/economy/pkg/entities"
+
+// AdvertisingResult summarizes one tick of industry marketing
+type AdvertisingResult struct {
+	TotalSpent float32
+	Spend      map[string]float32 // Per advertising industry
+}
+
+// Awareness is the chance a shopper considers an industry that spends the
+// given amount on advertising this tick. Without advertising it is the base
+// awareness; spend closes the gap to full awareness with diminishing
+// returns, halfSpend closing half of it.
+func Awareness(spend, base, halfSpend float32) float32 {
+	base = clamp(base, 0, 1)
+	if spend <= 0 || halfSpend <= 0 {
+		return base
+	}
+	return base + (1-base)*spend/(spend+halfSpend)
+}
+
+// ProcessAdvertising charges every industry its marketing spend, capped at
+// the money it has, and sets each industry's awareness for the tick. The
+// spend is paid out evenly to the people as media income, so it moves money
+// around the economy instead of destroying it.
+func ProcessAdvertising(region *entities.Region, base, halfSpend float32) *AdvertisingResult {
+	result := &AdvertisingResult{
+		Spend: make(map[string]float32),
+	}
+
+	for _, industry := range region.Industries {
+		spend := min(industry.MarketingSpend, max(industry.Money, 0))
+		industry.Awareness = Awareness(spend, base, halfSpend)
+		if spend <= 0 {
+			continue
+		}
+		industry.Money -= spend
+		result.Spend[industry.Name] = spend
+		result.TotalSpent += spend
+	}
+
+	if result.TotalSpent > 0 && len(region.People) > 0 {
+		share := result.TotalSpent / float32(len(region.People))
+		for _, person := range region.People {
+			person.Money += share
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,86 @@
+package market
+
+import (
+	"math/rand/v2"
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestAwareness_RisesWithSpend(t *testing.T) {
+	if got := Awareness(0, 0.2, 100); got != 0.2 {
+		t.Errorf("Expected base awareness 0.2 without spend, got %.2f", got)
+	}
+	if got := Awareness(100, 0.2, 100); got != 0.6 {
+		t.Errorf("Expected half spend to close half the gap (0.6), got %.2f", got)
+	}
+	if got := Awareness(1e9, 0.2, 100); got > 1 {
+		t.Errorf("Expected awareness to stay at most 1, got %.2f", got)
+	}
+}
+
+func TestProcessAdvertising_ChargesSpendAndPaysPeople(t *testing.T) {
+	region, food := newMarketRegion(2, 0)
+
+	farm := entities.CreateIndustry("Farm").SetupIndustry([]*entities.Problem{food}, nil, nil)
+	farm.Money = 300
+	farm.MarketingSpend = 100
+	region.AddIndustry(farm)
+	broke := entities.CreateIndustry("Broke").SetupIndustry([]*entities.Problem{food}, nil, nil)
+	broke.Money = 20
+	broke.MarketingSpend = 100
+	region.AddIndustry(broke)
+
+	result := ProcessAdvertising(region, 0.2, 100)
+
+	if result.TotalSpent != 120 {
+		t.Errorf("Expected $120 spent (capped at Broke's money), got $%.2f", result.TotalSpent)
+	}
+	if farm.Money != 200 || broke.Money != 0 {
+		t.Errorf("Expected $200 and $0 left, got $%.2f and $%.2f", farm.Money, broke.Money)
+	}
+	for _, person := range region.People {
+		if person.Money != 60 {
+			t.Errorf("Expected each person to earn $60, got $%.2f", person.Money)
+		}
+	}
+	if farm.Awareness <= broke.Awareness {
+		t.Errorf("Expected the bigger spender to be better known, got %.2f vs %.2f", farm.Awareness, broke.Awareness)
+	}
+}
+
+func TestProductMarket_AwarenessSteersShoppers(t *testing.T) {
+	region, food := newMarketRegion(200, 100.0)
+
+	// Rice is the better product, but hardly anyone has heard of it
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 1000
+	rice.Efficiency = 1.0
+	riceFarm := entities.CreateIndustry("RiceFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice})
+	riceFarm.Awareness = 0.1
+	region.AddIndustry(riceFarm)
+
+	wheat := entities.NewResource("Wheat", "kg")
+	wheat.Quantity = 1000
+	wheat.Efficiency = 0.8
+	wheatFarm := entities.CreateIndustry("WheatFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{wheat})
+	wheatFarm.Awareness = 1.0
+	region.AddIndustry(wheatFarm)
+
+	m := NewProductMarket()
+	m.Rand = rand.New(rand.NewPCG(1, 1))
+	m.Awareness = true
+	result := m.Process(region, 10.0)
+
+	sold := map[string]int{}
+	for _, purchase := range result.Purchases {
+		sold[purchase.ProductName]++
+	}
+	if sold["Wheat"] <= sold["Rice"] {
+		t.Errorf("Expected the advertised Wheat to outsell Rice, got %v", sold)
+	}
+	if sold["Rice"] == 0 {
+		t.Errorf("Expected some shoppers to know of Rice, got %v", sold)
+	}
+}
@@ -0,0 +1,194 @@
+package market
+
+import (
+	"sort"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Market modes selectable via simulation.market_mode
+const (
+	ModePosted    = "posted"    // Fixed posted price, first-come-first-served (default)
+	ModeOrderBook = "orderbook" // Asks and bids matched per problem each tick
+)
+
+// Ask is an industry's offer to sell a product
+type Ask struct {
+	Industry *entities.Industry
+	Product  *entities.Resource
+	Price    float32
+}
+
+// Bid is a person's offer to buy one unit for a need
+type Bid struct {
+	Person   *entities.Person
+	Problem  *entities.Problem
+	MaxPrice float32
+}
+
+// ProcessOrderBookMarket clears the product market with an order book.
+// Industries ask cost-plus prices for everything in stock (falling back to
+// referencePrice before they have cost history), people bid what their budget
+// allows for each need, and bids are matched to the cheapest asks with trades
+// at the midpoint between ask and bid. Price controls clamp both the asks
+// and the trade prices.
+func ProcessOrderBookMarket(
+	region *entities.Region,
+	referencePrice float32,
+	profitMargin float32,
+	controls PriceControls,
+) *MarketResult {
+	result := &MarketResult{
+		Purchases: make([]Purchase, 0),
+		NeedStats: collectNeedStats(region),
+	}
+
+	asks := collectAsks(region, referencePrice, profitMargin, controls)
+	bids := collectBids(region, result.NeedStats)
+
+	satisfiedPeople := make(map[int]bool)
+
+	for _, problem := range region.Problems {
+		problemAsks := asks[problem.ID]
+		problemBids := bids[problem.ID]
+		if len(problemBids) == 0 {
+			continue
+		}
+
+		sort.SliceStable(problemAsks, func(a, b int) bool {
+			return problemAsks[a].Price < problemAsks[b].Price
+		})
+		sort.SliceStable(problemBids, func(a, b int) bool {
+			return problemBids[a].MaxPrice > problemBids[b].MaxPrice
+		})
+
+		for i, bid := range problemBids {
+			ask := cheapestAvailableAsk(problemAsks)
+			if ask == nil || ask.Price > bid.MaxPrice {
+				// Remaining bids are lower still
+				reason := ReasonPriceTooHigh
+				if ask == nil {
+					reason = unstockedReason(region, problem)
+				}
+				for _, unmatched := range problemBids[i:] {
+					result.recordUnmet(unmatched.Person, problem, reason)
+				}
+				break
+			}
+
+			price := controls.Apply(ask.Product.Name, (ask.Price+bid.MaxPrice)/2)
+			if bid.Person.Money < price {
+				result.recordUnmet(bid.Person, problem, ReasonPriceTooHigh)
+				continue
+			}
+
+			purchase := transfer(bid.Person, ask.Industry, ask.Product, problem, 1.0, price)
+			purchase.Satisfaction = ask.Product.Efficiency
+			result.Purchases = append(result.Purchases, purchase)
+			result.TotalSpent += purchase.TotalCost
+			result.TotalRevenue += purchase.TotalCost
+			result.NeedStats[problem.ID].Satisfied++
+			satisfiedPeople[bid.Person.ID] = true
+		}
+	}
+
+	result.PeopleSatisfied = len(satisfiedPeople)
+	result.PeopleUnsatisfied = len(region.People) - result.PeopleSatisfied
+
+	return result
+}
+
+// unstockedReason tells apart a need nobody produces for from one whose
+// producers have sold out
+func unstockedReason(region *entities.Region, problem *entities.Problem) string {
+	if len(findIndustriesForProblem(region, problem)) == 0 {
+		return ReasonNoProducer
+	}
+	return ReasonOutOfStock
+}
+
+// AskPrice returns the cost-plus price an industry asks for its products
+func AskPrice(industry *entities.Industry, referencePrice, profitMargin float32) float32 {
+	cost := industry.GetLastProductionCost()
+	if cost <= 0 {
+		return referencePrice
+	}
+	return cost * (1 + profitMargin)
+}
+
+// collectAsks groups the industries' offers by the problems they solve
+func collectAsks(region *entities.Region, referencePrice, profitMargin float32, controls PriceControls) map[int][]*Ask {
+	asks := make(map[int][]*Ask)
+	for _, industry := range region.Industries {
+		if industry.SellsWholesale {
+			continue
+		}
+		price := AskPrice(industry, referencePrice, profitMargin)
+		for _, product := range industry.OutputProducts {
+			if product.Quantity < 1.0 {
+				continue
+			}
+			ask := &Ask{Industry: industry, Product: product, Price: controls.Apply(product.Name, price)}
+			for _, problem := range industry.OwnedProblems {
+				if product.SolvesProblem(problem) {
+					asks[problem.ID] = append(asks[problem.ID], ask)
+				}
+			}
+		}
+	}
+	return asks
+}
+
+// collectBids derives bids from each person's needs and budget. Basic needs
+// get twice the budget weight of other needs.
+func collectBids(region *entities.Region, needStats map[int]*NeedStats) map[int][]*Bid {
+	bids := make(map[int][]*Bid)
+	for _, person := range region.People {
+		if person.Money <= 0 {
+			continue
+		}
+
+		needs := person.GetAllProblems()
+		totalWeight := float32(0)
+		for _, need := range needs {
+			totalWeight += needWeight(need)
+		}
+
+		for _, need := range needs {
+			if person.CoveredProblems[need.ID] {
+				continue
+			}
+
+			stats := needStats[need.ID]
+			if stats.Seeking >= buyerQuota(need, stats.Needy) {
+				continue
+			}
+			stats.Seeking++
+
+			bids[need.ID] = append(bids[need.ID], &Bid{
+				Person:   person,
+				Problem:  need,
+				MaxPrice: person.Money * needWeight(need) / totalWeight,
+			})
+		}
+	}
+	return bids
+}
+
+func needWeight(problem *entities.Problem) float32 {
+	if problem.IsBasicNeed {
+		return 2.0
+	}
+	return 1.0
+}
+
+// cheapestAvailableAsk returns the first ask (asks are sorted by price) that
+// still has stock
+func cheapestAvailableAsk(asks []*Ask) *Ask {
+	for _, ask := range asks {
+		if ask.Product.Quantity >= 1.0 {
+			return ask
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package market
+
+import (
+	"sort"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// PriceControl caps or props up the price of one product (0 = no limit)
+type PriceControl struct {
+	Ceiling float32 // Highest price sellers may charge
+	Floor   float32 // Lowest price sellers may charge
+}
+
+// PriceControls are the controlled products' limits, by product name
+type PriceControls map[string]PriceControl
+
+// Apply returns the price a product may be sold at under the controls
+func (c PriceControls) Apply(product string, price float32) float32 {
+	control, exists := c[product]
+	if !exists {
+		return price
+	}
+	if control.Ceiling > 0 {
+		price = min(price, control.Ceiling)
+	}
+	if control.Floor > 0 {
+		price = max(price, control.Floor)
+	}
+	return price
+}
+
+// ControlEffect is how a controlled product fared in one tick's market
+type ControlEffect struct {
+	Product string
+	PriceControl
+	Sold   float32 // Units bought by people
+	Unsold float32 // Units left on the producers' shelves (the surplus)
+	Unmet  int     // Shoppers for the needs it serves who went without, short of stock or priced out
+	Margin float32 // Sales revenue less the producers' unit cost of what they sold
+}
+
+// ControlEffects reports, for each controlled product, what sold and what
+// didn't, and whether its producers sold above their unit cost. Products
+// are reported in name order.
+func ControlEffects(region *entities.Region, result *MarketResult, controls PriceControls) []ControlEffect {
+	effects := make([]ControlEffect, 0, len(controls))
+	for product, control := range controls {
+		effect := ControlEffect{Product: product, PriceControl: control}
+		needs := make(map[int]bool)
+		for _, industry := range region.Industries {
+			for _, output := range industry.OutputProducts {
+				if output.Name != product {
+					continue
+				}
+				effect.Unsold += output.Quantity
+				for _, problem := range industry.OwnedProblems {
+					if output.SolvesProblem(problem) {
+						needs[problem.ID] = true
+					}
+				}
+			}
+		}
+		for _, purchase := range result.Purchases {
+			if purchase.ProductName != product {
+				continue
+			}
+			effect.Sold += purchase.Quantity
+			effect.Margin += purchase.TotalCost + purchase.Subsidy
+			if industry := region.GetIndustry(purchase.IndustryName); industry != nil {
+				effect.Margin -= purchase.Quantity * industry.GetLastProductionCost()
+			}
+		}
+		for id := range needs {
+			if stats, exists := result.NeedStats[id]; exists {
+				effect.Unmet += stats.Unmet() + stats.Unaffordable
+			}
+		}
+		effects = append(effects, effect)
+	}
+	sort.Slice(effects, func(i, j int) bool { return effects[i].Product < effects[j].Product })
+	return effects
+}
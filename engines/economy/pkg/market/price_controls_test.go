@@ -0,0 +1,68 @@
+package market
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestPriceControls_Apply(t *testing.T) {
+	controls := PriceControls{
+		"Rice": {Ceiling: 30},
+		"Milk": {Floor: 60},
+	}
+	if got := controls.Apply("Rice", 50); got != 30 {
+		t.Errorf("Expected the ceiling to cap Rice at 30, got %.2f", got)
+	}
+	if got := controls.Apply("Milk", 50); got != 60 {
+		t.Errorf("Expected the floor to raise Milk to 60, got %.2f", got)
+	}
+	if got := controls.Apply("Bread", 50); got != 50 {
+		t.Errorf("Expected uncontrolled Bread to keep 50, got %.2f", got)
+	}
+}
+
+func TestProcessProductMarket_PriceFloorLeavesSurplus(t *testing.T) {
+	region, food := newMarketRegion(4, 60.0)
+	region.People[0].Money = 100.0
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 10
+	farm := entities.CreateIndustry("RiceFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice})
+	region.AddIndustry(farm)
+
+	m := NewProductMarket()
+	m.Controls = PriceControls{"Rice": {Floor: 80}}
+	result := m.Process(region, 50.0)
+
+	if len(result.Purchases) != 1 || result.Purchases[0].UnitPrice != 80 {
+		t.Fatalf("Expected one sale at the $80 floor, got %+v", result.Purchases)
+	}
+
+	effects := ControlEffects(region, result, m.Controls)
+	if len(effects) != 1 || effects[0].Sold != 1 || effects[0].Unsold != 9 || effects[0].Unmet != 3 {
+		t.Errorf("Expected 1 sold, 9 unsold and 3 unserved, got %+v", effects)
+	}
+}
+
+func TestProcessOrderBookMarket_CeilingForcesSalesBelowCost(t *testing.T) {
+	region, food := newMarketRegion(1, 100.0)
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 10
+	farm := entities.CreateIndustry("RiceFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice})
+	farm.RecordProduction(entities.ProductionRecord{UnitsProduced: 10, CostPerUnit: 40})
+	region.AddIndustry(farm)
+
+	controls := PriceControls{"Rice": {Ceiling: 30}}
+	result := ProcessOrderBookMarket(region, 50.0, 0.0, controls)
+
+	if len(result.Purchases) != 1 || result.Purchases[0].UnitPrice != 30 {
+		t.Fatalf("Expected one sale at the $30 ceiling, got %+v", result.Purchases)
+	}
+	effects := ControlEffects(region, result, controls)
+	if effects[0].Margin != -10 {
+		t.Errorf("Expected a $10 loss on the unit sold below cost, got %.2f", effects[0].Margin)
+	}
+}
@@ -1,6 +1,10 @@
 package market
 
 import (
+	"maps"
+	"math/rand/v2"
+	"sort"
+
 	"westex/engines/economy/pkg/entities"
 )
 
@@ -17,6 +21,88 @@ type Purchase struct {
 	Quantity      float32
 	UnitPrice     float32
 	TotalCost     float32
+	Satisfaction  float32 // Need units met, weighted by product efficiency (0 for complements)
+	IsComplement  bool    // Bought only because the main product requires it
+	TransportCost float32 // Shipping paid on top of TotalCost to bring goods across zones
+	Subsidy       float32 // Owed to a public seller by the treasury on top of TotalCost
+}
+
+// Reasons a shopper went without, most fundamental first
+const (
+	ReasonNoProducer   = "no_producer"    // Nobody in the region makes anything for the need
+	ReasonOutOfStock   = "out_of_stock"   // Producers exist, but the product or a complement was sold out
+	ReasonBuyerBroke   = "buyer_broke"    // The buyer had no money left
+	ReasonPriceTooHigh = "price_too_high" // In stock, but costlier than the buyer could pay
+)
+
+// UnmetReasons lists every reason in reporting order
+var UnmetReasons = []string{ReasonNoProducer, ReasonOutOfStock, ReasonBuyerBroke, ReasonPriceTooHigh}
+
+// NeedStats tracks how a single problem was served during one tick
+type NeedStats struct {
+	ProblemID    int
+	ProblemName  string
+	Needy        int            // People who have this problem
+	Seeking      int            // People who could pay and tried to buy for it this tick
+	Unaffordable int            // People who couldn't pay the posted price, not counted as seeking
+	Satisfied    int            // People who managed to buy for it
+	MoneyOfNeedy float32        // Combined money of the needy people before buying
+	Reasons      map[string]int // Unmet seekers and unaffordable shoppers by reason
+	UnitsWanted  int            // Units the seekers and unaffordable shoppers wanted in total
+	UnitsBought  int            // Units they got
+	UnitsMet     float32        // Of the units wanted, those the bought units were worth
+}
+
+// Unmet returns how many people sought a product but went without. Shoppers
+// who couldn't pay for it aren't counted: money, not scarcity, kept them out.
+func (n *NeedStats) Unmet() int {
+	return n.Seeking - n.Satisfied
+}
+
+// Met returns the share of the units sought that the purchases met, and
+// false if nobody sought any
+func (n *NeedStats) Met() (float32, bool) {
+	if n.UnitsWanted == 0 {
+		return 0, false
+	}
+	return n.UnitsMet / float32(n.UnitsWanted), true
+}
+
+// Satisfaction returns the share of a need met this tick. A composite need
+// rolls up its parts: the weighted average of the parts anyone shopped for.
+// It returns false when nobody shopped for the need or any of its parts.
+func Satisfaction(problem *entities.Problem, stats map[int]*NeedStats) (float32, bool) {
+	if !problem.IsComposite() {
+		if s, exists := stats[problem.ID]; exists {
+			return s.Met()
+		}
+		return 0, false
+	}
+
+	total, weights := float32(0), float32(0)
+	for _, part := range problem.Parts {
+		if met, ok := Satisfaction(part.Problem, stats); ok {
+			total += met * part.Weight
+			weights += part.Weight
+		}
+	}
+	if weights == 0 {
+		return 0, false
+	}
+	return total / weights, true
+}
+
+// UnmetNeed is a person who went shopping for a need and came back empty-handed
+type UnmetNeed struct {
+	Person  *entities.Person
+	Problem *entities.Problem
+	Reason  string
+}
+
+// recordUnmet notes a failed purchase and why it failed
+func (r *MarketResult) recordUnmet(person *entities.Person, problem *entities.Problem, reason string) {
+	r.Unmet = append(r.Unmet, UnmetNeed{Person: person, Problem: problem, Reason: reason})
+	r.NeedStats[problem.ID].Reasons[reason]++
 }
 
 // MarketResult summarizes market activity for one tick
@@ -26,6 +112,134 @@ type MarketResult struct {
 	TotalRevenue      float32
 	PeopleSatisfied   int
 	PeopleUnsatisfied int
+	NeedStats         map[int]*NeedStats // Keyed by problem ID
+	Unmet             []UnmetNeed
+}
+
+// AveragePrice returns the average unit price paid this tick, or fallback if
+// nothing was sold
+func (r *MarketResult) AveragePrice(fallback float32) float32 {
+	units := float32(0)
+	for _, purchase := range r.Purchases {
+		units += purchase.Quantity
+	}
+	if units == 0 {
+		return fallback
+	}
+	return r.TotalSpent / units
+}
+
+// Combine adds the result of another round of the same tick's market, such
+// as a later week's, copying what it keeps so the other can be reused
+func (r *MarketResult) Combine(other *MarketResult) {
+	r.Purchases = append(r.Purchases, other.Purchases...)
+	r.Unmet = append(r.Unmet, other.Unmet...)
+	r.TotalSpent += other.TotalSpent
+	r.TotalRevenue += other.TotalRevenue
+	r.PeopleSatisfied += other.PeopleSatisfied
+	r.PeopleUnsatisfied += other.PeopleUnsatisfied
+
+	if r.NeedStats == nil {
+		r.NeedStats = make(map[int]*NeedStats, len(other.NeedStats))
+	}
+	for id, stats := range other.NeedStats {
+		s, exists := r.NeedStats[id]
+		if !exists {
+			s = &NeedStats{ProblemID: stats.ProblemID, ProblemName: stats.ProblemName, Reasons: make(map[string]int)}
+			r.NeedStats[id] = s
+		}
+		s.Needy += stats.Needy
+		s.Seeking += stats.Seeking
+		s.Unaffordable += stats.Unaffordable
+		s.Satisfied += stats.Satisfied
+		s.MoneyOfNeedy += stats.MoneyOfNeedy
+		s.UnitsWanted += stats.UnitsWanted
+		s.UnitsBought += stats.UnitsBought
+		s.UnitsMet += stats.UnitsMet
+		for reason, count := range stats.Reasons {
+			s.Reasons[reason] += count
+		}
+	}
+}
+
+// Clone copies the result for a copy of the region, pointing its unmet needs
+// at the copy's people and problems, which keep their IDs
+func (r *MarketResult) Clone(people map[int]*entities.Person, problems map[int]*entities.Problem) *MarketResult {
+	clone := *r
+	clone.Purchases = append([]Purchase(nil), r.Purchases...)
+	clone.NeedStats = make(map[int]*NeedStats, len(r.NeedStats))
+	for id, stats := range r.NeedStats {
+		copied := *stats
+		copied.Reasons = maps.Clone(stats.Reasons)
+		clone.NeedStats[id] = &copied
+	}
+	clone.Unmet = make([]UnmetNeed, len(r.Unmet))
+	for i, need := range r.Unmet {
+		clone.Unmet[i] = UnmetNeed{Person: people[need.Person.ID], Problem: problems[need.Problem.ID], Reason: need.Reason}
+	}
+	return &clone
+}
+
+// ProductMarket runs the posted-price market and keeps its buffers between
+// ticks, so a long run doesn't rebuild the purchase list, need statistics and
+// seller lists every tick. The result returned by Process is only valid until
+// the next call.
+type ProductMarket struct {
+	// Rand drives the market's random choices; without it shoppers know
+	// every seller and rationing lotteries keep population order
+	Rand *rand.Rand
+	// Awareness makes a shopper choosing between several sellers consider
+	// each one only with its Awareness chance
+	Awareness bool
+	// Rationing allocates basic needs in short supply (RationNone keeps
+	// first come, first served)
+	Rationing string
+	// Queue is the order shoppers take their turn in (QueueFixed keeps
+	// population order)
+	Queue string
+	// Loyalty sends shoppers with a history back to the seller they last
+	// bought a need from, before trying the others
+	Loyalty bool
+	// Controls cap or prop up the prices of controlled products
+	Controls PriceControls
+	// WeeksPerTick is how many weeks of need a shopper buys for, for products
+	// that meet a share of a week's need (0 = 1)
+	WeeksPerTick int
+	// Weeks splits the tick's shoppers into that many weekly turns, each
+	// person shopping once a tick, and Week is the turn shopping now (Weeks
+	// of 0 or 1 lets everyone shop at once)
+	Week  int
+	Weeks int
+
+	result     MarketResult
+	shoppers   []*entities.Person   // People whose turn it is
+	due        []*entities.Person   // Buffer for the shoppers of a weekly turn
+	satisfied  map[int]bool         // People who bought something this tick
+	sellers    sellerIndex          // Industries per problem, rebuilt each tick
+	candidates []*entities.Industry // Per-person seller order when shipping matters
+	aware      []*entities.Industry // Sellers the shopper has heard of
+	list       []shoppingItem       // The current shopper's needs
+	short      map[int]bool         // Basic needs rationed this tick
+	caps       map[int]int          // Units per shopper for rationed needs
+	ranked     []queued             // Shoppers ranked for rationing
+	order      []*entities.Person   // Shopping order when rationing
+	line       []*entities.Person   // Shopping order before rationing
+	loyal      []*entities.Industry // Seller order with the shopper's usual seller first
+	round      int                  // Ticks processed, for rotating the queue
+}
+
+// NewProductMarket creates a posted-price market with empty buffers
+func NewProductMarket() *ProductMarket {
+	return &ProductMarket{
+		result: MarketResult{
+			Purchases: make([]Purchase, 0),
+			NeedStats: make(map[int]*NeedStats),
+		},
+		satisfied: make(map[int]bool),
+		sellers:   make(sellerIndex),
+		short:     make(map[int]bool),
+		caps:      make(map[int]int),
+	}
 }
 
 // ProcessProductMarket handles all purchases in one tick
@@ -33,81 +247,453 @@ func ProcessProductMarket(
 	region *entities.Region,
 	pricePerUnit float32,
 ) *MarketResult {
-	result := &MarketResult{
-		Purchases: make([]Purchase, 0),
-	}
+	return NewProductMarket().Process(region, pricePerUnit)
+}
 
-	satisfiedPeople := make(map[int]bool) // Track people who bought something
+// Process handles all purchases in one tick, reusing the market's buffers.
+// Each shopper buys one unit per need per round, going round their needs
+// until every need has its units or can't get more, so a rich shopper buys
+// as deep as their needs and the shelves allow without spending everything
+// on the first need.
+func (m *ProductMarket) Process(region *entities.Region, pricePerUnit float32) *MarketResult {
+	result := m.reset(region)
+	people := m.ration(region)
 
-	// For each person
-	for _, person := range region.People {
+	// For each person, in rationing order when basic needs are short
+	for _, person := range people {
 		// Get their needs (from all segments)
 		needs := person.GetAllProblems()
 
-		// Try to satisfy each need
+		// List the needs they might shop for; seek decides on each in turn
+		list := m.list[:0]
 		for _, need := range needs {
-			// Find industries that solve this need
-			industry := findIndustryForProblem(region, need)
-			if industry == nil {
+			// Subscribers already had this need served under contract
+			if person.CoveredProblems[need.ID] {
 				continue
 			}
 
-			// Try to buy product
-			purchase := attemptPurchase(person, industry, need, pricePerUnit)
-			if purchase != nil {
-				result.Purchases = append(result.Purchases, *purchase)
-				result.TotalSpent += purchase.TotalCost
-				result.TotalRevenue += purchase.TotalCost
-				satisfiedPeople[person.ID] = true
+			wanted := person.UnitsWanted(need)
+			left := float32(wanted)
+			if limit, rationed := m.caps[need.ID]; rationed {
+				left = min(left, float32(limit))
 			}
+			list = append(list, shoppingItem{need: need, stats: result.NeedStats[need.ID], wanted: float32(wanted), left: left})
 		}
+
+		// Buy a unit for each open need per round
+		for open := len(list); open > 0; {
+			open = 0
+			for i := range list {
+				item := &list[i]
+				if item.left < unitsMetEnough || item.stuck || item.skipped {
+					continue
+				}
+				if !item.seeking && !m.seek(region, person, item, pricePerUnit) {
+					item.skipped = true
+					continue
+				}
+				worth := m.buyUnit(region, person, item, pricePerUnit)
+				if worth == 0 {
+					item.stuck = true
+					continue
+				}
+				item.left -= worth
+				item.met += worth
+				item.bought++
+				if item.left >= unitsMetEnough {
+					open++
+				}
+			}
+		}
+
+		for _, item := range list {
+			if item.unaffordable {
+				reason := ReasonPriceTooHigh
+				if person.Money <= 0 {
+					reason = ReasonBuyerBroke
+				}
+				result.recordUnmet(person, item.need, reason)
+				continue
+			}
+			if !item.seeking {
+				continue
+			}
+			if item.bought == 0 {
+				result.recordUnmet(person, item.need, item.reason)
+				continue
+			}
+			item.stats.Satisfied++
+			item.stats.UnitsBought += item.bought
+			item.stats.UnitsMet += min(item.met, item.wanted)
+			m.satisfied[person.ID] = true
+		}
+		m.list = list
 	}
 
 	// Count satisfied vs unsatisfied people
-	result.PeopleSatisfied = len(satisfiedPeople)
-	result.PeopleUnsatisfied = len(region.People) - result.PeopleSatisfied
+	result.PeopleSatisfied = len(m.satisfied)
+	result.PeopleUnsatisfied = len(people) - result.PeopleSatisfied
+
+	return result
+}
+
+// unitsMetEnough is the share of a unit a need may still lack and count as
+// met, so rounding doesn't send a shopper back for one more unit
+const unitsMetEnough = 1e-4
+
+// shoppingItem is one need on a shopper's list for the tick. Wanted, left
+// and met are in the need's units, which a unit of product may only meet a
+// share of.
+type shoppingItem struct {
+	need   *entities.Problem
+	stats  *NeedStats
+	wanted float32 // Units wanted
+	left   float32 // Units still wanted
+	met    float32 // Units met so far
+	bought int     // Units of product bought so far
+	stuck  bool    // The last attempt failed, so no more this tick
+	// Seeking is set once the shopper turns to the need and counts as
+	// seeking it; skipped once demand for it was already used up or the
+	// shopper couldn't pay for it, which also sets unaffordable
+	seeking      bool
+	skipped      bool
+	unaffordable bool
+	reason       string // Why the last attempt failed
+}
+
+// buyUnit buys one unit for a shopping list item, trying substitutes from
+// the most to the least efficient, and returns how many of the need's units
+// it was worth (0 when nothing was bought). If all fail, price beats stock as
+// the reason since money was the last thing checked.
+func (m *ProductMarket) buyUnit(region *entities.Region, person *entities.Person, item *shoppingItem, pricePerUnit float32) float32 {
+	result := &m.result
+	item.reason = ReasonNoProducer
+	sellers := m.considered(m.sellers.forProblem(region, item.need))
+	for _, industry := range m.loyalFirst(person, item.need, m.nearestFirst(region, person, item.need, sellers)) {
+		purchases, failure := attemptPurchase(region, person, industry, item.need, pricePerUnit, m.Controls, result.Purchases)
+		if failure != "" {
+			if item.reason != ReasonPriceTooHigh && item.reason != ReasonBuyerBroke {
+				item.reason = failure
+			}
+			continue
+		}
+
+		// The main purchase satisfies as many of the need's units as it is worth
+		worth := industry.ProductFor(item.need).NeedUnits(item.need, m.WeeksPerTick)
+		purchases[len(result.Purchases)].Satisfaction *= worth
+		for _, purchase := range purchases[len(result.Purchases):] {
+			result.TotalSpent += purchase.TotalCost + purchase.TransportCost
+			result.TotalRevenue += purchase.TotalCost
+		}
+		result.Purchases = purchases
+		return worth
+	}
+	return 0
+}
+
+// seek decides whether a shopper turning to a need goes looking for it.
+// Demand sets the share of the needy who do, and only shoppers who can pay
+// the posted price at that moment count towards it, so the broke early in the
+// queue don't crowd out those behind. Those who can't pay go without, counted
+// as unaffordable rather than seeking, so demand doesn't read their lack of
+// money as scarcity on top of the affordability it already weighs.
+func (m *ProductMarket) seek(region *entities.Region, person *entities.Person, item *shoppingItem, pricePerUnit float32) bool {
+	stats := item.stats
+	if !m.canPay(region, person, item.need, pricePerUnit) {
+		stats.Unaffordable++
+		stats.UnitsWanted += int(item.wanted)
+		item.unaffordable = true
+		return false
+	}
+	if stats.Seeking >= buyerQuota(item.need, stats.Needy) {
+		return false
+	}
+	stats.Seeking++
+	stats.UnitsWanted += int(item.wanted)
+	item.seeking = true
+	return true
+}
+
+// canPay reports whether a person has the money for a unit of a need at the
+// cheapest posted price of a seller with stock, before shipping and
+// complements. With nothing in stock, money isn't what stops them, so they
+// can pay as far as it goes.
+func (m *ProductMarket) canPay(region *entities.Region, person *entities.Person, need *entities.Problem, pricePerUnit float32) bool {
+	inStock := false
+	for _, industry := range m.sellers.forProblem(region, need) {
+		product := industry.ProductFor(need)
+		if product.Quantity < 1 {
+			continue
+		}
+		inStock = true
+		price := m.Controls.Apply(product.Name, pricePerUnit)
+		if industry.Public {
+			price *= 1 - industry.Subsidy
+		}
+		if price <= 0 || person.Money > 0 && person.Money >= price {
+			return true
+		}
+	}
+	return !inStock
+}
 
+// considered returns the sellers a shopper weighs for one unit: each rival
+// with its awareness chance when awareness is on. A shopper who has heard of
+// none of them asks around and considers them all.
+func (m *ProductMarket) considered(sellers []*entities.Industry) []*entities.Industry {
+	if !m.Awareness || m.Rand == nil || len(sellers) < 2 {
+		return sellers
+	}
+	aware := m.aware[:0]
+	for _, industry := range sellers {
+		if industry.Awareness >= 1 || m.Rand.Float32() < industry.Awareness {
+			aware = append(aware, industry)
+		}
+	}
+	m.aware = aware
+	if len(aware) == 0 {
+		return sellers
+	}
+	return aware
+}
+
+// reset empties the buffers for a new tick, keeping their capacity
+func (m *ProductMarket) reset(region *entities.Region) *MarketResult {
+	result := &m.result
+	result.Purchases = result.Purchases[:0]
+	result.Unmet = result.Unmet[:0]
+	result.TotalSpent = 0
+	result.TotalRevenue = 0
+	result.PeopleSatisfied = 0
+	result.PeopleUnsatisfied = 0
+	m.shoppers = m.turn(region)
+	countNeeds(m.shoppers, result.NeedStats)
+
+	clear(m.satisfied)
+	clear(m.sellers)
 	return result
 }
 
-// findIndustryForProblem finds the first industry that solves a given problem
-func findIndustryForProblem(region *entities.Region, problem *entities.Problem) *entities.Industry {
+// turn returns the people shopping this call: everyone, or with weekly
+// turns those whose ID falls in the current week
+func (m *ProductMarket) turn(region *entities.Region) []*entities.Person {
+	if m.Weeks <= 1 {
+		return region.People
+	}
+	due := m.due[:0]
+	for _, person := range region.People {
+		if person.ID%m.Weeks == m.Week {
+			due = append(due, person)
+		}
+	}
+	m.due = due
+	return due
+}
+
+// nearestFirst orders a problem's sellers for one person. Without transport
+// costs the order is the same for everyone and the shared list is returned;
+// otherwise it is copied into the candidates buffer and sorted there.
+func (m *ProductMarket) nearestFirst(region *entities.Region, person *entities.Person, problem *entities.Problem, industries []*entities.Industry) []*entities.Industry {
+	if region.Transport == nil {
+		return industries
+	}
+	m.candidates = append(m.candidates[:0], industries...)
+	return nearestFirst(region, person, problem, m.candidates)
+}
+
+// collectNeedStats counts, per problem, the people who have it and their money
+func collectNeedStats(region *entities.Region) map[int]*NeedStats {
+	stats := make(map[int]*NeedStats)
+	countNeeds(region.People, stats)
+	return stats
+}
+
+// countNeeds fills stats with the needy counts among people, reusing the
+// entries left from earlier ticks and dropping problems nobody has any more
+func countNeeds(people []*entities.Person, stats map[int]*NeedStats) {
+	for _, s := range stats {
+		clear(s.Reasons)
+		*s = NeedStats{ProblemID: s.ProblemID, ProblemName: s.ProblemName, Reasons: s.Reasons}
+	}
+	for _, person := range people {
+		for _, need := range person.GetAllProblems() {
+			s, exists := stats[need.ID]
+			if !exists {
+				s = &NeedStats{ProblemID: need.ID, ProblemName: need.Name, Reasons: make(map[string]int)}
+				stats[need.ID] = s
+			}
+			s.Needy++
+			s.MoneyOfNeedy += person.Money
+		}
+	}
+	for id, s := range stats {
+		if s.Needy == 0 {
+			delete(stats, id)
+		}
+	}
+}
+
+// buyerQuota returns how many of the needy people look for a product this tick
+func buyerQuota(problem *entities.Problem, needy int) int {
+	return int(problem.Demand*float32(needy) + 0.5)
+}
+
+// sellerIndex caches findIndustriesForProblem per problem ID for the span of
+// one market phase, during which the set of selling industries doesn't change
+type sellerIndex map[int][]*entities.Industry
+
+// forProblem returns the industries solving a problem, most efficient first
+func (idx sellerIndex) forProblem(region *entities.Region, problem *entities.Problem) []*entities.Industry {
+	industries, exists := idx[problem.ID]
+	if !exists {
+		industries = findIndustriesForProblem(region, problem)
+		idx[problem.ID] = industries
+	}
+	return industries
+}
+
+// findIndustriesForProblem returns the industries solving a problem, ordered so
+// that substitutes with the most efficient product come first
+func findIndustriesForProblem(region *entities.Region, problem *entities.Problem) []*entities.Industry {
+	industries := make([]*entities.Industry, 0)
 	for _, industry := range region.Industries {
-		for _, p := range industry.OwnedProblems {
-			if p.ID == problem.ID {
-				return industry
+		if industry.SellsWholesale || industry.ProductFor(problem) == nil {
+			continue
+		}
+		industries = append(industries, industry)
+	}
+
+	sort.SliceStable(industries, func(a, b int) bool {
+		return industries[a].ProductFor(problem).Efficiency > industries[b].ProductFor(problem).Efficiency
+	})
+	return industries
+}
+
+// nearestFirst reorders equally efficient substitutes so the ones cheapest to
+// ship to the person come first
+func nearestFirst(region *entities.Region, person *entities.Person, problem *entities.Problem, industries []*entities.Industry) []*entities.Industry {
+	if region.Transport == nil {
+		return industries
+	}
+	sort.SliceStable(industries, func(a, b int) bool {
+		ea, eb := industries[a].ProductFor(problem).Efficiency, industries[b].ProductFor(problem).Efficiency
+		if ea != eb {
+			return ea > eb
+		}
+		return region.ShippingCost(industries[a].Zone, person.Zone) < region.ShippingCost(industries[b].Zone, person.Zone)
+	})
+	return industries
+}
+
+// findIndustrySelling finds the first industry selling a product (by name, so
+// retailers' stock counts) to people with enough in stock
+func findIndustrySelling(region *entities.Region, product *entities.Resource, quantity float32) (*entities.Industry, *entities.Resource) {
+	for _, industry := range region.Industries {
+		if industry.SellsWholesale {
+			continue
+		}
+		for _, output := range industry.OutputProducts {
+			if output.Name == product.Name && output.Quantity >= quantity {
+				return industry, output
 			}
 		}
 	}
-	return nil
+	return nil, nil
 }
 
-// attemptPurchase tries to make a purchase for a person
+// attemptPurchase tries to make a purchase for a person, buying any required
+// complements in the same transaction. The purchases are appended to dst;
+// if nothing was bought, failure gives the reason.
 func attemptPurchase(
+	region *entities.Region,
 	person *entities.Person,
 	industry *entities.Industry,
 	need *entities.Problem,
 	pricePerUnit float32,
-) *Purchase {
-	// Check if industry has products
-	if len(industry.OutputProducts) == 0 {
-		return nil
+	controls PriceControls,
+	dst []Purchase,
+) (purchases []Purchase, failure string) {
+	product := industry.ProductFor(need)
+	quantity := float32(1.0) // Buy 1 unit
+
+	// Check if product available
+	if product.Quantity < quantity {
+		return dst, ReasonOutOfStock
 	}
 
-	product := industry.OutputProducts[0] // Simplified: use first product
+	// Public industries charge what the treasury doesn't cover of the
+	// (controlled) price
+	listPrice := controls.Apply(product.Name, pricePerUnit)
+	price := listPrice
+	if industry.Public {
+		price = listPrice * (1 - industry.Subsidy)
+	}
 
-	// Check if product available
-	if product.Quantity < 1.0 {
-		return nil
+	// Every complement must be in stock somewhere
+	basket := (price + region.ShippingCost(industry.Zone, person.Zone)) * quantity
+	complementSellers := make([]*entities.Industry, 0, len(product.Complements))
+	complementStock := make([]*entities.Resource, 0, len(product.Complements))
+	for _, complement := range product.Complements {
+		seller, stock := findIndustrySelling(region, complement, quantity)
+		if seller == nil {
+			return dst, ReasonOutOfStock
+		}
+		complementSellers = append(complementSellers, seller)
+		complementStock = append(complementStock, stock)
+		basket += (controls.Apply(complement.Name, pricePerUnit) + region.ShippingCost(seller.Zone, person.Zone)) * quantity
 	}
 
-	// Check if person can afford
-	if person.Money < pricePerUnit {
-		return nil
+	// Check if person can afford the whole basket, shipping included
+	if basket > 0 && person.Money <= 0 {
+		return dst, ReasonBuyerBroke
+	}
+	if person.Money < basket {
+		return dst, ReasonPriceTooHigh
 	}
 
-	// Make purchase
-	quantity := float32(1.0) // Buy 1 unit
+	purchases = dst
+	main := transfer(person, industry, product, need, quantity, price)
+	main.Satisfaction = quantity * product.Efficiency
+	main.Subsidy = (listPrice - price) * quantity
+	ship(region, person, industry, &main)
+	purchases = append(purchases, main)
+
+	for i := range product.Complements {
+		extra := transfer(person, complementSellers[i], complementStock[i], need, quantity, controls.Apply(complementStock[i].Name, pricePerUnit))
+		extra.IsComplement = true
+		ship(region, person, complementSellers[i], &extra)
+		purchases = append(purchases, extra)
+	}
+
+	return purchases, ""
+}
+
+// ship charges the buyer for moving a purchase across zones. The fee goes to
+// the region's transport industry, or to the seller if there is none.
+func ship(region *entities.Region, person *entities.Person, seller *entities.Industry, purchase *Purchase) {
+	cost := region.ShippingCost(seller.Zone, person.Zone) * purchase.Quantity
+	if cost <= 0 {
+		return
+	}
+
+	carrier := region.Carrier()
+	if carrier == nil {
+		carrier = seller
+	}
+	person.Money -= cost
+	carrier.Money += cost
+	purchase.TransportCost = cost
+}
+
+// transfer moves money from person to industry and the product out of stock
+func transfer(
+	person *entities.Person,
+	industry *entities.Industry,
+	product *entities.Resource,
+	need *entities.Problem,
+	quantity float32,
+	pricePerUnit float32,
+) Purchase {
 	cost := pricePerUnit * quantity
 
 	// Transfer money
@@ -117,7 +703,7 @@ func attemptPurchase(
 	// Transfer product
 	product.Consume(quantity)
 
-	return &Purchase{
+	return Purchase{
 		PersonID:      person.ID,
 		PersonName:    person.Name,
 		IndustryID:    industry.ID,
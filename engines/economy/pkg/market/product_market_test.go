@@ -0,0 +1,522 @@
+package market
+
+import (
+	"math"
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+// newMarketRegion builds a region with one need and the given number of buyers
+func newMarketRegion(buyers int, money float32) (*entities.Region, *entities.Problem) {
+	region := entities.NewRegion("TestRegion")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	food.UpdateDemand(1.0)
+	region.AddProblem(food)
+
+	segment := entities.NewPopulationSegment("General", []*entities.Problem{food}, buyers)
+	for i := 0; i < buyers; i++ {
+		person := entities.NewPerson("Person", money, 0)
+		person.AddSegment(segment)
+		region.AddPerson(person)
+	}
+	return region, food
+}
+
+func TestProcessProductMarket_PrefersEfficientSubstitute(t *testing.T) {
+	region, food := newMarketRegion(1, 100.0)
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 10
+	rice.Efficiency = 0.5
+	region.AddIndustry(entities.CreateIndustry("RiceFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice}))
+
+	wheat := entities.NewResource("Wheat", "kg")
+	wheat.Quantity = 10
+	wheat.Efficiency = 0.8
+	region.AddIndustry(entities.CreateIndustry("WheatFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{wheat}))
+
+	result := ProcessProductMarket(region, 10.0)
+
+	if len(result.Purchases) != 1 {
+		t.Fatalf("Expected 1 purchase, got %d", len(result.Purchases))
+	}
+	if result.Purchases[0].ProductName != "Wheat" {
+		t.Errorf("Expected the more efficient Wheat, got %s", result.Purchases[0].ProductName)
+	}
+	if result.Purchases[0].Satisfaction != 0.8 {
+		t.Errorf("Expected satisfaction 0.8, got %.2f", result.Purchases[0].Satisfaction)
+	}
+}
+
+func TestProcessProductMarket_FallsBackToSubstituteWhenOutOfStock(t *testing.T) {
+	region, food := newMarketRegion(1, 100.0)
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 10
+	region.AddIndustry(entities.CreateIndustry("RiceFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice}))
+
+	wheat := entities.NewResource("Wheat", "kg")
+	wheat.Efficiency = 2.0 // Better, but empty
+	region.AddIndustry(entities.CreateIndustry("WheatFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{wheat}))
+
+	result := ProcessProductMarket(region, 10.0)
+
+	if len(result.Purchases) != 1 || result.Purchases[0].ProductName != "Rice" {
+		t.Errorf("Expected to fall back to Rice, got %+v", result.Purchases)
+	}
+}
+
+func TestProcessProductMarket_ComplementBoughtTogether(t *testing.T) {
+	region, food := newMarketRegion(1, 100.0)
+
+	fuel := entities.NewResource("Fuel", "liters")
+	fuel.Quantity = 5
+	fuelIndustry := entities.CreateIndustry("FuelCo").
+		SetupIndustry(nil, nil, []*entities.Resource{fuel})
+	region.AddIndustry(fuelIndustry)
+
+	bread := entities.NewResource("Bread", "loaves")
+	bread.Quantity = 5
+	bread.AddComplement(fuel)
+	region.AddIndustry(entities.CreateIndustry("Bakery").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{bread}))
+
+	result := ProcessProductMarket(region, 10.0)
+
+	if len(result.Purchases) != 2 {
+		t.Fatalf("Expected bread and fuel purchases, got %d", len(result.Purchases))
+	}
+	if !result.Purchases[1].IsComplement || result.Purchases[1].ProductName != "Fuel" {
+		t.Errorf("Expected second purchase to be the Fuel complement, got %+v", result.Purchases[1])
+	}
+	if fuel.Quantity != 4 || fuelIndustry.Money != 10.0 {
+		t.Errorf("Expected fuel sold by FuelCo, stock %.0f money %.2f", fuel.Quantity, fuelIndustry.Money)
+	}
+	if region.People[0].Money != 80.0 {
+		t.Errorf("Expected buyer to pay for both, has %.2f", region.People[0].Money)
+	}
+}
+
+func TestProcessProductMarket_MissingComplementBlocksPurchase(t *testing.T) {
+	region, food := newMarketRegion(1, 100.0)
+
+	fuel := entities.NewResource("Fuel", "liters") // None in stock
+	region.AddIndustry(entities.CreateIndustry("FuelCo").
+		SetupIndustry(nil, nil, []*entities.Resource{fuel}))
+
+	bread := entities.NewResource("Bread", "loaves")
+	bread.Quantity = 5
+	bread.AddComplement(fuel)
+	region.AddIndustry(entities.CreateIndustry("Bakery").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{bread}))
+
+	result := ProcessProductMarket(region, 10.0)
+
+	if len(result.Purchases) != 0 {
+		t.Errorf("Expected no purchases without fuel, got %d", len(result.Purchases))
+	}
+	if bread.Quantity != 5 {
+		t.Errorf("Expected bread stock untouched, got %.0f", bread.Quantity)
+	}
+}
+
+func TestProcessProductMarket_ShipsAcrossZones(t *testing.T) {
+	region, food := newMarketRegion(1, 100.0)
+	home := entities.NewZone("Home", 0, 0)
+	far := entities.NewZone("Far", 3, 4)
+	region.AddZone(home)
+	region.AddZone(far)
+	region.Transport = &entities.TransportNetwork{CostPerDistance: 2.0, CapacityScale: 100}
+	region.People[0].Zone = home
+
+	farGrain := entities.NewResource("Grain", "kg")
+	farGrain.Quantity = 10
+	farFarm := entities.CreateIndustry("FarFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{farGrain})
+	farFarm.Zone = far
+	region.AddIndustry(farFarm)
+
+	trucks := entities.CreateIndustry("Trucks")
+	trucks.IsTransport = true
+	region.AddIndustry(trucks)
+
+	// Distance 5 at $2 per unit distance, no transport capacity yet
+	result := ProcessProductMarket(region, 10.0)
+	if len(result.Purchases) != 1 || result.Purchases[0].TransportCost != 10.0 {
+		t.Fatalf("Expected one purchase with $10 shipping, got %+v", result.Purchases)
+	}
+	if region.People[0].Money != 80.0 || trucks.Money != 10.0 {
+		t.Errorf("Expected buyer $80 / carrier $10, got %.2f / %.2f", region.People[0].Money, trucks.Money)
+	}
+
+	// A local seller wins once it exists; transport output halves shipping
+	localGrain := entities.NewResource("Grain", "kg")
+	localGrain.Quantity = 10
+	localFarm := entities.CreateIndustry("LocalFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{localGrain})
+	localFarm.Zone = home
+	region.AddIndustry(localFarm)
+	trucks.RecordProduction(entities.ProductionRecord{UnitsProduced: 100})
+
+	if cost := region.ShippingCost(far, home); cost != 5.0 {
+		t.Errorf("Expected shipping to halve to $5, got %.2f", cost)
+	}
+	result = ProcessProductMarket(region, 10.0)
+	if len(result.Purchases) != 1 || result.Purchases[0].IndustryName != "LocalFarm" {
+		t.Errorf("Expected to buy from the local farm, got %+v", result.Purchases)
+	}
+}
+
+func TestProcessOrderBookMarket_MatchesHighestBidsToCheapestAsks(t *testing.T) {
+	region, food := newMarketRegion(0, 0)
+	general := entities.NewPopulationSegment("General", []*entities.Problem{food}, 3)
+	for _, money := range []float32{100.0, 30.0, 5.0} {
+		person := entities.NewPerson("Person", money, 0)
+		person.AddSegment(general)
+		region.AddPerson(person)
+	}
+
+	bread := entities.NewResource("Bread", "loaves")
+	bread.Quantity = 1
+	bakery := entities.CreateIndustry("Bakery").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{bread})
+	bakery.RecordProduction(entities.ProductionRecord{CostPerUnit: 10.0})
+	region.AddIndustry(bakery)
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 5
+	farm := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice})
+	farm.RecordProduction(entities.ProductionRecord{CostPerUnit: 20.0})
+	region.AddIndustry(farm)
+
+	result := ProcessOrderBookMarket(region, 50.0, 0.0, nil)
+
+	// Bids: 100, 30, 5. Asks: bakery 10 (1 unit), farm 20.
+	if len(result.Purchases) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(result.Purchases))
+	}
+	if result.Purchases[0].IndustryName != "Bakery" || result.Purchases[0].UnitPrice != 55.0 {
+		t.Errorf("Expected richest bidder to buy bakery bread at 55, got %+v", result.Purchases[0])
+	}
+	if result.Purchases[1].IndustryName != "Farm" || result.Purchases[1].UnitPrice != 25.0 {
+		t.Errorf("Expected second bidder to buy farm rice at 25, got %+v", result.Purchases[1])
+	}
+	if result.NeedStats[food.ID].Unmet() != 1 {
+		t.Errorf("Expected 1 unmet bidder, got %d", result.NeedStats[food.ID].Unmet())
+	}
+}
+
+func TestProductMarket_ReusedAcrossTicks(t *testing.T) {
+	region, food := newMarketRegion(3, 100.0)
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 2
+	region.AddIndustry(entities.CreateIndustry("RiceFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice}))
+
+	market := NewProductMarket()
+	first := market.Process(region, 10.0)
+	if len(first.Purchases) != 2 || len(first.Unmet) != 1 {
+		t.Fatalf("Expected 2 purchases and 1 unmet need, got %d and %d", len(first.Purchases), len(first.Unmet))
+	}
+
+	// Out of stock now: nothing from the first tick may carry over
+	second := market.Process(region, 10.0)
+	if len(second.Purchases) != 0 {
+		t.Errorf("Expected 0 purchases, got %d", len(second.Purchases))
+	}
+	if second.TotalSpent != 0 || second.PeopleSatisfied != 0 {
+		t.Errorf("Expected totals reset, got spent %.2f and %d satisfied", second.TotalSpent, second.PeopleSatisfied)
+	}
+	if len(second.Unmet) != 3 {
+		t.Errorf("Expected 3 unmet needs, got %d", len(second.Unmet))
+	}
+	stats := second.NeedStats[food.ID]
+	if stats.Needy != 3 || stats.Seeking != 3 || stats.Satisfied != 0 {
+		t.Errorf("Expected 3 needy, 3 seeking, 0 satisfied, got %+v", *stats)
+	}
+}
+
+func TestProcessProductMarket_RecordsUnmetReasons(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	fun := entities.NewProblem("Fun", "Need fun", 0.3)
+	health := entities.NewProblem("Health", "Need care", 0.8)
+	for _, problem := range []*entities.Problem{food, fun, health} {
+		problem.UpdateDemand(1.0)
+		region.AddProblem(problem)
+	}
+
+	// Food is in stock, Health is sold out and nobody makes Fun
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 10
+	region.AddIndustry(entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice}))
+	region.AddIndustry(entities.CreateIndustry("Clinic").
+		SetupIndustry([]*entities.Problem{health}, nil, []*entities.Resource{entities.NewResource("Care", "visits")}))
+
+	segment := entities.NewPopulationSegment("General", []*entities.Problem{food, fun, health}, 2)
+	for _, money := range []float32{0, 5} {
+		person := entities.NewPerson("Person", money, 0)
+		person.AddSegment(segment)
+		region.AddPerson(person)
+	}
+
+	result := ProcessProductMarket(region, 10.0)
+
+	expected := map[*entities.Problem]map[string]int{
+		food:   {ReasonBuyerBroke: 1, ReasonPriceTooHigh: 1},
+		fun:    {ReasonNoProducer: 2},
+		health: {ReasonOutOfStock: 2},
+	}
+	for problem, reasons := range expected {
+		got := result.NeedStats[problem.ID].Reasons
+		for reason, count := range reasons {
+			if got[reason] != count {
+				t.Errorf("Expected %d %s for %s, got %v", count, reason, problem.Name, got)
+			}
+		}
+	}
+	if len(result.Unmet) != 6 {
+		t.Errorf("Expected 6 unmet needs, got %d", len(result.Unmet))
+	}
+}
+
+func TestProcessProductMarket_BuysSeveralUnitsWithinBudgetAndStock(t *testing.T) {
+	region, food := newMarketRegion(2, 25.0)
+	food.UnitsPerPerson = 3
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 3
+	region.AddIndustry(entities.CreateIndustry("RiceFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice}))
+
+	result := ProcessProductMarket(region, 10.0)
+
+	// The first shopper can afford 2 units, leaving 1 for the second
+	stats := result.NeedStats[food.ID]
+	if stats.UnitsWanted != 6 {
+		t.Errorf("Expected 6 units wanted, got %d", stats.UnitsWanted)
+	}
+	if stats.UnitsBought != 3 || len(result.Purchases) != 3 {
+		t.Errorf("Expected 3 units bought, got %d in %d purchases", stats.UnitsBought, len(result.Purchases))
+	}
+	if stats.Satisfied != 2 {
+		t.Errorf("Expected both shoppers to get something, got %d", stats.Satisfied)
+	}
+	if rice.Quantity != 0 {
+		t.Errorf("Expected the rice to sell out, got %.0f left", rice.Quantity)
+	}
+}
+
+func TestProcessProductMarket_SpreadsBudgetAcrossNeeds(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	food := entities.NewProblem("Food", "Need food", 0.9)
+	water := entities.NewProblem("Water", "Need water", 0.9)
+	for _, problem := range []*entities.Problem{food, water} {
+		problem.UpdateDemand(1.0)
+		problem.UnitsPerPerson = 3
+		region.AddProblem(problem)
+	}
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 10
+	region.AddIndustry(entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice}))
+	bottles := entities.NewResource("Bottles", "l")
+	bottles.Quantity = 10
+	region.AddIndustry(entities.CreateIndustry("Well").
+		SetupIndustry([]*entities.Problem{water}, nil, []*entities.Resource{bottles}))
+
+	segment := entities.NewPopulationSegment("General", []*entities.Problem{food, water}, 1)
+	person := entities.NewPerson("Person", 40, 0)
+	person.AddSegment(segment)
+	region.AddPerson(person)
+
+	result := ProcessProductMarket(region, 10.0)
+
+	for _, problem := range []*entities.Problem{food, water} {
+		if bought := result.NeedStats[problem.ID].UnitsBought; bought != 2 {
+			t.Errorf("Expected 2 units of %s, got %d", problem.Name, bought)
+		}
+	}
+}
+
+func TestProcessProductMarket_PublicIndustrySellsAtSubsidizedPrice(t *testing.T) {
+	region, food := newMarketRegion(2, 0)
+	region.People[1].Money = 100.0
+
+	meals := entities.NewResource("Meals", "plate")
+	meals.Quantity = 10
+	kitchen := entities.CreateIndustry("PublicKitchen").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{meals})
+	kitchen.Public = true
+	kitchen.Subsidy = 1.0
+	region.AddIndustry(kitchen)
+
+	result := ProcessProductMarket(region, 10.0)
+
+	if len(result.Purchases) != 2 {
+		t.Fatalf("Expected both shoppers, broke or not, to get a free meal, got %d purchases", len(result.Purchases))
+	}
+	for _, purchase := range result.Purchases {
+		if purchase.TotalCost != 0 || purchase.Subsidy != 10.0 {
+			t.Errorf("Expected $0 paid and $10 owed by the treasury, got $%.2f and $%.2f", purchase.TotalCost, purchase.Subsidy)
+		}
+	}
+	if region.People[1].Money != 100.0 {
+		t.Errorf("Expected the shopper to keep their $100, got $%.2f", region.People[1].Money)
+	}
+}
+
+func TestProcessProductMarket_CompositeNeedRollsUp(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	nutrition := entities.NewProblem("Nutrition", "Need a balanced diet", 0.9)
+	medical := entities.NewProblem("Medical Care", "Need a doctor", 0.5)
+	health := entities.NewProblem("Health", "Need to stay healthy", 0.9).
+		AddPart(nutrition, 3).
+		AddPart(medical, 1)
+	for _, problem := range []*entities.Problem{nutrition, medical, health} {
+		problem.UpdateDemand(1.0)
+		region.AddProblem(problem)
+	}
+
+	segment := entities.NewPopulationSegment("General", []*entities.Problem{health}, 2)
+	for i := 0; i < 2; i++ {
+		person := entities.NewPerson("Person", 100, 0)
+		person.AddSegment(segment)
+		region.AddPerson(person)
+	}
+
+	// Food for both, a doctor for neither
+	meals := entities.NewResource("Meals", "plates")
+	meals.Quantity = 10
+	region.AddIndustry(entities.CreateIndustry("Kitchen").
+		SetupIndustry([]*entities.Problem{nutrition}, nil, []*entities.Resource{meals}))
+
+	result := ProcessProductMarket(region, 10.0)
+
+	if _, shopped := result.NeedStats[health.ID]; shopped {
+		t.Error("Expected people to shop for the parts of a composite need, not the need itself")
+	}
+	if stats := result.NeedStats[medical.ID]; stats == nil || stats.Needy != 2 {
+		t.Fatal("Expected both people to need medical care through Health")
+	}
+	met, ok := Satisfaction(health, result.NeedStats)
+	if !ok || met != 0.75 {
+		t.Errorf("Expected Health 75%% met (nutrition weighs 3 of 4), got %.2f", met)
+	}
+}
+
+func TestProductMarket_WeeklyTurnsServeEveryoneOnce(t *testing.T) {
+	region, food := newMarketRegion(8, 100.0)
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = 20
+	region.AddIndustry(entities.CreateIndustry("RiceFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice}))
+
+	market := NewProductMarket()
+	market.Weeks = 4
+	tick := &MarketResult{}
+	for week := 0; week < 4; week++ {
+		market.Week = week
+		result := market.Process(region, 10.0)
+		if result.PeopleSatisfied+result.PeopleUnsatisfied != 2 {
+			t.Errorf("Week %d: expected 2 shoppers, got %d", week, result.PeopleSatisfied+result.PeopleUnsatisfied)
+		}
+		tick.Combine(result)
+	}
+
+	if len(tick.Purchases) != 8 || tick.PeopleSatisfied != 8 {
+		t.Errorf("Expected 8 purchases by 8 people over the tick, got %d by %d", len(tick.Purchases), tick.PeopleSatisfied)
+	}
+	stats := tick.NeedStats[food.ID]
+	if stats.Needy != 8 || stats.Seeking != 8 || stats.Satisfied != 8 {
+		t.Errorf("Expected 8 needy, 8 seeking, 8 satisfied, got %+v", *stats)
+	}
+	if rice.Quantity != 12 {
+		t.Errorf("Expected 12 rice left, got %.0f", rice.Quantity)
+	}
+}
+
+func TestProcessProductMarket_SellsEachProductForItsProblems(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	prevention := entities.NewProblem("Prevention", "Stay healthy", 0.5)
+	treatment := entities.NewProblem("Treatment", "Get well", 0.9)
+	for _, problem := range []*entities.Problem{prevention, treatment} {
+		problem.UpdateDemand(1.0)
+		region.AddProblem(problem)
+	}
+
+	wellness := entities.NewResource("Wellness", "units")
+	wellness.Quantity = 10
+	wellness.Solves = []*entities.Problem{prevention}
+	medical := entities.NewResource("Medical", "units")
+	medical.Quantity = 10
+	medical.Solves = []*entities.Problem{treatment}
+	region.AddIndustry(entities.CreateIndustry("Clinic").
+		SetupIndustry([]*entities.Problem{prevention, treatment}, nil, []*entities.Resource{wellness, medical}))
+
+	for _, problem := range []*entities.Problem{prevention, treatment} {
+		segment := entities.NewPopulationSegment(problem.Name, []*entities.Problem{problem}, 1)
+		person := entities.NewPerson("Person", 100, 0)
+		person.AddSegment(segment)
+		region.AddPerson(person)
+	}
+
+	result := ProcessProductMarket(region, 10.0)
+
+	if len(result.Purchases) != 2 {
+		t.Fatalf("Expected 2 purchases, got %d", len(result.Purchases))
+	}
+	for _, purchase := range result.Purchases {
+		want := map[string]string{"Prevention": "Wellness", "Treatment": "Medical"}[purchase.ProblemSolved]
+		if purchase.ProductName != want {
+			t.Errorf("Expected %s bought for %s, got %s", want, purchase.ProblemSolved, purchase.ProductName)
+		}
+	}
+	if wellness.Quantity != 9 || medical.Quantity != 9 {
+		t.Errorf("Expected one unit of each sold, got %.0f Wellness and %.0f Medical left", wellness.Quantity, medical.Quantity)
+	}
+}
+
+func TestProcessProductMarket_BuysPortionsOfANeed(t *testing.T) {
+	region, food := newMarketRegion(2, 1000.0)
+
+	grain := entities.NewResource("Grain", "kg")
+	grain.Quantity = 24
+	grain.Satisfies = 0.25 // A kg feeds a person for a quarter of a week
+	region.AddIndustry(entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{grain}))
+
+	market := NewProductMarket()
+	market.WeeksPerTick = 4
+	result := market.Process(region, 1.0)
+
+	// The first shopper needs 16 kg for the tick and the second gets the rest
+	stats := result.NeedStats[food.ID]
+	if stats.UnitsBought != 24 || grain.Quantity != 0 {
+		t.Errorf("Expected all 24 kg bought, got %d with %.0f left", stats.UnitsBought, grain.Quantity)
+	}
+	if met, _ := stats.Met(); math.Abs(float64(met)-0.75) > 1e-4 {
+		t.Errorf("Expected 75%% of the need met, got %.4f", met)
+	}
+	if stats.Satisfied != 2 {
+		t.Errorf("Expected both shoppers to get some grain, got %d", stats.Satisfied)
+	}
+	fed := float32(0)
+	for _, purchase := range result.Purchases {
+		if purchase.PersonID == region.People[0].ID {
+			fed += purchase.Satisfaction
+		}
+	}
+	if math.Abs(float64(fed)-1) > 1e-4 {
+		t.Errorf("Expected the first shopper's need fully met, got %.4f", fed)
+	}
+}
@@ -0,0 +1,51 @@
+package market
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Queue orders decide who goes first each tick when people compete for
+// goods or jobs
+const (
+	QueueFixed   = ""        // Population order every tick, so early people always go first
+	QueueShuffle = "shuffle" // A fresh random order every tick
+	QueueRotate  = "rotate"  // Population order, starting one place later each tick
+)
+
+// Requeue reorders people in place for the given round. Shuffling needs a
+// random source and keeps the order without one.
+func Requeue(people []*entities.Person, order string, round int, rng *rand.Rand) {
+	if len(people) < 2 {
+		return
+	}
+	switch order {
+	case QueueShuffle:
+		if rng == nil {
+			return
+		}
+		rng.Shuffle(len(people), func(a, b int) {
+			people[a], people[b] = people[b], people[a]
+		})
+	case QueueRotate:
+		// Rotate left by round places
+		k := round % len(people)
+		slices.Reverse(people[:k])
+		slices.Reverse(people[k:])
+		slices.Reverse(people)
+	}
+}
+
+// queue returns the people shopping now in this tick's queue order
+func (m *ProductMarket) queue() []*entities.Person {
+	round := m.round
+	m.round++
+	if m.Queue == QueueFixed {
+		return m.shoppers
+	}
+	m.line = append(m.line[:0], m.shoppers...)
+	Requeue(m.line, m.Queue, round, m.Rand)
+	return m.line
+}
@@ -0,0 +1,40 @@
+package market
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestRequeue_RotatesByRound(t *testing.T) {
+	people := []*entities.Person{
+		entities.NewPerson("A", 0, 0),
+		entities.NewPerson("B", 0, 0),
+		entities.NewPerson("C", 0, 0),
+	}
+
+	Requeue(people, QueueRotate, 4, nil)
+
+	got := people[0].Name + people[1].Name + people[2].Name
+	if got != "BCA" {
+		t.Errorf("Expected BCA after rotating by 4, got %s", got)
+	}
+}
+
+func TestProductMarket_RotatingQueueTakesTurns(t *testing.T) {
+	region, _ := newShortRegion(3, 0)
+	stock := region.Industries[0].OutputProducts[0]
+
+	m := NewProductMarket()
+	m.Queue = QueueRotate
+	served := make(map[int]bool)
+	for tick := 0; tick < 3; tick++ {
+		stock.Quantity = 1
+		for _, purchase := range m.Process(region, 10.0).Purchases {
+			served[purchase.PersonID] = true
+		}
+	}
+
+	if len(served) != 3 {
+		t.Errorf("Expected each of the 3 people to be served once, got %d served", len(served))
+	}
+}
@@ -0,0 +1,96 @@
+package market
+
+import (
+	"sort"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Rationing modes for basic needs whose demand exceeds the stock on sale
+const (
+	RationNone     = ""         // First come, first served in population order
+	RationEqual    = "equal"    // Every needy person gets at most an equal share
+	RationSeverity = "severity" // People facing the most severe short needs shop first
+	RationLottery  = "lottery"  // Shoppers queue in a random order
+)
+
+// queued is a shopper and how badly the shortages hit them
+type queued struct {
+	person   *entities.Person
+	severity float32
+}
+
+// ration finds the basic needs that are short this tick and returns the
+// order people shop in. Outside shortages, or without a rationing mode,
+// that is the queue order.
+func (m *ProductMarket) ration(region *entities.Region) []*entities.Person {
+	clear(m.caps)
+	clear(m.short)
+	people := m.queue()
+	if m.Rationing == RationNone {
+		return people
+	}
+
+	for _, problem := range region.Problems {
+		stats, exists := m.result.NeedStats[problem.ID]
+		if !problem.IsBasicNeed || !exists || stats.Needy == 0 {
+			continue
+		}
+		supply := 0
+		for _, industry := range m.sellers.forProblem(region, problem) {
+			product := industry.ProductFor(problem)
+			supply += int(product.Quantity * product.NeedUnits(problem, m.WeeksPerTick))
+		}
+		if stats.Needy*problem.UnitsWanted() <= supply {
+			continue
+		}
+		m.short[problem.ID] = true
+		if m.Rationing == RationEqual {
+			m.caps[problem.ID] = max(supply/stats.Needy, 1)
+		}
+	}
+	if len(m.short) == 0 {
+		return people
+	}
+
+	switch m.Rationing {
+	case RationSeverity:
+		m.ranked = m.ranked[:0]
+		for _, person := range people {
+			m.ranked = append(m.ranked, queued{person: person, severity: m.severity(person)})
+		}
+		// The poorer of two equally needy people goes first
+		sort.SliceStable(m.ranked, func(a, b int) bool {
+			if m.ranked[a].severity != m.ranked[b].severity {
+				return m.ranked[a].severity > m.ranked[b].severity
+			}
+			return m.ranked[a].person.Money < m.ranked[b].person.Money
+		})
+		m.order = m.order[:0]
+		for _, q := range m.ranked {
+			m.order = append(m.order, q.person)
+		}
+		return m.order
+	case RationLottery:
+		if m.Rand == nil {
+			return people
+		}
+		m.order = append(m.order[:0], people...)
+		m.Rand.Shuffle(len(m.order), func(a, b int) {
+			m.order[a], m.order[b] = m.order[b], m.order[a]
+		})
+		return m.order
+	}
+	return people
+}
+
+// severity adds up how critical the person's short basic needs are
+func (m *ProductMarket) severity(person *entities.Person) float32 {
+	total := float32(0)
+	for _, need := range person.GetAllProblems() {
+		if m.short[need.ID] {
+			total += person.Severity(need)
+		}
+	}
+	return total
+}
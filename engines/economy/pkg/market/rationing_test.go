@@ -0,0 +1,99 @@
+package market
+
+import (
+	"math/rand/v2"
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+// newShortRegion builds a region whose buyers want more food than the farm has
+func newShortRegion(buyers int, stock float32) (*entities.Region, *entities.Problem) {
+	region, food := newMarketRegion(buyers, 1000.0)
+	food.IsBasicNeed = true
+
+	rice := entities.NewResource("Rice", "kg")
+	rice.Quantity = stock
+	region.AddIndustry(entities.CreateIndustry("RiceFarm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{rice}))
+	return region, food
+}
+
+// unitsBought counts the units each person bought
+func unitsBought(result *MarketResult) map[int]int {
+	bought := make(map[int]int)
+	for _, purchase := range result.Purchases {
+		bought[purchase.PersonID]++
+	}
+	return bought
+}
+
+func TestProductMarket_RationEqualSharesShortStock(t *testing.T) {
+	region, food := newShortRegion(4, 8)
+	food.UnitsPerPerson = 3
+
+	m := NewProductMarket()
+	m.Rationing = RationEqual
+	bought := unitsBought(m.Process(region, 10.0))
+
+	for _, person := range region.People {
+		if bought[person.ID] != 2 {
+			t.Errorf("Expected %s to get an equal share of 2, got %d", person.Name, bought[person.ID])
+		}
+	}
+}
+
+func TestProductMarket_RationSeverityServesNeediestFirst(t *testing.T) {
+	region, food := newShortRegion(1, 1)
+	water := entities.NewProblem("Water", "Need water", 0.9)
+	water.IsBasicNeed = true
+	water.UpdateDemand(1.0)
+	region.AddProblem(water)
+
+	// The second person also lacks water, which nobody sells
+	thirsty := entities.NewPerson("Thirsty", 1000.0, 0)
+	thirsty.AddSegment(entities.NewPopulationSegment("Thirsty", []*entities.Problem{food, water}, 1))
+	region.AddPerson(thirsty)
+
+	m := NewProductMarket()
+	m.Rationing = RationSeverity
+	bought := unitsBought(m.Process(region, 10.0))
+
+	if bought[thirsty.ID] != 1 {
+		t.Errorf("Expected the thirsty person to get the only unit, got %v", bought)
+	}
+}
+
+func TestProductMarket_RationLotteryShufflesQueue(t *testing.T) {
+	region, _ := newShortRegion(20, 10)
+
+	m := NewProductMarket()
+	m.Rationing = RationLottery
+	m.Rand = rand.New(rand.NewPCG(1, 2))
+	bought := unitsBought(m.Process(region, 10.0))
+
+	late := 0
+	for _, person := range region.People[10:] {
+		late += bought[person.ID]
+	}
+	if len(bought) != 10 {
+		t.Errorf("Expected 10 buyers, got %d", len(bought))
+	}
+	if late == 0 {
+		t.Error("Expected the lottery to serve some of the later half of the population")
+	}
+}
+
+func TestProductMarket_NoRationingWithoutShortage(t *testing.T) {
+	region, food := newShortRegion(4, 100)
+	food.UnitsPerPerson = 3
+
+	m := NewProductMarket()
+	m.Rationing = RationEqual
+	bought := unitsBought(m.Process(region, 10.0))
+
+	for _, person := range region.People {
+		if bought[person.ID] != 3 {
+			t.Errorf("Expected %s to buy all 3 units, got %d", person.Name, bought[person.ID])
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package market
+
+import "westex/engines/economy/pkg/entities"
+
+// UpdateSeverity moves every shopper's dynamic needs after a tick: needs
+// they sought and went without escalate, needs they bought for or had
+// covered by a subscription decay back towards the usual severity. Needs
+// nobody sought this tick stay as they were.
+func UpdateSeverity(region *entities.Region, result *MarketResult) {
+	relieved := make(map[historyKey]bool)
+	for _, purchase := range result.Purchases {
+		key := historyKey{purchase.PersonID, purchase.ProblemID}
+		if purchase.IsComplement || relieved[key] {
+			continue
+		}
+		relieved[key] = true
+		person := region.PersonByID(purchase.PersonID)
+		problem := problemByID(region, purchase.ProblemID)
+		if person != nil && problem != nil && problem.IsDynamic() {
+			person.Relieve(problem)
+		}
+	}
+
+	for _, unmet := range result.Unmet {
+		if unmet.Problem.IsDynamic() {
+			unmet.Person.Escalate(unmet.Problem)
+		}
+	}
+
+	for _, person := range region.People {
+		for id, covered := range person.CoveredProblems {
+			if !covered {
+				continue
+			}
+			if problem := problemByID(region, id); problem != nil && problem.IsDynamic() {
+				person.Relieve(problem)
+			}
+		}
+	}
+}
+
+// AverageSeverity returns how severe a need is on average among the people
+// who have it, and how many of them feel it more than usual
+func AverageSeverity(region *entities.Region, problem *entities.Problem) (float32, int) {
+	total, people, escalated := float32(0), 0, 0
+	for _, person := range region.People {
+		severity, tracked := person.Severities[problem.ID]
+		if !tracked {
+			severity = problem.Severity
+		} else {
+			escalated++
+		}
+		if tracked || hasNeed(person, problem) {
+			total += severity
+			people++
+		}
+	}
+	if people == 0 {
+		return problem.Severity, 0
+	}
+	return total / float32(people), escalated
+}
+
+// hasNeed reports whether the problem is among the person's needs
+func hasNeed(person *entities.Person, problem *entities.Problem) bool {
+	for _, need := range person.GetAllProblems() {
+		if need.ID == problem.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// problemByID finds a region's problem by ID
+func problemByID(region *entities.Region, id int) *entities.Problem {
+	for _, problem := range region.Problems {
+		if problem.ID == id {
+			return problem
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package market
+
+import (
+	"testing"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestUpdateSeverity_EscalatesUnmetAndDecaysMet(t *testing.T) {
+	region, food := newMarketRegion(2, 10.0)
+	food.Severity = 0.5
+	food.Escalation = 0.25
+	food.Decay = 0.5
+
+	bread := entities.NewResource("Bread", "loaves")
+	bread.Quantity = 1
+	region.AddIndustry(entities.CreateIndustry("Bakery").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{bread}))
+	fed, hungry := region.People[0], region.People[1]
+
+	UpdateSeverity(region, ProcessProductMarket(region, 10.0))
+	if fed.Severity(food) != 0.5 {
+		t.Errorf("Expected the fed person to stay at the usual 0.5, got %.2f", fed.Severity(food))
+	}
+	if hungry.Severity(food) != 0.75 {
+		t.Fatalf("Expected the hungry person's need to escalate to 0.75, got %.2f", hungry.Severity(food))
+	}
+	if hungry.UnitsWanted(food) != 1 {
+		t.Errorf("Expected 1 unit at severity 0.75, got %d", hungry.UnitsWanted(food))
+	}
+
+	hungry.Escalate(food)
+	if hungry.Severity(food) != 1 || hungry.UnitsWanted(food) != 2 {
+		t.Errorf("Expected severity 1 to want 2 units, got %.2f and %d", hungry.Severity(food), hungry.UnitsWanted(food))
+	}
+	hungry.Escalate(food)
+	if hungry.Severity(food) != 1 {
+		t.Errorf("Expected severity to stop at 1, got %.2f", hungry.Severity(food))
+	}
+
+	// Meeting the need halves the extra severity
+	hungry.Relieve(food)
+	if hungry.Severity(food) != 0.75 {
+		t.Errorf("Expected severity to decay to 0.75, got %.2f", hungry.Severity(food))
+	}
+	if average, escalated := AverageSeverity(region, food); average != 0.625 || escalated != 1 {
+		t.Errorf("Expected average 0.625 with 1 escalated, got %.3f and %d", average, escalated)
+	}
+}
@@ -0,0 +1,121 @@
+package market
+
+import "westex/engines/economy/pkg/entities"
+
+// WholesaleOrder records a retailer restocking a product from a supplier
+type WholesaleOrder struct {
+	RetailerName string
+	SupplierID   int
+	SupplierName string
+	ProductID    int // The supplier's product
+	ProductName  string
+	Ordered      float32 // Units the retailer wanted
+	Delivered    float32 // Units the supplier could deliver
+	UnitPrice    float32
+	TotalCost    float32
+}
+
+// Shortfall returns the units the supplier failed to deliver
+func (o WholesaleOrder) Shortfall() float32 {
+	return o.Ordered - o.Delivered
+}
+
+// WholesaleResult summarizes the distribution sector for one tick
+type WholesaleResult struct {
+	Orders         []WholesaleOrder
+	UnitsDelivered float32
+	TotalSpent     float32
+	Stockouts      int // Orders that could not be filled completely
+}
+
+// WholesalePrice is the price retailers pay so that their markup on top lands
+// on the retail price
+func WholesalePrice(retailer *entities.Industry, retailPrice float32) float32 {
+	return retailPrice / (1 + retailer.Markup)
+}
+
+// ProcessWholesaleMarket lets every retailer order each of its products up to
+// its target inventory from its suppliers, paying the wholesale price.
+func ProcessWholesaleMarket(region *entities.Region, retailPrice float32, tick int) *WholesaleResult {
+	result := &WholesaleResult{
+		Orders: make([]WholesaleOrder, 0),
+	}
+
+	for _, retailer := range region.Industries {
+		if !retailer.IsRetailer {
+			continue
+		}
+
+		unitPrice := WholesalePrice(retailer, retailPrice)
+		bought := float32(0)
+		spent := float32(0)
+
+		for _, stock := range retailer.OutputProducts {
+			wanted := retailer.TargetInventory - stock.Quantity
+			if wanted <= 0 {
+				continue
+			}
+
+			for _, supplier := range retailer.Suppliers {
+				supply := supplier.OutputProducts.Find(stock.Name)
+				if supply == nil || wanted <= 0 {
+					continue
+				}
+
+				// Limited by supplier stock and by what the retailer can pay
+				delivered := minFloat(wanted, supply.Quantity)
+				if unitPrice > 0 {
+					delivered = minFloat(delivered, float32(int(retailer.Money/unitPrice)))
+				}
+
+				order := WholesaleOrder{
+					RetailerName: retailer.Name,
+					SupplierID:   supplier.ID,
+					SupplierName: supplier.Name,
+					ProductID:    supply.ID,
+					ProductName:  stock.Name,
+					Ordered:      wanted,
+					Delivered:    delivered,
+					UnitPrice:    unitPrice,
+					TotalCost:    delivered * unitPrice,
+				}
+
+				if delivered > 0 {
+					entities.Transfer(supply, stock, delivered)
+					retailer.Money -= order.TotalCost
+					supplier.Money += order.TotalCost
+					bought += delivered
+					spent += order.TotalCost
+					wanted -= delivered
+				}
+				if order.Shortfall() > 0 {
+					result.Stockouts++
+				}
+
+				result.Orders = append(result.Orders, order)
+				result.UnitsDelivered += delivered
+				result.TotalSpent += order.TotalCost
+			}
+		}
+
+		// Record the restock as the retailer's cost of goods for pricing
+		if bought > 0 {
+			retailer.RecordProduction(entities.ProductionRecord{
+				Tick:          tick,
+				UnitsProduced: bought,
+				TotalCost:     spent,
+				CostPerUnit:   unitPrice,
+				ResourceCost:  spent,
+			})
+		}
+	}
+
+	return result
+}
+
+func minFloat(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
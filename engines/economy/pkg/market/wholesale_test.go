@@ -0,0 +1,71 @@
+package market
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestProcessWholesaleMarket_RestocksUpToTarget(t *testing.T) {
+	region, food := newMarketRegion(1, 100.0)
+
+	farmFood := entities.NewResource("Food", "kg")
+	farmFood.Quantity = 100
+	farm := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{farmFood})
+	region.AddIndustry(farm)
+
+	shopFood := entities.NewResource("Food", "kg")
+	shopFood.Quantity = 4
+	shop := entities.CreateIndustry("Shop").
+		SetupIndustry([]*entities.Problem{food}, nil, []*entities.Resource{shopFood}).
+		SetInitialCapital(1000.0).
+		AddSupplier(farm)
+	shop.TargetInventory = 10
+	shop.Markup = 0.25
+	region.AddIndustry(shop)
+
+	result := ProcessWholesaleMarket(region, 50.0, 1)
+
+	if result.UnitsDelivered != 6 {
+		t.Errorf("Expected 6 units delivered, got %.0f", result.UnitsDelivered)
+	}
+	if shopFood.Quantity != 10 || farmFood.Quantity != 94 {
+		t.Errorf("Expected shop 10 / farm 94, got %.0f / %.0f", shopFood.Quantity, farmFood.Quantity)
+	}
+	// Wholesale price 50 / 1.25 = 40
+	if farm.Money != 240.0 || shop.Money != 760.0 {
+		t.Errorf("Expected farm 240 / shop 760, got %.2f / %.2f", farm.Money, shop.Money)
+	}
+	if shop.GetLastProductionCost() != 40.0 {
+		t.Errorf("Expected retailer cost 40, got %.2f", shop.GetLastProductionCost())
+	}
+
+	// People buy from the retailer, never directly from the wholesale producer
+	market := ProcessProductMarket(region, 50.0)
+	if len(market.Purchases) != 1 || market.Purchases[0].IndustryName != "Shop" {
+		t.Errorf("Expected a single purchase from Shop, got %+v", market.Purchases)
+	}
+}
+
+func TestProcessWholesaleMarket_ReportsStockouts(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+
+	farmFood := entities.NewResource("Food", "kg")
+	farmFood.Quantity = 3
+	farm := entities.CreateIndustry("Farm").
+		SetupIndustry(nil, nil, []*entities.Resource{farmFood})
+	region.AddIndustry(farm)
+
+	shop := entities.CreateIndustry("Shop").
+		SetupIndustry(nil, nil, []*entities.Resource{entities.NewResource("Food", "kg")}).
+		SetInitialCapital(1000.0).
+		AddSupplier(farm)
+	shop.TargetInventory = 10
+	region.AddIndustry(shop)
+
+	result := ProcessWholesaleMarket(region, 50.0, 1)
+
+	if result.Stockouts != 1 || result.Orders[0].Shortfall() != 7 {
+		t.Errorf("Expected one stockout short 7 units, got %+v", result.Orders)
+	}
+}
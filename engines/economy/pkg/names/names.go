@@ -0,0 +1,90 @@
+package names
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+)
+
+// theme is a culture's first names and family names
+type theme struct {
+	first []string
+	last  []string
+}
+
+var themes = map[string]theme{
+	"indian": {
+		first: []string{"Aarav", "Aditi", "Anil", "Asha", "Deepa", "Farhan", "Gita", "Imran", "Kavya", "Kiran",
+			"Meera", "Nikhil", "Pooja", "Priya", "Rahul", "Ravi", "Sanjay", "Sunita", "Vikram", "Zoya"},
+		last: []string{"Bose", "Chopra", "Desai", "Gupta", "Iyer", "Joshi", "Kapoor", "Khan", "Kulkarni", "Menon",
+			"Mehta", "Nair", "Patel", "Pillai", "Rao", "Reddy", "Shah", "Sharma", "Singh", "Verma"},
+	},
+	"english": {
+		first: []string{"Alice", "Amelia", "Arthur", "Charlotte", "Daniel", "Edward", "Eleanor", "George", "Grace", "Harry",
+			"Isla", "Jack", "James", "Lucy", "Oliver", "Olivia", "Oscar", "Sophie", "Thomas", "William"},
+		last: []string{"Baker", "Carter", "Clarke", "Cooper", "Davies", "Evans", "Green", "Hall", "Harris", "Hughes",
+			"Jones", "King", "Lewis", "Morris", "Roberts", "Smith", "Taylor", "Turner", "Walker", "Wright"},
+	},
+	"spanish": {
+		first: []string{"Alejandro", "Ana", "Carlos", "Carmen", "Diego", "Elena", "Fernando", "Isabel", "Javier", "Lucia",
+			"Luis", "Maria", "Miguel", "Paula", "Pablo", "Rosa", "Sergio", "Sofia", "Teresa", "Victor"},
+		last: []string{"Alvarez", "Castro", "Diaz", "Fernandez", "Garcia", "Gomez", "Gonzalez", "Hernandez", "Jimenez", "Lopez",
+			"Martin", "Martinez", "Moreno", "Munoz", "Perez", "Romero", "Ruiz", "Sanchez", "Torres", "Vazquez"},
+	},
+}
+
+// Themes lists the available themes in name order
+func Themes() []string {
+	list := make([]string, 0, len(themes))
+	for name := range themes {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Generator hands out readable names for people and industries from one
+// theme. It is seeded, so a run names everyone the same way every time, and
+// never hands out the same name twice.
+type Generator struct {
+	theme theme
+	rng   *rand.Rand
+	used  map[string]int // Times each base name was handed out
+}
+
+// NewGenerator creates a generator for a theme, seeded for reproducible names
+func NewGenerator(themeName string, seed uint64) (*Generator, error) {
+	t, exists := themes[themeName]
+	if !exists {
+		return nil, fmt.Errorf("unknown name theme: %s", themeName)
+	}
+	return &Generator{
+		theme: t,
+		rng:   rand.New(rand.NewPCG(seed, seed^0x5bd1e995)),
+		used:  make(map[string]int),
+	}, nil
+}
+
+// Person returns a new "First Last" name, numbered once the combinations
+// run out (e.g. "Asha Patel 2")
+func (g *Generator) Person() string {
+	first := g.theme.first[g.rng.IntN(len(g.theme.first))]
+	last := g.theme.last[g.rng.IntN(len(g.theme.last))]
+	return g.unique(first + " " + last)
+}
+
+// Industry returns a new name for a business of the given kind, after a
+// family name (e.g. "Mehta Farm")
+func (g *Generator) Industry(kind string) string {
+	last := g.theme.last[g.rng.IntN(len(g.theme.last))]
+	return g.unique(last + " " + kind)
+}
+
+// unique numbers repeats of a name
+func (g *Generator) unique(name string) string {
+	g.used[name]++
+	if n := g.used[name]; n > 1 {
+		return fmt.Sprintf("%s %d", name, n)
+	}
+	return name
+}
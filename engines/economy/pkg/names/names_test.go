@@ -0,0 +1,32 @@
+package names
+
+import "testing"
+
+func TestGenerator_ReproducibleAndUnique(t *testing.T) {
+	first, err := NewGenerator("indian", 7)
+	if err != nil {
+		t.Fatalf("Expected the indian theme, got %v", err)
+	}
+	second, _ := NewGenerator("indian", 7)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		name := first.Person()
+		if again := second.Person(); again != name {
+			t.Fatalf("Expected the same seed to give the same names, got %q and %q", name, again)
+		}
+		if seen[name] {
+			t.Fatalf("Expected unique names, got %q twice", name)
+		}
+		seen[name] = true
+	}
+	if industry := first.Industry("Farm"); seen[industry] {
+		t.Errorf("Expected a fresh industry name, got %q", industry)
+	}
+}
+
+func TestNewGenerator_UnknownTheme(t *testing.T) {
+	if _, err := NewGenerator("klingon", 1); err == nil {
+		t.Error("Expected an error for an unknown theme")
+	}
+}
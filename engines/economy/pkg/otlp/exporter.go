@@ -0,0 +1,322 @@
+// Package otlp exports the engine's tick and phase spans to an OpenTelemetry
+// collector over OTLP/HTTP, in its JSON encoding, so traces reach a tracing
+// backend without the engine depending on the OpenTelemetry SDK
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"westex/engines/economy/pkg/core"
+)
+
+// TracesPath is where a collector accepts traces over OTLP/HTTP
+const TracesPath = "/v1/traces"
+
+// DefaultBatchSize is the number of ended spans sent per request
+const DefaultBatchSize = 512
+
+// DefaultQueueSize is the number of full batches that may wait to be sent
+const DefaultQueueSize = 8
+
+// Exporter is a core.Tracer sending its spans to a collector in batches.
+// Full batches are sent from a background goroutine, so ending a span never
+// waits on the collector; if the collector falls behind by more than
+// QueueSize batches, new batches are dropped. It is safe to share between
+// engines running concurrently. Call Flush when the run ends to send the
+// last batch.
+type Exporter struct {
+	Endpoint  string // Full URL of the collector's traces endpoint
+	Service   string // Reported as the service.name resource attribute
+	BatchSize int    // Spans per request (0 = DefaultBatchSize)
+	QueueSize int    // Full batches waiting to be sent (0 = DefaultQueueSize)
+	Client    *http.Client
+	OnError   func(error) // Called for every failed or dropped batch, as it happens
+
+	mu      sync.Mutex
+	ended   []span
+	err     error // First failed request, returned by Flush
+	once    sync.Once
+	queue   chan []span
+	pending sync.WaitGroup // Batches queued and not sent yet
+}
+
+// NewExporter creates an exporter for a collector. An endpoint without a
+// path, such as http://localhost:4318, gets the standard traces path.
+func NewExporter(endpoint, service string) (*Exporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q, expected a URL like http://localhost:4318", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = TracesPath
+	}
+	return &Exporter{
+		Endpoint: u.String(),
+		Service:  service,
+		Client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// spanKey is the context key of the span a new span is the child of
+type spanKey struct{}
+
+// Start opens a span, in the trace of the span in ctx or a new trace
+func (x *Exporter) Start(ctx context.Context, name string) (context.Context, core.Span) {
+	s := &span{exporter: x, name: name, start: time.Now(), spanID: newID(8)}
+	if parent, ok := ctx.Value(spanKey{}).(*span); ok {
+		s.traceID, s.parentID = parent.traceID, parent.spanID
+	} else {
+		s.traceID = newID(16)
+	}
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// Flush waits for the queued batches, sends the spans not sent yet and
+// returns the first error any request met
+func (x *Exporter) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		x.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	x.mu.Lock()
+	batch := x.ended
+	x.ended = nil
+	x.mu.Unlock()
+
+	if len(batch) > 0 {
+		x.send(ctx, batch)
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.err
+}
+
+// end keeps an ended span, queueing the batch once it is full
+func (x *Exporter) end(s span) {
+	size := x.BatchSize
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+	x.mu.Lock()
+	x.ended = append(x.ended, s)
+	var batch []span
+	if len(x.ended) >= size {
+		batch = x.ended
+		x.ended = nil
+	}
+	x.mu.Unlock()
+
+	if batch != nil {
+		x.enqueue(batch)
+	}
+}
+
+// enqueue hands a full batch to the sending goroutine, starting it on the
+// first batch. A batch that finds the queue full is dropped and reported.
+func (x *Exporter) enqueue(batch []span) {
+	x.once.Do(func() {
+		size := x.QueueSize
+		if size <= 0 {
+			size = DefaultQueueSize
+		}
+		x.queue = make(chan []span, size)
+		go func() {
+			for batch := range x.queue {
+				x.send(context.Background(), batch)
+				x.pending.Done()
+			}
+		}()
+	})
+	x.pending.Add(1)
+	select {
+	case x.queue <- batch:
+	default:
+		x.pending.Done()
+		x.fail(fmt.Errorf("dropped %d spans: the collector is %d batches behind", len(batch), cap(x.queue)))
+	}
+}
+
+// send posts a batch, reporting a failure
+func (x *Exporter) send(ctx context.Context, batch []span) {
+	if err := x.post(ctx, batch); err != nil {
+		x.fail(err)
+	}
+}
+
+// fail keeps the first error and passes every error to OnError
+func (x *Exporter) fail(err error) {
+	x.mu.Lock()
+	if x.err == nil {
+		x.err = err
+	}
+	x.mu.Unlock()
+	if x.OnError != nil {
+		x.OnError(err)
+	}
+}
+
+func (x *Exporter) post(ctx context.Context, batch []span) error {
+	body, err := json.Marshal(x.request(batch))
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, x.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to export spans: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := x.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export spans: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to export spans: collector answered %s", resp.Status)
+	}
+	return nil
+}
+
+// span is one operation, kept until it is sent
+type span struct {
+	exporter   *Exporter
+	name       string
+	traceID    string
+	spanID     string
+	parentID   string
+	start, end time.Time
+	attributes []keyValue
+}
+
+// SetAttribute records a value on the span. Integers, floats, booleans and
+// strings keep their type; anything else is sent as its string form.
+func (s *span) SetAttribute(key string, value any) {
+	s.attributes = append(s.attributes, keyValue{Key: key, Value: attributeValue(value)})
+}
+
+// End sends the span with the next batch
+func (s *span) End() {
+	s.end = time.Now()
+	s.exporter.end(*s)
+}
+
+// newID returns n random bytes in hex, as OTLP/JSON encodes trace and span IDs
+func newID(n int) string {
+	id := make([]byte, n)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// The OTLP/JSON request, cut down to the fields the engine fills in
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []jsonSpan `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type jsonSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"` // int64 is a string in JSON
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+// spanKindInternal marks spans of work inside the service
+const spanKindInternal = 1
+
+// request lays a batch out as one resource and scope
+func (x *Exporter) request(batch []span) exportRequest {
+	spans := make([]jsonSpan, len(batch))
+	for i, s := range batch {
+		spans[i] = jsonSpan{
+			TraceID:           s.traceID,
+			SpanID:            s.spanID,
+			ParentSpanID:      s.parentID,
+			Name:              s.name,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        s.attributes,
+		}
+	}
+	return exportRequest{ResourceSpans: []resourceSpans{{
+		Resource:   resource{Attributes: []keyValue{{Key: "service.name", Value: attributeValue(x.Service)}}},
+		ScopeSpans: []scopeSpans{{Scope: scope{Name: "westex/engines/economy"}, Spans: spans}},
+	}}}
+}
+
+// attributeValue wraps a value in its OTLP type
+func attributeValue(value any) anyValue {
+	switch v := value.(type) {
+	case string:
+		return anyValue{StringValue: &v}
+	case bool:
+		return anyValue{BoolValue: &v}
+	case int:
+		s := strconv.Itoa(v)
+		return anyValue{IntValue: &s}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return anyValue{IntValue: &s}
+	case float32:
+		f := float64(v)
+		return anyValue{DoubleValue: &f}
+	case float64:
+		return anyValue{DoubleValue: &v}
+	}
+	s := fmt.Sprint(value)
+	return anyValue{StringValue: &s}
+}
@@ -0,0 +1,130 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExporter_SendsNestedSpans(t *testing.T) {
+	var mu sync.Mutex
+	var requests []exportRequest
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != TracesPath || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected request to %s with %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		var request exportRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+	}))
+	defer collector.Close()
+
+	exporter, err := NewExporter(collector.URL, "economy")
+	if err != nil {
+		t.Fatalf("Expected an exporter, got %v", err)
+	}
+	exporter.BatchSize = 2
+
+	// A tick with two phases: the phases fill the first batch
+	ctx, tick := exporter.Start(context.Background(), "tick")
+	tick.SetAttribute("tick", 1)
+	tick.SetAttribute("region", "Mumbai")
+	for _, phase := range []string{"production", "market"} {
+		_, span := exporter.Start(ctx, phase)
+		span.End()
+	}
+	tick.End()
+	if err := exporter.Flush(context.Background()); err != nil {
+		t.Fatalf("Expected flush to succeed, got %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 batches, got %d", len(requests))
+	}
+	resource := requests[0].ResourceSpans[0].Resource.Attributes[0]
+	if resource.Key != "service.name" || *resource.Value.StringValue != "economy" {
+		t.Errorf("Expected the service name as a resource attribute, got %+v", resource)
+	}
+	phases := requests[0].ResourceSpans[0].ScopeSpans[0].Spans
+	root := requests[1].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if root.Name != "tick" || root.ParentSpanID != "" || len(root.TraceID) != 32 || len(root.SpanID) != 16 {
+		t.Errorf("Expected a root tick span with hex IDs, got %+v", root)
+	}
+	for _, phase := range phases {
+		if phase.TraceID != root.TraceID || phase.ParentSpanID != root.SpanID {
+			t.Errorf("Expected %s to be a child of the tick, got %+v", phase.Name, phase)
+		}
+	}
+	if len(root.Attributes) != 2 || *root.Attributes[0].Value.IntValue != "1" || *root.Attributes[1].Value.StringValue != "Mumbai" {
+		t.Errorf("Expected typed tick and region attributes, got %+v", root.Attributes)
+	}
+}
+
+func TestExporter_FlushReportsFailures(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer collector.Close()
+
+	exporter, _ := NewExporter(collector.URL+"/custom/traces", "economy")
+	if exporter.Endpoint != collector.URL+"/custom/traces" {
+		t.Errorf("Expected an endpoint with a path to be kept, got %s", exporter.Endpoint)
+	}
+	exporter.BatchSize = 1
+	failures := make(chan error, 1)
+	exporter.OnError = func(err error) { failures <- err }
+	_, span := exporter.Start(context.Background(), "tick")
+	span.End()
+
+	// The failure is reported as soon as the batch is sent, not at the end
+	select {
+	case <-failures:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the failed batch to be reported")
+	}
+	if err := exporter.Flush(context.Background()); err == nil {
+		t.Error("Expected the collector's error to be returned")
+	}
+
+	if _, err := NewExporter("localhost:4318", "economy"); err == nil {
+		t.Error("Expected an endpoint without a scheme to be rejected")
+	}
+}
+
+func TestExporter_EndDoesNotWaitForTheCollector(t *testing.T) {
+	release := make(chan struct{})
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer collector.Close()
+
+	exporter, _ := NewExporter(collector.URL, "economy")
+	exporter.BatchSize = 1
+	exporter.QueueSize = 1
+	var dropped []error
+	exporter.OnError = func(err error) { dropped = append(dropped, err) }
+
+	// The collector holds every request, so one batch is in flight, one
+	// waits and the rest are dropped without blocking the caller
+	for i := 0; i < 3; i++ {
+		_, span := exporter.Start(context.Background(), "tick")
+		span.End()
+	}
+	if len(dropped) == 0 || !strings.Contains(dropped[0].Error(), "dropped 1 spans") {
+		t.Errorf("Expected a full queue to drop and report batches, got %v", dropped)
+	}
+
+	close(release)
+	if err := exporter.Flush(context.Background()); err == nil {
+		t.Error("Expected the dropped batches to be returned")
+	}
+}
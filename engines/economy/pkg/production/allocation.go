@@ -0,0 +1,181 @@
+package production
+
+import (
+	"slices"
+	"westex/engines/economy/pkg/entities"
+)
+
+// Input allocation rules, for inputs several industries draw on that cannot
+// cover all of them in a tick
+const (
+	AllocateProRata  = "pro_rata" // In proportion to the units each needs
+	AllocatePriority = "priority" // Highest InputPriority first, ties in region order
+	AllocateAuction  = "auction"  // Highest bid per unit first, at the clearing price
+)
+
+// Claim is an industry's call on its inputs for a tick
+type Claim struct {
+	Industry *entities.Industry
+	Units    float32 // Units of each input it would use
+	Bid      float32 // Price per unit it offers under the auction rule
+}
+
+// Reservations are the units of scarce shared inputs set aside for each
+// industry before production, and what the auction rule charges for them
+type Reservations struct {
+	Units map[int]map[int]float32 // Units left by resource ID, then industry ID
+	Owed  map[int]float32         // Auction payments for free resources by industry ID
+}
+
+// Reserve splits every input that several claims share and that cannot
+// cover all of them, under the rule. Inputs that can cover everyone stay
+// first come, first served. Under the auction rule each auctioned input's
+// Price is set to its clearing price, and every other input's is reset.
+func Reserve(rule string, claims []Claim) *Reservations {
+	reserved := &Reservations{
+		Units: make(map[int]map[int]float32),
+		Owed:  make(map[int]float32),
+	}
+
+	// Gather the claims on each input, in the order inputs are first met
+	inputs := make([]*entities.Resource, 0)
+	users := make(map[*entities.Resource][]Claim)
+	for _, claim := range claims {
+		for _, input := range claim.Industry.InputResources {
+			if _, seen := users[input]; !seen {
+				inputs = append(inputs, input)
+				input.Price = 0
+			}
+			users[input] = append(users[input], claim)
+		}
+	}
+
+	for _, input := range inputs {
+		shared := users[input]
+		wanted := float32(0)
+		for _, claim := range shared {
+			wanted += needs(claim, input)
+		}
+		if len(shared) < 2 || wanted <= input.Quantity {
+			continue
+		}
+		shares := reserved.split(rule, input, shared, wanted)
+		// A stock resource's reservation includes what the industry holds
+		if input.IsStock() {
+			for _, claim := range shared {
+				shares[claim.Industry.ID] += claim.Industry.Holding(input)
+			}
+		}
+		reserved.Units[input.ID] = shares
+	}
+	return reserved
+}
+
+// needs returns the units a claim needs of an input: all of them for a flow
+// resource, and those beyond what the industry holds for a stock resource
+func needs(claim Claim, input *entities.Resource) float32 {
+	units := max(claim.Units, 0)
+	if input.IsStock() {
+		units = max(units-claim.Industry.Holding(input), 0)
+	}
+	return units
+}
+
+// split shares out an input the claims want more of than it holds
+func (r *Reservations) split(rule string, input *entities.Resource, claims []Claim, wanted float32) map[int]float32 {
+	available := max(input.Quantity, 0)
+	shares := make(map[int]float32, len(claims))
+
+	if rule == AllocateProRata {
+		for _, claim := range claims {
+			shares[claim.Industry.ID] = available * needs(claim, input) / wanted
+		}
+		return shares
+	}
+
+	// Priority and auction serve claims in full, in order, until it runs out
+	order := slices.Clone(claims)
+	slices.SortStableFunc(order, func(a, b Claim) int {
+		if rule == AllocateAuction {
+			return compareDesc(a.Bid, b.Bid)
+		}
+		return b.Industry.InputPriority - a.Industry.InputPriority
+	})
+	price := float32(-1)
+	for _, claim := range order {
+		need := needs(claim, input)
+		units := min(need, available)
+		shares[claim.Industry.ID] = units
+		available -= units
+		// The first claim left short sets the auction's clearing price
+		if units < need && price < 0 {
+			price = max(claim.Bid, 0)
+		}
+	}
+	if rule != AllocateAuction {
+		return shares
+	}
+
+	// Winners pay the clearing price, which is the input's cost in
+	// production; proceeds for free resources go to the government
+	input.Price = max(price, 0)
+	if input.IsFree {
+		for _, claim := range order {
+			r.Owed[claim.Industry.ID] += shares[claim.Industry.ID] * input.Price
+		}
+	}
+	return shares
+}
+
+// Allowance returns the units an industry can produce from its reserved
+// inputs, at most what is left of them, and false if none of its inputs are
+// reserved
+func (r *Reservations) Allowance(industry *entities.Industry) (float32, bool) {
+	if r == nil {
+		return 0, false
+	}
+	allowed, reserved := float32(0), false
+	for _, input := range industry.InputResources {
+		units, ok := r.Units[input.ID][industry.ID]
+		if !ok {
+			continue
+		}
+		free := max(input.Quantity, 0)
+		if input.IsStock() {
+			free += industry.Holding(input)
+		}
+		units = min(units, free)
+		if !reserved || units < allowed {
+			allowed = units
+		}
+		reserved = true
+	}
+	return allowed, reserved
+}
+
+// Use draws down an industry's reservations by the units it produced. Stock
+// resources serve every shift, so theirs stay whole.
+func (r *Reservations) Use(industry *entities.Industry, units float32) {
+	if r == nil {
+		return
+	}
+	for _, input := range industry.InputResources {
+		if input.IsStock() {
+			continue
+		}
+		if left, ok := r.Units[input.ID][industry.ID]; ok {
+			r.Units[input.ID][industry.ID] = max(left-units, 0)
+		}
+	}
+}
+
+// compareDesc orders larger values first
+func compareDesc(a, b float32) int {
+	switch {
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	}
+	return 0
+}
@@ -8,6 +8,7 @@ type ProductionResult struct {
 	LaborUsed     float32
 	LaborCost     float32
 	ResourceCost  float32
+	FixedCost     float32 // Overheads charged to this production run
 	TotalCost     float32
 	CostPerUnit   float32
 }
@@ -48,6 +49,103 @@ func CalculateProduction(
 	return result
 }
 
+// ApplySkill scales a production result by the workers' average skill, so a
+// trained workforce produces more from the same hours
+func ApplySkill(industry *entities.Industry, result *ProductionResult, workers []*entities.Person) {
+	if len(workers) == 0 {
+		return
+	}
+
+	totalSkill := float32(0)
+	for _, worker := range workers {
+		totalSkill += worker.Skill
+	}
+	scaleOutput(industry, result, totalSkill/float32(len(workers)))
+}
+
+// ApplySkillPay weighs each worker's hours by their skill, hours[i] being
+// workers[i]'s, and scales the labor cost to match. It returns the hours
+// each worker is paid for at the base wage.
+func ApplySkillPay(result *ProductionResult, workers []*entities.Person, hours []float32) []float32 {
+	paid := make([]float32, len(hours))
+	worked, weighted := float32(0), float32(0)
+	for i, h := range hours {
+		paid[i] = h * workers[i].Skill
+		worked += h
+		weighted += paid[i]
+	}
+	if worked <= 0 {
+		return paid
+	}
+	result.LaborCost *= weighted / worked
+	result.TotalCost = result.LaborCost + result.ResourceCost + result.FixedCost
+	result.CostPerUnit = 0
+	if result.UnitsProduced > 0 {
+		result.CostPerUnit = result.TotalCost / result.UnitsProduced
+	}
+	return paid
+}
+
+// ApplyCommute scales a production result by the share of paid hours workers
+// actually spend at work after commuting from their zones
+func ApplyCommute(
+	region *entities.Region,
+	industry *entities.Industry,
+	result *ProductionResult,
+	workers []*entities.Person,
+	hoursAvailable float32,
+) {
+	if len(workers) == 0 || hoursAvailable <= 0 || region.Transport == nil {
+		return
+	}
+
+	worked := float32(0)
+	for _, worker := range workers {
+		worked += max(hoursAvailable-region.CommuteHours(worker.Zone, industry.Zone), 0)
+	}
+	scaleOutput(industry, result, worked/(hoursAvailable*float32(len(workers))))
+}
+
+// ApplyHours scales a production result and its labor cost by the hours
+// workers chose to offer against the standard hours of the tick, hours[i]
+// being the i-th worker's
+func ApplyHours(industry *entities.Industry, result *ProductionResult, hours []float32, hoursAvailable float32) {
+	if len(hours) == 0 || hoursAvailable <= 0 {
+		return
+	}
+
+	worked := float32(0)
+	for _, h := range hours {
+		worked += h
+	}
+	factor := worked / (hoursAvailable * float32(len(hours)))
+	if factor == 1 {
+		return
+	}
+	result.LaborCost *= factor
+	scaleOutput(industry, result, factor)
+}
+
+// ApplyTarget caps a production result at the units the industry planned,
+// so it consumes no more inputs than its target needs
+func ApplyTarget(industry *entities.Industry, result *ProductionResult, target float32) {
+	if result.UnitsProduced <= target || result.UnitsProduced <= 0 {
+		return
+	}
+	scaleOutput(industry, result, target/result.UnitsProduced)
+}
+
+// scaleOutput multiplies units produced and refreshes the dependent costs
+func scaleOutput(industry *entities.Industry, result *ProductionResult, factor float32) {
+	result.UnitsProduced *= factor
+	result.ResourceCost = calculateResourceCost(industry, result.UnitsProduced)
+	result.TotalCost = result.LaborCost + result.ResourceCost + result.FixedCost
+	result.CostPerUnit = 0
+	if result.UnitsProduced > 0 {
+		result.CostPerUnit = result.TotalCost / result.UnitsProduced
+	}
+}
+
 // calculateResourceCost estimates the cost of resources consumed
 func calculateResourceCost(industry *entities.Industry, unitsProduced float32) float32 {
 	totalCost := float32(0)
@@ -59,10 +157,13 @@ func calculateResourceCost(industry *entities.Industry, unitsProduced float32) f
 		unitsNeeded := unitsProduced
 		costPerUnit := float32(1.0) // Default cost
 
-		// Free resources (land, water) have no cost
+		// Free resources (land, water) have no cost, unless auctioned
 		if input.IsFree {
 			costPerUnit = 0
 		}
+		if input.Price > 0 {
+			costPerUnit = input.Price
+		}
 
 		totalCost += unitsNeeded * costPerUnit
 	}
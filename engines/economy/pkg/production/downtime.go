@@ -0,0 +1,41 @@
+package production
+
+import (
+	"math/rand/v2"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Outage causes
+const (
+	Maintenance = "maintenance" // Scheduled every Reliability.Every ticks
+	Breakdown   = "breakdown"   // Random, with Reliability.Chance per tick
+)
+
+// CheckOutage reports whether the industry is down this tick, and why when
+// the outage starts this tick. An outage lasts Reliability.Duration ticks;
+// scheduled maintenance takes priority over breakdowns.
+func CheckOutage(industry *entities.Industry, tick int, rng *rand.Rand) (bool, string) {
+	if industry.DownFor > 0 {
+		industry.DownFor--
+		industry.DowntimeTicks++
+		return true, ""
+	}
+
+	reliability := industry.Reliability
+	if reliability == nil {
+		return false, ""
+	}
+	cause := ""
+	switch {
+	case reliability.Every > 0 && tick%reliability.Every == 0:
+		cause = Maintenance
+	case reliability.Chance > 0 && rng.Float32() < reliability.Chance:
+		cause = Breakdown
+	default:
+		return false, ""
+	}
+	industry.DownFor = max(reliability.Duration, 1) - 1
+	industry.DowntimeTicks++
+	return true, cause
+}
@@ -0,0 +1,47 @@
+package production
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestCheckOutage_ScheduledMaintenance(t *testing.T) {
+	industry := entities.CreateIndustry("Mill")
+	industry.Reliability = &entities.Reliability{Every: 4, Duration: 2}
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	down := make([]bool, 0, 8)
+	for tick := 1; tick <= 8; tick++ {
+		isDown, cause := CheckOutage(industry, tick, rng)
+		if tick%4 == 0 && cause != Maintenance {
+			t.Errorf("Expected maintenance to start at tick %d, got %q", tick, cause)
+		}
+		down = append(down, isDown)
+	}
+
+	expected := []bool{false, false, false, true, true, false, false, true}
+	for i := range expected {
+		if down[i] != expected[i] {
+			t.Errorf("Tick %d: expected down %v, got %v", i+1, expected[i], down[i])
+		}
+	}
+	if industry.DowntimeTicks != 3 {
+		t.Errorf("Expected 3 ticks of downtime, got %d", industry.DowntimeTicks)
+	}
+}
+
+func TestCheckOutage_Breakdowns(t *testing.T) {
+	industry := entities.CreateIndustry("Mill")
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	if down, _ := CheckOutage(industry, 1, rng); down {
+		t.Error("Expected an industry without a reliability schedule never to go down")
+	}
+
+	industry.Reliability = &entities.Reliability{Chance: 1}
+	if down, cause := CheckOutage(industry, 1, rng); !down || cause != Breakdown {
+		t.Errorf("Expected a certain breakdown, got down %v (%q)", down, cause)
+	}
+}
@@ -0,0 +1,89 @@
+package production
+
+import (
+	"math"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Forecasting methods
+const (
+	ForecastMovingAverage = "moving_average" // Mean of the last Window ticks of sales
+	ForecastExponential   = "exponential"    // Exponential smoothing of sales with weight Alpha
+)
+
+// Forecaster predicts an industry's sales from its recent sales, so it can
+// plan how much to produce and how many workers to hire instead of always
+// running at full capacity
+type Forecaster struct {
+	Method string
+	Window int     // Ticks the moving average spans (0 = every remembered tick)
+	Alpha  float32 // Weight of the latest tick in exponential smoothing (0-1)
+	Buffer float32 // Safety stock planned on top of the forecast, as a share of it
+}
+
+// Forecast predicts next tick's sales, and false while the industry has no
+// sales history to go on
+func (f *Forecaster) Forecast(industry *entities.Industry) (float32, bool) {
+	history := industry.SalesHistory
+	if len(history) == 0 {
+		return 0, false
+	}
+
+	if f.Method == ForecastExponential {
+		forecast := history[0]
+		for _, sold := range history[1:] {
+			forecast += f.Alpha * (sold - forecast)
+		}
+		return forecast, true
+	}
+
+	if f.Window > 0 && len(history) > f.Window {
+		history = history[len(history)-f.Window:]
+	}
+	total := float32(0)
+	for _, sold := range history {
+		total += sold
+	}
+	return total / float32(len(history)), true
+}
+
+// Target returns the units the industry plans to produce next: the forecast
+// plus the safety buffer, less what is still on the shelves of its scarcest
+// product. It returns false when there is nothing to forecast from.
+func (f *Forecaster) Target(industry *entities.Industry) (float32, bool) {
+	forecast, ok := f.Forecast(industry)
+	if !ok {
+		return 0, false
+	}
+	return Shortfall(industry, forecast*(1+f.Buffer)), true
+}
+
+// Shortfall returns how many units the industry has to produce to have the
+// given units of its scarcest product on the shelves
+func Shortfall(industry *entities.Industry, units float32) float32 {
+	return max(units-lowestStock(industry), 0)
+}
+
+// WorkersFor returns how many workers an industry needs to produce the given
+// units in a tick, at most its full labor needs. A full crew produces one
+// unit per hour available.
+func WorkersFor(industry *entities.Industry, units, hoursAvailable float32) int {
+	if hoursAvailable <= 0 || industry.LaborNeeded <= 0 {
+		return 0
+	}
+	workers := math.Ceil(float64(min(units/hoursAvailable, 1) * industry.LaborNeeded))
+	return int(workers)
+}
+
+// lowestStock returns the stock of the industry's scarcest output
+func lowestStock(industry *entities.Industry) float32 {
+	if len(industry.OutputProducts) == 0 {
+		return 0
+	}
+	lowest := industry.OutputProducts[0].Quantity
+	for _, product := range industry.OutputProducts[1:] {
+		lowest = min(lowest, product.Quantity)
+	}
+	return lowest
+}
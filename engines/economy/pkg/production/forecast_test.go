@@ -0,0 +1,57 @@
+package production
+
+import (
+	"testing"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestForecast_MovingAverage(t *testing.T) {
+	industry := entities.CreateIndustry("Bakery")
+	forecaster := &Forecaster{Method: ForecastMovingAverage, Window: 2}
+
+	if _, ok := forecaster.Forecast(industry); ok {
+		t.Error("Expected no forecast without sales history")
+	}
+
+	industry.RecordSales(100)
+	industry.RecordSales(40)
+	industry.RecordSales(60)
+	forecast, ok := forecaster.Forecast(industry)
+	if !ok || forecast != 50 {
+		t.Errorf("Expected forecast 50 over the last 2 ticks, got %.2f", forecast)
+	}
+}
+
+func TestForecast_Exponential(t *testing.T) {
+	industry := entities.CreateIndustry("Bakery")
+	industry.RecordSales(100)
+	industry.RecordSales(50)
+	forecaster := &Forecaster{Method: ForecastExponential, Alpha: 0.5}
+
+	if forecast, _ := forecaster.Forecast(industry); forecast != 75 {
+		t.Errorf("Expected forecast 75, got %.2f", forecast)
+	}
+}
+
+func TestTarget_SubtractsStock(t *testing.T) {
+	bread := entities.NewResource("Bread", "loaves")
+	bread.Quantity = 30
+	industry := entities.CreateIndustry("Bakery").
+		SetupIndustry(nil, nil, []*entities.Resource{bread}).
+		UpdateLabor(10)
+	industry.RecordSales(100)
+	forecaster := &Forecaster{Buffer: 0.5}
+
+	target, ok := forecaster.Target(industry)
+	if !ok || target != 120 {
+		t.Errorf("Expected target 120 (150 - 30 in stock), got %.2f", target)
+	}
+
+	if workers := WorkersFor(industry, target, 160); workers != 8 {
+		t.Errorf("Expected 8 workers for 120 of 160 units, got %d", workers)
+	}
+	if workers := WorkersFor(industry, 500, 160); workers != 10 {
+		t.Errorf("Expected full crew of 10 for more than capacity, got %d", workers)
+	}
+}
@@ -5,6 +5,25 @@ import (
 	"westex/engines/economy/pkg/entities"
 )
 
+// Wage payment timings
+const (
+	PayBeforeProduction = "before_production" // The whole wage bill before producing
+	PayAfterSales       = "after_sales"       // The whole wage bill once the tick's sales are in
+	PayWeekly           = "weekly"            // The first week's wages before producing, the rest after sales
+)
+
+// UpfrontShare returns the share of the wage bill paid before production
+// under a payment timing, for a tick of the given weeks
+func UpfrontShare(timing string, weeks int) float32 {
+	switch timing {
+	case PayAfterSales:
+		return 0
+	case PayWeekly:
+		return 1 / float32(max(weeks, 1))
+	}
+	return 1
+}
+
 // LaborPayment represents a wage payment to a worker
 type LaborPayment struct {
 	PersonName   string
@@ -20,14 +39,28 @@ func PayWorkers(
 	workers []*entities.Person,
 	hoursPerWorker float32,
 	wageRate float32,
+) ([]LaborPayment, error) {
+	hours := make([]float32, len(workers))
+	for i := range hours {
+		hours[i] = hoursPerWorker
+	}
+	return PayHours(industry, workers, hours, wageRate)
+}
+
+// PayHours pays each worker for the hours they worked, hours[i] being
+// workers[i]'s. Nobody is paid unless the industry can afford everyone.
+func PayHours(
+	industry *entities.Industry,
+	workers []*entities.Person,
+	hours []float32,
+	wageRate float32,
 ) ([]LaborPayment, error) {
 	payments := make([]LaborPayment, 0)
 	totalWages := float32(0)
 
 	// Calculate total wages needed
-	for range workers {
-		wages := hoursPerWorker * wageRate
-		totalWages += wages
+	for i := range workers {
+		totalWages += hours[i] * wageRate
 	}
 
 	// Check if industry can afford
@@ -37,8 +70,8 @@ func PayWorkers(
 	}
 
 	// Pay each worker
-	for _, worker := range workers {
-		wages := hoursPerWorker * wageRate
+	for i, worker := range workers {
+		wages := hours[i] * wageRate
 
 		// Deduct from industry
 		industry.Money -= wages
@@ -50,7 +83,7 @@ func PayWorkers(
 		payments = append(payments, LaborPayment{
 			PersonName:   worker.Name,
 			IndustryName: industry.Name,
-			HoursWorked:  hoursPerWorker,
+			HoursWorked:  hours[i],
 			WageRate:     wageRate,
 			TotalPaid:    wages,
 		})
@@ -79,3 +112,126 @@ func AllocateWorkers(
 
 	return availableWorkers[:count]
 }
+
+// AllocateHours hires workers in order until their offered hours fill the
+// crew hours, hours[i] being workers[i]'s offer. The last worker hired may
+// take only part of their offer. It returns the workers hired and the hours
+// each takes.
+func AllocateHours(workers []*entities.Person, hours []float32, crewHours float32) ([]*entities.Person, []float32) {
+	taken := make([]float32, 0)
+	remaining := crewHours
+	for i := range workers {
+		if remaining <= 0 {
+			break
+		}
+		h := min(hours[i], remaining)
+		taken = append(taken, h)
+		remaining -= h
+	}
+	return workers[:len(taken)], taken
+}
+
+// Eligible returns the workers from the segments the industry hires from,
+// with their offered hours, hours[i] being workers[i]'s offer
+func Eligible(industry *entities.Industry, workers []*entities.Person, hours []float32) ([]*entities.Person, []float32) {
+	eligible := make([]*entities.Person, 0, len(workers))
+	eligibleHours := make([]float32, 0, len(workers))
+	for i, worker := range workers {
+		if industry.Hires(worker) {
+			eligible = append(eligible, worker)
+			eligibleHours = append(eligibleHours, hours[i])
+		}
+	}
+	return eligible, eligibleHours
+}
+
+// RemainingHours takes the hours workers were hired for off their offers,
+// hours[i] being workers[i]'s offer and taken[i] the hours hired[i] took.
+// Hired workers with hours left stay in line, ahead of those not hired, who
+// keep their order.
+func RemainingHours(workers []*entities.Person, hours []float32, hired []*entities.Person, taken []float32) ([]*entities.Person, []float32) {
+	offers := make(map[*entities.Person]float32, len(workers))
+	for i, worker := range workers {
+		offers[worker] = hours[i]
+	}
+	leftWorkers := make([]*entities.Person, 0, len(workers))
+	leftHours := make([]float32, 0, len(workers))
+	for i, worker := range hired {
+		if h := offers[worker] - taken[i]; h > 0.001 {
+			leftWorkers = append(leftWorkers, worker)
+			leftHours = append(leftHours, h)
+		}
+		delete(offers, worker)
+	}
+	for i, worker := range workers {
+		if _, waiting := offers[worker]; waiting {
+			leftWorkers = append(leftWorkers, worker)
+			leftHours = append(leftHours, hours[i])
+		}
+	}
+	return leftWorkers, leftHours
+}
+
+// OfferHours is how many hours a worker chooses to work in a tick at the
+// given wage. Below their reservation wage they stay home. With a target
+// income they work just the hours that earn it: fewer than the standard
+// hours when the wage is high, overtime of up to maxOvertime times the
+// standard hours more when it is low. Without either they work the
+// standard hours.
+func OfferHours(person *entities.Person, wage, standardHours, maxOvertime float32) float32 {
+	if wage < person.ReservationWage() {
+		return 0
+	}
+	target := person.TargetIncome()
+	if target <= 0 || wage <= 0 {
+		return standardHours
+	}
+	return min(target/wage, standardHours*(1+max(maxOvertime, 0)))
+}
+
+// ResetHours gives every person their labor hours for a new tick: their
+// daily hours over the tick's working days, plus the overtime they may take
+// on as a share of that, less the hours they pledged for barter
+func ResetHours(people []*entities.Person, workingDays, maxOvertime float32) {
+	for _, person := range people {
+		hours := person.LaborHours * workingDays * (1 + max(maxOvertime, 0))
+		person.HoursLeft = max(hours-person.PledgedHours, 0)
+		person.PledgedHours = 0
+	}
+}
+
+// SettleWages pays workers the wages still owed for hours they already
+// worked, hours[i] being workers[i]'s. An industry that can't afford all of
+// it pays every worker the same share of what they are owed and returns the
+// amount left unpaid.
+func SettleWages(
+	industry *entities.Industry,
+	workers []*entities.Person,
+	hours []float32,
+	wageRate float32,
+) ([]LaborPayment, float32) {
+	owed := float32(0)
+	for i := range workers {
+		owed += hours[i] * wageRate
+	}
+	if owed <= 0 {
+		return nil, 0
+	}
+
+	share := min(max(industry.Money, 0)/owed, 1)
+	payments := make([]LaborPayment, 0, len(workers))
+	for i, worker := range workers {
+		// Rounding in the shares mustn't pay out more than is left
+		wages := min(hours[i]*wageRate*share, max(industry.Money, 0))
+		industry.Money -= wages
+		worker.Money += wages
+		payments = append(payments, LaborPayment{
+			PersonName:   worker.Name,
+			IndustryName: industry.Name,
+			HoursWorked:  hours[i],
+			WageRate:     wageRate,
+			TotalPaid:    wages,
+		})
+	}
+	return payments, owed * (1 - share)
+}
@@ -0,0 +1,54 @@
+package production
+
+import "westex/engines/economy/pkg/entities"
+
+// OverheadResult summarizes one tick of industries' fixed operating costs
+type OverheadResult struct {
+	TotalPaid float32
+	Paid      map[string]float32 // Per industry
+	Unpaid    map[string]float32 // Per industry that could not cover its overheads
+}
+
+// PayOverheads charges every industry its fixed costs, whether it produced
+// or not. Industries pay what their money covers and the rest is reported
+// unpaid. Overheads are paid out evenly to the people as rent and
+// administration income, so they move money around instead of destroying it.
+func PayOverheads(region *entities.Region) *OverheadResult {
+	result := &OverheadResult{
+		Paid:   make(map[string]float32),
+		Unpaid: make(map[string]float32),
+	}
+
+	for _, industry := range region.Industries {
+		if industry.FixedCosts <= 0 {
+			continue
+		}
+		paid := min(industry.FixedCosts, max(industry.Money, 0))
+		industry.Money -= paid
+		result.Paid[industry.Name] = paid
+		result.TotalPaid += paid
+		if paid < industry.FixedCosts {
+			result.Unpaid[industry.Name] = industry.FixedCosts - paid
+		}
+	}
+
+	if result.TotalPaid > 0 && len(region.People) > 0 {
+		share := result.TotalPaid / float32(len(region.People))
+		for _, person := range region.People {
+			person.Money += share
+		}
+	}
+
+	return result
+}
+
+// ApplyFixedCost adds a tick's overheads to a production result's costs, so
+// the cost per unit falls as output grows
+func ApplyFixedCost(result *ProductionResult, fixedCost float32) {
+	result.FixedCost = fixedCost
+	result.TotalCost = result.LaborCost + result.ResourceCost + result.FixedCost
+	result.CostPerUnit = 0
+	if result.UnitsProduced > 0 {
+		result.CostPerUnit = result.TotalCost / result.UnitsProduced
+	}
+}
@@ -0,0 +1,47 @@
+package production
+
+import (
+	"testing"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestPayOverheads(t *testing.T) {
+	region := entities.NewRegion("Test")
+	mill := entities.CreateIndustry("Mill").SetInitialCapital(1000)
+	mill.FixedCosts = 300
+	broke := entities.CreateIndustry("Broke").SetInitialCapital(100)
+	broke.FixedCosts = 300
+	region.AddIndustry(mill)
+	region.AddIndustry(broke)
+	landlord := entities.NewPerson("Landlord", 0, 40)
+	region.AddPerson(landlord)
+
+	result := PayOverheads(region)
+
+	if mill.Money != 700 || broke.Money != 0 {
+		t.Errorf("Expected $700 and $0 left, got %.2f and %.2f", mill.Money, broke.Money)
+	}
+	if result.Unpaid["Broke"] != 200 {
+		t.Errorf("Expected $200 unpaid, got %.2f", result.Unpaid["Broke"])
+	}
+	if _, short := result.Unpaid["Mill"]; short {
+		t.Error("Expected Mill to cover its overheads")
+	}
+	if landlord.Money != 400 {
+		t.Errorf("Expected overheads paid out to people, got %.2f", landlord.Money)
+	}
+}
+
+func TestApplyFixedCost_SpreadsOverOutput(t *testing.T) {
+	industry := entities.CreateIndustry("Mill").UpdateLabor(2.0)
+	small := CalculateProduction(industry, 1.0, 100.0, 10.0)
+	large := CalculateProduction(industry, 2.0, 100.0, 10.0)
+
+	ApplyFixedCost(small, 1000)
+	ApplyFixedCost(large, 1000)
+
+	if small.CostPerUnit != 40 || large.CostPerUnit != 30 {
+		t.Errorf("Expected $40 and $30 per unit, got %.2f and %.2f", small.CostPerUnit, large.CostPerUnit)
+	}
+}
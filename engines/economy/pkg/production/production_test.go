@@ -46,6 +46,47 @@ func TestCalculateProduction_InsufficientLabor(t *testing.T) {
 	}
 }
 
+func TestApplySkill(t *testing.T) {
+	industry := entities.CreateIndustry("TestCorp").
+		UpdateLabor(2.0)
+
+	trained := entities.NewPerson("Trained", 0, 40.0)
+	trained.Skill = 2.0
+	workers := []*entities.Person{trained, entities.NewPerson("Untrained", 0, 40.0)}
+
+	result := CalculateProduction(industry, 2.0, 40.0, 10.0)
+	ApplySkill(industry, result, workers)
+
+	// Average skill 1.5: 40 units become 60 at the same labor cost
+	if result.UnitsProduced != 60.0 {
+		t.Errorf("Expected 60 units, got %.2f", result.UnitsProduced)
+	}
+	if result.LaborCost != 800.0 {
+		t.Errorf("Expected labor cost unchanged at 800, got %.2f", result.LaborCost)
+	}
+}
+
+func TestApplyCommute(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	region.Transport = &entities.TransportNetwork{HoursPerDistance: 2.0}
+	home := entities.NewZone("Home", 0, 0)
+	work := entities.NewZone("Work", 10, 0)
+
+	industry := entities.CreateIndustry("TestCorp").
+		UpdateLabor(1.0)
+	industry.Zone = work
+	commuter := entities.NewPerson("Commuter", 0, 40.0)
+	commuter.Zone = home
+
+	result := CalculateProduction(industry, 1.0, 40.0, 10.0)
+	ApplyCommute(region, industry, result, []*entities.Person{commuter}, 40.0)
+
+	// 20 of 40 hours lost on the road
+	if result.UnitsProduced != 20.0 {
+		t.Errorf("Expected 20 units, got %.2f", result.UnitsProduced)
+	}
+}
+
 func TestPayWorkers(t *testing.T) {
 	industry := entities.CreateIndustry("TestCorp").
 		SetInitialCapital(10000.0)
@@ -96,6 +137,34 @@ func TestPayWorkers_InsufficientFunds(t *testing.T) {
 	}
 }
 
+func TestSettleWages_PaysSharesWhenShort(t *testing.T) {
+	industry := entities.CreateIndustry("TestCorp").SetInitialCapital(300.0)
+	alice := entities.NewPerson("Alice", 0, 8.0)
+	bob := entities.NewPerson("Bob", 0, 8.0)
+
+	_, unpaid := SettleWages(industry, []*entities.Person{alice, bob}, []float32{40, 20}, 10.0)
+
+	// $600 owed, half of it paid
+	if alice.Money != 200 || bob.Money != 100 {
+		t.Errorf("Expected $200 and $100 paid, got %.2f and %.2f", alice.Money, bob.Money)
+	}
+	if unpaid != 300 || industry.Money != 0 {
+		t.Errorf("Expected $300 unpaid and nothing left, got %.2f unpaid and %.2f left", unpaid, industry.Money)
+	}
+}
+
+func TestUpfrontShare(t *testing.T) {
+	if share := UpfrontShare("", 4); share != 1 {
+		t.Errorf("Expected the whole bill upfront by default, got %.2f", share)
+	}
+	if share := UpfrontShare(PayAfterSales, 4); share != 0 {
+		t.Errorf("Expected nothing upfront after sales, got %.2f", share)
+	}
+	if share := UpfrontShare(PayWeekly, 4); share != 0.25 {
+		t.Errorf("Expected one week of four upfront, got %.2f", share)
+	}
+}
+
 func TestAllocateWorkers(t *testing.T) {
 	industry := entities.CreateIndustry("TestCorp").
 		UpdateLabor(5.0) // Needs 5 workers
@@ -169,3 +238,201 @@ func TestConsumeResources_Insufficient(t *testing.T) {
 		t.Error("Expected error for insufficient resources")
 	}
 }
+
+func TestOfferHours(t *testing.T) {
+	segment := entities.NewPopulationSegment("Workers", nil, 1)
+	segment.ReservationWage = 8
+	segment.TargetIncome = 1600
+	person := entities.NewPerson("Worker", 0, 160)
+	person.AddSegment(segment)
+
+	if hours := OfferHours(person, 5, 160, 0.5); hours != 0 {
+		t.Errorf("Expected no hours below the reservation wage, got %.0f", hours)
+	}
+	if hours := OfferHours(person, 20, 160, 0.5); hours != 80 {
+		t.Errorf("Expected 80 hours to reach the target at $20, got %.0f", hours)
+	}
+	if hours := OfferHours(person, 8, 160, 0.5); hours != 200 {
+		t.Errorf("Expected 200 hours (target at $8), got %.0f", hours)
+	}
+	if hours := OfferHours(person, 8, 160, 0.1); hours != 176 {
+		t.Errorf("Expected overtime capped at 176 hours, got %.0f", hours)
+	}
+	if hours := OfferHours(entities.NewPerson("Plain", 0, 160), 1, 160, 0.5); hours != 160 {
+		t.Errorf("Expected standard hours without preferences, got %.0f", hours)
+	}
+}
+
+func TestResetHours_TakesPledgedHours(t *testing.T) {
+	bartered := entities.NewPerson("Bartered", 0, 8)
+	bartered.PledgedHours = 8
+	plain := entities.NewPerson("Plain", 0, 8)
+
+	ResetHours([]*entities.Person{bartered, plain}, 20, 0)
+
+	if bartered.HoursLeft != 152 || bartered.PledgedHours != 0 {
+		t.Errorf("Expected 152 hours left and the pledge worked off, got %.0f / %.0f", bartered.HoursLeft, bartered.PledgedHours)
+	}
+	if plain.HoursLeft != 160 {
+		t.Errorf("Expected 160 hours without a pledge, got %.0f", plain.HoursLeft)
+	}
+}
+
+func TestApplyHours(t *testing.T) {
+	industry := entities.CreateIndustry("TestCorp").UpdateLabor(2.0)
+	result := CalculateProduction(industry, 2.0, 100.0, 10.0)
+
+	ApplyHours(industry, result, []float32{50, 100}, 100.0)
+
+	if result.UnitsProduced != 75 {
+		t.Errorf("Expected 75 units from three quarters of the hours, got %.2f", result.UnitsProduced)
+	}
+	if result.LaborCost != 1500 {
+		t.Errorf("Expected $1500 labor cost for 150 hours, got %.2f", result.LaborCost)
+	}
+}
+
+func TestApplyTarget(t *testing.T) {
+	rawMaterial := entities.NewResource("RawMaterial", "units")
+	industry := entities.CreateIndustry("TestCorp").
+		SetupIndustry(nil, []*entities.Resource{rawMaterial}, nil).
+		UpdateLabor(2.0)
+	result := CalculateProduction(industry, 2.0, 100.0, 10.0)
+
+	ApplyTarget(industry, result, 50)
+
+	if result.UnitsProduced != 50 {
+		t.Errorf("Expected output capped at the 50 unit target, got %.2f", result.UnitsProduced)
+	}
+	if result.ResourceCost != 50 {
+		t.Errorf("Expected inputs for 50 units only, got %.2f", result.ResourceCost)
+	}
+	if result.LaborCost != 2000 {
+		t.Errorf("Expected labor cost unchanged at $2000, got %.2f", result.LaborCost)
+	}
+}
+
+func TestAllocateHours(t *testing.T) {
+	workers := []*entities.Person{
+		entities.NewPerson("First", 0, 8),
+		entities.NewPerson("Second", 0, 8),
+		entities.NewPerson("Third", 0, 8),
+	}
+	hours := []float32{60, 80, 100}
+
+	hired, taken := AllocateHours(workers, hours, 100)
+
+	if len(hired) != 2 || taken[0] != 60 || taken[1] != 40 {
+		t.Errorf("Expected 60 and 40 hours from 2 workers, got %d workers with %v", len(hired), taken)
+	}
+
+	left, leftHours := RemainingHours(workers, hours, hired, taken)
+
+	if len(left) != 2 || left[0] != workers[1] || leftHours[0] != 40 || leftHours[1] != 100 {
+		t.Errorf("Expected the second worker first with 40 hours left, got %d workers with %v", len(left), leftHours)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	water := entities.NewResource("Water", "liters")
+	water.Quantity = 90
+	water.IsFree = true
+	farm := entities.CreateIndustry("Farm").SetupIndustry(nil, []*entities.Resource{water}, nil)
+	mill := entities.CreateIndustry("Mill").SetupIndustry(nil, []*entities.Resource{water}, nil)
+	mill.InputPriority = 1
+	claims := []Claim{
+		{Industry: farm, Units: 60, Bid: 3},
+		{Industry: mill, Units: 60, Bid: 2},
+	}
+
+	tests := []struct {
+		rule       string
+		farm, mill float32
+		owed       float32 // Farm's auction payment
+	}{
+		{AllocateProRata, 45, 45, 0},
+		{AllocatePriority, 30, 60, 0},
+		{AllocateAuction, 60, 30, 120}, // Clearing at the Mill's $2 bid
+	}
+	for _, tt := range tests {
+		reserved := Reserve(tt.rule, claims)
+		farmUnits, _ := reserved.Allowance(farm)
+		millUnits, _ := reserved.Allowance(mill)
+		if farmUnits != tt.farm || millUnits != tt.mill {
+			t.Errorf("%s: expected %.0f and %.0f units, got %.0f and %.0f", tt.rule, tt.farm, tt.mill, farmUnits, millUnits)
+		}
+		if reserved.Owed[farm.ID] != tt.owed {
+			t.Errorf("%s: expected Farm to owe $%.0f, got $%.2f", tt.rule, tt.owed, reserved.Owed[farm.ID])
+		}
+	}
+
+	// The clearing price is the auctioned input's cost in production
+	Reserve(AllocateAuction, claims)
+	if result := CalculateProduction(farm.UpdateLabor(1), 1, 10, 0); result.ResourceCost != 20 {
+		t.Errorf("Expected 10 liters at the $2 clearing price to cost $20, got $%.2f", result.ResourceCost)
+	}
+	// A bought resource is auctioned the same, with nobody to pay
+	water.IsFree = false
+	if reserved := Reserve(AllocateAuction, claims); water.Price != 2 || reserved.Owed[farm.ID] != 0 {
+		t.Errorf("Expected a $2 price and nothing owed, got $%.2f and $%.2f", water.Price, reserved.Owed[farm.ID])
+	}
+
+	// Inputs that cover everyone are not reserved, nor priced
+	water.Quantity = 200
+	if _, reserved := Reserve(AllocateAuction, claims).Allowance(farm); reserved || water.Price != 0 {
+		t.Errorf("Expected no reservation or price while the input covers every claim, got $%.2f", water.Price)
+	}
+}
+
+func TestRegenerate(t *testing.T) {
+	forest := entities.NewResource("Timber", "tons")
+	forest.Quantity = 100
+	forest.RegenerationRate = 10
+	if grown := Regenerate(forest); grown != 10 || forest.Quantity != 110 {
+		t.Errorf("Expected constant regrowth of 10 to 110, got %.2f to %.2f", grown, forest.Quantity)
+	}
+
+	// Logistic regrowth peaks at half the capacity and stops at it
+	forest.Capacity = 220
+	if grown := Regenerate(forest); grown != 10 {
+		t.Errorf("Expected the full 10 at half capacity, got %.2f", grown)
+	}
+	forest.Quantity = 220
+	if grown := Regenerate(forest); grown != 0 {
+		t.Errorf("Expected no regrowth at capacity, got %.2f", grown)
+	}
+
+	// Overharvest below the collapse share cuts the rate for good
+	forest.CollapseBelow = 0.2
+	forest.Quantity = 22
+	Regenerate(forest)
+	if forest.RegenerationRate != 5 {
+		t.Errorf("Expected the rate halved at half the collapse share, got %.2f", forest.RegenerationRate)
+	}
+	forest.Quantity = 110
+	if grown := Regenerate(forest); grown != 5 {
+		t.Errorf("Expected only 5 at half capacity after the collapse, got %.2f", grown)
+	}
+}
+
+func TestReserve_CountsLandHeld(t *testing.T) {
+	land := entities.NewResource("Land", "acres")
+	land.Quantity = 30
+	land.Kind = entities.StockResource
+	farm := entities.CreateIndustry("Farm").SetupIndustry(nil, []*entities.Resource{land}, nil)
+	mill := entities.CreateIndustry("Mill").SetupIndustry(nil, []*entities.Resource{land}, nil)
+	farm.Occupy(land, 20)
+
+	// The farm only needs 10 more acres; the mill needs 30 of the 10 free
+	reserved := Reserve(AllocateProRata, []Claim{{Industry: farm, Units: 30}, {Industry: mill, Units: 30}})
+	farmUnits, _ := reserved.Allowance(farm)
+	millUnits, _ := reserved.Allowance(mill)
+	if farmUnits != 22.5 || millUnits != 7.5 {
+		t.Errorf("Expected 22.5 and 7.5 acres, got %.2f and %.2f", farmUnits, millUnits)
+	}
+
+	// Producing on held land takes nothing more from the free acres
+	if _, err := ConsumeResources(farm, 20); err != nil || land.Quantity != 10 {
+		t.Errorf("Expected 10 acres still free, got %.2f (%v)", land.Quantity, err)
+	}
+}
@@ -10,9 +10,12 @@ type ResourceConsumption struct {
 	ResourceName string
 	Quantity     float32
 	Cost         float32
+	Occupied     bool // A stock resource held rather than used up
 }
 
-// ConsumeResources deducts input resources needed for production
+// ConsumeResources deducts input resources needed for production. Stock
+// resources are occupied instead: the industry takes what it needs beyond
+// what it already holds, and keeps it.
 func ConsumeResources(
 	industry *entities.Industry,
 	unitsToProdu float32,
@@ -25,30 +28,43 @@ func ConsumeResources(
 		// Simplified: 1 unit of input → 1 unit of output
 		needed := unitsToProdu
 
-		// Check availability
-		if input.Quantity < needed {
-			return nil, fmt.Errorf("insufficient %s: need %.2f, have %.2f",
-				input.Name, needed, input.Quantity)
-		}
+		if input.IsStock() {
+			extra := needed - industry.Holding(input)
+			if extra > input.Quantity {
+				return nil, fmt.Errorf("insufficient %s: need %.2f more, %.2f free",
+					input.Name, extra, input.Quantity)
+			}
+			industry.Occupy(input, extra)
+		} else {
+			// Check availability
+			if input.Quantity < needed {
+				return nil, fmt.Errorf("insufficient %s: need %.2f, have %.2f",
+					input.Name, needed, input.Quantity)
+			}
 
-		// Consume
-		success := input.Consume(needed)
-		if !success {
-			return nil, fmt.Errorf("failed to consume %s", input.Name)
+			// Consume
+			success := input.Consume(needed)
+			if !success {
+				return nil, fmt.Errorf("failed to consume %s", input.Name)
+			}
 		}
 
 		// Calculate cost
 		costPerUnit := float32(1.0) // Default cost
 
-		// Free resources have no cost
+		// Free resources have no cost, and auctioned ones their clearing price
 		if input.IsFree {
 			costPerUnit = 0
 		}
+		if input.Price > 0 {
+			costPerUnit = input.Price
+		}
 
 		consumptions = append(consumptions, ResourceConsumption{
 			ResourceName: input.Name,
 			Quantity:     needed,
 			Cost:         needed * costPerUnit,
+			Occupied:     input.IsStock(),
 		})
 	}
 
@@ -58,8 +74,30 @@ func ConsumeResources(
 // RegenerateResources adds regeneration to renewable resources
 func RegenerateResources(resources []*entities.Resource) {
 	for _, resource := range resources {
-		if resource.RegenerationRate > 0 {
-			resource.Add(resource.RegenerationRate)
-		}
+		Regenerate(resource)
+	}
+}
+
+// Regenerate grows a renewable resource back and returns the units added.
+// Without a carrying capacity it grows by its regeneration rate. With one
+// it grows logistically: fastest, at the regeneration rate, at half the
+// capacity, and slower the emptier or fuller it gets. A stock harvested
+// below its collapse share of the capacity loses that much of its
+// regeneration rate for good, so overharvest feeds on itself.
+func Regenerate(resource *entities.Resource) float32 {
+	if resource.RegenerationRate <= 0 {
+		return 0
+	}
+	if resource.Capacity <= 0 {
+		resource.Add(resource.RegenerationRate)
+		return resource.RegenerationRate
+	}
+
+	share := max(resource.Quantity, 0) / resource.Capacity
+	if threshold := resource.CollapseBelow; share < threshold {
+		resource.RegenerationRate *= share / threshold
 	}
+	grown := max(4*resource.RegenerationRate*share*(1-share), 0)
+	resource.Add(grown)
+	return grown
 }
@@ -0,0 +1,328 @@
+// Package repl drives an engine from typed commands, one tick or a few at a
+// time, so a scenario can be explored and poked at while it runs
+package repl
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"westex/engines/economy/pkg/core"
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/runs"
+	"westex/engines/economy/pkg/shocks"
+)
+
+// errQuit ends the session
+var errQuit = errors.New("quit")
+
+// Session is an engine being explored from the command line
+type Session struct {
+	Engine *core.Engine
+	Out    io.Writer
+}
+
+// NewSession starts a session on an engine, printing to out
+func NewSession(engine *core.Engine, out io.Writer) *Session {
+	return &Session{Engine: engine, Out: out}
+}
+
+// Run reads commands line by line until the input ends or a quit command,
+// printing errors and carrying on
+func (s *Session) Run(ctx context.Context, in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprintf(s.Out, "tick %d> ", s.Engine.CurrentTick)
+		if !scanner.Scan() {
+			fmt.Fprintln(s.Out)
+			return scanner.Err()
+		}
+		err := s.Exec(ctx, scanner.Text())
+		if errors.Is(err, errQuit) {
+			return nil
+		}
+		if err != nil {
+			fmt.Fprintf(s.Out, "error: %v\n", err)
+		}
+	}
+}
+
+// Exec runs one command
+func (s *Session) Exec(ctx context.Context, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return nil
+	}
+	args := fields[1:]
+	switch fields[0] {
+	case "help", "?":
+		fmt.Fprint(s.Out, help)
+		return nil
+	case "step":
+		return s.step(ctx, args)
+	case "show":
+		return s.show(args)
+	case "set":
+		return s.set(args)
+	case "params":
+		s.params()
+		return nil
+	case "changes":
+		for _, change := range s.Engine.Changes {
+			fmt.Fprintf(s.Out, "After tick %d: %s %g -> %g\n", change.Tick, change.Parameter, change.From, change.To)
+		}
+		return nil
+	case "inject":
+		return s.inject(args)
+	case "save":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: save FILE")
+		}
+		if err := runs.WriteJSON(args[0], s.Engine.Checkpoint()); err != nil {
+			return err
+		}
+		fmt.Fprintf(s.Out, "Saved tick %d to %s\n", s.Engine.CurrentTick, args[0])
+		return nil
+	case "quit", "exit":
+		return errQuit
+	}
+	return fmt.Errorf("unknown command %q, try help", fields[0])
+}
+
+const help = `Commands:
+  step [N]                         Run N ticks (default 1)
+  show                             The region at a glance
+  show industry|segment|problem NAME
+  show person ID
+  set PARAMETER VALUE              Change a parameter from the next tick on
+  params                           The parameters set can change and their values
+  changes                          The parameters changed so far
+  inject crop_failure [INDUSTRY] [SHARE]
+                                   Destroy a share of an industry's stock next tick
+                                   (default: every industry, half its stock)
+  inject drought [SHARE]           A crop failure in every industry
+  inject health [SEGMENT] COST     A medical bill for every member next tick
+  save FILE                        Write the state as a checkpoint JSON file
+  quit
+`
+
+// step runs ticks, printing a line for each
+func (s *Session) step(ctx context.Context, args []string) error {
+	ticks := 1
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("usage: step [N], N a positive number of ticks")
+		}
+		ticks = n
+	}
+	for i := 0; i < ticks; i++ {
+		if err := s.Engine.Step(ctx); err != nil {
+			return err
+		}
+		tick := s.Engine.LastTick
+		fmt.Fprintf(s.Out, "Tick %d: wealth $%.2f, produced %.1f units, %d unemployed, %d/%d satisfied\n",
+			tick.Tick, tick.TotalWealth, tick.Production.UnitsProduced, tick.Production.Unemployed,
+			tick.Market.PeopleSatisfied, tick.Market.People)
+	}
+	return nil
+}
+
+// show prints the region, or one of its industries, segments, problems or
+// people
+func (s *Session) show(args []string) error {
+	region := s.Engine.Region
+	if len(args) == 0 {
+		wealth := float32(0)
+		for _, person := range region.People {
+			wealth += person.Wealth()
+		}
+		fmt.Fprintf(s.Out, "%s at tick %d: %d people holding $%.2f, wage $%.2f/hour\n",
+			region.Name, s.Engine.CurrentTick, len(region.People), wealth, s.Engine.WagePerHour)
+		for _, industry := range region.Industries {
+			fmt.Fprintf(s.Out, "  %-24s $%.2f\n", industry.Name, industry.Money)
+		}
+		return nil
+	}
+
+	name := strings.Join(args[1:], " ")
+	switch args[0] {
+	case "industry":
+		industry := region.GetIndustry(name)
+		if industry == nil {
+			return fmt.Errorf("no industry named %q", name)
+		}
+		s.showIndustry(industry)
+	case "segment":
+		segment := region.GetPopulationSegment(name)
+		if segment == nil {
+			return fmt.Errorf("no segment named %q", name)
+		}
+		members, wealth := 0, float32(0)
+		for _, person := range region.People {
+			if person.HasSegment(segment) {
+				members++
+				wealth += person.Wealth()
+			}
+		}
+		fmt.Fprintf(s.Out, "%s: %d members holding $%.2f", segment.Name, members, wealth)
+		if members > 0 {
+			fmt.Fprintf(s.Out, " ($%.2f each)", wealth/float32(members))
+		}
+		fmt.Fprintln(s.Out)
+	case "problem":
+		problem := region.GetProblem(name)
+		if problem == nil {
+			return fmt.Errorf("no problem named %q", name)
+		}
+		fmt.Fprintf(s.Out, "%s: demand %.2f, severity %.2f\n", problem.Name, problem.Demand, problem.Severity)
+	case "person":
+		id, err := strconv.Atoi(name)
+		if err != nil {
+			return fmt.Errorf("usage: show person ID")
+		}
+		person := region.PersonByID(id)
+		if person == nil {
+			return fmt.Errorf("no person with ID %d", id)
+		}
+		segments := make([]string, len(person.Segments))
+		for i, segment := range person.Segments {
+			segments[i] = segment.Name
+		}
+		fmt.Fprintf(s.Out, "%s (%d): cash $%.2f, savings $%.2f, skill %.2f, segments %s\n",
+			person.Name, person.ID, person.Money, person.Savings, person.Skill, strings.Join(segments, ", "))
+	default:
+		return fmt.Errorf("usage: show [industry|segment|problem NAME | person ID]")
+	}
+	return nil
+}
+
+func (s *Session) showIndustry(industry *entities.Industry) {
+	fmt.Fprintf(s.Out, "%s: $%.2f", industry.Name, industry.Money)
+	if industry.Bankrupt {
+		fmt.Fprint(s.Out, ", bankrupt")
+	}
+	fmt.Fprintln(s.Out)
+	for _, product := range industry.OutputProducts {
+		fmt.Fprintf(s.Out, "  %-16s %.1f %s in stock\n", product.Name, product.Quantity, product.Unit)
+	}
+	if tick := s.Engine.LastTick; tick != nil {
+		for _, production := range tick.Production.Industries {
+			if production.Industry == industry.Name {
+				fmt.Fprintf(s.Out, "  Last tick: %.1f units by %d workers for $%.2f\n",
+					production.Units, production.Workers, production.Cost)
+			}
+		}
+	}
+}
+
+// set changes a parameter from the next tick on
+func (s *Session) set(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: set PARAMETER VALUE, see params")
+	}
+	value, err := strconv.ParseFloat(args[1], 32)
+	if err != nil {
+		return fmt.Errorf("%s is not a number", args[1])
+	}
+	from, _ := s.Engine.GetParameter(args[0])
+	if err := s.Engine.SetParameter(args[0], float32(value)); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.Out, "%s changed from %g to %g from tick %d on\n", args[0], from, float32(value), s.Engine.CurrentTick+1)
+	return nil
+}
+
+// params lists the parameters with their current values, or why this engine
+// has none, and the price controls in force
+func (s *Session) params() {
+	for _, parameter := range core.Parameters {
+		if strings.HasPrefix(parameter.Name, "price_") {
+			fmt.Fprintf(s.Out, "  %-22s %-12s %s\n", parameter.Name+".PRODUCT", "", parameter.Description)
+			continue
+		}
+		value := "-"
+		if current, err := s.Engine.GetParameter(parameter.Name); err == nil {
+			value = fmt.Sprintf("%g", current)
+		}
+		fmt.Fprintf(s.Out, "  %-22s %-12s %s\n", parameter.Name, value, parameter.Description)
+	}
+	if government := s.Engine.Government; government != nil {
+		products := make([]string, 0, len(government.PriceControls))
+		for product := range government.PriceControls {
+			products = append(products, product)
+		}
+		sort.Strings(products)
+		for _, product := range products {
+			control := government.PriceControls[product]
+			fmt.Fprintf(s.Out, "  %s: ceiling %g, floor %g\n", product, control.Ceiling, control.Floor)
+		}
+	}
+}
+
+// inject adds a shock that fires in the next tick
+func (s *Session) inject(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: inject crop_failure|drought|health ...")
+	}
+	region, next := s.Engine.Region, s.Engine.CurrentTick+1
+	kind, args := args[0], args[1:]
+
+	// The severity is the last argument when it is a number; the rest names
+	// the target
+	severity, hasSeverity := float32(0), false
+	if len(args) > 0 {
+		if value, err := strconv.ParseFloat(args[len(args)-1], 32); err == nil {
+			severity, hasSeverity = float32(value), true
+			args = args[:len(args)-1]
+		}
+	}
+	target := strings.Join(args, " ")
+
+	switch kind {
+	case "crop_failure", "drought":
+		if !hasSeverity {
+			severity = 0.5
+		}
+		if severity <= 0 || severity > 1 {
+			return fmt.Errorf("a crop failure destroys a share of stock between 0 and 1, got %g", severity)
+		}
+		industries := region.Industries
+		if kind == "crop_failure" && target != "" {
+			industry := region.GetIndustry(target)
+			if industry == nil {
+				return fmt.Errorf("no industry named %q", target)
+			}
+			industries = []*entities.Industry{industry}
+		}
+		for _, industry := range industries {
+			s.Engine.Shocks = append(s.Engine.Shocks, &shocks.Shock{
+				Type: shocks.CropFailure, Tick: next, Industry: industry, Severity: severity,
+			})
+		}
+		fmt.Fprintf(s.Out, "Crop failure in %d industries at tick %d, destroying %.0f%% of stock\n",
+			len(industries), next, severity*100)
+	case "health":
+		if !hasSeverity || severity <= 0 {
+			return fmt.Errorf("usage: inject health [SEGMENT] COST, COST a positive amount")
+		}
+		shock := &shocks.Shock{Type: shocks.HealthEvent, Tick: next, Severity: severity}
+		who := "everyone"
+		if target != "" {
+			if shock.Segment = region.GetPopulationSegment(target); shock.Segment == nil {
+				return fmt.Errorf("no segment named %q", target)
+			}
+			who = target
+		}
+		s.Engine.Shocks = append(s.Engine.Shocks, shock)
+		fmt.Fprintf(s.Out, "Health event for %s at tick %d, costing $%.2f each\n", who, next, severity)
+	default:
+		return fmt.Errorf("unknown event %q, expected crop_failure, drought or health", kind)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package repl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"westex/engines/economy/pkg/core"
+	"westex/engines/economy/pkg/scenarios"
+	"westex/engines/economy/pkg/shocks"
+)
+
+func TestSession_Commands(t *testing.T) {
+	engine := core.CreateNewEngine(scenarios.Standard(50))
+	engine.Logger.SetEnabled(false)
+	var out strings.Builder
+	session := NewSession(engine, &out)
+	snapshot := filepath.Join(t.TempDir(), "snapshot.json")
+
+	script := strings.Join([]string{
+		"step 2",
+		"show industry Agriculture",
+		"set wage 12",
+		"inject crop_failure Agriculture 0.4",
+		"inject health Workers 5",
+		"step",
+		"save " + snapshot,
+		"quit",
+		"step", // Never reached
+	}, "\n")
+	if err := session.Run(context.Background(), strings.NewReader(script)); err != nil {
+		t.Fatalf("Session failed: %v", err)
+	}
+
+	if engine.CurrentTick != 3 {
+		t.Errorf("Expected 3 ticks run before quitting, got %d", engine.CurrentTick)
+	}
+	if engine.WagePerHour != 12 || len(engine.Changes) != 1 || engine.Changes[0].Tick != 2 {
+		t.Errorf("Expected the wage set to 12 after tick 2, got %.2f and changes %+v", engine.WagePerHour, engine.Changes)
+	}
+	if len(engine.Shocks) != 2 || engine.Shocks[0].Type != shocks.CropFailure || engine.Shocks[0].Tick != 3 ||
+		engine.Shocks[1].Segment == nil || engine.Shocks[1].Severity != 5 {
+		t.Errorf("Expected a crop failure and a health event at tick 3, got %+v", engine.Shocks)
+	}
+	if _, err := os.Stat(snapshot); err != nil {
+		t.Errorf("Expected the snapshot saved: %v", err)
+	}
+	for _, want := range []string{"Tick 1: wealth", "Agriculture: $", "wage changed from 10 to 12 from tick 3 on", "Saved tick 3"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestSession_Errors(t *testing.T) {
+	engine := core.CreateNewEngine(scenarios.Standard(50))
+	engine.Logger.SetEnabled(false)
+	session := NewSession(engine, &strings.Builder{})
+
+	for _, line := range []string{
+		"fly",
+		"step -1",
+		"show industry Nowhere",
+		"set wage -3",
+		"set interest 0.1",
+		"set wage many",
+		"set wage NaN",
+		"set wage Inf",
+		"set sales_tax 0.1", // The standard scenario has no government
+		"inject crop_failure Nowhere",
+		"inject drought 2",
+		"inject health Workers",
+		"inject locusts",
+	} {
+		if err := session.Exec(context.Background(), line); err == nil {
+			t.Errorf("Expected %q to fail", line)
+		}
+	}
+	if engine.CurrentTick != 0 || engine.WagePerHour == -3 || len(engine.Shocks) != 0 {
+		t.Error("Expected failed commands to leave the engine alone")
+	}
+}
@@ -0,0 +1,157 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"westex/engines/economy/pkg/core"
+)
+
+// Image formats charts can be saved in
+const (
+	FormatSVG = "svg"
+	FormatPNG = "png"
+)
+
+// Chart layout, in pixels
+const (
+	chartWidth  = 720
+	chartHeight = 260
+	plotLeft    = 70 // Room for the value labels
+	plotRight   = 150
+	plotTop     = 30
+	plotBottom  = 30
+	gridLines   = 4
+)
+
+// palette colors the series of a chart in turn
+var palette = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#7f7f7f"}
+
+// chart is a line chart of one or more series over the run's ticks
+type chart struct {
+	Title  string
+	File   string // Name of the chart's image, without the extension
+	Ticks  []int
+	Series []series
+	Format func(float64) string // Labels values on the axis
+}
+
+// series is one line; NaN values are gaps
+type series struct {
+	Name   string
+	Values []float64
+}
+
+// bounds returns the range of values to plot, always including zero
+func (c *chart) bounds() (low, high float64) {
+	for _, s := range c.Series {
+		for _, value := range s.Values {
+			if !math.IsNaN(value) {
+				low, high = min(low, value), max(high, value)
+			}
+		}
+	}
+	if high == low {
+		high = low + 1
+	}
+	return low, high
+}
+
+// svg draws the chart as an inline SVG element
+func (c *chart) svg() template.HTML {
+	low, high := c.bounds()
+	plotWidth := float64(chartWidth - plotLeft - plotRight)
+	plotHeight := float64(chartHeight - plotTop - plotBottom)
+	x := func(i int) float64 {
+		if len(c.Ticks) < 2 {
+			return plotLeft + plotWidth/2
+		}
+		return plotLeft + plotWidth*float64(i)/float64(len(c.Ticks)-1)
+	}
+	y := func(value float64) float64 {
+		return plotTop + plotHeight*(high-value)/(high-low)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		chartWidth, chartHeight, chartWidth, chartHeight)
+	fmt.Fprintf(&b, `<text x="%d" y="18" style="font-size:14px;font-weight:bold">%s</text>`, plotLeft, html.EscapeString(c.Title))
+	for i := 0; i <= gridLines; i++ {
+		value := low + (high-low)*float64(i)/gridLines
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#ddd"/>`, plotLeft, y(value), plotLeft+plotWidth, y(value))
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end">%s</text>`, plotLeft-6, y(value)+4, html.EscapeString(c.Format(value)))
+	}
+	if len(c.Ticks) > 0 {
+		bottom := chartHeight - plotBottom + 16
+		fmt.Fprintf(&b, `<text x="%d" y="%d">tick %d</text>`, plotLeft, bottom, c.Ticks[0])
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="end">tick %d</text>`, plotLeft+plotWidth, bottom, c.Ticks[len(c.Ticks)-1])
+	}
+
+	for i, s := range c.Series {
+		color := palette[i%len(palette)]
+		var path strings.Builder
+		move := true
+		for j, value := range s.Values {
+			if math.IsNaN(value) {
+				move = true
+				continue
+			}
+			command := "L"
+			if move {
+				command, move = "M", false
+			}
+			fmt.Fprintf(&path, "%s%.1f %.1f ", command, x(j), y(value))
+		}
+		if path.Len() > 0 {
+			fmt.Fprintf(&b, `<path d="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.TrimSpace(path.String()), color)
+		}
+		legend := plotTop + 16*i
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%d" width="10" height="10" fill="%s"/>`, plotLeft+plotWidth+12, legend, color)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d">%s</text>`, plotLeft+plotWidth+28, legend+9, html.EscapeString(s.Name))
+	}
+	if len(c.Series) == 0 {
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">No data</text>`, plotLeft+plotWidth/2, plotTop+plotHeight/2)
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// SaveCharts draws the key time series of a run's history, the same charts
+// as the HTML report, into a directory as one SVG or PNG image each
+func SaveCharts(dir string, history *core.History, format string) error {
+	if format != FormatSVG && format != FormatPNG {
+		return fmt.Errorf("unknown chart format %q, expected %s or %s", format, FormatSVG, FormatPNG)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create chart directory: %w", err)
+	}
+	for _, c := range charts(history) {
+		if err := c.save(filepath.Join(dir, c.File+"."+format), format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *chart) save(path, format string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create chart: %w", err)
+	}
+	defer file.Close()
+	if format == FormatPNG {
+		err = png.Encode(file, c.png())
+	} else {
+		_, err = file.WriteString(string(c.svg()))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return file.Close()
+}
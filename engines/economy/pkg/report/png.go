@@ -0,0 +1,77 @@
+package report
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strconv"
+)
+
+// png draws the chart as an image. There is no font to draw text with, so
+// the image has the grid, the lines and a color swatch per series in legend
+// order but no labels; the SVG has them.
+func (c *chart) png() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	low, high := c.bounds()
+	plotWidth := float64(chartWidth - plotLeft - plotRight)
+	plotHeight := float64(chartHeight - plotTop - plotBottom)
+	x := func(i int) float64 {
+		if len(c.Ticks) < 2 {
+			return plotLeft + plotWidth/2
+		}
+		return plotLeft + plotWidth*float64(i)/float64(len(c.Ticks)-1)
+	}
+	y := func(value float64) float64 {
+		return plotTop + plotHeight*(high-value)/(high-low)
+	}
+
+	grid := color.RGBA{0xdd, 0xdd, 0xdd, 0xff}
+	for i := 0; i <= gridLines; i++ {
+		row := y(low + (high-low)*float64(i)/gridLines)
+		drawLine(img, plotLeft, row, plotLeft+plotWidth, row, grid, 1)
+	}
+
+	for i, s := range c.Series {
+		stroke := parseColor(palette[i%len(palette)])
+		last := -1
+		for j, value := range s.Values {
+			if math.IsNaN(value) {
+				last = -1
+				continue
+			}
+			if last >= 0 {
+				drawLine(img, x(last), y(s.Values[last]), x(j), y(value), stroke, 2)
+			} else {
+				drawLine(img, x(j), y(value), x(j), y(value), stroke, 2)
+			}
+			last = j
+		}
+		swatch := image.Rect(int(plotLeft+plotWidth)+12, plotTop+16*i, int(plotLeft+plotWidth)+22, plotTop+16*i+10)
+		draw.Draw(img, swatch, image.NewUniform(stroke), image.Point{}, draw.Src)
+	}
+	return img
+}
+
+// drawLine draws a straight line of the given width, in pixels, between two
+// points by stepping along its longer side
+func drawLine(img *image.RGBA, x0, y0, x1, y1 float64, stroke color.Color, width int) {
+	steps := int(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))) + 1
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		px, py := int(math.Round(x0+(x1-x0)*t)), int(math.Round(y0+(y1-y0)*t))
+		for dx := 0; dx < width; dx++ {
+			for dy := 0; dy < width; dy++ {
+				img.Set(px+dx-width/2, py+dy-width/2, stroke)
+			}
+		}
+	}
+}
+
+// parseColor reads a #rrggbb color from the palette
+func parseColor(hex string) color.RGBA {
+	value, _ := strconv.ParseUint(hex[1:], 16, 32)
+	return color.RGBA{uint8(value >> 16), uint8(value >> 8), uint8(value), 0xff}
+}
@@ -0,0 +1,210 @@
+// Package report renders an exported run as a single standalone HTML page,
+// with its charts drawn as inline SVG so the page can be shared as one file
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"westex/engines/economy/pkg/core"
+	"westex/engines/economy/pkg/runs"
+)
+
+// Run is an exported run directory read back for reporting
+type Run struct {
+	Dir      string
+	Manifest *runs.Manifest
+	Results  *core.Results
+	History  *core.History
+}
+
+// Load reads the manifest, results and tick history of a run directory
+func Load(dir string) (*Run, error) {
+	run := &Run{Dir: dir}
+	if err := readJSON(filepath.Join(dir, runs.ManifestFile), &run.Manifest); err != nil {
+		return nil, err
+	}
+	if err := readJSON(filepath.Join(dir, runs.ResultsFile), &run.Results); err != nil {
+		return nil, err
+	}
+	if err := readJSON(filepath.Join(dir, runs.HistoryFile), &run.History); err != nil {
+		return nil, fmt.Errorf("%w; export the run again with -out to record one", err)
+	}
+	return run, nil
+}
+
+func readJSON(path string, value any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// WriteFile writes the run's report to an HTML file
+func WriteFile(path string, run *Run) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer file.Close()
+	if err := Write(file, run); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// Write renders the run's report: a summary of the run and charts of wealth,
+// prices, employment and needs satisfaction over time
+func Write(w io.Writer, run *Run) error {
+	page := struct {
+		*Run
+		Summary [][2]string
+		Charts  []template.HTML
+	}{Run: run, Summary: summary(run)}
+	for _, chart := range charts(run.History) {
+		page.Charts = append(page.Charts, chart.svg())
+	}
+	if err := pageTemplate.Execute(w, page); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// summary lists the run's settings and outcome as label and value pairs
+func summary(run *Run) [][2]string {
+	m, r := run.Manifest, run.Results
+	rows := [][2]string{
+		{"Run", m.ID},
+		{"Config", m.ConfigPath},
+		{"Seed", fmt.Sprint(m.Seed)},
+		{"Ticks", fmt.Sprint(r.Ticks)},
+	}
+	if m.GitRevision != "" {
+		rows = append(rows, [2]string{"Revision", m.GitRevision})
+	}
+	if m.Interrupted {
+		rows = append(rows, [2]string{"Interrupted", fmt.Sprintf("after tick %d", m.Ticks)})
+	}
+	rows = append(rows,
+		[2]string{"Starting wealth", money(float64(r.StartWealth))},
+		[2]string{"Final wealth", money(float64(r.TotalWealth))},
+		[2]string{"People's wealth", money(float64(r.PeopleWealth))},
+	)
+	if r.Welfare != 0 {
+		rows = append(rows, [2]string{"Welfare", fmt.Sprintf("%.3f", r.Welfare)})
+	}
+	if r.ConvergedAt > 0 {
+		rows = append(rows, [2]string{"Converged at tick", fmt.Sprint(r.ConvergedAt)})
+	}
+	if len(r.Failures) > 0 {
+		rows = append(rows, [2]string{"Failed assertions", fmt.Sprint(len(r.Failures))})
+	}
+	return rows
+}
+
+// charts lays out the report's charts from the tick history
+func charts(history *core.History) []*chart {
+	wealth := &chart{Title: "Wealth", File: "wealth", Format: money}
+	total := series{Name: "Total"}
+	people := series{Name: "People"}
+	industries := series{Name: "Industries"}
+	employment := &chart{Title: "Employment", File: "employment", Format: percent}
+	employed := series{Name: "Employed"}
+	satisfaction := &chart{Title: "Needs satisfaction", File: "satisfaction", Format: percent}
+	met := series{Name: "Needs met"}
+	prices := &chart{Title: "Prices", File: "prices", Format: money}
+
+	var names []string
+	for _, tick := range history.Ticks {
+		for name := range tick.Prices {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	sold := make([]series, len(names))
+	for i, name := range names {
+		sold[i].Name = name
+	}
+
+	for _, tick := range history.Ticks {
+		for _, c := range []*chart{wealth, employment, satisfaction, prices} {
+			c.Ticks = append(c.Ticks, tick.Tick)
+		}
+		total.Values = append(total.Values, float64(tick.TotalWealth))
+		people.Values = append(people.Values, float64(tick.PeopleWealth))
+		industries.Values = append(industries.Values, float64(tick.IndustryMoney))
+		employed.Values = append(employed.Values, float64(tick.Employed))
+		share := math.NaN()
+		if tick.Satisfaction != nil {
+			share = float64(*tick.Satisfaction)
+		}
+		met.Values = append(met.Values, share)
+		for i, name := range names {
+			price, ok := tick.Prices[name]
+			value := math.NaN()
+			if ok {
+				value = float64(price)
+			}
+			sold[i].Values = append(sold[i].Values, value)
+		}
+	}
+
+	wealth.Series = []series{total, people, industries}
+	employment.Series = []series{employed}
+	satisfaction.Series = []series{met}
+	prices.Series = sold
+	return []*chart{wealth, prices, employment, satisfaction}
+}
+
+// money formats an amount of money for axis labels and the summary
+func money(value float64) string {
+	switch abs := math.Abs(value); {
+	case abs >= 1e6:
+		return fmt.Sprintf("$%.2fM", value/1e6)
+	case abs >= 1e4:
+		return fmt.Sprintf("$%.1fk", value/1e3)
+	}
+	return fmt.Sprintf("$%.2f", value)
+}
+
+// percent formats a share between 0 and 1
+func percent(value float64) string {
+	return fmt.Sprintf("%.0f%%", value*100)
+}
+
+var pageTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Results.Region}} run {{.Manifest.ID}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em auto; max-width: 760px; color: #222; }
+h1 { font-size: 1.5em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+td { padding: 0.2em 1.5em 0.2em 0; }
+td:first-child { color: #666; }
+svg { display: block; margin-bottom: 2em; }
+svg text { font-size: 12px; fill: #444; }
+</style>
+</head>
+<body>
+<h1>{{.Results.Region}}</h1>
+<table>
+{{range .Summary}}<tr><td>{{index . 0}}</td><td>{{index . 1}}</td></tr>
+{{end}}</table>
+{{range .Charts}}{{.}}
+{{end}}</body>
+</html>
+`))
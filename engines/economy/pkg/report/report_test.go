@@ -0,0 +1,130 @@
+package report
+
+import (
+	"context"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"westex/engines/economy/pkg/core"
+	"westex/engines/economy/pkg/entities"
+	"westex/engines/economy/pkg/runs"
+)
+
+func TestWrite_RunWithHistory(t *testing.T) {
+	region := entities.NewRegion("Test<Region>")
+	problem := entities.NewProblem("Food", "Need food", 0.9)
+	problem.UpdateDemand(1.0)
+	region.AddProblem(problem)
+	resource := entities.NewResource("RawMaterial", "units")
+	resource.Quantity = 1000
+	region.AddResource(resource)
+	farm := entities.CreateIndustry("Farm").
+		SetupIndustry([]*entities.Problem{problem}, []*entities.Resource{resource}, []*entities.Resource{entities.NewResource("Food", "kg")}).
+		UpdateLabor(2.0).
+		SetInitialCapital(10000.0)
+	region.AddIndustry(farm)
+	segment := entities.NewPopulationSegment("Workers", []*entities.Problem{problem}, 2)
+	region.AddPopulationSegment(segment)
+	for i := 0; i < 2; i++ {
+		person := entities.NewPerson("Worker", 100, 40.0)
+		person.AddSegment(segment)
+		region.AddPerson(person)
+	}
+
+	engine := core.CreateNewEngine(region)
+	engine.Logger.SetEnabled(false)
+	engine.OnProgress = func(core.Progress) {}
+	engine.TrackHistory()
+	if err := engine.Run(context.Background(), 3); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(engine.History.Ticks) != 3 {
+		t.Fatalf("Expected 3 ticks of history, got %d", len(engine.History.Ticks))
+	}
+	last := engine.History.Ticks[2]
+	if last.People != 2 || last.Employed <= 0 || last.Satisfaction == nil {
+		t.Errorf("Expected employment and satisfaction in the last tick, got %+v", last)
+	}
+
+	root := t.TempDir()
+	manifest := runs.NewManifest("test.yaml", []byte("test"), 1, nil)
+	if err := manifest.Save(root, engine.Results()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(manifest.Dir(root)); err == nil || !strings.Contains(err.Error(), runs.HistoryFile) {
+		t.Errorf("Expected a run without history to fail to load, got %v", err)
+	}
+	if err := manifest.WriteFile(root, runs.HistoryFile, engine.History); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := Load(manifest.Dir(root))
+	if err != nil {
+		t.Fatalf("Failed to load run: %v", err)
+	}
+	var page strings.Builder
+	if err := Write(&page, run); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	html := page.String()
+	for _, want := range []string{"Test&lt;Region&gt;", manifest.ID, ">Wealth<", ">Prices<", ">Farm<", ">Employment<", ">Needs satisfaction<"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected the report to contain %q", want)
+		}
+	}
+	if strings.Contains(html, "<Region>") {
+		t.Error("Expected the region's name to be escaped")
+	}
+	if got := strings.Count(html, "<svg"); got != 4 {
+		t.Errorf("Expected 4 charts, got %d", got)
+	}
+}
+
+func TestChart_GapsSplitTheLine(t *testing.T) {
+	c := &chart{Title: "Prices", Ticks: []int{1, 2, 3, 4}, Format: money,
+		Series: []series{{Name: "Farm", Values: []float64{1, 2, math.NaN(), 4}}}}
+	svg := string(c.svg())
+	start := strings.Index(svg, `<path d="`) + len(`<path d="`)
+	path := svg[start : start+strings.Index(svg[start:], `"`)]
+	if strings.Count(path, "M") != 2 || strings.Count(path, "L") != 1 {
+		t.Errorf("Expected two segments around the missing tick, got %q", path)
+	}
+}
+
+func TestSaveCharts(t *testing.T) {
+	satisfaction := float32(0.5)
+	history := &core.History{Ticks: []core.HistoryTick{
+		{Tick: 1, TotalWealth: 100, PeopleWealth: 60, IndustryMoney: 40, People: 2, Employed: 0.5},
+		{Tick: 2, TotalWealth: 110, PeopleWealth: 70, IndustryMoney: 40, People: 2, Employed: 1,
+			Satisfaction: &satisfaction, Prices: map[string]float32{"Farm": 5}},
+	}}
+	dir := t.TempDir()
+	if err := SaveCharts(dir, history, "gif"); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+
+	for _, format := range []string{FormatSVG, FormatPNG} {
+		if err := SaveCharts(dir, history, format); err != nil {
+			t.Fatalf("Failed to save %s charts: %v", format, err)
+		}
+	}
+	for _, name := range []string{"wealth", "prices", "employment", "satisfaction"} {
+		svg, err := os.ReadFile(filepath.Join(dir, name+".svg"))
+		if err != nil || !strings.HasPrefix(string(svg), "<svg") {
+			t.Errorf("Expected %s.svg to hold an SVG, got %v", name, err)
+		}
+		file, err := os.Open(filepath.Join(dir, name+".png"))
+		if err != nil {
+			t.Fatalf("Expected %s.png: %v", name, err)
+		}
+		img, err := png.Decode(file)
+		file.Close()
+		if err != nil || img.Bounds().Dx() != chartWidth {
+			t.Errorf("Expected %s.png to decode as a %dpx wide image, got %v", name, chartWidth, err)
+		}
+	}
+}
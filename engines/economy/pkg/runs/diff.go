@@ -0,0 +1,130 @@
+package runs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Difference is a value that changed between a golden run's results and a
+// new run's
+type Difference struct {
+	Path   string // Dotted path to the value, such as industry_money.Farm
+	Golden string // "" when the golden results lack it
+	Got    string // "" when the new results lack it
+}
+
+func (d Difference) String() string {
+	switch {
+	case d.Golden == "":
+		return fmt.Sprintf("%s: new, %s", d.Path, d.Got)
+	case d.Got == "":
+		return fmt.Sprintf("%s: missing, was %s", d.Path, d.Golden)
+	}
+	return fmt.Sprintf("%s: %s, was %s", d.Path, d.Got, d.Golden)
+}
+
+// Tolerances bound how far numbers may drift before they count as changed.
+// A number may move by its tolerance times the larger of the two values, or
+// times 1 for values under 1.
+type Tolerances struct {
+	Default float64
+	Fields  map[string]float64 // By path prefix; the longest match wins
+}
+
+// forPath returns the tolerance for a value's path
+func (t Tolerances) forPath(path string) float64 {
+	tolerance, longest := t.Default, -1
+	for prefix, value := range t.Fields {
+		if (path == prefix || strings.HasPrefix(path, prefix+".")) && len(prefix) > longest {
+			tolerance, longest = value, len(prefix)
+		}
+	}
+	return tolerance
+}
+
+// Diff compares a golden run's results with a new run's, both as JSON, and
+// returns the values that differ beyond the tolerances, in path order
+func Diff(golden, got []byte, tolerances Tolerances) ([]Difference, error) {
+	var want, have any
+	if err := json.Unmarshal(golden, &want); err != nil {
+		return nil, fmt.Errorf("failed to parse golden results: %w", err)
+	}
+	if err := json.Unmarshal(got, &have); err != nil {
+		return nil, fmt.Errorf("failed to parse results: %w", err)
+	}
+
+	var differences []Difference
+	diffValue("", want, have, tolerances, &differences)
+	sort.Slice(differences, func(i, j int) bool { return differences[i].Path < differences[j].Path })
+	return differences, nil
+}
+
+// diffValue compares two decoded JSON values at a path
+func diffValue(path string, want, have any, tolerances Tolerances, differences *[]Difference) {
+	// Results leave out empty numbers, so a missing number is 0
+	if _, ok := have.(float64); ok && want == nil {
+		want = 0.0
+	}
+	if _, ok := want.(float64); ok && have == nil {
+		have = 0.0
+	}
+
+	switch w := want.(type) {
+	case map[string]any:
+		if h, ok := have.(map[string]any); ok {
+			for key, value := range w {
+				diffValue(join(path, key), value, h[key], tolerances, differences)
+			}
+			for key, value := range h {
+				if _, ok := w[key]; !ok {
+					diffValue(join(path, key), nil, value, tolerances, differences)
+				}
+			}
+			return
+		}
+	case []any:
+		if h, ok := have.([]any); ok && len(h) == len(w) {
+			for i := range w {
+				diffValue(join(path, fmt.Sprint(i)), w[i], h[i], tolerances, differences)
+			}
+			return
+		}
+	case float64:
+		if h, ok := have.(float64); ok {
+			if math.Abs(w-h) <= tolerances.forPath(path)*math.Max(1, math.Max(math.Abs(w), math.Abs(h))) {
+				return
+			}
+		}
+	default:
+		if want == have {
+			return
+		}
+	}
+	*differences = append(*differences, Difference{Path: path, Golden: describe(want), Got: describe(have)})
+}
+
+// join extends a dotted path with a key
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// describe prints a decoded JSON value for a difference ("" when absent)
+func describe(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case map[string]any:
+		return fmt.Sprintf("%d fields", len(v))
+	case []any:
+		return fmt.Sprintf("%d items", len(v))
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,33 @@
+package runs
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	golden := []byte(`{"ticks": 10, "total_wealth": 1000, "treasury": 5, "region": "Test", "window": {"ticks": 5},
+		"industry_money": {"Farm": 200, "Mill": 300}, "last_tick": {"industries": [{"units": 4}]}}`)
+	got := []byte(`{"ticks": 10, "total_wealth": 1000.05, "region": "Test",
+		"industry_money": {"Farm": 210, "Bakery": 1}, "last_tick": {"industries": [{"units": 4}]}}`)
+
+	differences, err := Diff(golden, got, Tolerances{Default: 1e-4, Fields: map[string]float64{"industry_money.Farm": 0.1}})
+	if err != nil {
+		t.Fatalf("Expected the results to parse, got %v", err)
+	}
+	want := []string{
+		"industry_money.Bakery: 1, was 0", // Missing numbers count as 0
+		"industry_money.Mill: 0, was 300",
+		"treasury: 0, was 5",
+		"window: missing, was 1 fields",
+	}
+	if len(differences) != len(want) {
+		t.Fatalf("Expected %d differences, got %v", len(want), differences)
+	}
+	for i, difference := range differences {
+		if difference.String() != want[i] {
+			t.Errorf("Expected %q, got %q", want[i], difference.String())
+		}
+	}
+
+	if _, err := Diff([]byte("{"), got, Tolerances{}); err == nil {
+		t.Error("Expected error for invalid golden results")
+	}
+}
@@ -0,0 +1,159 @@
+package runs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// File names inside a run directory
+const (
+	ManifestFile     = "manifest.json"
+	ResultsFile      = "results.json"
+	CheckpointFile   = "checkpoint.json"
+	TrajectoriesFile = "trajectories.json"
+	CohortsFile      = "cohorts.csv"
+	MatricesFile     = "matrices.json"
+	HistoryFile      = "history.json"
+)
+
+// Manifest records everything needed to reproduce a run
+type Manifest struct {
+	ID          string            `json:"id"`
+	ConfigPath  string            `json:"config_path"`
+	ConfigHash  string            `json:"config_hash"` // SHA-256 of the config file contents
+	Seed        uint64            `json:"seed"`
+	GitRevision string            `json:"git_revision,omitempty"`
+	StartTime   time.Time         `json:"start_time"`
+	EndTime     time.Time         `json:"end_time,omitempty"`
+	Ticks       int               `json:"ticks"`
+	Interrupted bool              `json:"interrupted,omitempty"` // Stopped early; Ticks is the last completed tick
+	Overrides   map[string]string `json:"overrides,omitempty"`   // Command-line values that replaced config values
+	Changes     []ParameterChange `json:"changes,omitempty"`     // Settings changed between ticks, in order
+}
+
+// ParameterChange is a setting changed partway through a run, such as from
+// the REPL
+type ParameterChange struct {
+	Tick      int     `json:"tick"` // The last tick run with the old value
+	Parameter string  `json:"parameter"`
+	From      float32 `json:"from"`
+	To        float32 `json:"to"`
+}
+
+// NewManifest starts a manifest for a run of the given config file
+func NewManifest(configPath string, configData []byte, seed uint64, overrides map[string]string) *Manifest {
+	sum := sha256.Sum256(configData)
+	hash := hex.EncodeToString(sum[:])
+	start := time.Now()
+
+	return &Manifest{
+		ID:          fmt.Sprintf("%s-%s", start.Format("20060102-150405"), hash[:8]),
+		ConfigPath:  configPath,
+		ConfigHash:  hash,
+		Seed:        seed,
+		GitRevision: GitRevision(),
+		StartTime:   start,
+		Overrides:   overrides,
+	}
+}
+
+// GitRevision returns the current commit of the working directory, or an
+// empty string outside a git checkout
+func GitRevision() string {
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// Dir returns the directory the run's files are exported to
+func (m *Manifest) Dir(root string) string {
+	return filepath.Join(root, m.ID)
+}
+
+// Save writes the manifest and the run's results into its run directory
+func (m *Manifest) Save(root string, results any) error {
+	dir := m.Dir(root)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create run directory: %w", err)
+	}
+	if err := writeJSON(filepath.Join(dir, ManifestFile), m); err != nil {
+		return err
+	}
+	if results != nil {
+		if err := writeJSON(filepath.Join(dir, ResultsFile), results); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFile writes an extra JSON file, such as a checkpoint, into the run
+// directory
+func (m *Manifest) WriteFile(root, name string, value any) error {
+	dir := m.Dir(root)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create run directory: %w", err)
+	}
+	return writeJSON(m.Path(root, name), value)
+}
+
+// Path returns the path of a file inside the run directory
+func (m *Manifest) Path(root, name string) string {
+	return filepath.Join(m.Dir(root), name)
+}
+
+// WriteJSON writes a value as indented JSON to a path outside any run directory
+func WriteJSON(path string, value any) error {
+	return writeJSON(path, value)
+}
+
+// List returns the manifests of every run under root, oldest first
+func List(root string) ([]*Manifest, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read runs directory: %w", err)
+	}
+
+	manifests := make([]*Manifest, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, entry.Name(), ManifestFile))
+		if err != nil {
+			continue // Not a run directory
+		}
+		var manifest Manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest of %s: %w", entry.Name(), err)
+		}
+		manifests = append(manifests, &manifest)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].StartTime.Before(manifests[j].StartTime)
+	})
+	return manifests, nil
+}
+
+// writeJSON writes a value as indented JSON
+func writeJSON(path string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
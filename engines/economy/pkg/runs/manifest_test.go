@@ -0,0 +1,48 @@
+package runs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestManifest_SaveAndList(t *testing.T) {
+	root := t.TempDir()
+	config := []byte("region:\n  name: Test\n")
+
+	first := NewManifest("a.yaml", config, 42, map[string]string{"ticks": "5"})
+	first.Ticks = 5
+	if err := first.Save(root, map[string]float32{"total_wealth": 100}); err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	second := NewManifest("b.yaml", []byte("other"), 7, nil)
+	second.ID = "later"
+	second.StartTime = first.StartTime.Add(time.Minute)
+	if err := second.Save(root, nil); err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	// Stray directories without a manifest are ignored
+	if err := os.Mkdir(filepath.Join(root, "scratch"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	manifests, err := List(root)
+	if err != nil {
+		t.Fatalf("Failed to list runs: %v", err)
+	}
+	if len(manifests) != 2 {
+		t.Fatalf("Expected 2 runs, got %d", len(manifests))
+	}
+	if manifests[0].ConfigPath != "a.yaml" || manifests[0].Seed != 42 || manifests[0].Overrides["ticks"] != "5" {
+		t.Errorf("Expected first run to round-trip, got %+v", manifests[0])
+	}
+	if manifests[0].ConfigHash == manifests[1].ConfigHash {
+		t.Error("Expected different configs to hash differently")
+	}
+	if _, err := os.Stat(filepath.Join(first.Dir(root), ResultsFile)); err != nil {
+		t.Errorf("Expected results file in run directory: %v", err)
+	}
+}
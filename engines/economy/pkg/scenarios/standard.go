@@ -0,0 +1,67 @@
+package scenarios
+
+import (
+	"fmt"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Standard builds the benchmark economy: food, healthcare and entertainment
+// industries serving a population of the given size, a fifth of whom work.
+// Labor demand and stocks scale with the population so every size exercises
+// the same phases; the result is fully deterministic.
+func Standard(people int) *entities.Region {
+	region := entities.NewRegion(fmt.Sprintf("Standard-%d", people))
+
+	food := entities.NewProblem("Food", "Need for sustenance", 0.9)
+	food.UpdateDemand(0.9)
+	health := entities.NewProblem("Healthcare", "Need for medical services", 0.8)
+	health.UpdateDemand(0.3)
+	fun := entities.NewProblem("Entertainment", "Need for leisure", 0.3)
+	fun.UpdateDemand(0.5)
+	for _, problem := range []*entities.Problem{food, health, fun} {
+		region.AddProblem(problem)
+	}
+
+	raw := entities.NewResource("RawMaterial", "units")
+	raw.Quantity = float32(people) * 1000
+	raw.RegenerationRate = float32(people) * 50
+	region.AddResource(raw)
+
+	workers := people / 5
+	industries := []struct {
+		name    string
+		problem *entities.Problem
+		product string
+		share   float32 // Share of the workforce the industry needs
+	}{
+		{"Agriculture", food, "Food", 0.5},
+		{"Health", health, "Medical", 0.3},
+		{"Entertainment", fun, "Shows", 0.2},
+	}
+	for _, spec := range industries {
+		product := entities.NewResource(spec.product, "units")
+		region.AddIndustry(entities.CreateIndustry(spec.name).
+			SetupIndustry([]*entities.Problem{spec.problem}, []*entities.Resource{raw}, []*entities.Resource{product}).
+			UpdateLabor(float32(workers) * spec.share).
+			SetInitialCapital(float32(people) * 500))
+	}
+
+	workerSegment := entities.NewPopulationSegment("Workers", []*entities.Problem{food, health, fun}, workers)
+	general := entities.NewPopulationSegment("General Population", []*entities.Problem{food, health, fun}, people-workers)
+	workerSegment.Role = entities.RoleWorkers
+	region.AddPopulationSegment(workerSegment)
+	region.AddPopulationSegment(general)
+
+	for i := 0; i < people; i++ {
+		person := entities.NewPerson(fmt.Sprintf("Person-%d", i+1), 200.0, 40.0)
+		if i < workers {
+			person.AddSegment(workerSegment)
+		} else {
+			person.AddSegment(general)
+		}
+		region.AddPerson(person)
+	}
+
+	return region
+}
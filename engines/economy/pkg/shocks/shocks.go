@@ -0,0 +1,86 @@
+package shocks
+
+import (
+	"math/rand/v2"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Shock types
+const (
+	CropFailure = "crop_failure" // An industry loses a share of its output stock
+	HealthEvent = "health"       // Members of a segment face a medical bill
+)
+
+// Shock describes an adverse event that can hit the economy. It fires at a
+// fixed Tick, or with Probability each tick (per member for health events).
+type Shock struct {
+	Type        string
+	Tick        int     // Fixed tick, 0 to use Probability
+	Probability float32 // Chance per tick (per person for health events)
+	Industry    *entities.Industry
+	Segment     *entities.PopulationSegment
+	Severity    float32 // Share of stock lost (crop failure) or cost per person (health)
+}
+
+// Loss is the damage a shock caused to one industry or person
+type Loss struct {
+	Type     string
+	Industry *entities.Industry
+	Person   *entities.Person
+	Units    float32 // Stock destroyed
+	Amount   float32 // Money value of the loss
+}
+
+// Apply fires the shocks due this tick and returns the losses they caused.
+// unitValue values destroyed stock; rng decides probabilistic shocks.
+func Apply(region *entities.Region, shocks []*Shock, tick int, unitValue float32, rng *rand.Rand) []Loss {
+	losses := make([]Loss, 0)
+
+	for _, shock := range shocks {
+		switch shock.Type {
+		case CropFailure:
+			if shock.Industry == nil || !shock.fires(tick, rng) {
+				continue
+			}
+			units := float32(0)
+			for _, product := range shock.Industry.OutputProducts {
+				lost := product.Quantity * shock.Severity
+				product.Consume(lost)
+				units += lost
+			}
+			losses = append(losses, Loss{
+				Type:     shock.Type,
+				Industry: shock.Industry,
+				Units:    units,
+				Amount:   units * unitValue,
+			})
+
+		case HealthEvent:
+			for _, person := range region.People {
+				if shock.Segment != nil && !person.HasSegment(shock.Segment) {
+					continue
+				}
+				if !shock.fires(tick, rng) {
+					continue
+				}
+				// The bill is paid out of pocket; insurance may refund it later
+				person.Money -= min(shock.Severity, person.Money)
+				losses = append(losses, Loss{
+					Type:   shock.Type,
+					Person: person,
+					Amount: shock.Severity,
+				})
+			}
+		}
+	}
+
+	return losses
+}
+
+func (s *Shock) fires(tick int, rng *rand.Rand) bool {
+	if s.Tick > 0 {
+		return s.Tick == tick
+	}
+	return rng.Float32() < s.Probability
+}
@@ -0,0 +1,91 @@
+package shocks
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestApply_CropFailureFiresAtItsTick(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	grain := entities.NewResource("Grain", "kg")
+	grain.Quantity = 100
+	farm := entities.CreateIndustry("Farm").SetupIndustry(nil, nil, []*entities.Resource{grain})
+	region.AddIndustry(farm)
+	shocks := []*Shock{{Type: CropFailure, Tick: 3, Industry: farm, Severity: 0.25}}
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	if losses := Apply(region, shocks, 2, 2.0, rng); len(losses) != 0 || grain.Quantity != 100 {
+		t.Fatalf("Expected nothing to happen before tick 3, got %+v and %.0f kg", losses, grain.Quantity)
+	}
+
+	losses := Apply(region, shocks, 3, 2.0, rng)
+	if len(losses) != 1 || losses[0].Industry != farm || losses[0].Units != 25 || losses[0].Amount != 50 {
+		t.Fatalf("Expected the farm to lose 25 kg worth $50, got %+v", losses)
+	}
+	if grain.Quantity != 75 {
+		t.Errorf("Expected 75 kg left, got %.0f", grain.Quantity)
+	}
+}
+
+func TestApply_HealthEventBillsSegmentMembers(t *testing.T) {
+	region := entities.NewRegion("TestRegion")
+	elderly := entities.NewPopulationSegment("Elderly", nil, 2)
+	rich := entities.NewPerson("Rich", 100, 0)
+	poor := entities.NewPerson("Poor", 10, 0)
+	young := entities.NewPerson("Young", 100, 0)
+	rich.AddSegment(elderly)
+	poor.AddSegment(elderly)
+	for _, person := range []*entities.Person{rich, poor, young} {
+		region.AddPerson(person)
+	}
+	shocks := []*Shock{{Type: HealthEvent, Tick: 1, Segment: elderly, Severity: 30}}
+
+	losses := Apply(region, shocks, 1, 0, rand.New(rand.NewPCG(1, 2)))
+
+	if len(losses) != 2 {
+		t.Fatalf("Expected a bill for each of the 2 members, got %d", len(losses))
+	}
+	for _, loss := range losses {
+		if loss.Amount != 30 {
+			t.Errorf("Expected a $30 bill for %s, got %.2f", loss.Person.Name, loss.Amount)
+		}
+	}
+	// Money never goes negative; the bill is still the loss insurers see
+	if rich.Money != 70 || poor.Money != 0 || young.Money != 100 {
+		t.Errorf("Expected $70, $0 and an untouched $100, got %.2f, %.2f and %.2f", rich.Money, poor.Money, young.Money)
+	}
+}
+
+func TestApply_SeededShocksReproduce(t *testing.T) {
+	run := func(seed uint64) []int {
+		region := entities.NewRegion("TestRegion")
+		for i := 0; i < 20; i++ {
+			region.AddPerson(entities.NewPerson("Person", 1000, 0))
+		}
+		shocks := []*Shock{{Type: HealthEvent, Probability: 0.3, Severity: 10}}
+		rng := rand.New(rand.NewPCG(seed, seed))
+
+		hit := make([]int, 0)
+		for tick := 1; tick <= 5; tick++ {
+			for _, loss := range Apply(region, shocks, tick, 0, rng) {
+				hit = append(hit, tick*100+loss.Person.ID-region.People[0].ID)
+			}
+		}
+		return hit
+	}
+
+	first, second := run(7), run(7)
+	if len(first) == 0 || len(first) == 100 {
+		t.Fatalf("Expected some but not all of 100 draws to fire at 30%%, got %d", len(first))
+	}
+	if len(first) != len(second) {
+		t.Fatalf("Expected the same seed to fire the same shocks, got %d and %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same seed to hit the same people, got %v and %v", first, second)
+		}
+	}
+}
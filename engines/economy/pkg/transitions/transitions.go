@@ -0,0 +1,142 @@
+package transitions
+
+import (
+	"math/rand/v2"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Trigger is what makes a person eligible to move between segments
+type Trigger string
+
+const (
+	// Unemployed moves people left idle for Ticks ticks in a row
+	Unemployed Trigger = "unemployed"
+	// After moves people once they have spent Ticks ticks in the segment
+	After Trigger = "after"
+)
+
+// Rule moves people from one population segment to another. Segments are
+// named rather than referenced so a forked region resolves its own copies.
+type Rule struct {
+	From    string
+	To      string
+	Trigger Trigger
+	Ticks   int     // Ticks the trigger has to hold (at least 1)
+	Chance  float32 // Chance an eligible person moves each tick (0 = always)
+}
+
+// Tracker applies transition rules every tick and remembers how long people
+// have been idle and in their segments
+type Tracker struct {
+	Rules []Rule
+
+	idleFor map[int]int            // Consecutive idle ticks, by person ID
+	joined  map[string]map[int]int // Tick each member joined, by segment name and person ID
+}
+
+// NewTracker creates a tracker for the rules
+func NewTracker(rules []Rule) *Tracker {
+	return &Tracker{
+		Rules:   rules,
+		idleFor: make(map[int]int),
+		joined:  make(map[string]map[int]int),
+	}
+}
+
+// Clone returns an independent copy of the tracker and its memory
+func (t *Tracker) Clone() *Tracker {
+	clone := NewTracker(append([]Rule(nil), t.Rules...))
+	for id, ticks := range t.idleFor {
+		clone.idleFor[id] = ticks
+	}
+	for segment, members := range t.joined {
+		copied := make(map[int]int, len(members))
+		for id, tick := range members {
+			copied[id] = tick
+		}
+		clone.joined[segment] = copied
+	}
+	return clone
+}
+
+// Move is one person changing segment
+type Move struct {
+	Person *entities.Person
+	From   string
+	To     string
+}
+
+// Apply moves everyone a rule makes eligible, after recording who was idle
+// this tick. Rules run in order and a person moves at most once per tick.
+func (t *Tracker) Apply(region *entities.Region, idle []*entities.Person, tick int, rng *rand.Rand) []Move {
+	wasIdle := make(map[int]bool, len(idle))
+	for _, person := range idle {
+		wasIdle[person.ID] = true
+	}
+	for id := range t.idleFor {
+		if !wasIdle[id] {
+			delete(t.idleFor, id)
+		}
+	}
+	for id := range wasIdle {
+		t.idleFor[id]++
+	}
+
+	moved := make(map[int]bool)
+	moves := make([]Move, 0)
+	for _, rule := range t.Rules {
+		from := region.GetPopulationSegment(rule.From)
+		to := region.GetPopulationSegment(rule.To)
+		if from == nil || to == nil {
+			continue
+		}
+		// Copy the members, the loop below changes them
+		for _, person := range append([]*entities.Person(nil), from.Members()...) {
+			if moved[person.ID] || !t.eligible(rule, person, tick) {
+				continue
+			}
+			if rule.Chance > 0 && rng.Float32() >= rule.Chance {
+				continue
+			}
+			person.RemoveSegment(from)
+			person.AddSegment(to)
+			t.since(to.Name)[person.ID] = tick + 1 // Their first tick there is the next one
+			delete(t.since(from.Name), person.ID)
+			delete(t.idleFor, person.ID)
+			moved[person.ID] = true
+			moves = append(moves, Move{Person: person, From: from.Name, To: to.Name})
+		}
+	}
+	return moves
+}
+
+// eligible reports whether the rule's trigger holds for a member of its
+// From segment. People already in a segment when first seen count as
+// joining on that tick.
+func (t *Tracker) eligible(rule Rule, person *entities.Person, tick int) bool {
+	ticks := max(rule.Ticks, 1)
+	switch rule.Trigger {
+	case Unemployed:
+		return t.idleFor[person.ID] >= ticks
+	case After:
+		members := t.since(rule.From)
+		joined, known := members[person.ID]
+		if !known {
+			joined = tick
+			members[person.ID] = tick
+		}
+		return tick-joined+1 >= ticks
+	}
+	return false
+}
+
+// since returns the join ticks of a segment's members
+func (t *Tracker) since(segment string) map[int]int {
+	members, exists := t.joined[segment]
+	if !exists {
+		members = make(map[int]int)
+		t.joined[segment] = members
+	}
+	return members
+}
@@ -0,0 +1,78 @@
+package transitions
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+func newRegion(segments ...string) *entities.Region {
+	region := entities.NewRegion("TestRegion")
+	for _, name := range segments {
+		region.AddPopulationSegment(entities.NewPopulationSegment(name, nil, 0))
+	}
+	return region
+}
+
+func TestTracker_UnemployedMoveAfterIdleStreak(t *testing.T) {
+	region := newRegion("Workers", "Informal")
+	workers := region.GetPopulationSegment("Workers")
+	idle := entities.NewPerson("Idle", 0, 40)
+	busy := entities.NewPerson("Busy", 0, 40)
+	for _, person := range []*entities.Person{idle, busy} {
+		person.AddSegment(workers)
+		region.AddPerson(person)
+	}
+
+	tracker := NewTracker([]Rule{{From: "Workers", To: "Informal", Trigger: Unemployed, Ticks: 2}})
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	if moves := tracker.Apply(region, []*entities.Person{idle, busy}, 1, rng); len(moves) != 0 {
+		t.Fatalf("Expected nobody to move after one idle tick, got %d", len(moves))
+	}
+	// Busy found work, breaking their streak
+	moves := tracker.Apply(region, []*entities.Person{idle}, 2, rng)
+	if len(moves) != 1 || moves[0].Person != idle {
+		t.Fatalf("Expected only the idle worker to move, got %d moves", len(moves))
+	}
+	if !idle.HasSegment(region.GetPopulationSegment("Informal")) || idle.HasSegment(workers) {
+		t.Error("Expected the idle worker to be informal only")
+	}
+	if workers.Size != 1 {
+		t.Errorf("Expected 1 worker left, got %d", workers.Size)
+	}
+}
+
+func TestTracker_AfterTicksInSegment(t *testing.T) {
+	region := newRegion("Students", "Workers")
+	student := entities.NewPerson("Student", 0, 40)
+	student.AddSegment(region.GetPopulationSegment("Students"))
+	region.AddPerson(student)
+
+	tracker := NewTracker([]Rule{
+		{From: "Students", To: "Workers", Trigger: After, Ticks: 3},
+		{From: "Workers", To: "Students", Trigger: After, Ticks: 1},
+	})
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for tick := 1; tick <= 2; tick++ {
+		if moves := tracker.Apply(region, nil, tick, rng); len(moves) != 0 {
+			t.Fatalf("Expected the student to stay at tick %d", tick)
+		}
+	}
+	// Moves once, even though the second rule would send them straight back
+	if moves := tracker.Apply(region, nil, 3, rng); len(moves) != 1 || moves[0].To != "Workers" {
+		t.Fatalf("Expected the student to graduate at tick 3, got %v", moves)
+	}
+	// A full tick as a worker before the second rule applies
+	if moves := tracker.Apply(region, nil, 4, rng); len(moves) != 1 || moves[0].To != "Students" {
+		t.Errorf("Expected the worker to go back to school at tick 4, got %v", moves)
+	}
+
+	clone := tracker.Clone()
+	clone.Rules[0].Ticks = 10
+	if tracker.Rules[0].Ticks != 3 {
+		t.Error("Expected the clone's rules to be independent")
+	}
+}
@@ -0,0 +1,125 @@
+package units
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dimensions of measure. A count unit the registry defines without a
+// dimension, such as logs or visits, is a dimension of its own.
+const (
+	Count  = "count"  // Generic units, counted in "units"
+	Mass   = "mass"   // Counted in kg
+	Volume = "volume" // Counted in liters
+	Area   = "area"   // Counted in acres
+)
+
+// Unit is a unit of measure and its size in the base unit of its dimension
+type Unit struct {
+	Name      string
+	Dimension string
+	PerBase   float32 // Base units in one of this unit, e.g. 1000 for tons of kg
+}
+
+// Registry knows the units resources and products are counted in and
+// converts between those of the same dimension. Names are matched without
+// regard to case.
+type Registry struct {
+	Units map[string]Unit
+}
+
+// NewRegistry creates a registry of the common units
+func NewRegistry() *Registry {
+	r := &Registry{Units: make(map[string]Unit)}
+	for _, unit := range []Unit{
+		{"units", Count, 1}, {"unit", Count, 1}, {"pieces", Count, 1}, {"items", Count, 1},
+		{"kg", Mass, 1}, {"g", Mass, 0.001}, {"tons", Mass, 1000}, {"tonnes", Mass, 1000}, {"lb", Mass, 0.4536},
+		{"liters", Volume, 1}, {"litres", Volume, 1}, {"l", Volume, 1}, {"ml", Volume, 0.001},
+		{"m3", Volume, 1000}, {"gallons", Volume, 3.785},
+		{"acres", Area, 1}, {"hectares", Area, 2.471}, {"m2", Area, 0.000247},
+		{"logs", "logs", 1}, {"bales", "bales", 1},
+	} {
+		r.Units[unit.Name] = unit
+	}
+	return r
+}
+
+// Define registers a unit as factor of another, such as sacks of 50 kg, or
+// as a count of its own when of is empty. Redefining a unit the same way is
+// allowed; any other redefinition is an error.
+func (r *Registry) Define(name, of string, factor float32) error {
+	unit := Unit{Name: key(name), Dimension: key(name), PerBase: 1}
+	if of != "" {
+		base, ok := r.Lookup(of)
+		if !ok {
+			return fmt.Errorf("unit %s is defined in unknown unit %s", name, of)
+		}
+		if factor <= 0 {
+			return fmt.Errorf("unit %s needs a positive factor", name)
+		}
+		unit.Dimension = base.Dimension
+		unit.PerBase = base.PerBase * factor
+	}
+	if existing, ok := r.Units[unit.Name]; ok && existing != unit {
+		return fmt.Errorf("unit %s is already defined", name)
+	}
+	r.Units[unit.Name] = unit
+	return nil
+}
+
+// Lookup returns a registered unit
+func (r *Registry) Lookup(name string) (Unit, bool) {
+	unit, ok := r.Units[key(name)]
+	return unit, ok
+}
+
+// Compatible reports whether quantities in one unit convert to the other
+func (r *Registry) Compatible(from, to string) bool {
+	_, err := r.Convert(1, from, to)
+	return err == nil
+}
+
+// Convert turns a quantity in one unit into another of the same dimension
+func (r *Registry) Convert(quantity float32, from, to string) (float32, error) {
+	if key(from) == key(to) {
+		return quantity, nil
+	}
+	source, ok := r.Lookup(from)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit: %s", from)
+	}
+	target, ok := r.Lookup(to)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit: %s", to)
+	}
+	if source.Dimension != target.Dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", from, source.Dimension, to, target.Dimension)
+	}
+	return quantity * source.PerBase / target.PerBase, nil
+}
+
+// Base converts a quantity to the base unit of its dimension, for reports
+// that add up or compare quantities. Unknown units are returned as they are.
+func (r *Registry) Base(quantity float32, unit string) (float32, string) {
+	found, ok := r.Lookup(unit)
+	if !ok {
+		return quantity, unit
+	}
+	if base, ok := baseNames[found.Dimension]; ok {
+		return quantity * found.PerBase, base
+	}
+	return quantity * found.PerBase, found.Dimension
+}
+
+// baseNames are the base units of the built-in dimensions; a count's own
+// dimension is named after its unit
+var baseNames = map[string]string{
+	Count:  "units",
+	Mass:   "kg",
+	Volume: "liters",
+	Area:   "acres",
+}
+
+func key(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
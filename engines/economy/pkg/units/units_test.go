@@ -0,0 +1,32 @@
+package units
+
+import "testing"
+
+func TestRegistry_Convert(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Define("Sacks", "kg", 50); err != nil {
+		t.Fatalf("Failed to define sacks: %v", err)
+	}
+	if err := r.Define("visits", "", 0); err != nil {
+		t.Fatalf("Failed to define visits: %v", err)
+	}
+
+	if kg, err := r.Convert(2, "tons", "sacks"); err != nil || kg != 40 {
+		t.Errorf("Expected 2 tons to be 40 sacks, got %.2f (%v)", kg, err)
+	}
+	if _, err := r.Convert(1, "kg", "liters"); err == nil {
+		t.Error("Expected kg and liters not to convert")
+	}
+	if r.Compatible("visits", "units") || !r.Compatible("Visits", "visits") {
+		t.Error("Expected visits to convert only to themselves")
+	}
+	if _, err := r.Convert(1, "kg", "bushels"); err == nil {
+		t.Error("Expected an unknown unit to fail")
+	}
+	if quantity, unit := r.Base(3, "sacks"); quantity != 150 || unit != "kg" {
+		t.Errorf("Expected 150 kg, got %.2f %s", quantity, unit)
+	}
+	if err := r.Define("sacks", "kg", 25); err == nil {
+		t.Error("Expected redefining sacks to fail")
+	}
+}
@@ -0,0 +1,89 @@
+package welfare
+
+import (
+	"sort"
+
+	"westex/engines/economy/pkg/entities"
+)
+
+// Utility scores how well off a person is from three parts, each between 0
+// and 1: the share of their needs met this tick, the share of the tick they
+// had as leisure, and their wealth relative to SavingsScale. The score is
+// the weighted average of the parts, so it is between 0 and 1 too.
+type Utility struct {
+	NeedsWeight   float32
+	LeisureWeight float32
+	SavingsWeight float32
+	SavingsScale  float32 // Wealth at which the savings part reaches one half
+}
+
+// DefaultUtility weighs needs most, with leisure and savings as extras
+func DefaultUtility() *Utility {
+	return &Utility{
+		NeedsWeight:   1.0,
+		LeisureWeight: 0.25,
+		SavingsWeight: 0.25,
+		SavingsScale:  1000,
+	}
+}
+
+// Score combines the parts of a person's wellbeing into one utility
+func (u *Utility) Score(needsMet, leisure, wealth float32) float32 {
+	total := u.NeedsWeight + u.LeisureWeight + u.SavingsWeight
+	if total <= 0 {
+		return 0
+	}
+	savings := float32(0)
+	if wealth > 0 && u.SavingsScale > 0 {
+		savings = wealth / (wealth + u.SavingsScale)
+	}
+	return (u.NeedsWeight*clamp(needsMet) + u.LeisureWeight*clamp(leisure) + u.SavingsWeight*savings) / total
+}
+
+// Report is one tick's welfare across the population next to its output
+type Report struct {
+	Tick    int     `json:"tick"`
+	Average float32 `json:"average"`
+	Median  float32 `json:"median"`
+	Lowest  float32 `json:"lowest"`
+	GDP     float32 `json:"gdp"` // Value of the goods produced this tick
+}
+
+// Tick records what happened to people during a tick, for scoring
+type Tick struct {
+	Number int
+	Busy   map[int]bool    // People who worked or studied, by ID
+	Met    map[int]float32 // Share of each person's needs met, by ID
+	GDP    float32
+}
+
+// Measure scores every person for the tick and summarizes the scores. Met
+// holds, per person ID, the share of their needs met; busy people had no
+// leisure and everyone else had the whole tick.
+func (u *Utility) Measure(people []*entities.Person, tick Tick) Report {
+	report := Report{Tick: tick.Number, GDP: tick.GDP}
+	if len(people) == 0 {
+		return report
+	}
+
+	scores := make([]float32, len(people))
+	total := float32(0)
+	for i, person := range people {
+		leisure := float32(1)
+		if tick.Busy[person.ID] {
+			leisure = 0
+		}
+		scores[i] = u.Score(tick.Met[person.ID], leisure, person.Wealth())
+		total += scores[i]
+	}
+
+	sort.Slice(scores, func(a, b int) bool { return scores[a] < scores[b] })
+	report.Average = total / float32(len(scores))
+	report.Median = scores[len(scores)/2]
+	report.Lowest = scores[0]
+	return report
+}
+
+func clamp(value float32) float32 {
+	return min(max(value, 0), 1)
+}
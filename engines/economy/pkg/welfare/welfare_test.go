@@ -0,0 +1,42 @@
+package welfare
+
+import (
+	"testing"
+	"westex/engines/economy/pkg/entities"
+)
+
+func TestUtility_ScoreWeighsParts(t *testing.T) {
+	u := &Utility{NeedsWeight: 2, LeisureWeight: 1, SavingsWeight: 1, SavingsScale: 100}
+
+	if got := u.Score(1, 1, 1e9); got < 0.99 {
+		t.Errorf("Expected nearly full utility, got %.2f", got)
+	}
+	// Needs fully met, no leisure, savings at half scale: (2 + 0 + 0.5) / 4
+	if got := u.Score(1, 0, 100); got != 0.625 {
+		t.Errorf("Expected 0.625, got %.3f", got)
+	}
+	if got := u.Score(3, -1, -50); got != 0.5 {
+		t.Errorf("Expected parts clamped to 0.5, got %.3f", got)
+	}
+}
+
+func TestUtility_MeasureSummarizesPeople(t *testing.T) {
+	u := &Utility{NeedsWeight: 1, LeisureWeight: 1}
+	worker := entities.NewPerson("Worker", 0, 40)
+	idle := entities.NewPerson("Idle", 0, 40)
+	hungry := entities.NewPerson("Hungry", 0, 40)
+
+	report := u.Measure([]*entities.Person{worker, idle, hungry}, Tick{
+		Number: 3,
+		Busy:   map[int]bool{worker.ID: true, hungry.ID: true},
+		Met:    map[int]float32{worker.ID: 1, idle.ID: 1},
+		GDP:    500,
+	})
+
+	if report.Lowest != 0 || report.Median != 0.5 {
+		t.Errorf("Expected lowest 0 and median 0.5, got %.2f and %.2f", report.Lowest, report.Median)
+	}
+	if report.Average != 0.5 || report.GDP != 500 || report.Tick != 3 {
+		t.Errorf("Expected average 0.5, GDP 500 at tick 3, got %+v", report)
+	}
+}